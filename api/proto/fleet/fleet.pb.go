@@ -0,0 +1,4037 @@
+// Define the protocol buffer version
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: fleet/fleet.proto
+
+// Specify the package to prevent name clashes
+
+package fleet
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// --- Machine Model ---
+type Machine struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SerialNumber     string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Type             string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`     // DRONE or ROBOT
+	Status           string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // IDLE, IN_TRANSIT, CHARGING, MAINTENANCE, OFFLINE or DECOMMISSIONED
+	Latitude         float64                `protobuf:"fixed64,5,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude        float64                `protobuf:"fixed64,6,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	BatteryLevel     int32                  `protobuf:"varint,7,opt,name=battery_level,json=batteryLevel,proto3" json:"battery_level,omitempty"`            // Percent
+	DecommissionedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=decommissioned_at,json=decommissionedAt,proto3" json:"decommissioned_at,omitempty"` // Unset while in service
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	HealthFlags      []string               `protobuf:"bytes,11,rep,name=health_flags,json=healthFlags,proto3" json:"health_flags,omitempty"` // Faults reported with the last heartbeat
+	LastHeartbeatAt  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_heartbeat_at,json=lastHeartbeatAt,proto3" json:"last_heartbeat_at,omitempty"`
+	OdometerMeters   float64                `protobuf:"fixed64,13,opt,name=odometer_meters,json=odometerMeters,proto3" json:"odometer_meters,omitempty"`      // Distance travelled in transit
+	OperatingSeconds int64                  `protobuf:"varint,14,opt,name=operating_seconds,json=operatingSeconds,proto3" json:"operating_seconds,omitempty"` // Time in transit; flight time for drones
+	LastInspectedAt  *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=last_inspected_at,json=lastInspectedAt,proto3" json:"last_inspected_at,omitempty"`   // Unset until the first inspection
+	HomeHubId        string                 `protobuf:"bytes,16,opt,name=home_hub_id,json=homeHubId,proto3" json:"home_hub_id,omitempty"`                     // Empty when not based at a hub
+	Version          int64                  `protobuf:"varint,17,opt,name=version,proto3" json:"version,omitempty"`                                           // Bumped on every change of status or home hub
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Machine) Reset() {
+	*x = Machine{}
+	mi := &file_fleet_fleet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Machine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Machine) ProtoMessage() {}
+
+func (x *Machine) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Machine.ProtoReflect.Descriptor instead.
+func (*Machine) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Machine) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Machine) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Machine) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Machine) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Machine) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Machine) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Machine) GetBatteryLevel() int32 {
+	if x != nil {
+		return x.BatteryLevel
+	}
+	return 0
+}
+
+func (x *Machine) GetDecommissionedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DecommissionedAt
+	}
+	return nil
+}
+
+func (x *Machine) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Machine) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Machine) GetHealthFlags() []string {
+	if x != nil {
+		return x.HealthFlags
+	}
+	return nil
+}
+
+func (x *Machine) GetLastHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeatAt
+	}
+	return nil
+}
+
+func (x *Machine) GetOdometerMeters() float64 {
+	if x != nil {
+		return x.OdometerMeters
+	}
+	return 0
+}
+
+func (x *Machine) GetOperatingSeconds() int64 {
+	if x != nil {
+		return x.OperatingSeconds
+	}
+	return 0
+}
+
+func (x *Machine) GetLastInspectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastInspectedAt
+	}
+	return nil
+}
+
+func (x *Machine) GetHomeHubId() string {
+	if x != nil {
+		return x.HomeHubId
+	}
+	return ""
+}
+
+func (x *Machine) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// --- Machine Status Event Model ---
+type MachineStatusEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MachineId     string                 `protobuf:"bytes,2,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Version       int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`               // The machine's version after the change
+	ActorId       string                 `protobuf:"bytes,7,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // The machine or operator that made it; empty when made by the system
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MachineStatusEvent) Reset() {
+	*x = MachineStatusEvent{}
+	mi := &file_fleet_fleet_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MachineStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineStatusEvent) ProtoMessage() {}
+
+func (x *MachineStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineStatusEvent.ProtoReflect.Descriptor instead.
+func (*MachineStatusEvent) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MachineStatusEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MachineStatusEvent) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *MachineStatusEvent) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *MachineStatusEvent) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *MachineStatusEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MachineStatusEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MachineStatusEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *MachineStatusEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// --- Machine Credentials Model ---
+// The client certificate a machine presents over mutual TLS. The private key
+// is only returned once, at registration, and is not stored by the service.
+type MachineCredentials struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SerialNumber     string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"` // Hex encoded certificate serial number
+	CertificatePem   string                 `protobuf:"bytes,2,opt,name=certificate_pem,json=certificatePem,proto3" json:"certificate_pem,omitempty"`
+	PrivateKeyPem    string                 `protobuf:"bytes,3,opt,name=private_key_pem,json=privateKeyPem,proto3" json:"private_key_pem,omitempty"`
+	CaCertificatePem string                 `protobuf:"bytes,4,opt,name=ca_certificate_pem,json=caCertificatePem,proto3" json:"ca_certificate_pem,omitempty"` // To verify the servers
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MachineCredentials) Reset() {
+	*x = MachineCredentials{}
+	mi := &file_fleet_fleet_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MachineCredentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineCredentials) ProtoMessage() {}
+
+func (x *MachineCredentials) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineCredentials.ProtoReflect.Descriptor instead.
+func (*MachineCredentials) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MachineCredentials) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *MachineCredentials) GetCertificatePem() string {
+	if x != nil {
+		return x.CertificatePem
+	}
+	return ""
+}
+
+func (x *MachineCredentials) GetPrivateKeyPem() string {
+	if x != nil {
+		return x.PrivateKeyPem
+	}
+	return ""
+}
+
+func (x *MachineCredentials) GetCaCertificatePem() string {
+	if x != nil {
+		return x.CaCertificatePem
+	}
+	return ""
+}
+
+func (x *MachineCredentials) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// A latitude/longitude rectangle. A box whose min_longitude is greater than
+// its max_longitude crosses the antimeridian.
+type BoundingBox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLatitude   float64                `protobuf:"fixed64,1,opt,name=min_latitude,json=minLatitude,proto3" json:"min_latitude,omitempty"`
+	MinLongitude  float64                `protobuf:"fixed64,2,opt,name=min_longitude,json=minLongitude,proto3" json:"min_longitude,omitempty"`
+	MaxLatitude   float64                `protobuf:"fixed64,3,opt,name=max_latitude,json=maxLatitude,proto3" json:"max_latitude,omitempty"`
+	MaxLongitude  float64                `protobuf:"fixed64,4,opt,name=max_longitude,json=maxLongitude,proto3" json:"max_longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoundingBox) Reset() {
+	*x = BoundingBox{}
+	mi := &file_fleet_fleet_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoundingBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoundingBox) ProtoMessage() {}
+
+func (x *BoundingBox) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoundingBox.ProtoReflect.Descriptor instead.
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BoundingBox) GetMinLatitude() float64 {
+	if x != nil {
+		return x.MinLatitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMinLongitude() float64 {
+	if x != nil {
+		return x.MinLongitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLatitude() float64 {
+	if x != nil {
+		return x.MaxLatitude
+	}
+	return 0
+}
+
+func (x *BoundingBox) GetMaxLongitude() float64 {
+	if x != nil {
+		return x.MaxLongitude
+	}
+	return 0
+}
+
+// --- Charging Models ---
+type ChargingStation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MachineType   string                 `protobuf:"bytes,3,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"` // DRONE or ROBOT
+	Latitude      float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Slots         int32                  `protobuf:"varint,6,opt,name=slots,proto3" json:"slots,omitempty"`
+	SlotsInUse    int32                  `protobuf:"varint,7,opt,name=slots_in_use,json=slotsInUse,proto3" json:"slots_in_use,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChargingStation) Reset() {
+	*x = ChargingStation{}
+	mi := &file_fleet_fleet_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargingStation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargingStation) ProtoMessage() {}
+
+func (x *ChargingStation) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargingStation.ProtoReflect.Descriptor instead.
+func (*ChargingStation) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChargingStation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChargingStation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChargingStation) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *ChargingStation) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *ChargingStation) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *ChargingStation) GetSlots() int32 {
+	if x != nil {
+		return x.Slots
+	}
+	return 0
+}
+
+func (x *ChargingStation) GetSlotsInUse() int32 {
+	if x != nil {
+		return x.SlotsInUse
+	}
+	return 0
+}
+
+func (x *ChargingStation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// A charging slot held for a machine.
+type ChargingReservation struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MachineId           string                 `protobuf:"bytes,2,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Station             *ChargingStation       `protobuf:"bytes,3,opt,name=station,proto3" json:"station,omitempty"`
+	Status              string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // active, completed or cancelled
+	Reason              string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // low_battery or upcoming_trip
+	BatteryLevelAtStart int32                  `protobuf:"varint,6,opt,name=battery_level_at_start,json=batteryLevelAtStart,proto3" json:"battery_level_at_start,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ChargingReservation) Reset() {
+	*x = ChargingReservation{}
+	mi := &file_fleet_fleet_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargingReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargingReservation) ProtoMessage() {}
+
+func (x *ChargingReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargingReservation.ProtoReflect.Descriptor instead.
+func (*ChargingReservation) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ChargingReservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChargingReservation) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *ChargingReservation) GetStation() *ChargingStation {
+	if x != nil {
+		return x.Station
+	}
+	return nil
+}
+
+func (x *ChargingReservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChargingReservation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ChargingReservation) GetBatteryLevelAtStart() int32 {
+	if x != nil {
+		return x.BatteryLevelAtStart
+	}
+	return 0
+}
+
+func (x *ChargingReservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_fleet_fleet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+// The predicted battery use of a machine on a trip.
+type TripBatteryCheck struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Machine               *Machine               `protobuf:"bytes,1,opt,name=machine,proto3" json:"machine,omitempty"`
+	PickupDistanceMeters  float64                `protobuf:"fixed64,2,opt,name=pickup_distance_meters,json=pickupDistanceMeters,proto3" json:"pickup_distance_meters,omitempty"`    // From the machine's position
+	Consumption           float64                `protobuf:"fixed64,3,opt,name=consumption,proto3" json:"consumption,omitempty"`                                                    // Percent
+	ProjectedBatteryLevel float64                `protobuf:"fixed64,4,opt,name=projected_battery_level,json=projectedBatteryLevel,proto3" json:"projected_battery_level,omitempty"` // Percent left at the end of the trip
+	Feasible              bool                   `protobuf:"varint,5,opt,name=feasible,proto3" json:"feasible,omitempty"`                                                           // Whether the safety reserve is kept
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TripBatteryCheck) Reset() {
+	*x = TripBatteryCheck{}
+	mi := &file_fleet_fleet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripBatteryCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripBatteryCheck) ProtoMessage() {}
+
+func (x *TripBatteryCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripBatteryCheck.ProtoReflect.Descriptor instead.
+func (*TripBatteryCheck) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TripBatteryCheck) GetMachine() *Machine {
+	if x != nil {
+		return x.Machine
+	}
+	return nil
+}
+
+func (x *TripBatteryCheck) GetPickupDistanceMeters() float64 {
+	if x != nil {
+		return x.PickupDistanceMeters
+	}
+	return 0
+}
+
+func (x *TripBatteryCheck) GetConsumption() float64 {
+	if x != nil {
+		return x.Consumption
+	}
+	return 0
+}
+
+func (x *TripBatteryCheck) GetProjectedBatteryLevel() float64 {
+	if x != nil {
+		return x.ProjectedBatteryLevel
+	}
+	return 0
+}
+
+func (x *TripBatteryCheck) GetFeasible() bool {
+	if x != nil {
+		return x.Feasible
+	}
+	return false
+}
+
+// --- Maintenance Models ---
+type ReplacedPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    string                 `protobuf:"bytes,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplacedPart) Reset() {
+	*x = ReplacedPart{}
+	mi := &file_fleet_fleet_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplacedPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplacedPart) ProtoMessage() {}
+
+func (x *ReplacedPart) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplacedPart.ProtoReflect.Descriptor instead.
+func (*ReplacedPart) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReplacedPart) GetPartNumber() string {
+	if x != nil {
+		return x.PartNumber
+	}
+	return ""
+}
+
+func (x *ReplacedPart) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReplacedPart) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type WorkOrder struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MachineId        string                 `protobuf:"bytes,2,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Kind             string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                            // fault, inspection or repair
+	Status           string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                        // open, in_progress, completed or cancelled
+	Blocking         bool                   `protobuf:"varint,5,opt,name=blocking,proto3" json:"blocking,omitempty"`                   // Keeps the machine in MAINTENANCE while open
+	FaultCode        string                 `protobuf:"bytes,6,opt,name=fault_code,json=faultCode,proto3" json:"fault_code,omitempty"` // Set for faults
+	Description      string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	TechnicianId     string                 `protobuf:"bytes,8,opt,name=technician_id,json=technicianId,proto3" json:"technician_id,omitempty"`
+	TechnicianNotes  string                 `protobuf:"bytes,9,opt,name=technician_notes,json=technicianNotes,proto3" json:"technician_notes,omitempty"`
+	PartsReplaced    []*ReplacedPart        `protobuf:"bytes,10,rep,name=parts_replaced,json=partsReplaced,proto3" json:"parts_replaced,omitempty"`
+	OdometerMeters   float64                `protobuf:"fixed64,11,opt,name=odometer_meters,json=odometerMeters,proto3" json:"odometer_meters,omitempty"` // Machine usage when opened
+	OperatingSeconds int64                  `protobuf:"varint,12,opt,name=operating_seconds,json=operatingSeconds,proto3" json:"operating_seconds,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	ClosedAt         *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WorkOrder) Reset() {
+	*x = WorkOrder{}
+	mi := &file_fleet_fleet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkOrder) ProtoMessage() {}
+
+func (x *WorkOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkOrder.ProtoReflect.Descriptor instead.
+func (*WorkOrder) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WorkOrder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetBlocking() bool {
+	if x != nil {
+		return x.Blocking
+	}
+	return false
+}
+
+func (x *WorkOrder) GetFaultCode() string {
+	if x != nil {
+		return x.FaultCode
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetTechnicianId() string {
+	if x != nil {
+		return x.TechnicianId
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetTechnicianNotes() string {
+	if x != nil {
+		return x.TechnicianNotes
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetPartsReplaced() []*ReplacedPart {
+	if x != nil {
+		return x.PartsReplaced
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetOdometerMeters() float64 {
+	if x != nil {
+		return x.OdometerMeters
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetOperatingSeconds() int64 {
+	if x != nil {
+		return x.OperatingSeconds
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+// --- Hub Models ---
+type Hub struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude            float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude           float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Capacity            int32                  `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"` // Machines that can be based here
+	ServiceRadiusMeters float64                `protobuf:"fixed64,6,opt,name=service_radius_meters,json=serviceRadiusMeters,proto3" json:"service_radius_meters,omitempty"`
+	Timezone            string                 `protobuf:"bytes,7,opt,name=timezone,proto3" json:"timezone,omitempty"`                 // IANA name the opening hours are in
+	OpensAt             string                 `protobuf:"bytes,8,opt,name=opens_at,json=opensAt,proto3" json:"opens_at,omitempty"`    // HH:MM; empty when open around the clock
+	ClosesAt            string                 `protobuf:"bytes,9,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"` // HH:MM; before opens_at when open overnight
+	HomeBasedMachines   int32                  `protobuf:"varint,10,opt,name=home_based_machines,json=homeBasedMachines,proto3" json:"home_based_machines,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Hub) Reset() {
+	*x = Hub{}
+	mi := &file_fleet_fleet_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hub) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hub) ProtoMessage() {}
+
+func (x *Hub) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hub.ProtoReflect.Descriptor instead.
+func (*Hub) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Hub) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Hub) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Hub) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Hub) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Hub) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Hub) GetServiceRadiusMeters() float64 {
+	if x != nil {
+		return x.ServiceRadiusMeters
+	}
+	return 0
+}
+
+func (x *Hub) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Hub) GetOpensAt() string {
+	if x != nil {
+		return x.OpensAt
+	}
+	return ""
+}
+
+func (x *Hub) GetClosesAt() string {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return ""
+}
+
+func (x *Hub) GetHomeBasedMachines() int32 {
+	if x != nil {
+		return x.HomeBasedMachines
+	}
+	return 0
+}
+
+func (x *Hub) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// The machines of one type at a hub.
+type HubStock struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MachineType     string                 `protobuf:"bytes,1,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	HomeBased       int32                  `protobuf:"varint,2,opt,name=home_based,json=homeBased,proto3" json:"home_based,omitempty"`
+	IdleMachineIds  []string               `protobuf:"bytes,3,rep,name=idle_machine_ids,json=idleMachineIds,proto3" json:"idle_machine_ids,omitempty"`     // Idle and parked at the hub
+	ForecastDemand  float64                `protobuf:"fixed64,4,opt,name=forecast_demand,json=forecastDemand,proto3" json:"forecast_demand,omitempty"`     // Orders expected over the forecast horizon; set by SuggestRebalancing
+	TargetIdleCount int32                  `protobuf:"varint,5,opt,name=target_idle_count,json=targetIdleCount,proto3" json:"target_idle_count,omitempty"` // Set by SuggestRebalancing
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *HubStock) Reset() {
+	*x = HubStock{}
+	mi := &file_fleet_fleet_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HubStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HubStock) ProtoMessage() {}
+
+func (x *HubStock) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HubStock.ProtoReflect.Descriptor instead.
+func (*HubStock) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HubStock) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *HubStock) GetHomeBased() int32 {
+	if x != nil {
+		return x.HomeBased
+	}
+	return 0
+}
+
+func (x *HubStock) GetIdleMachineIds() []string {
+	if x != nil {
+		return x.IdleMachineIds
+	}
+	return nil
+}
+
+func (x *HubStock) GetForecastDemand() float64 {
+	if x != nil {
+		return x.ForecastDemand
+	}
+	return 0
+}
+
+func (x *HubStock) GetTargetIdleCount() int32 {
+	if x != nil {
+		return x.TargetIdleCount
+	}
+	return 0
+}
+
+type HubInventory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hub           *Hub                   `protobuf:"bytes,1,opt,name=hub,proto3" json:"hub,omitempty"`
+	Open          bool                   `protobuf:"varint,2,opt,name=open,proto3" json:"open,omitempty"`
+	Stocks        []*HubStock            `protobuf:"bytes,3,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HubInventory) Reset() {
+	*x = HubInventory{}
+	mi := &file_fleet_fleet_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HubInventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HubInventory) ProtoMessage() {}
+
+func (x *HubInventory) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HubInventory.ProtoReflect.Descriptor instead.
+func (*HubInventory) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HubInventory) GetHub() *Hub {
+	if x != nil {
+		return x.Hub
+	}
+	return nil
+}
+
+func (x *HubInventory) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+func (x *HubInventory) GetStocks() []*HubStock {
+	if x != nil {
+		return x.Stocks
+	}
+	return nil
+}
+
+type RebalancingMove struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MachineType    string                 `protobuf:"bytes,1,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	FromHubId      string                 `protobuf:"bytes,2,opt,name=from_hub_id,json=fromHubId,proto3" json:"from_hub_id,omitempty"`
+	ToHubId        string                 `protobuf:"bytes,3,opt,name=to_hub_id,json=toHubId,proto3" json:"to_hub_id,omitempty"`
+	MachineIds     []string               `protobuf:"bytes,4,rep,name=machine_ids,json=machineIds,proto3" json:"machine_ids,omitempty"`
+	DistanceMeters float64                `protobuf:"fixed64,5,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RebalancingMove) Reset() {
+	*x = RebalancingMove{}
+	mi := &file_fleet_fleet_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebalancingMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebalancingMove) ProtoMessage() {}
+
+func (x *RebalancingMove) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebalancingMove.ProtoReflect.Descriptor instead.
+func (*RebalancingMove) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RebalancingMove) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *RebalancingMove) GetFromHubId() string {
+	if x != nil {
+		return x.FromHubId
+	}
+	return ""
+}
+
+func (x *RebalancingMove) GetToHubId() string {
+	if x != nil {
+		return x.ToHubId
+	}
+	return ""
+}
+
+func (x *RebalancingMove) GetMachineIds() []string {
+	if x != nil {
+		return x.MachineIds
+	}
+	return nil
+}
+
+func (x *RebalancingMove) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+type MachineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Machine       *Machine               `protobuf:"bytes,1,opt,name=machine,proto3" json:"machine,omitempty"`
+	Credentials   *MachineCredentials    `protobuf:"bytes,2,opt,name=credentials,proto3" json:"credentials,omitempty"` // Only set by RegisterMachine
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MachineResponse) Reset() {
+	*x = MachineResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MachineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineResponse) ProtoMessage() {}
+
+func (x *MachineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineResponse.ProtoReflect.Descriptor instead.
+func (*MachineResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MachineResponse) GetMachine() *Machine {
+	if x != nil {
+		return x.Machine
+	}
+	return nil
+}
+
+func (x *MachineResponse) GetCredentials() *MachineCredentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type RegisterMachineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SerialNumber  string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	BatteryLevel  int32                  `protobuf:"varint,5,opt,name=battery_level,json=batteryLevel,proto3" json:"battery_level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterMachineRequest) Reset() {
+	*x = RegisterMachineRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterMachineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterMachineRequest) ProtoMessage() {}
+
+func (x *RegisterMachineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterMachineRequest.ProtoReflect.Descriptor instead.
+func (*RegisterMachineRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RegisterMachineRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *RegisterMachineRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RegisterMachineRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *RegisterMachineRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *RegisterMachineRequest) GetBatteryLevel() int32 {
+	if x != nil {
+		return x.BatteryLevel
+	}
+	return 0
+}
+
+type GetMachineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineId     string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMachineRequest) Reset() {
+	*x = GetMachineRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMachineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMachineRequest) ProtoMessage() {}
+
+func (x *GetMachineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMachineRequest.ProtoReflect.Descriptor instead.
+func (*GetMachineRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetMachineRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+type ListMachinesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                  // Optional filter
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                              // Optional filter; decommissioned machines are only listed when asked for
+	BoundingBox   *BoundingBox           `protobuf:"bytes,3,opt,name=bounding_box,json=boundingBox,proto3" json:"bounding_box,omitempty"` // Optional filter on the last known position
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`                                 // The requested page number
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                               // The number of machines per page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMachinesRequest) Reset() {
+	*x = ListMachinesRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMachinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMachinesRequest) ProtoMessage() {}
+
+func (x *ListMachinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMachinesRequest.ProtoReflect.Descriptor instead.
+func (*ListMachinesRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListMachinesRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListMachinesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListMachinesRequest) GetBoundingBox() *BoundingBox {
+	if x != nil {
+		return x.BoundingBox
+	}
+	return nil
+}
+
+func (x *ListMachinesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMachinesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListMachinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Machines      []*Machine             `protobuf:"bytes,1,rep,name=machines,proto3" json:"machines,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMachinesResponse) Reset() {
+	*x = ListMachinesResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMachinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMachinesResponse) ProtoMessage() {}
+
+func (x *ListMachinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMachinesResponse.ProtoReflect.Descriptor instead.
+func (*ListMachinesResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListMachinesResponse) GetMachines() []*Machine {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+func (x *ListMachinesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type UpdateMachineStatusRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MachineId       string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Status          string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Latitude        float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude       float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	BatteryLevel    *int32                 `protobuf:"varint,5,opt,name=battery_level,json=batteryLevel,proto3,oneof" json:"battery_level,omitempty"`          // Unchanged when unset
+	ExpectedVersion *int64                 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"` // Refused with ABORTED when the machine moved on from it
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateMachineStatusRequest) Reset() {
+	*x = UpdateMachineStatusRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMachineStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMachineStatusRequest) ProtoMessage() {}
+
+func (x *UpdateMachineStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMachineStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMachineStatusRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateMachineStatusRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *UpdateMachineStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateMachineStatusRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdateMachineStatusRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *UpdateMachineStatusRequest) GetBatteryLevel() int32 {
+	if x != nil && x.BatteryLevel != nil {
+		return *x.BatteryLevel
+	}
+	return 0
+}
+
+func (x *UpdateMachineStatusRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type DecommissionMachineRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MachineId       string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"` // Refused with ABORTED when the machine moved on from it
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DecommissionMachineRequest) Reset() {
+	*x = DecommissionMachineRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecommissionMachineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecommissionMachineRequest) ProtoMessage() {}
+
+func (x *DecommissionMachineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecommissionMachineRequest.ProtoReflect.Descriptor instead.
+func (*DecommissionMachineRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DecommissionMachineRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *DecommissionMachineRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type GetMachineStatusHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineId     string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMachineStatusHistoryRequest) Reset() {
+	*x = GetMachineStatusHistoryRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMachineStatusHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMachineStatusHistoryRequest) ProtoMessage() {}
+
+func (x *GetMachineStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMachineStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetMachineStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetMachineStatusHistoryRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *GetMachineStatusHistoryRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetMachineStatusHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetMachineStatusHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*MachineStatusEvent  `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // Newest first
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMachineStatusHistoryResponse) Reset() {
+	*x = GetMachineStatusHistoryResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMachineStatusHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMachineStatusHistoryResponse) ProtoMessage() {}
+
+func (x *GetMachineStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMachineStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetMachineStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetMachineStatusHistoryResponse) GetEvents() []*MachineStatusEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetMachineStatusHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	BatteryLevel  int32                  `protobuf:"varint,3,opt,name=battery_level,json=batteryLevel,proto3" json:"battery_level,omitempty"`
+	HealthFlags   []string               `protobuf:"bytes,4,rep,name=health_flags,json=healthFlags,proto3" json:"health_flags,omitempty"` // GPS_DEGRADED, COMMS_DEGRADED, MOTOR_FAULT, SENSOR_FAULT or OVERHEATING
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HeartbeatRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetBatteryLevel() int32 {
+	if x != nil {
+		return x.BatteryLevel
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetHealthFlags() []string {
+	if x != nil {
+		return x.HealthFlags
+	}
+	return nil
+}
+
+type HeartbeatResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Status                   string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // The machine's status after the heartbeat
+	ServerTime               *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`
+	HeartbeatIntervalSeconds int32                  `protobuf:"varint,3,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"` // When to send the next heartbeat
+	Charging                 *ChargingReservation   `protobuf:"bytes,4,opt,name=charging,proto3" json:"charging,omitempty"`                                                                    // Where to charge, while the status is CHARGING
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *HeartbeatResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HeartbeatResponse) GetServerTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ServerTime
+	}
+	return nil
+}
+
+func (x *HeartbeatResponse) GetHeartbeatIntervalSeconds() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+func (x *HeartbeatResponse) GetCharging() *ChargingReservation {
+	if x != nil {
+		return x.Charging
+	}
+	return nil
+}
+
+type CreateChargingStationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MachineType   string                 `protobuf:"bytes,2,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Slots         int32                  `protobuf:"varint,5,opt,name=slots,proto3" json:"slots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChargingStationRequest) Reset() {
+	*x = CreateChargingStationRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChargingStationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChargingStationRequest) ProtoMessage() {}
+
+func (x *CreateChargingStationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChargingStationRequest.ProtoReflect.Descriptor instead.
+func (*CreateChargingStationRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateChargingStationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateChargingStationRequest) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *CreateChargingStationRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *CreateChargingStationRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *CreateChargingStationRequest) GetSlots() int32 {
+	if x != nil {
+		return x.Slots
+	}
+	return 0
+}
+
+type ChargingStationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Station       *ChargingStation       `protobuf:"bytes,1,opt,name=station,proto3" json:"station,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChargingStationResponse) Reset() {
+	*x = ChargingStationResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargingStationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargingStationResponse) ProtoMessage() {}
+
+func (x *ChargingStationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargingStationResponse.ProtoReflect.Descriptor instead.
+func (*ChargingStationResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ChargingStationResponse) GetStation() *ChargingStation {
+	if x != nil {
+		return x.Station
+	}
+	return nil
+}
+
+type ListChargingStationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineType   string                 `protobuf:"bytes,1,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"` // Optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChargingStationsRequest) Reset() {
+	*x = ListChargingStationsRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChargingStationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChargingStationsRequest) ProtoMessage() {}
+
+func (x *ListChargingStationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChargingStationsRequest.ProtoReflect.Descriptor instead.
+func (*ListChargingStationsRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListChargingStationsRequest) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+type ListChargingStationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stations      []*ChargingStation     `protobuf:"bytes,1,rep,name=stations,proto3" json:"stations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChargingStationsResponse) Reset() {
+	*x = ListChargingStationsResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChargingStationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChargingStationsResponse) ProtoMessage() {}
+
+func (x *ListChargingStationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChargingStationsResponse.ProtoReflect.Descriptor instead.
+func (*ListChargingStationsResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListChargingStationsResponse) GetStations() []*ChargingStation {
+	if x != nil {
+		return x.Stations
+	}
+	return nil
+}
+
+type CheckTripBatteryRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MachineId      string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	DistanceMeters float64                `protobuf:"fixed64,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"` // From pickup to dropoff
+	PayloadKg      float64                `protobuf:"fixed64,3,opt,name=payload_kg,json=payloadKg,proto3" json:"payload_kg,omitempty"`
+	Pickup         *Location              `protobuf:"bytes,4,opt,name=pickup,proto3" json:"pickup,omitempty"`                                          // Optional; the way there is included when set
+	ChargeIfNeeded bool                   `protobuf:"varint,5,opt,name=charge_if_needed,json=chargeIfNeeded,proto3" json:"charge_if_needed,omitempty"` // Send an idle machine to charge when it cannot make the trip
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckTripBatteryRequest) Reset() {
+	*x = CheckTripBatteryRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTripBatteryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTripBatteryRequest) ProtoMessage() {}
+
+func (x *CheckTripBatteryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTripBatteryRequest.ProtoReflect.Descriptor instead.
+func (*CheckTripBatteryRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CheckTripBatteryRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *CheckTripBatteryRequest) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *CheckTripBatteryRequest) GetPayloadKg() float64 {
+	if x != nil {
+		return x.PayloadKg
+	}
+	return 0
+}
+
+func (x *CheckTripBatteryRequest) GetPickup() *Location {
+	if x != nil {
+		return x.Pickup
+	}
+	return nil
+}
+
+func (x *CheckTripBatteryRequest) GetChargeIfNeeded() bool {
+	if x != nil {
+		return x.ChargeIfNeeded
+	}
+	return false
+}
+
+type CheckTripBatteryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Check         *TripBatteryCheck      `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	Charging      *ChargingReservation   `protobuf:"bytes,2,opt,name=charging,proto3" json:"charging,omitempty"` // Set when the machine was sent to charge
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckTripBatteryResponse) Reset() {
+	*x = CheckTripBatteryResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTripBatteryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTripBatteryResponse) ProtoMessage() {}
+
+func (x *CheckTripBatteryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTripBatteryResponse.ProtoReflect.Descriptor instead.
+func (*CheckTripBatteryResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CheckTripBatteryResponse) GetCheck() *TripBatteryCheck {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+func (x *CheckTripBatteryResponse) GetCharging() *ChargingReservation {
+	if x != nil {
+		return x.Charging
+	}
+	return nil
+}
+
+type ListEligibleMachinesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MachineType    string                 `protobuf:"bytes,1,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	DistanceMeters float64                `protobuf:"fixed64,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"` // From pickup to dropoff
+	PayloadKg      float64                `protobuf:"fixed64,3,opt,name=payload_kg,json=payloadKg,proto3" json:"payload_kg,omitempty"`
+	Pickup         *Location              `protobuf:"bytes,4,opt,name=pickup,proto3" json:"pickup,omitempty"` // Optional; closest machines come first when set
+	Limit          int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`  // All eligible machines when unset
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListEligibleMachinesRequest) Reset() {
+	*x = ListEligibleMachinesRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEligibleMachinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEligibleMachinesRequest) ProtoMessage() {}
+
+func (x *ListEligibleMachinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEligibleMachinesRequest.ProtoReflect.Descriptor instead.
+func (*ListEligibleMachinesRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListEligibleMachinesRequest) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *ListEligibleMachinesRequest) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *ListEligibleMachinesRequest) GetPayloadKg() float64 {
+	if x != nil {
+		return x.PayloadKg
+	}
+	return 0
+}
+
+func (x *ListEligibleMachinesRequest) GetPickup() *Location {
+	if x != nil {
+		return x.Pickup
+	}
+	return nil
+}
+
+func (x *ListEligibleMachinesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListEligibleMachinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Machines      []*TripBatteryCheck    `protobuf:"bytes,1,rep,name=machines,proto3" json:"machines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEligibleMachinesResponse) Reset() {
+	*x = ListEligibleMachinesResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEligibleMachinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEligibleMachinesResponse) ProtoMessage() {}
+
+func (x *ListEligibleMachinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEligibleMachinesResponse.ProtoReflect.Descriptor instead.
+func (*ListEligibleMachinesResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListEligibleMachinesResponse) GetMachines() []*TripBatteryCheck {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+type WorkOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkOrder     *WorkOrder             `protobuf:"bytes,1,opt,name=work_order,json=workOrder,proto3" json:"work_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkOrderResponse) Reset() {
+	*x = WorkOrderResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkOrderResponse) ProtoMessage() {}
+
+func (x *WorkOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkOrderResponse.ProtoReflect.Descriptor instead.
+func (*WorkOrderResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WorkOrderResponse) GetWorkOrder() *WorkOrder {
+	if x != nil {
+		return x.WorkOrder
+	}
+	return nil
+}
+
+type ReportFaultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineId     string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	FaultCode     string                 `protobuf:"bytes,2,opt,name=fault_code,json=faultCode,proto3" json:"fault_code,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportFaultRequest) Reset() {
+	*x = ReportFaultRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportFaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportFaultRequest) ProtoMessage() {}
+
+func (x *ReportFaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportFaultRequest.ProtoReflect.Descriptor instead.
+func (*ReportFaultRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReportFaultRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *ReportFaultRequest) GetFaultCode() string {
+	if x != nil {
+		return x.FaultCode
+	}
+	return ""
+}
+
+func (x *ReportFaultRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type OpenWorkOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineId     string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`          // inspection or repair
+	Blocking      bool                   `protobuf:"varint,3,opt,name=blocking,proto3" json:"blocking,omitempty"` // Take the machine out of service until the work is done
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenWorkOrderRequest) Reset() {
+	*x = OpenWorkOrderRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenWorkOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenWorkOrderRequest) ProtoMessage() {}
+
+func (x *OpenWorkOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenWorkOrderRequest.ProtoReflect.Descriptor instead.
+func (*OpenWorkOrderRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *OpenWorkOrderRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *OpenWorkOrderRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *OpenWorkOrderRequest) GetBlocking() bool {
+	if x != nil {
+		return x.Blocking
+	}
+	return false
+}
+
+func (x *OpenWorkOrderRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type StartWorkOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkOrderId   string                 `protobuf:"bytes,1,opt,name=work_order_id,json=workOrderId,proto3" json:"work_order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartWorkOrderRequest) Reset() {
+	*x = StartWorkOrderRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartWorkOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartWorkOrderRequest) ProtoMessage() {}
+
+func (x *StartWorkOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartWorkOrderRequest.ProtoReflect.Descriptor instead.
+func (*StartWorkOrderRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *StartWorkOrderRequest) GetWorkOrderId() string {
+	if x != nil {
+		return x.WorkOrderId
+	}
+	return ""
+}
+
+type CloseWorkOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WorkOrderId     string                 `protobuf:"bytes,1,opt,name=work_order_id,json=workOrderId,proto3" json:"work_order_id,omitempty"`
+	TechnicianNotes string                 `protobuf:"bytes,2,opt,name=technician_notes,json=technicianNotes,proto3" json:"technician_notes,omitempty"`
+	PartsReplaced   []*ReplacedPart        `protobuf:"bytes,3,rep,name=parts_replaced,json=partsReplaced,proto3" json:"parts_replaced,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CloseWorkOrderRequest) Reset() {
+	*x = CloseWorkOrderRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseWorkOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseWorkOrderRequest) ProtoMessage() {}
+
+func (x *CloseWorkOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseWorkOrderRequest.ProtoReflect.Descriptor instead.
+func (*CloseWorkOrderRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CloseWorkOrderRequest) GetWorkOrderId() string {
+	if x != nil {
+		return x.WorkOrderId
+	}
+	return ""
+}
+
+func (x *CloseWorkOrderRequest) GetTechnicianNotes() string {
+	if x != nil {
+		return x.TechnicianNotes
+	}
+	return ""
+}
+
+func (x *CloseWorkOrderRequest) GetPartsReplaced() []*ReplacedPart {
+	if x != nil {
+		return x.PartsReplaced
+	}
+	return nil
+}
+
+type ListWorkOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineId     string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"` // Optional filter; the machine's service history
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                            // Optional filter
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                        // Optional filter
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkOrdersRequest) Reset() {
+	*x = ListWorkOrdersRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkOrdersRequest) ProtoMessage() {}
+
+func (x *ListWorkOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListWorkOrdersRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *ListWorkOrdersRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListWorkOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListWorkOrdersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListWorkOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWorkOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkOrders    []*WorkOrder           `protobuf:"bytes,1,rep,name=work_orders,json=workOrders,proto3" json:"work_orders,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkOrdersResponse) Reset() {
+	*x = ListWorkOrdersResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkOrdersResponse) ProtoMessage() {}
+
+func (x *ListWorkOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListWorkOrdersResponse) GetWorkOrders() []*WorkOrder {
+	if x != nil {
+		return x.WorkOrders
+	}
+	return nil
+}
+
+func (x *ListWorkOrdersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateHubRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Latitude            float64                `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude           float64                `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Capacity            int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	ServiceRadiusMeters float64                `protobuf:"fixed64,5,opt,name=service_radius_meters,json=serviceRadiusMeters,proto3" json:"service_radius_meters,omitempty"`
+	Timezone            string                 `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`              // Defaults to UTC
+	OpensAt             string                 `protobuf:"bytes,7,opt,name=opens_at,json=opensAt,proto3" json:"opens_at,omitempty"` // HH:MM; leave both unset when open around the clock
+	ClosesAt            string                 `protobuf:"bytes,8,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateHubRequest) Reset() {
+	*x = CreateHubRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHubRequest) ProtoMessage() {}
+
+func (x *CreateHubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHubRequest.ProtoReflect.Descriptor instead.
+func (*CreateHubRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateHubRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateHubRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *CreateHubRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *CreateHubRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateHubRequest) GetServiceRadiusMeters() float64 {
+	if x != nil {
+		return x.ServiceRadiusMeters
+	}
+	return 0
+}
+
+func (x *CreateHubRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateHubRequest) GetOpensAt() string {
+	if x != nil {
+		return x.OpensAt
+	}
+	return ""
+}
+
+func (x *CreateHubRequest) GetClosesAt() string {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return ""
+}
+
+type HubResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hub           *Hub                   `protobuf:"bytes,1,opt,name=hub,proto3" json:"hub,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HubResponse) Reset() {
+	*x = HubResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HubResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HubResponse) ProtoMessage() {}
+
+func (x *HubResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HubResponse.ProtoReflect.Descriptor instead.
+func (*HubResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *HubResponse) GetHub() *Hub {
+	if x != nil {
+		return x.Hub
+	}
+	return nil
+}
+
+type ListHubsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHubsRequest) Reset() {
+	*x = ListHubsRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHubsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHubsRequest) ProtoMessage() {}
+
+func (x *ListHubsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHubsRequest.ProtoReflect.Descriptor instead.
+func (*ListHubsRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{42}
+}
+
+type ListHubsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hubs          []*Hub                 `protobuf:"bytes,1,rep,name=hubs,proto3" json:"hubs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHubsResponse) Reset() {
+	*x = ListHubsResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHubsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHubsResponse) ProtoMessage() {}
+
+func (x *ListHubsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHubsResponse.ProtoReflect.Descriptor instead.
+func (*ListHubsResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListHubsResponse) GetHubs() []*Hub {
+	if x != nil {
+		return x.Hubs
+	}
+	return nil
+}
+
+type AssignHomeHubRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineId     string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	HubId         string                 `protobuf:"bytes,2,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"` // Empty to unassign
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignHomeHubRequest) Reset() {
+	*x = AssignHomeHubRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignHomeHubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignHomeHubRequest) ProtoMessage() {}
+
+func (x *AssignHomeHubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignHomeHubRequest.ProtoReflect.Descriptor instead.
+func (*AssignHomeHubRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AssignHomeHubRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *AssignHomeHubRequest) GetHubId() string {
+	if x != nil {
+		return x.HubId
+	}
+	return ""
+}
+
+type ListHubInventoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHubInventoriesRequest) Reset() {
+	*x = ListHubInventoriesRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHubInventoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHubInventoriesRequest) ProtoMessage() {}
+
+func (x *ListHubInventoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHubInventoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListHubInventoriesRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{45}
+}
+
+type ListHubInventoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inventories   []*HubInventory        `protobuf:"bytes,1,rep,name=inventories,proto3" json:"inventories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHubInventoriesResponse) Reset() {
+	*x = ListHubInventoriesResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHubInventoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHubInventoriesResponse) ProtoMessage() {}
+
+func (x *ListHubInventoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHubInventoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListHubInventoriesResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListHubInventoriesResponse) GetInventories() []*HubInventory {
+	if x != nil {
+		return x.Inventories
+	}
+	return nil
+}
+
+type SuggestRebalancingRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MachineType    string                 `protobuf:"bytes,1,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`           // Optional; all types when unset
+	HorizonMinutes int32                  `protobuf:"varint,2,opt,name=horizon_minutes,json=horizonMinutes,proto3" json:"horizon_minutes,omitempty"` // The period to plan for; 120 when unset
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SuggestRebalancingRequest) Reset() {
+	*x = SuggestRebalancingRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRebalancingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRebalancingRequest) ProtoMessage() {}
+
+func (x *SuggestRebalancingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRebalancingRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRebalancingRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SuggestRebalancingRequest) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *SuggestRebalancingRequest) GetHorizonMinutes() int32 {
+	if x != nil {
+		return x.HorizonMinutes
+	}
+	return 0
+}
+
+type SuggestRebalancingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Moves         []*RebalancingMove     `protobuf:"bytes,1,rep,name=moves,proto3" json:"moves,omitempty"`
+	Inventories   []*HubInventory        `protobuf:"bytes,2,rep,name=inventories,proto3" json:"inventories,omitempty"` // With the forecast and targets the moves were planned from
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRebalancingResponse) Reset() {
+	*x = SuggestRebalancingResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRebalancingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRebalancingResponse) ProtoMessage() {}
+
+func (x *SuggestRebalancingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRebalancingResponse.ProtoReflect.Descriptor instead.
+func (*SuggestRebalancingResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SuggestRebalancingResponse) GetMoves() []*RebalancingMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *SuggestRebalancingResponse) GetInventories() []*HubInventory {
+	if x != nil {
+		return x.Inventories
+	}
+	return nil
+}
+
+type FindNearbyMachinesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Latitude        float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"` // The pickup
+	Longitude       float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	RadiusMeters    float64                `protobuf:"fixed64,3,opt,name=radius_meters,json=radiusMeters,proto3" json:"radius_meters,omitempty"`           // Defaults to 5 km; at most 50 km
+	MachineType     string                 `protobuf:"bytes,4,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`                // Optional; any type able to carry the payload when unset
+	MinBatteryLevel int32                  `protobuf:"varint,5,opt,name=min_battery_level,json=minBatteryLevel,proto3" json:"min_battery_level,omitempty"` // Percent
+	PayloadKg       float64                `protobuf:"fixed64,6,opt,name=payload_kg,json=payloadKg,proto3" json:"payload_kg,omitempty"`
+	Limit           int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 10; at most 100
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FindNearbyMachinesRequest) Reset() {
+	*x = FindNearbyMachinesRequest{}
+	mi := &file_fleet_fleet_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindNearbyMachinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearbyMachinesRequest) ProtoMessage() {}
+
+func (x *FindNearbyMachinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearbyMachinesRequest.ProtoReflect.Descriptor instead.
+func (*FindNearbyMachinesRequest) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *FindNearbyMachinesRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *FindNearbyMachinesRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *FindNearbyMachinesRequest) GetRadiusMeters() float64 {
+	if x != nil {
+		return x.RadiusMeters
+	}
+	return 0
+}
+
+func (x *FindNearbyMachinesRequest) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *FindNearbyMachinesRequest) GetMinBatteryLevel() int32 {
+	if x != nil {
+		return x.MinBatteryLevel
+	}
+	return 0
+}
+
+func (x *FindNearbyMachinesRequest) GetPayloadKg() float64 {
+	if x != nil {
+		return x.PayloadKg
+	}
+	return 0
+}
+
+func (x *FindNearbyMachinesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type NearbyMachine struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Machine        *Machine               `protobuf:"bytes,1,opt,name=machine,proto3" json:"machine,omitempty"`
+	DistanceMeters float64                `protobuf:"fixed64,2,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NearbyMachine) Reset() {
+	*x = NearbyMachine{}
+	mi := &file_fleet_fleet_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyMachine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyMachine) ProtoMessage() {}
+
+func (x *NearbyMachine) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyMachine.ProtoReflect.Descriptor instead.
+func (*NearbyMachine) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *NearbyMachine) GetMachine() *Machine {
+	if x != nil {
+		return x.Machine
+	}
+	return nil
+}
+
+func (x *NearbyMachine) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+type FindNearbyMachinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Machines      []*NearbyMachine       `protobuf:"bytes,1,rep,name=machines,proto3" json:"machines,omitempty"` // Nearest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindNearbyMachinesResponse) Reset() {
+	*x = FindNearbyMachinesResponse{}
+	mi := &file_fleet_fleet_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindNearbyMachinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearbyMachinesResponse) ProtoMessage() {}
+
+func (x *FindNearbyMachinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fleet_fleet_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearbyMachinesResponse.ProtoReflect.Descriptor instead.
+func (*FindNearbyMachinesResponse) Descriptor() ([]byte, []int) {
+	return file_fleet_fleet_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *FindNearbyMachinesResponse) GetMachines() []*NearbyMachine {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+var File_fleet_fleet_proto protoreflect.FileDescriptor
+
+const file_fleet_fleet_proto_rawDesc = "" +
+	"\n" +
+	"\x11fleet/fleet.proto\x12\x05fleet\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcb\x05\n" +
+	"\aMachine\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rserial_number\x18\x02 \x01(\tR\fserialNumber\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
+	"\blatitude\x18\x05 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x06 \x01(\x01R\tlongitude\x12#\n" +
+	"\rbattery_level\x18\a \x01(\x05R\fbatteryLevel\x12G\n" +
+	"\x11decommissioned_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x10decommissionedAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fhealth_flags\x18\v \x03(\tR\vhealthFlags\x12F\n" +
+	"\x11last_heartbeat_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x0flastHeartbeatAt\x12'\n" +
+	"\x0fodometer_meters\x18\r \x01(\x01R\x0eodometerMeters\x12+\n" +
+	"\x11operating_seconds\x18\x0e \x01(\x03R\x10operatingSeconds\x12F\n" +
+	"\x11last_inspected_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x0flastInspectedAt\x12\x1e\n" +
+	"\vhome_hub_id\x18\x10 \x01(\tR\thomeHubId\x12\x18\n" +
+	"\aversion\x18\x11 \x01(\x03R\aversion\"\x89\x02\n" +
+	"\x12MachineStatusEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x02 \x01(\tR\tmachineId\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x12\x19\n" +
+	"\bactor_id\x18\a \x01(\tR\aactorId\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf3\x01\n" +
+	"\x12MachineCredentials\x12#\n" +
+	"\rserial_number\x18\x01 \x01(\tR\fserialNumber\x12'\n" +
+	"\x0fcertificate_pem\x18\x02 \x01(\tR\x0ecertificatePem\x12&\n" +
+	"\x0fprivate_key_pem\x18\x03 \x01(\tR\rprivateKeyPem\x12,\n" +
+	"\x12ca_certificate_pem\x18\x04 \x01(\tR\x10caCertificatePem\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x9d\x01\n" +
+	"\vBoundingBox\x12!\n" +
+	"\fmin_latitude\x18\x01 \x01(\x01R\vminLatitude\x12#\n" +
+	"\rmin_longitude\x18\x02 \x01(\x01R\fminLongitude\x12!\n" +
+	"\fmax_latitude\x18\x03 \x01(\x01R\vmaxLatitude\x12#\n" +
+	"\rmax_longitude\x18\x04 \x01(\x01R\fmaxLongitude\"\x85\x02\n" +
+	"\x0fChargingStation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fmachine_type\x18\x03 \x01(\tR\vmachineType\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12\x14\n" +
+	"\x05slots\x18\x06 \x01(\x05R\x05slots\x12 \n" +
+	"\fslots_in_use\x18\a \x01(\x05R\n" +
+	"slotsInUse\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x96\x02\n" +
+	"\x13ChargingReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x02 \x01(\tR\tmachineId\x120\n" +
+	"\astation\x18\x03 \x01(\v2\x16.fleet.ChargingStationR\astation\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x123\n" +
+	"\x16battery_level_at_start\x18\x06 \x01(\x05R\x13batteryLevelAtStart\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"D\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xe8\x01\n" +
+	"\x10TripBatteryCheck\x12(\n" +
+	"\amachine\x18\x01 \x01(\v2\x0e.fleet.MachineR\amachine\x124\n" +
+	"\x16pickup_distance_meters\x18\x02 \x01(\x01R\x14pickupDistanceMeters\x12 \n" +
+	"\vconsumption\x18\x03 \x01(\x01R\vconsumption\x126\n" +
+	"\x17projected_battery_level\x18\x04 \x01(\x01R\x15projectedBatteryLevel\x12\x1a\n" +
+	"\bfeasible\x18\x05 \x01(\bR\bfeasible\"_\n" +
+	"\fReplacedPart\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\tR\n" +
+	"partNumber\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\xd4\x04\n" +
+	"\tWorkOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x02 \x01(\tR\tmachineId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
+	"\bblocking\x18\x05 \x01(\bR\bblocking\x12\x1d\n" +
+	"\n" +
+	"fault_code\x18\x06 \x01(\tR\tfaultCode\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12#\n" +
+	"\rtechnician_id\x18\b \x01(\tR\ftechnicianId\x12)\n" +
+	"\x10technician_notes\x18\t \x01(\tR\x0ftechnicianNotes\x12:\n" +
+	"\x0eparts_replaced\x18\n" +
+	" \x03(\v2\x13.fleet.ReplacedPartR\rpartsReplaced\x12'\n" +
+	"\x0fodometer_meters\x18\v \x01(\x01R\x0eodometerMeters\x12+\n" +
+	"\x11operating_seconds\x18\f \x01(\x03R\x10operatingSeconds\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x127\n" +
+	"\tclosed_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\"\xf2\x02\n" +
+	"\x03Hub\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\bcapacity\x18\x05 \x01(\x05R\bcapacity\x122\n" +
+	"\x15service_radius_meters\x18\x06 \x01(\x01R\x13serviceRadiusMeters\x12\x1a\n" +
+	"\btimezone\x18\a \x01(\tR\btimezone\x12\x19\n" +
+	"\bopens_at\x18\b \x01(\tR\aopensAt\x12\x1b\n" +
+	"\tcloses_at\x18\t \x01(\tR\bclosesAt\x12.\n" +
+	"\x13home_based_machines\x18\n" +
+	" \x01(\x05R\x11homeBasedMachines\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xcb\x01\n" +
+	"\bHubStock\x12!\n" +
+	"\fmachine_type\x18\x01 \x01(\tR\vmachineType\x12\x1d\n" +
+	"\n" +
+	"home_based\x18\x02 \x01(\x05R\thomeBased\x12(\n" +
+	"\x10idle_machine_ids\x18\x03 \x03(\tR\x0eidleMachineIds\x12'\n" +
+	"\x0fforecast_demand\x18\x04 \x01(\x01R\x0eforecastDemand\x12*\n" +
+	"\x11target_idle_count\x18\x05 \x01(\x05R\x0ftargetIdleCount\"i\n" +
+	"\fHubInventory\x12\x1c\n" +
+	"\x03hub\x18\x01 \x01(\v2\n" +
+	".fleet.HubR\x03hub\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\bR\x04open\x12'\n" +
+	"\x06stocks\x18\x03 \x03(\v2\x0f.fleet.HubStockR\x06stocks\"\xba\x01\n" +
+	"\x0fRebalancingMove\x12!\n" +
+	"\fmachine_type\x18\x01 \x01(\tR\vmachineType\x12\x1e\n" +
+	"\vfrom_hub_id\x18\x02 \x01(\tR\tfromHubId\x12\x1a\n" +
+	"\tto_hub_id\x18\x03 \x01(\tR\atoHubId\x12\x1f\n" +
+	"\vmachine_ids\x18\x04 \x03(\tR\n" +
+	"machineIds\x12'\n" +
+	"\x0fdistance_meters\x18\x05 \x01(\x01R\x0edistanceMeters\"x\n" +
+	"\x0fMachineResponse\x12(\n" +
+	"\amachine\x18\x01 \x01(\v2\x0e.fleet.MachineR\amachine\x12;\n" +
+	"\vcredentials\x18\x02 \x01(\v2\x19.fleet.MachineCredentialsR\vcredentials\"\xb0\x01\n" +
+	"\x16RegisterMachineRequest\x12#\n" +
+	"\rserial_number\x18\x01 \x01(\tR\fserialNumber\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12#\n" +
+	"\rbattery_level\x18\x05 \x01(\x05R\fbatteryLevel\"2\n" +
+	"\x11GetMachineRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\"\xa2\x01\n" +
+	"\x13ListMachinesRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x125\n" +
+	"\fbounding_box\x18\x03 \x01(\v2\x12.fleet.BoundingBoxR\vboundingBox\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"X\n" +
+	"\x14ListMachinesResponse\x12*\n" +
+	"\bmachines\x18\x01 \x03(\v2\x0e.fleet.MachineR\bmachines\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\x8e\x02\n" +
+	"\x1aUpdateMachineStatusRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12(\n" +
+	"\rbattery_level\x18\x05 \x01(\x05H\x00R\fbatteryLevel\x88\x01\x01\x12.\n" +
+	"\x10expected_version\x18\x06 \x01(\x03H\x01R\x0fexpectedVersion\x88\x01\x01B\x10\n" +
+	"\x0e_battery_levelB\x13\n" +
+	"\x11_expected_version\"\x80\x01\n" +
+	"\x1aDecommissionMachineRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"i\n" +
+	"\x1eGetMachineStatusHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"j\n" +
+	"\x1fGetMachineStatusHistoryResponse\x121\n" +
+	"\x06events\x18\x01 \x03(\v2\x19.fleet.MachineStatusEventR\x06events\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\x94\x01\n" +
+	"\x10HeartbeatRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12#\n" +
+	"\rbattery_level\x18\x03 \x01(\x05R\fbatteryLevel\x12!\n" +
+	"\fhealth_flags\x18\x04 \x03(\tR\vhealthFlags\"\xde\x01\n" +
+	"\x11HeartbeatResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12;\n" +
+	"\vserver_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"serverTime\x12<\n" +
+	"\x1aheartbeat_interval_seconds\x18\x03 \x01(\x05R\x18heartbeatIntervalSeconds\x126\n" +
+	"\bcharging\x18\x04 \x01(\v2\x1a.fleet.ChargingReservationR\bcharging\"\xa5\x01\n" +
+	"\x1cCreateChargingStationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fmachine_type\x18\x02 \x01(\tR\vmachineType\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12\x14\n" +
+	"\x05slots\x18\x05 \x01(\x05R\x05slots\"K\n" +
+	"\x17ChargingStationResponse\x120\n" +
+	"\astation\x18\x01 \x01(\v2\x16.fleet.ChargingStationR\astation\"@\n" +
+	"\x1bListChargingStationsRequest\x12!\n" +
+	"\fmachine_type\x18\x01 \x01(\tR\vmachineType\"R\n" +
+	"\x1cListChargingStationsResponse\x122\n" +
+	"\bstations\x18\x01 \x03(\v2\x16.fleet.ChargingStationR\bstations\"\xd3\x01\n" +
+	"\x17CheckTripBatteryRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\x12\x1d\n" +
+	"\n" +
+	"payload_kg\x18\x03 \x01(\x01R\tpayloadKg\x12'\n" +
+	"\x06pickup\x18\x04 \x01(\v2\x0f.fleet.LocationR\x06pickup\x12(\n" +
+	"\x10charge_if_needed\x18\x05 \x01(\bR\x0echargeIfNeeded\"\x81\x01\n" +
+	"\x18CheckTripBatteryResponse\x12-\n" +
+	"\x05check\x18\x01 \x01(\v2\x17.fleet.TripBatteryCheckR\x05check\x126\n" +
+	"\bcharging\x18\x02 \x01(\v2\x1a.fleet.ChargingReservationR\bcharging\"\xc7\x01\n" +
+	"\x1bListEligibleMachinesRequest\x12!\n" +
+	"\fmachine_type\x18\x01 \x01(\tR\vmachineType\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\x12\x1d\n" +
+	"\n" +
+	"payload_kg\x18\x03 \x01(\x01R\tpayloadKg\x12'\n" +
+	"\x06pickup\x18\x04 \x01(\v2\x0f.fleet.LocationR\x06pickup\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"S\n" +
+	"\x1cListEligibleMachinesResponse\x123\n" +
+	"\bmachines\x18\x01 \x03(\v2\x17.fleet.TripBatteryCheckR\bmachines\"D\n" +
+	"\x11WorkOrderResponse\x12/\n" +
+	"\n" +
+	"work_order\x18\x01 \x01(\v2\x10.fleet.WorkOrderR\tworkOrder\"t\n" +
+	"\x12ReportFaultRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12\x1d\n" +
+	"\n" +
+	"fault_code\x18\x02 \x01(\tR\tfaultCode\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\x87\x01\n" +
+	"\x14OpenWorkOrderRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bblocking\x18\x03 \x01(\bR\bblocking\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\";\n" +
+	"\x15StartWorkOrderRequest\x12\"\n" +
+	"\rwork_order_id\x18\x01 \x01(\tR\vworkOrderId\"\xa2\x01\n" +
+	"\x15CloseWorkOrderRequest\x12\"\n" +
+	"\rwork_order_id\x18\x01 \x01(\tR\vworkOrderId\x12)\n" +
+	"\x10technician_notes\x18\x02 \x01(\tR\x0ftechnicianNotes\x12:\n" +
+	"\x0eparts_replaced\x18\x03 \x03(\v2\x13.fleet.ReplacedPartR\rpartsReplaced\"\x8c\x01\n" +
+	"\x15ListWorkOrdersRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"a\n" +
+	"\x16ListWorkOrdersResponse\x121\n" +
+	"\vwork_orders\x18\x01 \x03(\v2\x10.fleet.WorkOrderR\n" +
+	"workOrders\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\x84\x02\n" +
+	"\x10CreateHubRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x02 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x03 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x122\n" +
+	"\x15service_radius_meters\x18\x05 \x01(\x01R\x13serviceRadiusMeters\x12\x1a\n" +
+	"\btimezone\x18\x06 \x01(\tR\btimezone\x12\x19\n" +
+	"\bopens_at\x18\a \x01(\tR\aopensAt\x12\x1b\n" +
+	"\tcloses_at\x18\b \x01(\tR\bclosesAt\"+\n" +
+	"\vHubResponse\x12\x1c\n" +
+	"\x03hub\x18\x01 \x01(\v2\n" +
+	".fleet.HubR\x03hub\"\x11\n" +
+	"\x0fListHubsRequest\"2\n" +
+	"\x10ListHubsResponse\x12\x1e\n" +
+	"\x04hubs\x18\x01 \x03(\v2\n" +
+	".fleet.HubR\x04hubs\"L\n" +
+	"\x14AssignHomeHubRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12\x15\n" +
+	"\x06hub_id\x18\x02 \x01(\tR\x05hubId\"\x1b\n" +
+	"\x19ListHubInventoriesRequest\"S\n" +
+	"\x1aListHubInventoriesResponse\x125\n" +
+	"\vinventories\x18\x01 \x03(\v2\x13.fleet.HubInventoryR\vinventories\"g\n" +
+	"\x19SuggestRebalancingRequest\x12!\n" +
+	"\fmachine_type\x18\x01 \x01(\tR\vmachineType\x12'\n" +
+	"\x0fhorizon_minutes\x18\x02 \x01(\x05R\x0ehorizonMinutes\"\x81\x01\n" +
+	"\x1aSuggestRebalancingResponse\x12,\n" +
+	"\x05moves\x18\x01 \x03(\v2\x16.fleet.RebalancingMoveR\x05moves\x125\n" +
+	"\vinventories\x18\x02 \x03(\v2\x13.fleet.HubInventoryR\vinventories\"\xfe\x01\n" +
+	"\x19FindNearbyMachinesRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12#\n" +
+	"\rradius_meters\x18\x03 \x01(\x01R\fradiusMeters\x12!\n" +
+	"\fmachine_type\x18\x04 \x01(\tR\vmachineType\x12*\n" +
+	"\x11min_battery_level\x18\x05 \x01(\x05R\x0fminBatteryLevel\x12\x1d\n" +
+	"\n" +
+	"payload_kg\x18\x06 \x01(\x01R\tpayloadKg\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"b\n" +
+	"\rNearbyMachine\x12(\n" +
+	"\amachine\x18\x01 \x01(\v2\x0e.fleet.MachineR\amachine\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\"N\n" +
+	"\x1aFindNearbyMachinesResponse\x120\n" +
+	"\bmachines\x18\x01 \x03(\v2\x14.fleet.NearbyMachineR\bmachines2\xb3\x0e\n" +
+	"\fFleetService\x12H\n" +
+	"\x0fRegisterMachine\x12\x1d.fleet.RegisterMachineRequest\x1a\x16.fleet.MachineResponse\x12>\n" +
+	"\n" +
+	"GetMachine\x12\x18.fleet.GetMachineRequest\x1a\x16.fleet.MachineResponse\x12G\n" +
+	"\fListMachines\x12\x1a.fleet.ListMachinesRequest\x1a\x1b.fleet.ListMachinesResponse\x12P\n" +
+	"\x13DecommissionMachine\x12!.fleet.DecommissionMachineRequest\x1a\x16.fleet.MachineResponse\x12P\n" +
+	"\x13UpdateMachineStatus\x12!.fleet.UpdateMachineStatusRequest\x1a\x16.fleet.MachineResponse\x12h\n" +
+	"\x17GetMachineStatusHistory\x12%.fleet.GetMachineStatusHistoryRequest\x1a&.fleet.GetMachineStatusHistoryResponse\x12B\n" +
+	"\tHeartbeat\x12\x17.fleet.HeartbeatRequest\x1a\x18.fleet.HeartbeatResponse(\x010\x01\x12\\\n" +
+	"\x15CreateChargingStation\x12#.fleet.CreateChargingStationRequest\x1a\x1e.fleet.ChargingStationResponse\x12_\n" +
+	"\x14ListChargingStations\x12\".fleet.ListChargingStationsRequest\x1a#.fleet.ListChargingStationsResponse\x12S\n" +
+	"\x10CheckTripBattery\x12\x1e.fleet.CheckTripBatteryRequest\x1a\x1f.fleet.CheckTripBatteryResponse\x12_\n" +
+	"\x14ListEligibleMachines\x12\".fleet.ListEligibleMachinesRequest\x1a#.fleet.ListEligibleMachinesResponse\x12Y\n" +
+	"\x12FindNearbyMachines\x12 .fleet.FindNearbyMachinesRequest\x1a!.fleet.FindNearbyMachinesResponse\x12B\n" +
+	"\vReportFault\x12\x19.fleet.ReportFaultRequest\x1a\x18.fleet.WorkOrderResponse\x12F\n" +
+	"\rOpenWorkOrder\x12\x1b.fleet.OpenWorkOrderRequest\x1a\x18.fleet.WorkOrderResponse\x12H\n" +
+	"\x0eStartWorkOrder\x12\x1c.fleet.StartWorkOrderRequest\x1a\x18.fleet.WorkOrderResponse\x12K\n" +
+	"\x11CompleteWorkOrder\x12\x1c.fleet.CloseWorkOrderRequest\x1a\x18.fleet.WorkOrderResponse\x12I\n" +
+	"\x0fCancelWorkOrder\x12\x1c.fleet.CloseWorkOrderRequest\x1a\x18.fleet.WorkOrderResponse\x12M\n" +
+	"\x0eListWorkOrders\x12\x1c.fleet.ListWorkOrdersRequest\x1a\x1d.fleet.ListWorkOrdersResponse\x128\n" +
+	"\tCreateHub\x12\x17.fleet.CreateHubRequest\x1a\x12.fleet.HubResponse\x12;\n" +
+	"\bListHubs\x12\x16.fleet.ListHubsRequest\x1a\x17.fleet.ListHubsResponse\x12D\n" +
+	"\rAssignHomeHub\x12\x1b.fleet.AssignHomeHubRequest\x1a\x16.fleet.MachineResponse\x12Y\n" +
+	"\x12ListHubInventories\x12 .fleet.ListHubInventoriesRequest\x1a!.fleet.ListHubInventoriesResponse\x12Y\n" +
+	"\x12SuggestRebalancing\x12 .fleet.SuggestRebalancingRequest\x1a!.fleet.SuggestRebalancingResponseB\x16Z\x14laas/api/proto/fleetb\x06proto3"
+
+var (
+	file_fleet_fleet_proto_rawDescOnce sync.Once
+	file_fleet_fleet_proto_rawDescData []byte
+)
+
+func file_fleet_fleet_proto_rawDescGZIP() []byte {
+	file_fleet_fleet_proto_rawDescOnce.Do(func() {
+		file_fleet_fleet_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fleet_fleet_proto_rawDesc), len(file_fleet_fleet_proto_rawDesc)))
+	})
+	return file_fleet_fleet_proto_rawDescData
+}
+
+var file_fleet_fleet_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_fleet_fleet_proto_goTypes = []any{
+	(*Machine)(nil),                         // 0: fleet.Machine
+	(*MachineStatusEvent)(nil),              // 1: fleet.MachineStatusEvent
+	(*MachineCredentials)(nil),              // 2: fleet.MachineCredentials
+	(*BoundingBox)(nil),                     // 3: fleet.BoundingBox
+	(*ChargingStation)(nil),                 // 4: fleet.ChargingStation
+	(*ChargingReservation)(nil),             // 5: fleet.ChargingReservation
+	(*Location)(nil),                        // 6: fleet.Location
+	(*TripBatteryCheck)(nil),                // 7: fleet.TripBatteryCheck
+	(*ReplacedPart)(nil),                    // 8: fleet.ReplacedPart
+	(*WorkOrder)(nil),                       // 9: fleet.WorkOrder
+	(*Hub)(nil),                             // 10: fleet.Hub
+	(*HubStock)(nil),                        // 11: fleet.HubStock
+	(*HubInventory)(nil),                    // 12: fleet.HubInventory
+	(*RebalancingMove)(nil),                 // 13: fleet.RebalancingMove
+	(*MachineResponse)(nil),                 // 14: fleet.MachineResponse
+	(*RegisterMachineRequest)(nil),          // 15: fleet.RegisterMachineRequest
+	(*GetMachineRequest)(nil),               // 16: fleet.GetMachineRequest
+	(*ListMachinesRequest)(nil),             // 17: fleet.ListMachinesRequest
+	(*ListMachinesResponse)(nil),            // 18: fleet.ListMachinesResponse
+	(*UpdateMachineStatusRequest)(nil),      // 19: fleet.UpdateMachineStatusRequest
+	(*DecommissionMachineRequest)(nil),      // 20: fleet.DecommissionMachineRequest
+	(*GetMachineStatusHistoryRequest)(nil),  // 21: fleet.GetMachineStatusHistoryRequest
+	(*GetMachineStatusHistoryResponse)(nil), // 22: fleet.GetMachineStatusHistoryResponse
+	(*HeartbeatRequest)(nil),                // 23: fleet.HeartbeatRequest
+	(*HeartbeatResponse)(nil),               // 24: fleet.HeartbeatResponse
+	(*CreateChargingStationRequest)(nil),    // 25: fleet.CreateChargingStationRequest
+	(*ChargingStationResponse)(nil),         // 26: fleet.ChargingStationResponse
+	(*ListChargingStationsRequest)(nil),     // 27: fleet.ListChargingStationsRequest
+	(*ListChargingStationsResponse)(nil),    // 28: fleet.ListChargingStationsResponse
+	(*CheckTripBatteryRequest)(nil),         // 29: fleet.CheckTripBatteryRequest
+	(*CheckTripBatteryResponse)(nil),        // 30: fleet.CheckTripBatteryResponse
+	(*ListEligibleMachinesRequest)(nil),     // 31: fleet.ListEligibleMachinesRequest
+	(*ListEligibleMachinesResponse)(nil),    // 32: fleet.ListEligibleMachinesResponse
+	(*WorkOrderResponse)(nil),               // 33: fleet.WorkOrderResponse
+	(*ReportFaultRequest)(nil),              // 34: fleet.ReportFaultRequest
+	(*OpenWorkOrderRequest)(nil),            // 35: fleet.OpenWorkOrderRequest
+	(*StartWorkOrderRequest)(nil),           // 36: fleet.StartWorkOrderRequest
+	(*CloseWorkOrderRequest)(nil),           // 37: fleet.CloseWorkOrderRequest
+	(*ListWorkOrdersRequest)(nil),           // 38: fleet.ListWorkOrdersRequest
+	(*ListWorkOrdersResponse)(nil),          // 39: fleet.ListWorkOrdersResponse
+	(*CreateHubRequest)(nil),                // 40: fleet.CreateHubRequest
+	(*HubResponse)(nil),                     // 41: fleet.HubResponse
+	(*ListHubsRequest)(nil),                 // 42: fleet.ListHubsRequest
+	(*ListHubsResponse)(nil),                // 43: fleet.ListHubsResponse
+	(*AssignHomeHubRequest)(nil),            // 44: fleet.AssignHomeHubRequest
+	(*ListHubInventoriesRequest)(nil),       // 45: fleet.ListHubInventoriesRequest
+	(*ListHubInventoriesResponse)(nil),      // 46: fleet.ListHubInventoriesResponse
+	(*SuggestRebalancingRequest)(nil),       // 47: fleet.SuggestRebalancingRequest
+	(*SuggestRebalancingResponse)(nil),      // 48: fleet.SuggestRebalancingResponse
+	(*FindNearbyMachinesRequest)(nil),       // 49: fleet.FindNearbyMachinesRequest
+	(*NearbyMachine)(nil),                   // 50: fleet.NearbyMachine
+	(*FindNearbyMachinesResponse)(nil),      // 51: fleet.FindNearbyMachinesResponse
+	(*timestamppb.Timestamp)(nil),           // 52: google.protobuf.Timestamp
+}
+var file_fleet_fleet_proto_depIdxs = []int32{
+	52, // 0: fleet.Machine.decommissioned_at:type_name -> google.protobuf.Timestamp
+	52, // 1: fleet.Machine.created_at:type_name -> google.protobuf.Timestamp
+	52, // 2: fleet.Machine.updated_at:type_name -> google.protobuf.Timestamp
+	52, // 3: fleet.Machine.last_heartbeat_at:type_name -> google.protobuf.Timestamp
+	52, // 4: fleet.Machine.last_inspected_at:type_name -> google.protobuf.Timestamp
+	52, // 5: fleet.MachineStatusEvent.created_at:type_name -> google.protobuf.Timestamp
+	52, // 6: fleet.MachineCredentials.expires_at:type_name -> google.protobuf.Timestamp
+	52, // 7: fleet.ChargingStation.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 8: fleet.ChargingReservation.station:type_name -> fleet.ChargingStation
+	52, // 9: fleet.ChargingReservation.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 10: fleet.TripBatteryCheck.machine:type_name -> fleet.Machine
+	8,  // 11: fleet.WorkOrder.parts_replaced:type_name -> fleet.ReplacedPart
+	52, // 12: fleet.WorkOrder.created_at:type_name -> google.protobuf.Timestamp
+	52, // 13: fleet.WorkOrder.started_at:type_name -> google.protobuf.Timestamp
+	52, // 14: fleet.WorkOrder.closed_at:type_name -> google.protobuf.Timestamp
+	52, // 15: fleet.Hub.created_at:type_name -> google.protobuf.Timestamp
+	10, // 16: fleet.HubInventory.hub:type_name -> fleet.Hub
+	11, // 17: fleet.HubInventory.stocks:type_name -> fleet.HubStock
+	0,  // 18: fleet.MachineResponse.machine:type_name -> fleet.Machine
+	2,  // 19: fleet.MachineResponse.credentials:type_name -> fleet.MachineCredentials
+	3,  // 20: fleet.ListMachinesRequest.bounding_box:type_name -> fleet.BoundingBox
+	0,  // 21: fleet.ListMachinesResponse.machines:type_name -> fleet.Machine
+	1,  // 22: fleet.GetMachineStatusHistoryResponse.events:type_name -> fleet.MachineStatusEvent
+	52, // 23: fleet.HeartbeatResponse.server_time:type_name -> google.protobuf.Timestamp
+	5,  // 24: fleet.HeartbeatResponse.charging:type_name -> fleet.ChargingReservation
+	4,  // 25: fleet.ChargingStationResponse.station:type_name -> fleet.ChargingStation
+	4,  // 26: fleet.ListChargingStationsResponse.stations:type_name -> fleet.ChargingStation
+	6,  // 27: fleet.CheckTripBatteryRequest.pickup:type_name -> fleet.Location
+	7,  // 28: fleet.CheckTripBatteryResponse.check:type_name -> fleet.TripBatteryCheck
+	5,  // 29: fleet.CheckTripBatteryResponse.charging:type_name -> fleet.ChargingReservation
+	6,  // 30: fleet.ListEligibleMachinesRequest.pickup:type_name -> fleet.Location
+	7,  // 31: fleet.ListEligibleMachinesResponse.machines:type_name -> fleet.TripBatteryCheck
+	9,  // 32: fleet.WorkOrderResponse.work_order:type_name -> fleet.WorkOrder
+	8,  // 33: fleet.CloseWorkOrderRequest.parts_replaced:type_name -> fleet.ReplacedPart
+	9,  // 34: fleet.ListWorkOrdersResponse.work_orders:type_name -> fleet.WorkOrder
+	10, // 35: fleet.HubResponse.hub:type_name -> fleet.Hub
+	10, // 36: fleet.ListHubsResponse.hubs:type_name -> fleet.Hub
+	12, // 37: fleet.ListHubInventoriesResponse.inventories:type_name -> fleet.HubInventory
+	13, // 38: fleet.SuggestRebalancingResponse.moves:type_name -> fleet.RebalancingMove
+	12, // 39: fleet.SuggestRebalancingResponse.inventories:type_name -> fleet.HubInventory
+	0,  // 40: fleet.NearbyMachine.machine:type_name -> fleet.Machine
+	50, // 41: fleet.FindNearbyMachinesResponse.machines:type_name -> fleet.NearbyMachine
+	15, // 42: fleet.FleetService.RegisterMachine:input_type -> fleet.RegisterMachineRequest
+	16, // 43: fleet.FleetService.GetMachine:input_type -> fleet.GetMachineRequest
+	17, // 44: fleet.FleetService.ListMachines:input_type -> fleet.ListMachinesRequest
+	20, // 45: fleet.FleetService.DecommissionMachine:input_type -> fleet.DecommissionMachineRequest
+	19, // 46: fleet.FleetService.UpdateMachineStatus:input_type -> fleet.UpdateMachineStatusRequest
+	21, // 47: fleet.FleetService.GetMachineStatusHistory:input_type -> fleet.GetMachineStatusHistoryRequest
+	23, // 48: fleet.FleetService.Heartbeat:input_type -> fleet.HeartbeatRequest
+	25, // 49: fleet.FleetService.CreateChargingStation:input_type -> fleet.CreateChargingStationRequest
+	27, // 50: fleet.FleetService.ListChargingStations:input_type -> fleet.ListChargingStationsRequest
+	29, // 51: fleet.FleetService.CheckTripBattery:input_type -> fleet.CheckTripBatteryRequest
+	31, // 52: fleet.FleetService.ListEligibleMachines:input_type -> fleet.ListEligibleMachinesRequest
+	49, // 53: fleet.FleetService.FindNearbyMachines:input_type -> fleet.FindNearbyMachinesRequest
+	34, // 54: fleet.FleetService.ReportFault:input_type -> fleet.ReportFaultRequest
+	35, // 55: fleet.FleetService.OpenWorkOrder:input_type -> fleet.OpenWorkOrderRequest
+	36, // 56: fleet.FleetService.StartWorkOrder:input_type -> fleet.StartWorkOrderRequest
+	37, // 57: fleet.FleetService.CompleteWorkOrder:input_type -> fleet.CloseWorkOrderRequest
+	37, // 58: fleet.FleetService.CancelWorkOrder:input_type -> fleet.CloseWorkOrderRequest
+	38, // 59: fleet.FleetService.ListWorkOrders:input_type -> fleet.ListWorkOrdersRequest
+	40, // 60: fleet.FleetService.CreateHub:input_type -> fleet.CreateHubRequest
+	42, // 61: fleet.FleetService.ListHubs:input_type -> fleet.ListHubsRequest
+	44, // 62: fleet.FleetService.AssignHomeHub:input_type -> fleet.AssignHomeHubRequest
+	45, // 63: fleet.FleetService.ListHubInventories:input_type -> fleet.ListHubInventoriesRequest
+	47, // 64: fleet.FleetService.SuggestRebalancing:input_type -> fleet.SuggestRebalancingRequest
+	14, // 65: fleet.FleetService.RegisterMachine:output_type -> fleet.MachineResponse
+	14, // 66: fleet.FleetService.GetMachine:output_type -> fleet.MachineResponse
+	18, // 67: fleet.FleetService.ListMachines:output_type -> fleet.ListMachinesResponse
+	14, // 68: fleet.FleetService.DecommissionMachine:output_type -> fleet.MachineResponse
+	14, // 69: fleet.FleetService.UpdateMachineStatus:output_type -> fleet.MachineResponse
+	22, // 70: fleet.FleetService.GetMachineStatusHistory:output_type -> fleet.GetMachineStatusHistoryResponse
+	24, // 71: fleet.FleetService.Heartbeat:output_type -> fleet.HeartbeatResponse
+	26, // 72: fleet.FleetService.CreateChargingStation:output_type -> fleet.ChargingStationResponse
+	28, // 73: fleet.FleetService.ListChargingStations:output_type -> fleet.ListChargingStationsResponse
+	30, // 74: fleet.FleetService.CheckTripBattery:output_type -> fleet.CheckTripBatteryResponse
+	32, // 75: fleet.FleetService.ListEligibleMachines:output_type -> fleet.ListEligibleMachinesResponse
+	51, // 76: fleet.FleetService.FindNearbyMachines:output_type -> fleet.FindNearbyMachinesResponse
+	33, // 77: fleet.FleetService.ReportFault:output_type -> fleet.WorkOrderResponse
+	33, // 78: fleet.FleetService.OpenWorkOrder:output_type -> fleet.WorkOrderResponse
+	33, // 79: fleet.FleetService.StartWorkOrder:output_type -> fleet.WorkOrderResponse
+	33, // 80: fleet.FleetService.CompleteWorkOrder:output_type -> fleet.WorkOrderResponse
+	33, // 81: fleet.FleetService.CancelWorkOrder:output_type -> fleet.WorkOrderResponse
+	39, // 82: fleet.FleetService.ListWorkOrders:output_type -> fleet.ListWorkOrdersResponse
+	41, // 83: fleet.FleetService.CreateHub:output_type -> fleet.HubResponse
+	43, // 84: fleet.FleetService.ListHubs:output_type -> fleet.ListHubsResponse
+	14, // 85: fleet.FleetService.AssignHomeHub:output_type -> fleet.MachineResponse
+	46, // 86: fleet.FleetService.ListHubInventories:output_type -> fleet.ListHubInventoriesResponse
+	48, // 87: fleet.FleetService.SuggestRebalancing:output_type -> fleet.SuggestRebalancingResponse
+	65, // [65:88] is the sub-list for method output_type
+	42, // [42:65] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_fleet_fleet_proto_init() }
+func file_fleet_fleet_proto_init() {
+	if File_fleet_fleet_proto != nil {
+		return
+	}
+	file_fleet_fleet_proto_msgTypes[19].OneofWrappers = []any{}
+	file_fleet_fleet_proto_msgTypes[20].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fleet_fleet_proto_rawDesc), len(file_fleet_fleet_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   52,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_fleet_fleet_proto_goTypes,
+		DependencyIndexes: file_fleet_fleet_proto_depIdxs,
+		MessageInfos:      file_fleet_fleet_proto_msgTypes,
+	}.Build()
+	File_fleet_fleet_proto = out.File
+	file_fleet_fleet_proto_goTypes = nil
+	file_fleet_fleet_proto_depIdxs = nil
+}
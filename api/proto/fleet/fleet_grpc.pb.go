@@ -0,0 +1,992 @@
+// Define the protocol buffer version
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: fleet/fleet.proto
+
+// Specify the package to prevent name clashes
+
+package fleet
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FleetService_RegisterMachine_FullMethodName         = "/fleet.FleetService/RegisterMachine"
+	FleetService_GetMachine_FullMethodName              = "/fleet.FleetService/GetMachine"
+	FleetService_ListMachines_FullMethodName            = "/fleet.FleetService/ListMachines"
+	FleetService_DecommissionMachine_FullMethodName     = "/fleet.FleetService/DecommissionMachine"
+	FleetService_UpdateMachineStatus_FullMethodName     = "/fleet.FleetService/UpdateMachineStatus"
+	FleetService_GetMachineStatusHistory_FullMethodName = "/fleet.FleetService/GetMachineStatusHistory"
+	FleetService_Heartbeat_FullMethodName               = "/fleet.FleetService/Heartbeat"
+	FleetService_CreateChargingStation_FullMethodName   = "/fleet.FleetService/CreateChargingStation"
+	FleetService_ListChargingStations_FullMethodName    = "/fleet.FleetService/ListChargingStations"
+	FleetService_CheckTripBattery_FullMethodName        = "/fleet.FleetService/CheckTripBattery"
+	FleetService_ListEligibleMachines_FullMethodName    = "/fleet.FleetService/ListEligibleMachines"
+	FleetService_FindNearbyMachines_FullMethodName      = "/fleet.FleetService/FindNearbyMachines"
+	FleetService_ReportFault_FullMethodName             = "/fleet.FleetService/ReportFault"
+	FleetService_OpenWorkOrder_FullMethodName           = "/fleet.FleetService/OpenWorkOrder"
+	FleetService_StartWorkOrder_FullMethodName          = "/fleet.FleetService/StartWorkOrder"
+	FleetService_CompleteWorkOrder_FullMethodName       = "/fleet.FleetService/CompleteWorkOrder"
+	FleetService_CancelWorkOrder_FullMethodName         = "/fleet.FleetService/CancelWorkOrder"
+	FleetService_ListWorkOrders_FullMethodName          = "/fleet.FleetService/ListWorkOrders"
+	FleetService_CreateHub_FullMethodName               = "/fleet.FleetService/CreateHub"
+	FleetService_ListHubs_FullMethodName                = "/fleet.FleetService/ListHubs"
+	FleetService_AssignHomeHub_FullMethodName           = "/fleet.FleetService/AssignHomeHub"
+	FleetService_ListHubInventories_FullMethodName      = "/fleet.FleetService/ListHubInventories"
+	FleetService_SuggestRebalancing_FullMethodName      = "/fleet.FleetService/SuggestRebalancing"
+)
+
+// FleetServiceClient is the client API for FleetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// The FleetService defines all the RPCs for managing the registry of delivery
+// machines and their current status.
+type FleetServiceClient interface {
+	// Registry
+	RegisterMachine(ctx context.Context, in *RegisterMachineRequest, opts ...grpc.CallOption) (*MachineResponse, error)
+	GetMachine(ctx context.Context, in *GetMachineRequest, opts ...grpc.CallOption) (*MachineResponse, error)
+	ListMachines(ctx context.Context, in *ListMachinesRequest, opts ...grpc.CallOption) (*ListMachinesResponse, error)
+	DecommissionMachine(ctx context.Context, in *DecommissionMachineRequest, opts ...grpc.CallOption) (*MachineResponse, error)
+	// Status reports, from the machine itself or an operator. Machines move
+	// between statuses along a state machine, and every change is recorded in
+	// their status history.
+	UpdateMachineStatus(ctx context.Context, in *UpdateMachineStatusRequest, opts ...grpc.CallOption) (*MachineResponse, error)
+	GetMachineStatusHistory(ctx context.Context, in *GetMachineStatusHistoryRequest, opts ...grpc.CallOption) (*GetMachineStatusHistoryResponse, error)
+	// Liveness: machines stream heartbeats and get an acknowledgement for each.
+	// Machines that stay silent for too long are marked OFFLINE.
+	Heartbeat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HeartbeatRequest, HeartbeatResponse], error)
+	// Charging: machines low on battery are sent to the nearest station with a
+	// free slot, and trips that would drain a machine below the safety reserve
+	// are refused.
+	CreateChargingStation(ctx context.Context, in *CreateChargingStationRequest, opts ...grpc.CallOption) (*ChargingStationResponse, error)
+	ListChargingStations(ctx context.Context, in *ListChargingStationsRequest, opts ...grpc.CallOption) (*ListChargingStationsResponse, error)
+	CheckTripBattery(ctx context.Context, in *CheckTripBatteryRequest, opts ...grpc.CallOption) (*CheckTripBatteryResponse, error)
+	ListEligibleMachines(ctx context.Context, in *ListEligibleMachinesRequest, opts ...grpc.CallOption) (*ListEligibleMachinesResponse, error)
+	// Dispatch: the idle machines nearest to a pickup that can take an order.
+	FindNearbyMachines(ctx context.Context, in *FindNearbyMachinesRequest, opts ...grpc.CallOption) (*FindNearbyMachinesResponse, error)
+	// Maintenance: machines with a blocking work order open are kept in
+	// MAINTENANCE and not dispatched. The work orders of a machine are its
+	// maintenance log.
+	ReportFault(ctx context.Context, in *ReportFaultRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error)
+	OpenWorkOrder(ctx context.Context, in *OpenWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error)
+	StartWorkOrder(ctx context.Context, in *StartWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error)
+	CompleteWorkOrder(ctx context.Context, in *CloseWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error)
+	CancelWorkOrder(ctx context.Context, in *CloseWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error)
+	ListWorkOrders(ctx context.Context, in *ListWorkOrdersRequest, opts ...grpc.CallOption) (*ListWorkOrdersResponse, error)
+	// Hubs: depots machines are based at and park at while idle. Rebalancing
+	// suggests moving idle machines towards the hubs expecting the most orders.
+	CreateHub(ctx context.Context, in *CreateHubRequest, opts ...grpc.CallOption) (*HubResponse, error)
+	ListHubs(ctx context.Context, in *ListHubsRequest, opts ...grpc.CallOption) (*ListHubsResponse, error)
+	AssignHomeHub(ctx context.Context, in *AssignHomeHubRequest, opts ...grpc.CallOption) (*MachineResponse, error)
+	ListHubInventories(ctx context.Context, in *ListHubInventoriesRequest, opts ...grpc.CallOption) (*ListHubInventoriesResponse, error)
+	SuggestRebalancing(ctx context.Context, in *SuggestRebalancingRequest, opts ...grpc.CallOption) (*SuggestRebalancingResponse, error)
+}
+
+type fleetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFleetServiceClient(cc grpc.ClientConnInterface) FleetServiceClient {
+	return &fleetServiceClient{cc}
+}
+
+func (c *fleetServiceClient) RegisterMachine(ctx context.Context, in *RegisterMachineRequest, opts ...grpc.CallOption) (*MachineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MachineResponse)
+	err := c.cc.Invoke(ctx, FleetService_RegisterMachine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) GetMachine(ctx context.Context, in *GetMachineRequest, opts ...grpc.CallOption) (*MachineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MachineResponse)
+	err := c.cc.Invoke(ctx, FleetService_GetMachine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) ListMachines(ctx context.Context, in *ListMachinesRequest, opts ...grpc.CallOption) (*ListMachinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMachinesResponse)
+	err := c.cc.Invoke(ctx, FleetService_ListMachines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) DecommissionMachine(ctx context.Context, in *DecommissionMachineRequest, opts ...grpc.CallOption) (*MachineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MachineResponse)
+	err := c.cc.Invoke(ctx, FleetService_DecommissionMachine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) UpdateMachineStatus(ctx context.Context, in *UpdateMachineStatusRequest, opts ...grpc.CallOption) (*MachineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MachineResponse)
+	err := c.cc.Invoke(ctx, FleetService_UpdateMachineStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) GetMachineStatusHistory(ctx context.Context, in *GetMachineStatusHistoryRequest, opts ...grpc.CallOption) (*GetMachineStatusHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMachineStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, FleetService_GetMachineStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) Heartbeat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[HeartbeatRequest, HeartbeatResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[0], FleetService_Heartbeat_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[HeartbeatRequest, HeartbeatResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_HeartbeatClient = grpc.BidiStreamingClient[HeartbeatRequest, HeartbeatResponse]
+
+func (c *fleetServiceClient) CreateChargingStation(ctx context.Context, in *CreateChargingStationRequest, opts ...grpc.CallOption) (*ChargingStationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChargingStationResponse)
+	err := c.cc.Invoke(ctx, FleetService_CreateChargingStation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) ListChargingStations(ctx context.Context, in *ListChargingStationsRequest, opts ...grpc.CallOption) (*ListChargingStationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChargingStationsResponse)
+	err := c.cc.Invoke(ctx, FleetService_ListChargingStations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) CheckTripBattery(ctx context.Context, in *CheckTripBatteryRequest, opts ...grpc.CallOption) (*CheckTripBatteryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckTripBatteryResponse)
+	err := c.cc.Invoke(ctx, FleetService_CheckTripBattery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) ListEligibleMachines(ctx context.Context, in *ListEligibleMachinesRequest, opts ...grpc.CallOption) (*ListEligibleMachinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListEligibleMachinesResponse)
+	err := c.cc.Invoke(ctx, FleetService_ListEligibleMachines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) FindNearbyMachines(ctx context.Context, in *FindNearbyMachinesRequest, opts ...grpc.CallOption) (*FindNearbyMachinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindNearbyMachinesResponse)
+	err := c.cc.Invoke(ctx, FleetService_FindNearbyMachines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) ReportFault(ctx context.Context, in *ReportFaultRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkOrderResponse)
+	err := c.cc.Invoke(ctx, FleetService_ReportFault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) OpenWorkOrder(ctx context.Context, in *OpenWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkOrderResponse)
+	err := c.cc.Invoke(ctx, FleetService_OpenWorkOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) StartWorkOrder(ctx context.Context, in *StartWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkOrderResponse)
+	err := c.cc.Invoke(ctx, FleetService_StartWorkOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) CompleteWorkOrder(ctx context.Context, in *CloseWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkOrderResponse)
+	err := c.cc.Invoke(ctx, FleetService_CompleteWorkOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) CancelWorkOrder(ctx context.Context, in *CloseWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkOrderResponse)
+	err := c.cc.Invoke(ctx, FleetService_CancelWorkOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) ListWorkOrders(ctx context.Context, in *ListWorkOrdersRequest, opts ...grpc.CallOption) (*ListWorkOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkOrdersResponse)
+	err := c.cc.Invoke(ctx, FleetService_ListWorkOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) CreateHub(ctx context.Context, in *CreateHubRequest, opts ...grpc.CallOption) (*HubResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HubResponse)
+	err := c.cc.Invoke(ctx, FleetService_CreateHub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) ListHubs(ctx context.Context, in *ListHubsRequest, opts ...grpc.CallOption) (*ListHubsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHubsResponse)
+	err := c.cc.Invoke(ctx, FleetService_ListHubs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) AssignHomeHub(ctx context.Context, in *AssignHomeHubRequest, opts ...grpc.CallOption) (*MachineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MachineResponse)
+	err := c.cc.Invoke(ctx, FleetService_AssignHomeHub_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) ListHubInventories(ctx context.Context, in *ListHubInventoriesRequest, opts ...grpc.CallOption) (*ListHubInventoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHubInventoriesResponse)
+	err := c.cc.Invoke(ctx, FleetService_ListHubInventories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) SuggestRebalancing(ctx context.Context, in *SuggestRebalancingRequest, opts ...grpc.CallOption) (*SuggestRebalancingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestRebalancingResponse)
+	err := c.cc.Invoke(ctx, FleetService_SuggestRebalancing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FleetServiceServer is the server API for FleetService service.
+// All implementations must embed UnimplementedFleetServiceServer
+// for forward compatibility.
+//
+// The FleetService defines all the RPCs for managing the registry of delivery
+// machines and their current status.
+type FleetServiceServer interface {
+	// Registry
+	RegisterMachine(context.Context, *RegisterMachineRequest) (*MachineResponse, error)
+	GetMachine(context.Context, *GetMachineRequest) (*MachineResponse, error)
+	ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error)
+	DecommissionMachine(context.Context, *DecommissionMachineRequest) (*MachineResponse, error)
+	// Status reports, from the machine itself or an operator. Machines move
+	// between statuses along a state machine, and every change is recorded in
+	// their status history.
+	UpdateMachineStatus(context.Context, *UpdateMachineStatusRequest) (*MachineResponse, error)
+	GetMachineStatusHistory(context.Context, *GetMachineStatusHistoryRequest) (*GetMachineStatusHistoryResponse, error)
+	// Liveness: machines stream heartbeats and get an acknowledgement for each.
+	// Machines that stay silent for too long are marked OFFLINE.
+	Heartbeat(grpc.BidiStreamingServer[HeartbeatRequest, HeartbeatResponse]) error
+	// Charging: machines low on battery are sent to the nearest station with a
+	// free slot, and trips that would drain a machine below the safety reserve
+	// are refused.
+	CreateChargingStation(context.Context, *CreateChargingStationRequest) (*ChargingStationResponse, error)
+	ListChargingStations(context.Context, *ListChargingStationsRequest) (*ListChargingStationsResponse, error)
+	CheckTripBattery(context.Context, *CheckTripBatteryRequest) (*CheckTripBatteryResponse, error)
+	ListEligibleMachines(context.Context, *ListEligibleMachinesRequest) (*ListEligibleMachinesResponse, error)
+	// Dispatch: the idle machines nearest to a pickup that can take an order.
+	FindNearbyMachines(context.Context, *FindNearbyMachinesRequest) (*FindNearbyMachinesResponse, error)
+	// Maintenance: machines with a blocking work order open are kept in
+	// MAINTENANCE and not dispatched. The work orders of a machine are its
+	// maintenance log.
+	ReportFault(context.Context, *ReportFaultRequest) (*WorkOrderResponse, error)
+	OpenWorkOrder(context.Context, *OpenWorkOrderRequest) (*WorkOrderResponse, error)
+	StartWorkOrder(context.Context, *StartWorkOrderRequest) (*WorkOrderResponse, error)
+	CompleteWorkOrder(context.Context, *CloseWorkOrderRequest) (*WorkOrderResponse, error)
+	CancelWorkOrder(context.Context, *CloseWorkOrderRequest) (*WorkOrderResponse, error)
+	ListWorkOrders(context.Context, *ListWorkOrdersRequest) (*ListWorkOrdersResponse, error)
+	// Hubs: depots machines are based at and park at while idle. Rebalancing
+	// suggests moving idle machines towards the hubs expecting the most orders.
+	CreateHub(context.Context, *CreateHubRequest) (*HubResponse, error)
+	ListHubs(context.Context, *ListHubsRequest) (*ListHubsResponse, error)
+	AssignHomeHub(context.Context, *AssignHomeHubRequest) (*MachineResponse, error)
+	ListHubInventories(context.Context, *ListHubInventoriesRequest) (*ListHubInventoriesResponse, error)
+	SuggestRebalancing(context.Context, *SuggestRebalancingRequest) (*SuggestRebalancingResponse, error)
+	mustEmbedUnimplementedFleetServiceServer()
+}
+
+// UnimplementedFleetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFleetServiceServer struct{}
+
+func (UnimplementedFleetServiceServer) RegisterMachine(context.Context, *RegisterMachineRequest) (*MachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterMachine not implemented")
+}
+func (UnimplementedFleetServiceServer) GetMachine(context.Context, *GetMachineRequest) (*MachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMachine not implemented")
+}
+func (UnimplementedFleetServiceServer) ListMachines(context.Context, *ListMachinesRequest) (*ListMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMachines not implemented")
+}
+func (UnimplementedFleetServiceServer) DecommissionMachine(context.Context, *DecommissionMachineRequest) (*MachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecommissionMachine not implemented")
+}
+func (UnimplementedFleetServiceServer) UpdateMachineStatus(context.Context, *UpdateMachineStatusRequest) (*MachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMachineStatus not implemented")
+}
+func (UnimplementedFleetServiceServer) GetMachineStatusHistory(context.Context, *GetMachineStatusHistoryRequest) (*GetMachineStatusHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMachineStatusHistory not implemented")
+}
+func (UnimplementedFleetServiceServer) Heartbeat(grpc.BidiStreamingServer[HeartbeatRequest, HeartbeatResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedFleetServiceServer) CreateChargingStation(context.Context, *CreateChargingStationRequest) (*ChargingStationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateChargingStation not implemented")
+}
+func (UnimplementedFleetServiceServer) ListChargingStations(context.Context, *ListChargingStationsRequest) (*ListChargingStationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChargingStations not implemented")
+}
+func (UnimplementedFleetServiceServer) CheckTripBattery(context.Context, *CheckTripBatteryRequest) (*CheckTripBatteryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTripBattery not implemented")
+}
+func (UnimplementedFleetServiceServer) ListEligibleMachines(context.Context, *ListEligibleMachinesRequest) (*ListEligibleMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEligibleMachines not implemented")
+}
+func (UnimplementedFleetServiceServer) FindNearbyMachines(context.Context, *FindNearbyMachinesRequest) (*FindNearbyMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindNearbyMachines not implemented")
+}
+func (UnimplementedFleetServiceServer) ReportFault(context.Context, *ReportFaultRequest) (*WorkOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportFault not implemented")
+}
+func (UnimplementedFleetServiceServer) OpenWorkOrder(context.Context, *OpenWorkOrderRequest) (*WorkOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenWorkOrder not implemented")
+}
+func (UnimplementedFleetServiceServer) StartWorkOrder(context.Context, *StartWorkOrderRequest) (*WorkOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartWorkOrder not implemented")
+}
+func (UnimplementedFleetServiceServer) CompleteWorkOrder(context.Context, *CloseWorkOrderRequest) (*WorkOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteWorkOrder not implemented")
+}
+func (UnimplementedFleetServiceServer) CancelWorkOrder(context.Context, *CloseWorkOrderRequest) (*WorkOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelWorkOrder not implemented")
+}
+func (UnimplementedFleetServiceServer) ListWorkOrders(context.Context, *ListWorkOrdersRequest) (*ListWorkOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkOrders not implemented")
+}
+func (UnimplementedFleetServiceServer) CreateHub(context.Context, *CreateHubRequest) (*HubResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHub not implemented")
+}
+func (UnimplementedFleetServiceServer) ListHubs(context.Context, *ListHubsRequest) (*ListHubsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHubs not implemented")
+}
+func (UnimplementedFleetServiceServer) AssignHomeHub(context.Context, *AssignHomeHubRequest) (*MachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignHomeHub not implemented")
+}
+func (UnimplementedFleetServiceServer) ListHubInventories(context.Context, *ListHubInventoriesRequest) (*ListHubInventoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHubInventories not implemented")
+}
+func (UnimplementedFleetServiceServer) SuggestRebalancing(context.Context, *SuggestRebalancingRequest) (*SuggestRebalancingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestRebalancing not implemented")
+}
+func (UnimplementedFleetServiceServer) mustEmbedUnimplementedFleetServiceServer() {}
+func (UnimplementedFleetServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeFleetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FleetServiceServer will
+// result in compilation errors.
+type UnsafeFleetServiceServer interface {
+	mustEmbedUnimplementedFleetServiceServer()
+}
+
+func RegisterFleetServiceServer(s grpc.ServiceRegistrar, srv FleetServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFleetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FleetService_ServiceDesc, srv)
+}
+
+func _FleetService_RegisterMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).RegisterMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_RegisterMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).RegisterMachine(ctx, req.(*RegisterMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_GetMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).GetMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_GetMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).GetMachine(ctx, req.(*GetMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_ListMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMachinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).ListMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_ListMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).ListMachines(ctx, req.(*ListMachinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_DecommissionMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecommissionMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).DecommissionMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_DecommissionMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).DecommissionMachine(ctx, req.(*DecommissionMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_UpdateMachineStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMachineStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).UpdateMachineStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_UpdateMachineStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).UpdateMachineStatus(ctx, req.(*UpdateMachineStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_GetMachineStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMachineStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).GetMachineStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_GetMachineStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).GetMachineStatusHistory(ctx, req.(*GetMachineStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_Heartbeat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FleetServiceServer).Heartbeat(&grpc.GenericServerStream[HeartbeatRequest, HeartbeatResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_HeartbeatServer = grpc.BidiStreamingServer[HeartbeatRequest, HeartbeatResponse]
+
+func _FleetService_CreateChargingStation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChargingStationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).CreateChargingStation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_CreateChargingStation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).CreateChargingStation(ctx, req.(*CreateChargingStationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_ListChargingStations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChargingStationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).ListChargingStations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_ListChargingStations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).ListChargingStations(ctx, req.(*ListChargingStationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_CheckTripBattery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckTripBatteryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).CheckTripBattery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_CheckTripBattery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).CheckTripBattery(ctx, req.(*CheckTripBatteryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_ListEligibleMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEligibleMachinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).ListEligibleMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_ListEligibleMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).ListEligibleMachines(ctx, req.(*ListEligibleMachinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_FindNearbyMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNearbyMachinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).FindNearbyMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_FindNearbyMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).FindNearbyMachines(ctx, req.(*FindNearbyMachinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_ReportFault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportFaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).ReportFault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_ReportFault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).ReportFault(ctx, req.(*ReportFaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_OpenWorkOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenWorkOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).OpenWorkOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_OpenWorkOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).OpenWorkOrder(ctx, req.(*OpenWorkOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_StartWorkOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartWorkOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).StartWorkOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_StartWorkOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).StartWorkOrder(ctx, req.(*StartWorkOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_CompleteWorkOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseWorkOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).CompleteWorkOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_CompleteWorkOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).CompleteWorkOrder(ctx, req.(*CloseWorkOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_CancelWorkOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseWorkOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).CancelWorkOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_CancelWorkOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).CancelWorkOrder(ctx, req.(*CloseWorkOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_ListWorkOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).ListWorkOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_ListWorkOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).ListWorkOrders(ctx, req.(*ListWorkOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_CreateHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).CreateHub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_CreateHub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).CreateHub(ctx, req.(*CreateHubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_ListHubs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHubsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).ListHubs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_ListHubs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).ListHubs(ctx, req.(*ListHubsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_AssignHomeHub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignHomeHubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).AssignHomeHub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_AssignHomeHub_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).AssignHomeHub(ctx, req.(*AssignHomeHubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_ListHubInventories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHubInventoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).ListHubInventories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_ListHubInventories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).ListHubInventories(ctx, req.(*ListHubInventoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_SuggestRebalancing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRebalancingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).SuggestRebalancing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_SuggestRebalancing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).SuggestRebalancing(ctx, req.(*SuggestRebalancingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FleetService_ServiceDesc is the grpc.ServiceDesc for FleetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FleetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fleet.FleetService",
+	HandlerType: (*FleetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterMachine",
+			Handler:    _FleetService_RegisterMachine_Handler,
+		},
+		{
+			MethodName: "GetMachine",
+			Handler:    _FleetService_GetMachine_Handler,
+		},
+		{
+			MethodName: "ListMachines",
+			Handler:    _FleetService_ListMachines_Handler,
+		},
+		{
+			MethodName: "DecommissionMachine",
+			Handler:    _FleetService_DecommissionMachine_Handler,
+		},
+		{
+			MethodName: "UpdateMachineStatus",
+			Handler:    _FleetService_UpdateMachineStatus_Handler,
+		},
+		{
+			MethodName: "GetMachineStatusHistory",
+			Handler:    _FleetService_GetMachineStatusHistory_Handler,
+		},
+		{
+			MethodName: "CreateChargingStation",
+			Handler:    _FleetService_CreateChargingStation_Handler,
+		},
+		{
+			MethodName: "ListChargingStations",
+			Handler:    _FleetService_ListChargingStations_Handler,
+		},
+		{
+			MethodName: "CheckTripBattery",
+			Handler:    _FleetService_CheckTripBattery_Handler,
+		},
+		{
+			MethodName: "ListEligibleMachines",
+			Handler:    _FleetService_ListEligibleMachines_Handler,
+		},
+		{
+			MethodName: "FindNearbyMachines",
+			Handler:    _FleetService_FindNearbyMachines_Handler,
+		},
+		{
+			MethodName: "ReportFault",
+			Handler:    _FleetService_ReportFault_Handler,
+		},
+		{
+			MethodName: "OpenWorkOrder",
+			Handler:    _FleetService_OpenWorkOrder_Handler,
+		},
+		{
+			MethodName: "StartWorkOrder",
+			Handler:    _FleetService_StartWorkOrder_Handler,
+		},
+		{
+			MethodName: "CompleteWorkOrder",
+			Handler:    _FleetService_CompleteWorkOrder_Handler,
+		},
+		{
+			MethodName: "CancelWorkOrder",
+			Handler:    _FleetService_CancelWorkOrder_Handler,
+		},
+		{
+			MethodName: "ListWorkOrders",
+			Handler:    _FleetService_ListWorkOrders_Handler,
+		},
+		{
+			MethodName: "CreateHub",
+			Handler:    _FleetService_CreateHub_Handler,
+		},
+		{
+			MethodName: "ListHubs",
+			Handler:    _FleetService_ListHubs_Handler,
+		},
+		{
+			MethodName: "AssignHomeHub",
+			Handler:    _FleetService_AssignHomeHub_Handler,
+		},
+		{
+			MethodName: "ListHubInventories",
+			Handler:    _FleetService_ListHubInventories_Handler,
+		},
+		{
+			MethodName: "SuggestRebalancing",
+			Handler:    _FleetService_SuggestRebalancing_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Heartbeat",
+			Handler:       _FleetService_Heartbeat_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "fleet/fleet.proto",
+}
@@ -0,0 +1,458 @@
+// Define the protocol buffer version
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: inference/inference.proto
+
+// Specify the package to prevent name clashes
+
+package inference
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// --- Dispatch State ---
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_inference_inference_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_inference_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_inference_inference_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+// A paid order waiting for a machine.
+type Job struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MachineType   string                 `protobuf:"bytes,2,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"` // DRONE or ROBOT
+	WeightKg      float64                `protobuf:"fixed64,3,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+	Pickup        *Location              `protobuf:"bytes,4,opt,name=pickup,proto3" json:"pickup,omitempty"`
+	Dropoffs      []*Location            `protobuf:"bytes,5,rep,name=dropoffs,proto3" json:"dropoffs,omitempty"` // The pending stops, in the order they were placed
+	QueuedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=queued_at,json=queuedAt,proto3" json:"queued_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_inference_inference_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_inference_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_inference_inference_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Job) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Job) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *Job) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+func (x *Job) GetPickup() *Location {
+	if x != nil {
+		return x.Pickup
+	}
+	return nil
+}
+
+func (x *Job) GetDropoffs() []*Location {
+	if x != nil {
+		return x.Dropoffs
+	}
+	return nil
+}
+
+func (x *Job) GetQueuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.QueuedAt
+	}
+	return nil
+}
+
+// A machine that can take the job.
+type Candidate struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	MachineId             string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	MachineType           string                 `protobuf:"bytes,2,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	PickupDistanceMeters  float64                `protobuf:"fixed64,3,opt,name=pickup_distance_meters,json=pickupDistanceMeters,proto3" json:"pickup_distance_meters,omitempty"`
+	PickupEtaSeconds      float64                `protobuf:"fixed64,4,opt,name=pickup_eta_seconds,json=pickupEtaSeconds,proto3" json:"pickup_eta_seconds,omitempty"`
+	ProjectedBatteryLevel float64                `protobuf:"fixed64,5,opt,name=projected_battery_level,json=projectedBatteryLevel,proto3" json:"projected_battery_level,omitempty"` // Percent left after the trip
+	MaxPayloadKg          float64                `protobuf:"fixed64,6,opt,name=max_payload_kg,json=maxPayloadKg,proto3" json:"max_payload_kg,omitempty"`
+	Load                  int32                  `protobuf:"varint,7,opt,name=load,proto3" json:"load,omitempty"` // Orders the machine is already assigned
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Candidate) Reset() {
+	*x = Candidate{}
+	mi := &file_inference_inference_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candidate) ProtoMessage() {}
+
+func (x *Candidate) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_inference_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candidate.ProtoReflect.Descriptor instead.
+func (*Candidate) Descriptor() ([]byte, []int) {
+	return file_inference_inference_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Candidate) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *Candidate) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *Candidate) GetPickupDistanceMeters() float64 {
+	if x != nil {
+		return x.PickupDistanceMeters
+	}
+	return 0
+}
+
+func (x *Candidate) GetPickupEtaSeconds() float64 {
+	if x != nil {
+		return x.PickupEtaSeconds
+	}
+	return 0
+}
+
+func (x *Candidate) GetProjectedBatteryLevel() float64 {
+	if x != nil {
+		return x.ProjectedBatteryLevel
+	}
+	return 0
+}
+
+func (x *Candidate) GetMaxPayloadKg() float64 {
+	if x != nil {
+		return x.MaxPayloadKg
+	}
+	return 0
+}
+
+func (x *Candidate) GetLoad() int32 {
+	if x != nil {
+		return x.Load
+	}
+	return 0
+}
+
+// --- Requests & Responses ---
+type ScoreCandidatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Candidates    []*Candidate           `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreCandidatesRequest) Reset() {
+	*x = ScoreCandidatesRequest{}
+	mi := &file_inference_inference_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreCandidatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreCandidatesRequest) ProtoMessage() {}
+
+func (x *ScoreCandidatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_inference_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreCandidatesRequest.ProtoReflect.Descriptor instead.
+func (*ScoreCandidatesRequest) Descriptor() ([]byte, []int) {
+	return file_inference_inference_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScoreCandidatesRequest) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *ScoreCandidatesRequest) GetCandidates() []*Candidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type ScoreCandidatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Costs         []float64              `protobuf:"fixed64,1,rep,packed,name=costs,proto3" json:"costs,omitempty"` // One per candidate, in request order. Lower is better.
+	ModelVersion  string                 `protobuf:"bytes,2,opt,name=model_version,json=modelVersion,proto3" json:"model_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreCandidatesResponse) Reset() {
+	*x = ScoreCandidatesResponse{}
+	mi := &file_inference_inference_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreCandidatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreCandidatesResponse) ProtoMessage() {}
+
+func (x *ScoreCandidatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inference_inference_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreCandidatesResponse.ProtoReflect.Descriptor instead.
+func (*ScoreCandidatesResponse) Descriptor() ([]byte, []int) {
+	return file_inference_inference_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ScoreCandidatesResponse) GetCosts() []float64 {
+	if x != nil {
+		return x.Costs
+	}
+	return nil
+}
+
+func (x *ScoreCandidatesResponse) GetModelVersion() string {
+	if x != nil {
+		return x.ModelVersion
+	}
+	return ""
+}
+
+var File_inference_inference_proto protoreflect.FileDescriptor
+
+const file_inference_inference_proto_rawDesc = "" +
+	"\n" +
+	"\x19inference/inference.proto\x12\tinference\x1a\x1fgoogle/protobuf/timestamp.proto\"D\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xf7\x01\n" +
+	"\x03Job\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12!\n" +
+	"\fmachine_type\x18\x02 \x01(\tR\vmachineType\x12\x1b\n" +
+	"\tweight_kg\x18\x03 \x01(\x01R\bweightKg\x12+\n" +
+	"\x06pickup\x18\x04 \x01(\v2\x13.inference.LocationR\x06pickup\x12/\n" +
+	"\bdropoffs\x18\x05 \x03(\v2\x13.inference.LocationR\bdropoffs\x127\n" +
+	"\tqueued_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bqueuedAt\"\xa3\x02\n" +
+	"\tCandidate\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12!\n" +
+	"\fmachine_type\x18\x02 \x01(\tR\vmachineType\x124\n" +
+	"\x16pickup_distance_meters\x18\x03 \x01(\x01R\x14pickupDistanceMeters\x12,\n" +
+	"\x12pickup_eta_seconds\x18\x04 \x01(\x01R\x10pickupEtaSeconds\x126\n" +
+	"\x17projected_battery_level\x18\x05 \x01(\x01R\x15projectedBatteryLevel\x12$\n" +
+	"\x0emax_payload_kg\x18\x06 \x01(\x01R\fmaxPayloadKg\x12\x12\n" +
+	"\x04load\x18\a \x01(\x05R\x04load\"p\n" +
+	"\x16ScoreCandidatesRequest\x12 \n" +
+	"\x03job\x18\x01 \x01(\v2\x0e.inference.JobR\x03job\x124\n" +
+	"\n" +
+	"candidates\x18\x02 \x03(\v2\x14.inference.CandidateR\n" +
+	"candidates\"T\n" +
+	"\x17ScoreCandidatesResponse\x12\x14\n" +
+	"\x05costs\x18\x01 \x03(\x01R\x05costs\x12#\n" +
+	"\rmodel_version\x18\x02 \x01(\tR\fmodelVersion2l\n" +
+	"\x10InferenceService\x12X\n" +
+	"\x0fScoreCandidates\x12!.inference.ScoreCandidatesRequest\x1a\".inference.ScoreCandidatesResponseB\x1aZ\x18laas/api/proto/inferenceb\x06proto3"
+
+var (
+	file_inference_inference_proto_rawDescOnce sync.Once
+	file_inference_inference_proto_rawDescData []byte
+)
+
+func file_inference_inference_proto_rawDescGZIP() []byte {
+	file_inference_inference_proto_rawDescOnce.Do(func() {
+		file_inference_inference_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_inference_inference_proto_rawDesc), len(file_inference_inference_proto_rawDesc)))
+	})
+	return file_inference_inference_proto_rawDescData
+}
+
+var file_inference_inference_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_inference_inference_proto_goTypes = []any{
+	(*Location)(nil),                // 0: inference.Location
+	(*Job)(nil),                     // 1: inference.Job
+	(*Candidate)(nil),               // 2: inference.Candidate
+	(*ScoreCandidatesRequest)(nil),  // 3: inference.ScoreCandidatesRequest
+	(*ScoreCandidatesResponse)(nil), // 4: inference.ScoreCandidatesResponse
+	(*timestamppb.Timestamp)(nil),   // 5: google.protobuf.Timestamp
+}
+var file_inference_inference_proto_depIdxs = []int32{
+	0, // 0: inference.Job.pickup:type_name -> inference.Location
+	0, // 1: inference.Job.dropoffs:type_name -> inference.Location
+	5, // 2: inference.Job.queued_at:type_name -> google.protobuf.Timestamp
+	1, // 3: inference.ScoreCandidatesRequest.job:type_name -> inference.Job
+	2, // 4: inference.ScoreCandidatesRequest.candidates:type_name -> inference.Candidate
+	3, // 5: inference.InferenceService.ScoreCandidates:input_type -> inference.ScoreCandidatesRequest
+	4, // 6: inference.InferenceService.ScoreCandidates:output_type -> inference.ScoreCandidatesResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_inference_inference_proto_init() }
+func file_inference_inference_proto_init() {
+	if File_inference_inference_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inference_inference_proto_rawDesc), len(file_inference_inference_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_inference_inference_proto_goTypes,
+		DependencyIndexes: file_inference_inference_proto_depIdxs,
+		MessageInfos:      file_inference_inference_proto_msgTypes,
+	}.Build()
+	File_inference_inference_proto = out.File
+	file_inference_inference_proto_goTypes = nil
+	file_inference_inference_proto_depIdxs = nil
+}
@@ -0,0 +1,4264 @@
+// Define the protocol buffer version
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: order/order.proto
+
+// Specify the package to prevent name clashes
+
+package order
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// --- Order Model ---
+type Order struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MachineId        string                 `protobuf:"bytes,3,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	PickupAddressId  string                 `protobuf:"bytes,4,opt,name=pickup_address_id,json=pickupAddressId,proto3" json:"pickup_address_id,omitempty"`
+	DropoffAddressId string                 `protobuf:"bytes,5,opt,name=dropoff_address_id,json=dropoffAddressId,proto3" json:"dropoff_address_id,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Dimensions       *Dimensions            `protobuf:"bytes,7,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	ItemWeightKg     float64                `protobuf:"fixed64,8,opt,name=item_weight_kg,json=itemWeightKg,proto3" json:"item_weight_kg,omitempty"` // Computed from the item manifest
+	Cost             float64                `protobuf:"fixed64,9,opt,name=cost,proto3" json:"cost,omitempty"`
+	Items            []*OrderItem           `protobuf:"bytes,10,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	MachineType      string                 `protobuf:"bytes,13,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	PickupWindow     *PickupWindow          `protobuf:"bytes,14,opt,name=pickup_window,json=pickupWindow,proto3" json:"pickup_window,omitempty"` // Unset for immediate orders
+	ScheduleId       string                 `protobuf:"bytes,15,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`       // Set when the order was created by a recurring schedule
+	TripId           string                 `protobuf:"bytes,16,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`                   // Set once the order is dispatched
+	Stops            []*Stop                `protobuf:"bytes,17,rep,name=stops,proto3" json:"stops,omitempty"`                                   // Dropoffs in visit order
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_order_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *Order) GetPickupAddressId() string {
+	if x != nil {
+		return x.PickupAddressId
+	}
+	return ""
+}
+
+func (x *Order) GetDropoffAddressId() string {
+	if x != nil {
+		return x.DropoffAddressId
+	}
+	return ""
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *Order) GetItemWeightKg() float64 {
+	if x != nil {
+		return x.ItemWeightKg
+	}
+	return 0
+}
+
+func (x *Order) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *Order) GetPickupWindow() *PickupWindow {
+	if x != nil {
+		return x.PickupWindow
+	}
+	return nil
+}
+
+func (x *Order) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *Order) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *Order) GetStops() []*Stop {
+	if x != nil {
+		return x.Stops
+	}
+	return nil
+}
+
+// --- Stop Model ---
+type Stop struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId           string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Sequence          int32                  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"` // Position in the order as placed, starting at 1
+	AddressId         string                 `protobuf:"bytes,4,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // pending, delivered or failed
+	TripId            string                 `protobuf:"bytes,6,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	TripSequence      int32                  `protobuf:"varint,7,opt,name=trip_sequence,json=tripSequence,proto3" json:"trip_sequence,omitempty"`                   // Visit position on the trip, 0 until dispatched
+	LegDistanceMeters float64                `protobuf:"fixed64,8,opt,name=leg_distance_meters,json=legDistanceMeters,proto3" json:"leg_distance_meters,omitempty"` // Straight-line distance from the previous stop, 0 when not geocoded
+	DeliveredAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Stop) Reset() {
+	*x = Stop{}
+	mi := &file_order_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stop) ProtoMessage() {}
+
+func (x *Stop) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stop.ProtoReflect.Descriptor instead.
+func (*Stop) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Stop) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Stop) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Stop) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Stop) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+func (x *Stop) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Stop) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *Stop) GetTripSequence() int32 {
+	if x != nil {
+		return x.TripSequence
+	}
+	return 0
+}
+
+func (x *Stop) GetLegDistanceMeters() float64 {
+	if x != nil {
+		return x.LegDistanceMeters
+	}
+	return 0
+}
+
+func (x *Stop) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+// --- ETA Model ---
+type StopETA struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StopId        string                 `protobuf:"bytes,1,opt,name=stop_id,json=stopId,proto3" json:"stop_id,omitempty"`
+	Sequence      int32                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	ArrivesAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=arrives_at,json=arrivesAt,proto3" json:"arrives_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopETA) Reset() {
+	*x = StopETA{}
+	mi := &file_order_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopETA) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopETA) ProtoMessage() {}
+
+func (x *StopETA) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopETA.ProtoReflect.Descriptor instead.
+func (*StopETA) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StopETA) GetStopId() string {
+	if x != nil {
+		return x.StopId
+	}
+	return ""
+}
+
+func (x *StopETA) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *StopETA) GetArrivesAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArrivesAt
+	}
+	return nil
+}
+
+type OrderETA struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId                 string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status                  string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // assigned or in_transit
+	MachineId               string                 `protobuf:"bytes,3,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Latitude                float64                `protobuf:"fixed64,4,opt,name=latitude,proto3" json:"latitude,omitempty"` // Last reported position of the machine
+	Longitude               float64                `protobuf:"fixed64,5,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	PositionAt              *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=position_at,json=positionAt,proto3" json:"position_at,omitempty"`
+	Stops                   []*StopETA             `protobuf:"bytes,7,rep,name=stops,proto3" json:"stops,omitempty"`                          // Pending stops of the order, in visit order
+	ArrivesAt               *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=arrives_at,json=arrivesAt,proto3" json:"arrives_at,omitempty"` // At the last pending stop
+	RemainingDistanceMeters float64                `protobuf:"fixed64,9,opt,name=remaining_distance_meters,json=remainingDistanceMeters,proto3" json:"remaining_distance_meters,omitempty"`
+	CalibrationTrips        int32                  `protobuf:"varint,10,opt,name=calibration_trips,json=calibrationTrips,proto3" json:"calibration_trips,omitempty"` // Past trips the travel time is calibrated from
+	EstimatedAt             *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=estimated_at,json=estimatedAt,proto3" json:"estimated_at,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *OrderETA) Reset() {
+	*x = OrderETA{}
+	mi := &file_order_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderETA) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderETA) ProtoMessage() {}
+
+func (x *OrderETA) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderETA.ProtoReflect.Descriptor instead.
+func (*OrderETA) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderETA) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderETA) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderETA) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *OrderETA) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *OrderETA) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *OrderETA) GetPositionAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PositionAt
+	}
+	return nil
+}
+
+func (x *OrderETA) GetStops() []*StopETA {
+	if x != nil {
+		return x.Stops
+	}
+	return nil
+}
+
+func (x *OrderETA) GetArrivesAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArrivesAt
+	}
+	return nil
+}
+
+func (x *OrderETA) GetRemainingDistanceMeters() float64 {
+	if x != nil {
+		return x.RemainingDistanceMeters
+	}
+	return 0
+}
+
+func (x *OrderETA) GetCalibrationTrips() int32 {
+	if x != nil {
+		return x.CalibrationTrips
+	}
+	return 0
+}
+
+func (x *OrderETA) GetEstimatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedAt
+	}
+	return nil
+}
+
+// --- Trip Model ---
+type Trip struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MachineId       string                 `protobuf:"bytes,2,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	PickupAddressId string                 `protobuf:"bytes,3,opt,name=pickup_address_id,json=pickupAddressId,proto3" json:"pickup_address_id,omitempty"`
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                         // active, completed or cancelled
+	DistanceMeters  float64                `protobuf:"fixed64,5,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"` // 0 when a stop is not geocoded
+	OrderIds        []string               `protobuf:"bytes,6,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	Stops           []*Stop                `protobuf:"bytes,7,rep,name=stops,proto3" json:"stops,omitempty"` // In visit order
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Trip) Reset() {
+	*x = Trip{}
+	mi := &file_order_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trip) ProtoMessage() {}
+
+func (x *Trip) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trip.ProtoReflect.Descriptor instead.
+func (*Trip) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Trip) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Trip) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *Trip) GetPickupAddressId() string {
+	if x != nil {
+		return x.PickupAddressId
+	}
+	return ""
+}
+
+func (x *Trip) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Trip) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *Trip) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *Trip) GetStops() []*Stop {
+	if x != nil {
+		return x.Stops
+	}
+	return nil
+}
+
+func (x *Trip) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PickupWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickupWindow) Reset() {
+	*x = PickupWindow{}
+	mi := &file_order_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupWindow) ProtoMessage() {}
+
+func (x *PickupWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupWindow.ProtoReflect.Descriptor instead.
+func (*PickupWindow) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PickupWindow) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *PickupWindow) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type Dimensions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LengthM       float64                `protobuf:"fixed64,1,opt,name=length_m,json=lengthM,proto3" json:"length_m,omitempty"`
+	WidthM        float64                `protobuf:"fixed64,2,opt,name=width_m,json=widthM,proto3" json:"width_m,omitempty"`
+	HeightM       float64                `protobuf:"fixed64,3,opt,name=height_m,json=heightM,proto3" json:"height_m,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dimensions) Reset() {
+	*x = Dimensions{}
+	mi := &file_order_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dimensions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dimensions) ProtoMessage() {}
+
+func (x *Dimensions) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dimensions.ProtoReflect.Descriptor instead.
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Dimensions) GetLengthM() float64 {
+	if x != nil {
+		return x.LengthM
+	}
+	return 0
+}
+
+func (x *Dimensions) GetWidthM() float64 {
+	if x != nil {
+		return x.WidthM
+	}
+	return 0
+}
+
+func (x *Dimensions) GetHeightM() float64 {
+	if x != nil {
+		return x.HeightM
+	}
+	return 0
+}
+
+// OrderItem is one line of the item manifest. Weight and hazmat flags are
+// filled in from the inventory catalog and ignored on input.
+type OrderItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sku           string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitWeightKg  float64                `protobuf:"fixed64,4,opt,name=unit_weight_kg,json=unitWeightKg,proto3" json:"unit_weight_kg,omitempty"`
+	Hazmat        bool                   `protobuf:"varint,5,opt,name=hazmat,proto3" json:"hazmat,omitempty"`
+	HazmatClass   string                 `protobuf:"bytes,6,opt,name=hazmat_class,json=hazmatClass,proto3" json:"hazmat_class,omitempty"`
+	DeclaredValue float64                `protobuf:"fixed64,7,opt,name=declared_value,json=declaredValue,proto3" json:"declared_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_order_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OrderItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *OrderItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetUnitWeightKg() float64 {
+	if x != nil {
+		return x.UnitWeightKg
+	}
+	return 0
+}
+
+func (x *OrderItem) GetHazmat() bool {
+	if x != nil {
+		return x.Hazmat
+	}
+	return false
+}
+
+func (x *OrderItem) GetHazmatClass() string {
+	if x != nil {
+		return x.HazmatClass
+	}
+	return ""
+}
+
+func (x *OrderItem) GetDeclaredValue() float64 {
+	if x != nil {
+		return x.DeclaredValue
+	}
+	return 0
+}
+
+// --- Schedule Model ---
+type Schedule struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PickupAddressId  string                 `protobuf:"bytes,2,opt,name=pickup_address_id,json=pickupAddressId,proto3" json:"pickup_address_id,omitempty"`
+	DropoffAddressId string                 `protobuf:"bytes,3,opt,name=dropoff_address_id,json=dropoffAddressId,proto3" json:"dropoff_address_id,omitempty"`
+	MachineType      string                 `protobuf:"bytes,4,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	Price            float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Dimensions       *Dimensions            `protobuf:"bytes,6,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Items            []*OrderItem           `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
+	Rule             string                 `protobuf:"bytes,8,opt,name=rule,proto3" json:"rule,omitempty"` // Weekly RRULE, e.g. "FREQ=WEEKLY;BYDAY=MO,TH;BYHOUR=9"
+	Timezone         string                 `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	StartsAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	WindowMinutes    int32                  `protobuf:"varint,11,opt,name=window_minutes,json=windowMinutes,proto3" json:"window_minutes,omitempty"`
+	Active           bool                   `protobuf:"varint,12,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_order_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Schedule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Schedule) GetPickupAddressId() string {
+	if x != nil {
+		return x.PickupAddressId
+	}
+	return ""
+}
+
+func (x *Schedule) GetDropoffAddressId() string {
+	if x != nil {
+		return x.DropoffAddressId
+	}
+	return ""
+}
+
+func (x *Schedule) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *Schedule) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Schedule) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *Schedule) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Schedule) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Schedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Schedule) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Schedule) GetWindowMinutes() int32 {
+	if x != nil {
+		return x.WindowMinutes
+	}
+	return 0
+}
+
+func (x *Schedule) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Schedule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Schedule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type UpcomingDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"` // Empty for occurrences that have not been materialized yet
+	ScheduleId    string                 `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // Order status, or "projected"
+	PickupWindow  *PickupWindow          `protobuf:"bytes,4,opt,name=pickup_window,json=pickupWindow,proto3" json:"pickup_window,omitempty"`
+	MachineType   string                 `protobuf:"bytes,5,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpcomingDelivery) Reset() {
+	*x = UpcomingDelivery{}
+	mi := &file_order_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpcomingDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpcomingDelivery) ProtoMessage() {}
+
+func (x *UpcomingDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpcomingDelivery.ProtoReflect.Descriptor instead.
+func (*UpcomingDelivery) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpcomingDelivery) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpcomingDelivery) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *UpcomingDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpcomingDelivery) GetPickupWindow() *PickupWindow {
+	if x != nil {
+		return x.PickupWindow
+	}
+	return nil
+}
+
+func (x *UpcomingDelivery) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+// --- Payment Model ---
+type Payment struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId           string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Provider          string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderPaymentId string                 `protobuf:"bytes,4,opt,name=provider_payment_id,json=providerPaymentId,proto3" json:"provider_payment_id,omitempty"`
+	AmountCents       int64                  `protobuf:"varint,5,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	Currency          string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	FailureReason     string                 `protobuf:"bytes,8,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_order_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Payment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Payment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Payment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Payment) GetProviderPaymentId() string {
+	if x != nil {
+		return x.ProviderPaymentId
+	}
+	return ""
+}
+
+func (x *Payment) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *Payment) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Payment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Payment) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Payment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// --- Refund Model ---
+type Refund struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PaymentId        string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	OrderId          string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProviderRefundId string                 `protobuf:"bytes,4,opt,name=provider_refund_id,json=providerRefundId,proto3" json:"provider_refund_id,omitempty"`
+	AmountCents      int64                  `protobuf:"varint,5,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	Currency         string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Reason           string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status           string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_order_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Refund) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Refund) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Refund) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Refund) GetProviderRefundId() string {
+	if x != nil {
+		return x.ProviderRefundId
+	}
+	return ""
+}
+
+func (x *Refund) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *Refund) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Refund) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Refund) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Refund) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Refund) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// --- Proof of Delivery Model ---
+type ProofOfDelivery struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OrderId          string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MachineId        string                 `protobuf:"bytes,2,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	PhotoRef         string                 `protobuf:"bytes,3,opt,name=photo_ref,json=photoRef,proto3" json:"photo_ref,omitempty"`
+	TrackingEventId  string                 `protobuf:"bytes,4,opt,name=tracking_event_id,json=trackingEventId,proto3" json:"tracking_event_id,omitempty"`
+	Latitude         float64                `protobuf:"fixed64,5,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude        float64                `protobuf:"fixed64,6,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	FixRecordedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=fix_recorded_at,json=fixRecordedAt,proto3" json:"fix_recorded_at,omitempty"`
+	DistanceMeters   float64                `protobuf:"fixed64,8,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"` // Distance to the stop's address, 0 when location_verified is false
+	LocationVerified bool                   `protobuf:"varint,9,opt,name=location_verified,json=locationVerified,proto3" json:"location_verified,omitempty"`
+	PinVerified      bool                   `protobuf:"varint,10,opt,name=pin_verified,json=pinVerified,proto3" json:"pin_verified,omitempty"`
+	DeliveredAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StopId           string                 `protobuf:"bytes,13,opt,name=stop_id,json=stopId,proto3" json:"stop_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ProofOfDelivery) Reset() {
+	*x = ProofOfDelivery{}
+	mi := &file_order_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProofOfDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofOfDelivery) ProtoMessage() {}
+
+func (x *ProofOfDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofOfDelivery.ProtoReflect.Descriptor instead.
+func (*ProofOfDelivery) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ProofOfDelivery) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ProofOfDelivery) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *ProofOfDelivery) GetPhotoRef() string {
+	if x != nil {
+		return x.PhotoRef
+	}
+	return ""
+}
+
+func (x *ProofOfDelivery) GetTrackingEventId() string {
+	if x != nil {
+		return x.TrackingEventId
+	}
+	return ""
+}
+
+func (x *ProofOfDelivery) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *ProofOfDelivery) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *ProofOfDelivery) GetFixRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FixRecordedAt
+	}
+	return nil
+}
+
+func (x *ProofOfDelivery) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *ProofOfDelivery) GetLocationVerified() bool {
+	if x != nil {
+		return x.LocationVerified
+	}
+	return false
+}
+
+func (x *ProofOfDelivery) GetPinVerified() bool {
+	if x != nil {
+		return x.PinVerified
+	}
+	return false
+}
+
+func (x *ProofOfDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *ProofOfDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProofOfDelivery) GetStopId() string {
+	if x != nil {
+		return x.StopId
+	}
+	return ""
+}
+
+// --- Delivery Attempt Model ---
+type DeliveryAttempt struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId         string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MachineId       string                 `protobuf:"bytes,3,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	AttemptNumber   int32                  `protobuf:"varint,4,opt,name=attempt_number,json=attemptNumber,proto3" json:"attempt_number,omitempty"`
+	Reason          string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // recipient_unavailable, address_inaccessible, recipient_refused, handoff_locked, package_damaged or machine_fault
+	Notes           string                 `protobuf:"bytes,6,opt,name=notes,proto3" json:"notes,omitempty"`
+	TrackingEventId string                 `protobuf:"bytes,7,opt,name=tracking_event_id,json=trackingEventId,proto3" json:"tracking_event_id,omitempty"`
+	Latitude        float64                `protobuf:"fixed64,8,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude       float64                `protobuf:"fixed64,9,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Outcome         string                 `protobuf:"bytes,10,opt,name=outcome,proto3" json:"outcome,omitempty"`                                        // reattempt or return; either way the machine heads back to the pickup address
+	Fee             float64                `protobuf:"fixed64,11,opt,name=fee,proto3" json:"fee,omitempty"`                                              // Added to the order's cost
+	ReattemptWindow *PickupWindow          `protobuf:"bytes,12,opt,name=reattempt_window,json=reattemptWindow,proto3" json:"reattempt_window,omitempty"` // Set when the outcome is reattempt
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StopId          string                 `protobuf:"bytes,14,opt,name=stop_id,json=stopId,proto3" json:"stop_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeliveryAttempt) Reset() {
+	*x = DeliveryAttempt{}
+	mi := &file_order_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAttempt) ProtoMessage() {}
+
+func (x *DeliveryAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAttempt.ProtoReflect.Descriptor instead.
+func (*DeliveryAttempt) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeliveryAttempt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetAttemptNumber() int32 {
+	if x != nil {
+		return x.AttemptNumber
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetTrackingEventId() string {
+	if x != nil {
+		return x.TrackingEventId
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetReattemptWindow() *PickupWindow {
+	if x != nil {
+		return x.ReattemptWindow
+	}
+	return nil
+}
+
+func (x *DeliveryAttempt) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DeliveryAttempt) GetStopId() string {
+	if x != nil {
+		return x.StopId
+	}
+	return ""
+}
+
+// --- Feedback Model ---
+type Feedback struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feedback) Reset() {
+	*x = Feedback{}
+	mi := &file_order_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feedback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feedback) ProtoMessage() {}
+
+func (x *Feedback) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feedback.ProtoReflect.Descriptor instead.
+func (*Feedback) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Feedback) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Feedback) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Feedback) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Feedback) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Feedback) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Feedback) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type RatingStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FeedbackCount  int32                  `protobuf:"varint,1,opt,name=feedback_count,json=feedbackCount,proto3" json:"feedback_count,omitempty"`
+	AverageRating  float64                `protobuf:"fixed64,2,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	LowRatingCount int32                  `protobuf:"varint,3,opt,name=low_rating_count,json=lowRatingCount,proto3" json:"low_rating_count,omitempty"`
+	RatingCounts   []int32                `protobuf:"varint,4,rep,packed,name=rating_counts,json=ratingCounts,proto3" json:"rating_counts,omitempty"` // Number of 1 to 5 star ratings, in that order.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RatingStats) Reset() {
+	*x = RatingStats{}
+	mi := &file_order_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingStats) ProtoMessage() {}
+
+func (x *RatingStats) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingStats.ProtoReflect.Descriptor instead.
+func (*RatingStats) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RatingStats) GetFeedbackCount() int32 {
+	if x != nil {
+		return x.FeedbackCount
+	}
+	return 0
+}
+
+func (x *RatingStats) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *RatingStats) GetLowRatingCount() int32 {
+	if x != nil {
+		return x.LowRatingCount
+	}
+	return 0
+}
+
+func (x *RatingStats) GetRatingCounts() []int32 {
+	if x != nil {
+		return x.RatingCounts
+	}
+	return nil
+}
+
+type MachineRatingStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineId     string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	MachineType   string                 `protobuf:"bytes,2,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	Stats         *RatingStats           `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MachineRatingStats) Reset() {
+	*x = MachineRatingStats{}
+	mi := &file_order_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MachineRatingStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineRatingStats) ProtoMessage() {}
+
+func (x *MachineRatingStats) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineRatingStats.ProtoReflect.Descriptor instead.
+func (*MachineRatingStats) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MachineRatingStats) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *MachineRatingStats) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *MachineRatingStats) GetStats() *RatingStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type MachineTypeRatingStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MachineType   string                 `protobuf:"bytes,1,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`
+	Stats         *RatingStats           `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MachineTypeRatingStats) Reset() {
+	*x = MachineTypeRatingStats{}
+	mi := &file_order_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MachineTypeRatingStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineTypeRatingStats) ProtoMessage() {}
+
+func (x *MachineTypeRatingStats) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineTypeRatingStats.ProtoReflect.Descriptor instead.
+func (*MachineTypeRatingStats) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MachineTypeRatingStats) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *MachineTypeRatingStats) GetStats() *RatingStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type CreateOrderRequest struct {
+	state                       protoimpl.MessageState `protogen:"open.v1"`
+	RouteOptionId               string                 `protobuf:"bytes,1,opt,name=route_option_id,json=routeOptionId,proto3" json:"route_option_id,omitempty"`
+	Dimensions                  *Dimensions            `protobuf:"bytes,2,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Items                       []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	PickupWindow                *PickupWindow          `protobuf:"bytes,4,opt,name=pickup_window,json=pickupWindow,proto3" json:"pickup_window,omitempty"`                                                  // Optional. Schedules the pickup instead of dispatching immediately.
+	AdditionalDropoffAddressIds []string               `protobuf:"bytes,5,rep,name=additional_dropoff_address_ids,json=additionalDropoffAddressIds,proto3" json:"additional_dropoff_address_ids,omitempty"` // Optional. Extra stops after the quoted dropoff.
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_order_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateOrderRequest) GetRouteOptionId() string {
+	if x != nil {
+		return x.RouteOptionId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetPickupWindow() *PickupWindow {
+	if x != nil {
+		return x.PickupWindow
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetAdditionalDropoffAddressIds() []string {
+	if x != nil {
+		return x.AdditionalDropoffAddressIds
+	}
+	return nil
+}
+
+type OrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderResponse) Reset() {
+	*x = OrderResponse{}
+	mi := &file_order_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderResponse) ProtoMessage() {}
+
+func (x *OrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderResponse.ProtoReflect.Descriptor instead.
+func (*OrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_order_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,3,opt,name=refund,proto3" json:"refund,omitempty"` // Unset when nothing was paid or the refund must be retried.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_order_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CancelOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CancelOrderResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+type CreateScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RouteOptionId string                 `protobuf:"bytes,1,opt,name=route_option_id,json=routeOptionId,proto3" json:"route_option_id,omitempty"`
+	Dimensions    *Dimensions            `protobuf:"bytes,2,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Rule          string                 `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"` // IANA name, defaults to UTC
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	WindowMinutes int32                  `protobuf:"varint,7,opt,name=window_minutes,json=windowMinutes,proto3" json:"window_minutes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduleRequest) Reset() {
+	*x = CreateScheduleRequest{}
+	mi := &file_order_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduleRequest) ProtoMessage() {}
+
+func (x *CreateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateScheduleRequest) GetRouteOptionId() string {
+	if x != nil {
+		return x.RouteOptionId
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetDimensions() *Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *CreateScheduleRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateScheduleRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreateScheduleRequest) GetWindowMinutes() int32 {
+	if x != nil {
+		return x.WindowMinutes
+	}
+	return 0
+}
+
+type ScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleResponse) Reset() {
+	*x = ScheduleResponse{}
+	mi := &file_order_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleResponse) ProtoMessage() {}
+
+func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_order_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{24}
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*Schedule            `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_order_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type CancelScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduleRequest) Reset() {
+	*x = CancelScheduleRequest{}
+	mi := &file_order_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduleRequest) ProtoMessage() {}
+
+func (x *CancelScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CancelScheduleRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type CancelScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduleResponse) Reset() {
+	*x = CancelScheduleResponse{}
+	mi := &file_order_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduleResponse) ProtoMessage() {}
+
+func (x *CancelScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduleResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{27}
+}
+
+type ListUpcomingDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // Optional, defaults to now
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // Optional, defaults to the schedule horizon
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUpcomingDeliveriesRequest) Reset() {
+	*x = ListUpcomingDeliveriesRequest{}
+	mi := &file_order_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUpcomingDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUpcomingDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListUpcomingDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUpcomingDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListUpcomingDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListUpcomingDeliveriesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListUpcomingDeliveriesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type ListUpcomingDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*UpcomingDelivery    `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"` // Earliest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUpcomingDeliveriesResponse) Reset() {
+	*x = ListUpcomingDeliveriesResponse{}
+	mi := &file_order_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUpcomingDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUpcomingDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListUpcomingDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUpcomingDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListUpcomingDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListUpcomingDeliveriesResponse) GetDeliveries() []*UpcomingDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type PayOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PaymentMethodId string                 `protobuf:"bytes,2,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PayOrderRequest) Reset() {
+	*x = PayOrderRequest{}
+	mi := &file_order_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderRequest) ProtoMessage() {}
+
+func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderRequest.ProtoReflect.Descriptor instead.
+func (*PayOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PayOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PayOrderRequest) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+type PaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentResponse) Reset() {
+	*x = PaymentResponse{}
+	mi := &file_order_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentResponse) ProtoMessage() {}
+
+func (x *PaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentResponse.ProtoReflect.Descriptor instead.
+func (*PaymentResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type AssignOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MachineId     string                 `protobuf:"bytes,2,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignOrderRequest) Reset() {
+	*x = AssignOrderRequest{}
+	mi := &file_order_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignOrderRequest) ProtoMessage() {}
+
+func (x *AssignOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignOrderRequest.ProtoReflect.Descriptor instead.
+func (*AssignOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AssignOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AssignOrderRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+type AssignTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderIds      []string               `protobuf:"bytes,1,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	MachineId     string                 `protobuf:"bytes,2,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignTripRequest) Reset() {
+	*x = AssignTripRequest{}
+	mi := &file_order_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignTripRequest) ProtoMessage() {}
+
+func (x *AssignTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignTripRequest.ProtoReflect.Descriptor instead.
+func (*AssignTripRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AssignTripRequest) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *AssignTripRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+type TripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripResponse) Reset() {
+	*x = TripResponse{}
+	mi := &file_order_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripResponse) ProtoMessage() {}
+
+func (x *TripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripResponse.ProtoReflect.Descriptor instead.
+func (*TripResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TripResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+type ListOrderStopsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderStopsRequest) Reset() {
+	*x = ListOrderStopsRequest{}
+	mi := &file_order_order_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderStopsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderStopsRequest) ProtoMessage() {}
+
+func (x *ListOrderStopsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderStopsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrderStopsRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListOrderStopsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListOrderStopsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stops         []*Stop                `protobuf:"bytes,1,rep,name=stops,proto3" json:"stops,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderStopsResponse) Reset() {
+	*x = ListOrderStopsResponse{}
+	mi := &file_order_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderStopsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderStopsResponse) ProtoMessage() {}
+
+func (x *ListOrderStopsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderStopsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrderStopsResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListOrderStopsResponse) GetStops() []*Stop {
+	if x != nil {
+		return x.Stops
+	}
+	return nil
+}
+
+type GetOrderETARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderETARequest) Reset() {
+	*x = GetOrderETARequest{}
+	mi := &file_order_order_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderETARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderETARequest) ProtoMessage() {}
+
+func (x *GetOrderETARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderETARequest.ProtoReflect.Descriptor instead.
+func (*GetOrderETARequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetOrderETARequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type OrderETAResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Eta           *OrderETA              `protobuf:"bytes,1,opt,name=eta,proto3" json:"eta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderETAResponse) Reset() {
+	*x = OrderETAResponse{}
+	mi := &file_order_order_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderETAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderETAResponse) ProtoMessage() {}
+
+func (x *OrderETAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderETAResponse.ProtoReflect.Descriptor instead.
+func (*OrderETAResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *OrderETAResponse) GetEta() *OrderETA {
+	if x != nil {
+		return x.Eta
+	}
+	return nil
+}
+
+type RefundOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`                               // customer_cancelled, failed_delivery or admin
+	AmountCents   int64                  `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"` // Optional. Zero means "use the refund policy".
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_order_order_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RefundOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundOrderRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+type RefundResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refund        *Refund                `protobuf:"bytes,1,opt,name=refund,proto3" json:"refund,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundResponse) Reset() {
+	*x = RefundResponse{}
+	mi := &file_order_order_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundResponse) ProtoMessage() {}
+
+func (x *RefundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundResponse.ProtoReflect.Descriptor instead.
+func (*RefundResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RefundResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+type ConfirmPickupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPickupRequest) Reset() {
+	*x = ConfirmPickupRequest{}
+	mi := &file_order_order_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPickupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPickupRequest) ProtoMessage() {}
+
+func (x *ConfirmPickupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPickupRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPickupRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ConfirmPickupRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type SubmitProofOfDeliveryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PhotoRef        string                 `protobuf:"bytes,2,opt,name=photo_ref,json=photoRef,proto3" json:"photo_ref,omitempty"`                        // Blob storage reference of the handoff photo
+	TrackingEventId string                 `protobuf:"bytes,3,opt,name=tracking_event_id,json=trackingEventId,proto3" json:"tracking_event_id,omitempty"` // GPS fix reported at the handoff
+	DeliveredAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	RecipientPin    string                 `protobuf:"bytes,5,opt,name=recipient_pin,json=recipientPin,proto3" json:"recipient_pin,omitempty"` // Required if the order was dispatched with a handoff PIN
+	StopId          string                 `protobuf:"bytes,6,opt,name=stop_id,json=stopId,proto3" json:"stop_id,omitempty"`                   // Optional. Defaults to the next pending stop.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SubmitProofOfDeliveryRequest) Reset() {
+	*x = SubmitProofOfDeliveryRequest{}
+	mi := &file_order_order_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitProofOfDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitProofOfDeliveryRequest) ProtoMessage() {}
+
+func (x *SubmitProofOfDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitProofOfDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*SubmitProofOfDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SubmitProofOfDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SubmitProofOfDeliveryRequest) GetPhotoRef() string {
+	if x != nil {
+		return x.PhotoRef
+	}
+	return ""
+}
+
+func (x *SubmitProofOfDeliveryRequest) GetTrackingEventId() string {
+	if x != nil {
+		return x.TrackingEventId
+	}
+	return ""
+}
+
+func (x *SubmitProofOfDeliveryRequest) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *SubmitProofOfDeliveryRequest) GetRecipientPin() string {
+	if x != nil {
+		return x.RecipientPin
+	}
+	return ""
+}
+
+func (x *SubmitProofOfDeliveryRequest) GetStopId() string {
+	if x != nil {
+		return x.StopId
+	}
+	return ""
+}
+
+type GetProofOfDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProofOfDeliveryRequest) Reset() {
+	*x = GetProofOfDeliveryRequest{}
+	mi := &file_order_order_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProofOfDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProofOfDeliveryRequest) ProtoMessage() {}
+
+func (x *GetProofOfDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProofOfDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*GetProofOfDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetProofOfDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ProofOfDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Proof         *ProofOfDelivery       `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`   // The first delivered stop
+	Proofs        []*ProofOfDelivery     `protobuf:"bytes,2,rep,name=proofs,proto3" json:"proofs,omitempty"` // One per delivered stop
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProofOfDeliveryResponse) Reset() {
+	*x = ProofOfDeliveryResponse{}
+	mi := &file_order_order_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProofOfDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofOfDeliveryResponse) ProtoMessage() {}
+
+func (x *ProofOfDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofOfDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*ProofOfDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ProofOfDeliveryResponse) GetProof() *ProofOfDelivery {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ProofOfDeliveryResponse) GetProofs() []*ProofOfDelivery {
+	if x != nil {
+		return x.Proofs
+	}
+	return nil
+}
+
+type ReportFailedDeliveryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason          string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	TrackingEventId string                 `protobuf:"bytes,3,opt,name=tracking_event_id,json=trackingEventId,proto3" json:"tracking_event_id,omitempty"` // GPS fix reported at the failed handoff
+	Notes           string                 `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`                                              // Optional
+	StopId          string                 `protobuf:"bytes,5,opt,name=stop_id,json=stopId,proto3" json:"stop_id,omitempty"`                              // Optional. Defaults to the next pending stop.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReportFailedDeliveryRequest) Reset() {
+	*x = ReportFailedDeliveryRequest{}
+	mi := &file_order_order_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportFailedDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportFailedDeliveryRequest) ProtoMessage() {}
+
+func (x *ReportFailedDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportFailedDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*ReportFailedDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReportFailedDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReportFailedDeliveryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReportFailedDeliveryRequest) GetTrackingEventId() string {
+	if x != nil {
+		return x.TrackingEventId
+	}
+	return ""
+}
+
+func (x *ReportFailedDeliveryRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *ReportFailedDeliveryRequest) GetStopId() string {
+	if x != nil {
+		return x.StopId
+	}
+	return ""
+}
+
+type DeliveryAttemptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempt       *DeliveryAttempt       `protobuf:"bytes,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryAttemptResponse) Reset() {
+	*x = DeliveryAttemptResponse{}
+	mi := &file_order_order_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryAttemptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAttemptResponse) ProtoMessage() {}
+
+func (x *DeliveryAttemptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAttemptResponse.ProtoReflect.Descriptor instead.
+func (*DeliveryAttemptResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeliveryAttemptResponse) GetAttempt() *DeliveryAttempt {
+	if x != nil {
+		return x.Attempt
+	}
+	return nil
+}
+
+type ConfirmReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReturnRequest) Reset() {
+	*x = ConfirmReturnRequest{}
+	mi := &file_order_order_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReturnRequest) ProtoMessage() {}
+
+func (x *ConfirmReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReturnRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ConfirmReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ConfirmReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,3,opt,name=refund,proto3" json:"refund,omitempty"` // Set when the failure was our fault and the order was refunded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReturnResponse) Reset() {
+	*x = ConfirmReturnResponse{}
+	mi := &file_order_order_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReturnResponse) ProtoMessage() {}
+
+func (x *ConfirmReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReturnResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmReturnResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ConfirmReturnResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ConfirmReturnResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ConfirmReturnResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+type ListDeliveryAttemptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeliveryAttemptsRequest) Reset() {
+	*x = ListDeliveryAttemptsRequest{}
+	mi := &file_order_order_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeliveryAttemptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeliveryAttemptsRequest) ProtoMessage() {}
+
+func (x *ListDeliveryAttemptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeliveryAttemptsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeliveryAttemptsRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListDeliveryAttemptsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListDeliveryAttemptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attempts      []*DeliveryAttempt     `protobuf:"bytes,1,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeliveryAttemptsResponse) Reset() {
+	*x = ListDeliveryAttemptsResponse{}
+	mi := &file_order_order_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeliveryAttemptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeliveryAttemptsResponse) ProtoMessage() {}
+
+func (x *ListDeliveryAttemptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeliveryAttemptsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeliveryAttemptsResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListDeliveryAttemptsResponse) GetAttempts() []*DeliveryAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+type SubmitFeedbackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Rating        int32                  `protobuf:"varint,2,opt,name=rating,proto3" json:"rating,omitempty"` // 1 to 5
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitFeedbackRequest) Reset() {
+	*x = SubmitFeedbackRequest{}
+	mi := &file_order_order_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitFeedbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitFeedbackRequest) ProtoMessage() {}
+
+func (x *SubmitFeedbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitFeedbackRequest.ProtoReflect.Descriptor instead.
+func (*SubmitFeedbackRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SubmitFeedbackRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *SubmitFeedbackRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *SubmitFeedbackRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type FeedbackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feedback      *Feedback              `protobuf:"bytes,1,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackResponse) Reset() {
+	*x = FeedbackResponse{}
+	mi := &file_order_order_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackResponse) ProtoMessage() {}
+
+func (x *FeedbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackResponse.ProtoReflect.Descriptor instead.
+func (*FeedbackResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *FeedbackResponse) GetFeedback() *Feedback {
+	if x != nil {
+		return x.Feedback
+	}
+	return nil
+}
+
+type GetMachineRatingStatsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MachineId        string                 `protobuf:"bytes,1,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`                         // Optional
+	MachineType      string                 `protobuf:"bytes,2,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`                   // Optional, DRONE or ROBOT
+	Since            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`                                                  // Optional
+	MinFeedbackCount int32                  `protobuf:"varint,4,opt,name=min_feedback_count,json=minFeedbackCount,proto3" json:"min_feedback_count,omitempty"` // Leave out machines with fewer ratings
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetMachineRatingStatsRequest) Reset() {
+	*x = GetMachineRatingStatsRequest{}
+	mi := &file_order_order_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMachineRatingStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMachineRatingStatsRequest) ProtoMessage() {}
+
+func (x *GetMachineRatingStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMachineRatingStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetMachineRatingStatsRequest) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetMachineRatingStatsRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *GetMachineRatingStatsRequest) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *GetMachineRatingStatsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetMachineRatingStatsRequest) GetMinFeedbackCount() int32 {
+	if x != nil {
+		return x.MinFeedbackCount
+	}
+	return 0
+}
+
+type GetMachineRatingStatsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Machines      []*MachineRatingStats     `protobuf:"bytes,1,rep,name=machines,proto3" json:"machines,omitempty"` // Worst average rating first
+	MachineTypes  []*MachineTypeRatingStats `protobuf:"bytes,2,rep,name=machine_types,json=machineTypes,proto3" json:"machine_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMachineRatingStatsResponse) Reset() {
+	*x = GetMachineRatingStatsResponse{}
+	mi := &file_order_order_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMachineRatingStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMachineRatingStatsResponse) ProtoMessage() {}
+
+func (x *GetMachineRatingStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_order_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMachineRatingStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetMachineRatingStatsResponse) Descriptor() ([]byte, []int) {
+	return file_order_order_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetMachineRatingStatsResponse) GetMachines() []*MachineRatingStats {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+func (x *GetMachineRatingStatsResponse) GetMachineTypes() []*MachineTypeRatingStats {
+	if x != nil {
+		return x.MachineTypes
+	}
+	return nil
+}
+
+var File_order_order_proto protoreflect.FileDescriptor
+
+const file_order_order_proto_rawDesc = "" +
+	"\n" +
+	"\x11order/order.proto\x12\x05order\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x05\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x03 \x01(\tR\tmachineId\x12*\n" +
+	"\x11pickup_address_id\x18\x04 \x01(\tR\x0fpickupAddressId\x12,\n" +
+	"\x12dropoff_address_id\x18\x05 \x01(\tR\x10dropoffAddressId\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x121\n" +
+	"\n" +
+	"dimensions\x18\a \x01(\v2\x11.order.DimensionsR\n" +
+	"dimensions\x12$\n" +
+	"\x0eitem_weight_kg\x18\b \x01(\x01R\fitemWeightKg\x12\x12\n" +
+	"\x04cost\x18\t \x01(\x01R\x04cost\x12&\n" +
+	"\x05items\x18\n" +
+	" \x03(\v2\x10.order.OrderItemR\x05items\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fmachine_type\x18\r \x01(\tR\vmachineType\x128\n" +
+	"\rpickup_window\x18\x0e \x01(\v2\x13.order.PickupWindowR\fpickupWindow\x12\x1f\n" +
+	"\vschedule_id\x18\x0f \x01(\tR\n" +
+	"scheduleId\x12\x17\n" +
+	"\atrip_id\x18\x10 \x01(\tR\x06tripId\x12!\n" +
+	"\x05stops\x18\x11 \x03(\v2\v.order.StopR\x05stops\"\xb1\x02\n" +
+	"\x04Stop\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x05R\bsequence\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x04 \x01(\tR\taddressId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x17\n" +
+	"\atrip_id\x18\x06 \x01(\tR\x06tripId\x12#\n" +
+	"\rtrip_sequence\x18\a \x01(\x05R\ftripSequence\x12.\n" +
+	"\x13leg_distance_meters\x18\b \x01(\x01R\x11legDistanceMeters\x12=\n" +
+	"\fdelivered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\"y\n" +
+	"\aStopETA\x12\x17\n" +
+	"\astop_id\x18\x01 \x01(\tR\x06stopId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x05R\bsequence\x129\n" +
+	"\n" +
+	"arrives_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tarrivesAt\"\xdc\x03\n" +
+	"\bOrderETA\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x03 \x01(\tR\tmachineId\x12\x1a\n" +
+	"\blatitude\x18\x04 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x05 \x01(\x01R\tlongitude\x12;\n" +
+	"\vposition_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"positionAt\x12$\n" +
+	"\x05stops\x18\a \x03(\v2\x0e.order.StopETAR\x05stops\x129\n" +
+	"\n" +
+	"arrives_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tarrivesAt\x12:\n" +
+	"\x19remaining_distance_meters\x18\t \x01(\x01R\x17remainingDistanceMeters\x12+\n" +
+	"\x11calibration_trips\x18\n" +
+	" \x01(\x05R\x10calibrationTrips\x12=\n" +
+	"\festimated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vestimatedAt\"\x9d\x02\n" +
+	"\x04Trip\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x02 \x01(\tR\tmachineId\x12*\n" +
+	"\x11pickup_address_id\x18\x03 \x01(\tR\x0fpickupAddressId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12'\n" +
+	"\x0fdistance_meters\x18\x05 \x01(\x01R\x0edistanceMeters\x12\x1b\n" +
+	"\torder_ids\x18\x06 \x03(\tR\borderIds\x12!\n" +
+	"\x05stops\x18\a \x03(\v2\v.order.StopR\x05stops\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"n\n" +
+	"\fPickupWindow\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"[\n" +
+	"\n" +
+	"Dimensions\x12\x19\n" +
+	"\blength_m\x18\x01 \x01(\x01R\alengthM\x12\x17\n" +
+	"\awidth_m\x18\x02 \x01(\x01R\x06widthM\x12\x19\n" +
+	"\bheight_m\x18\x03 \x01(\x01R\aheightM\"\xd5\x01\n" +
+	"\tOrderItem\x12\x10\n" +
+	"\x03sku\x18\x01 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12$\n" +
+	"\x0eunit_weight_kg\x18\x04 \x01(\x01R\funitWeightKg\x12\x16\n" +
+	"\x06hazmat\x18\x05 \x01(\bR\x06hazmat\x12!\n" +
+	"\fhazmat_class\x18\x06 \x01(\tR\vhazmatClass\x12%\n" +
+	"\x0edeclared_value\x18\a \x01(\x01R\rdeclaredValue\"\xa6\x04\n" +
+	"\bSchedule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x11pickup_address_id\x18\x02 \x01(\tR\x0fpickupAddressId\x12,\n" +
+	"\x12dropoff_address_id\x18\x03 \x01(\tR\x10dropoffAddressId\x12!\n" +
+	"\fmachine_type\x18\x04 \x01(\tR\vmachineType\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x121\n" +
+	"\n" +
+	"dimensions\x18\x06 \x01(\v2\x11.order.DimensionsR\n" +
+	"dimensions\x12&\n" +
+	"\x05items\x18\a \x03(\v2\x10.order.OrderItemR\x05items\x12\x12\n" +
+	"\x04rule\x18\b \x01(\tR\x04rule\x12\x1a\n" +
+	"\btimezone\x18\t \x01(\tR\btimezone\x127\n" +
+	"\tstarts_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x12%\n" +
+	"\x0ewindow_minutes\x18\v \x01(\x05R\rwindowMinutes\x12\x16\n" +
+	"\x06active\x18\f \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc3\x01\n" +
+	"\x10UpcomingDelivery\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vschedule_id\x18\x02 \x01(\tR\n" +
+	"scheduleId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x128\n" +
+	"\rpickup_window\x18\x04 \x01(\v2\x13.order.PickupWindowR\fpickupWindow\x12!\n" +
+	"\fmachine_type\x18\x05 \x01(\tR\vmachineType\"\xf4\x02\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12.\n" +
+	"\x13provider_payment_id\x18\x04 \x01(\tR\x11providerPaymentId\x12!\n" +
+	"\famount_cents\x18\x05 \x01(\x03R\vamountCents\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\b \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe5\x02\n" +
+	"\x06Refund\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12,\n" +
+	"\x12provider_refund_id\x18\x04 \x01(\tR\x10providerRefundId\x12!\n" +
+	"\famount_cents\x18\x05 \x01(\x03R\vamountCents\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9e\x04\n" +
+	"\x0fProofOfDelivery\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x02 \x01(\tR\tmachineId\x12\x1b\n" +
+	"\tphoto_ref\x18\x03 \x01(\tR\bphotoRef\x12*\n" +
+	"\x11tracking_event_id\x18\x04 \x01(\tR\x0ftrackingEventId\x12\x1a\n" +
+	"\blatitude\x18\x05 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x06 \x01(\x01R\tlongitude\x12B\n" +
+	"\x0ffix_recorded_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rfixRecordedAt\x12'\n" +
+	"\x0fdistance_meters\x18\b \x01(\x01R\x0edistanceMeters\x12+\n" +
+	"\x11location_verified\x18\t \x01(\bR\x10locationVerified\x12!\n" +
+	"\fpin_verified\x18\n" +
+	" \x01(\bR\vpinVerified\x12=\n" +
+	"\fdelivered_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x17\n" +
+	"\astop_id\x18\r \x01(\tR\x06stopId\"\xd6\x03\n" +
+	"\x0fDeliveryAttempt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x03 \x01(\tR\tmachineId\x12%\n" +
+	"\x0eattempt_number\x18\x04 \x01(\x05R\rattemptNumber\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05notes\x18\x06 \x01(\tR\x05notes\x12*\n" +
+	"\x11tracking_event_id\x18\a \x01(\tR\x0ftrackingEventId\x12\x1a\n" +
+	"\blatitude\x18\b \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\t \x01(\x01R\tlongitude\x12\x18\n" +
+	"\aoutcome\x18\n" +
+	" \x01(\tR\aoutcome\x12\x10\n" +
+	"\x03fee\x18\v \x01(\x01R\x03fee\x12>\n" +
+	"\x10reattempt_window\x18\f \x01(\v2\x13.order.PickupWindowR\x0freattemptWindow\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x17\n" +
+	"\astop_id\x18\x0e \x01(\tR\x06stopId\"\xdd\x01\n" +
+	"\bFeedback\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\x05R\x06rating\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xaa\x01\n" +
+	"\vRatingStats\x12%\n" +
+	"\x0efeedback_count\x18\x01 \x01(\x05R\rfeedbackCount\x12%\n" +
+	"\x0eaverage_rating\x18\x02 \x01(\x01R\raverageRating\x12(\n" +
+	"\x10low_rating_count\x18\x03 \x01(\x05R\x0elowRatingCount\x12#\n" +
+	"\rrating_counts\x18\x04 \x03(\x05R\fratingCounts\"\x80\x01\n" +
+	"\x12MachineRatingStats\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12!\n" +
+	"\fmachine_type\x18\x02 \x01(\tR\vmachineType\x12(\n" +
+	"\x05stats\x18\x03 \x01(\v2\x12.order.RatingStatsR\x05stats\"e\n" +
+	"\x16MachineTypeRatingStats\x12!\n" +
+	"\fmachine_type\x18\x01 \x01(\tR\vmachineType\x12(\n" +
+	"\x05stats\x18\x02 \x01(\v2\x12.order.RatingStatsR\x05stats\"\x96\x02\n" +
+	"\x12CreateOrderRequest\x12&\n" +
+	"\x0froute_option_id\x18\x01 \x01(\tR\rrouteOptionId\x121\n" +
+	"\n" +
+	"dimensions\x18\x02 \x01(\v2\x11.order.DimensionsR\n" +
+	"dimensions\x12&\n" +
+	"\x05items\x18\x03 \x03(\v2\x10.order.OrderItemR\x05items\x128\n" +
+	"\rpickup_window\x18\x04 \x01(\v2\x13.order.PickupWindowR\fpickupWindow\x12C\n" +
+	"\x1eadditional_dropoff_address_ids\x18\x05 \x03(\tR\x1badditionalDropoffAddressIds\"3\n" +
+	"\rOrderResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"/\n" +
+	"\x12CancelOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"o\n" +
+	"\x13CancelOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12%\n" +
+	"\x06refund\x18\x03 \x01(\v2\r.order.RefundR\x06refund\"\xaa\x02\n" +
+	"\x15CreateScheduleRequest\x12&\n" +
+	"\x0froute_option_id\x18\x01 \x01(\tR\rrouteOptionId\x121\n" +
+	"\n" +
+	"dimensions\x18\x02 \x01(\v2\x11.order.DimensionsR\n" +
+	"dimensions\x12&\n" +
+	"\x05items\x18\x03 \x03(\v2\x10.order.OrderItemR\x05items\x12\x12\n" +
+	"\x04rule\x18\x04 \x01(\tR\x04rule\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x127\n" +
+	"\tstarts_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x12%\n" +
+	"\x0ewindow_minutes\x18\a \x01(\x05R\rwindowMinutes\"?\n" +
+	"\x10ScheduleResponse\x12+\n" +
+	"\bschedule\x18\x01 \x01(\v2\x0f.order.ScheduleR\bschedule\"\x16\n" +
+	"\x14ListSchedulesRequest\"F\n" +
+	"\x15ListSchedulesResponse\x12-\n" +
+	"\tschedules\x18\x01 \x03(\v2\x0f.order.ScheduleR\tschedules\"8\n" +
+	"\x15CancelScheduleRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\"\x18\n" +
+	"\x16CancelScheduleResponse\"{\n" +
+	"\x1dListUpcomingDeliveriesRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"Y\n" +
+	"\x1eListUpcomingDeliveriesResponse\x127\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x17.order.UpcomingDeliveryR\n" +
+	"deliveries\"X\n" +
+	"\x0fPayOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12*\n" +
+	"\x11payment_method_id\x18\x02 \x01(\tR\x0fpaymentMethodId\";\n" +
+	"\x0fPaymentResponse\x12(\n" +
+	"\apayment\x18\x01 \x01(\v2\x0e.order.PaymentR\apayment\"N\n" +
+	"\x12AssignOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x02 \x01(\tR\tmachineId\"O\n" +
+	"\x11AssignTripRequest\x12\x1b\n" +
+	"\torder_ids\x18\x01 \x03(\tR\borderIds\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x02 \x01(\tR\tmachineId\"/\n" +
+	"\fTripResponse\x12\x1f\n" +
+	"\x04trip\x18\x01 \x01(\v2\v.order.TripR\x04trip\"2\n" +
+	"\x15ListOrderStopsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\";\n" +
+	"\x16ListOrderStopsResponse\x12!\n" +
+	"\x05stops\x18\x01 \x03(\v2\v.order.StopR\x05stops\"/\n" +
+	"\x12GetOrderETARequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"5\n" +
+	"\x10OrderETAResponse\x12!\n" +
+	"\x03eta\x18\x01 \x01(\v2\x0f.order.OrderETAR\x03eta\"j\n" +
+	"\x12RefundOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12!\n" +
+	"\famount_cents\x18\x03 \x01(\x03R\vamountCents\"7\n" +
+	"\x0eRefundResponse\x12%\n" +
+	"\x06refund\x18\x01 \x01(\v2\r.order.RefundR\x06refund\"1\n" +
+	"\x14ConfirmPickupRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\xff\x01\n" +
+	"\x1cSubmitProofOfDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1b\n" +
+	"\tphoto_ref\x18\x02 \x01(\tR\bphotoRef\x12*\n" +
+	"\x11tracking_event_id\x18\x03 \x01(\tR\x0ftrackingEventId\x12=\n" +
+	"\fdelivered_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x12#\n" +
+	"\rrecipient_pin\x18\x05 \x01(\tR\frecipientPin\x12\x17\n" +
+	"\astop_id\x18\x06 \x01(\tR\x06stopId\"6\n" +
+	"\x19GetProofOfDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"w\n" +
+	"\x17ProofOfDeliveryResponse\x12,\n" +
+	"\x05proof\x18\x01 \x01(\v2\x16.order.ProofOfDeliveryR\x05proof\x12.\n" +
+	"\x06proofs\x18\x02 \x03(\v2\x16.order.ProofOfDeliveryR\x06proofs\"\xab\x01\n" +
+	"\x1bReportFailedDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12*\n" +
+	"\x11tracking_event_id\x18\x03 \x01(\tR\x0ftrackingEventId\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notes\x12\x17\n" +
+	"\astop_id\x18\x05 \x01(\tR\x06stopId\"K\n" +
+	"\x17DeliveryAttemptResponse\x120\n" +
+	"\aattempt\x18\x01 \x01(\v2\x16.order.DeliveryAttemptR\aattempt\"1\n" +
+	"\x14ConfirmReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"q\n" +
+	"\x15ConfirmReturnResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12%\n" +
+	"\x06refund\x18\x03 \x01(\v2\r.order.RefundR\x06refund\"8\n" +
+	"\x1bListDeliveryAttemptsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"R\n" +
+	"\x1cListDeliveryAttemptsResponse\x122\n" +
+	"\battempts\x18\x01 \x03(\v2\x16.order.DeliveryAttemptR\battempts\"d\n" +
+	"\x15SubmitFeedbackRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06rating\x18\x02 \x01(\x05R\x06rating\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"?\n" +
+	"\x10FeedbackResponse\x12+\n" +
+	"\bfeedback\x18\x01 \x01(\v2\x0f.order.FeedbackR\bfeedback\"\xc0\x01\n" +
+	"\x1cGetMachineRatingStatsRequest\x12\x1d\n" +
+	"\n" +
+	"machine_id\x18\x01 \x01(\tR\tmachineId\x12!\n" +
+	"\fmachine_type\x18\x02 \x01(\tR\vmachineType\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12,\n" +
+	"\x12min_feedback_count\x18\x04 \x01(\x05R\x10minFeedbackCount\"\x9a\x01\n" +
+	"\x1dGetMachineRatingStatsResponse\x125\n" +
+	"\bmachines\x18\x01 \x03(\v2\x19.order.MachineRatingStatsR\bmachines\x12B\n" +
+	"\rmachine_types\x18\x02 \x03(\v2\x1d.order.MachineTypeRatingStatsR\fmachineTypes2\x9b\f\n" +
+	"\fOrderService\x12>\n" +
+	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x14.order.OrderResponse\x12D\n" +
+	"\vCancelOrder\x12\x19.order.CancelOrderRequest\x1a\x1a.order.CancelOrderResponse\x12G\n" +
+	"\x0eCreateSchedule\x12\x1c.order.CreateScheduleRequest\x1a\x17.order.ScheduleResponse\x12J\n" +
+	"\rListSchedules\x12\x1b.order.ListSchedulesRequest\x1a\x1c.order.ListSchedulesResponse\x12M\n" +
+	"\x0eCancelSchedule\x12\x1c.order.CancelScheduleRequest\x1a\x1d.order.CancelScheduleResponse\x12e\n" +
+	"\x16ListUpcomingDeliveries\x12$.order.ListUpcomingDeliveriesRequest\x1a%.order.ListUpcomingDeliveriesResponse\x12:\n" +
+	"\bPayOrder\x12\x16.order.PayOrderRequest\x1a\x16.order.PaymentResponse\x12B\n" +
+	"\rConfirmPickup\x12\x1b.order.ConfirmPickupRequest\x1a\x14.order.OrderResponse\x12\\\n" +
+	"\x15SubmitProofOfDelivery\x12#.order.SubmitProofOfDeliveryRequest\x1a\x1e.order.ProofOfDeliveryResponse\x12V\n" +
+	"\x12GetProofOfDelivery\x12 .order.GetProofOfDeliveryRequest\x1a\x1e.order.ProofOfDeliveryResponse\x12Z\n" +
+	"\x14ReportFailedDelivery\x12\".order.ReportFailedDeliveryRequest\x1a\x1e.order.DeliveryAttemptResponse\x12J\n" +
+	"\rConfirmReturn\x12\x1b.order.ConfirmReturnRequest\x1a\x1c.order.ConfirmReturnResponse\x12_\n" +
+	"\x14ListDeliveryAttempts\x12\".order.ListDeliveryAttemptsRequest\x1a#.order.ListDeliveryAttemptsResponse\x12M\n" +
+	"\x0eListOrderStops\x12\x1c.order.ListOrderStopsRequest\x1a\x1d.order.ListOrderStopsResponse\x12A\n" +
+	"\vGetOrderETA\x12\x19.order.GetOrderETARequest\x1a\x17.order.OrderETAResponse\x12G\n" +
+	"\x0eSubmitFeedback\x12\x1c.order.SubmitFeedbackRequest\x1a\x17.order.FeedbackResponse\x12>\n" +
+	"\vAssignOrder\x12\x19.order.AssignOrderRequest\x1a\x14.order.OrderResponse\x12;\n" +
+	"\n" +
+	"AssignTrip\x12\x18.order.AssignTripRequest\x1a\x13.order.TripResponse\x12?\n" +
+	"\vRefundOrder\x12\x19.order.RefundOrderRequest\x1a\x15.order.RefundResponse\x12b\n" +
+	"\x15GetMachineRatingStats\x12#.order.GetMachineRatingStatsRequest\x1a$.order.GetMachineRatingStatsResponseB\x16Z\x14laas/api/proto/orderb\x06proto3"
+
+var (
+	file_order_order_proto_rawDescOnce sync.Once
+	file_order_order_proto_rawDescData []byte
+)
+
+func file_order_order_proto_rawDescGZIP() []byte {
+	file_order_order_proto_rawDescOnce.Do(func() {
+		file_order_order_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_order_order_proto_rawDesc), len(file_order_order_proto_rawDesc)))
+	})
+	return file_order_order_proto_rawDescData
+}
+
+var file_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
+var file_order_order_proto_goTypes = []any{
+	(*Order)(nil),                          // 0: order.Order
+	(*Stop)(nil),                           // 1: order.Stop
+	(*StopETA)(nil),                        // 2: order.StopETA
+	(*OrderETA)(nil),                       // 3: order.OrderETA
+	(*Trip)(nil),                           // 4: order.Trip
+	(*PickupWindow)(nil),                   // 5: order.PickupWindow
+	(*Dimensions)(nil),                     // 6: order.Dimensions
+	(*OrderItem)(nil),                      // 7: order.OrderItem
+	(*Schedule)(nil),                       // 8: order.Schedule
+	(*UpcomingDelivery)(nil),               // 9: order.UpcomingDelivery
+	(*Payment)(nil),                        // 10: order.Payment
+	(*Refund)(nil),                         // 11: order.Refund
+	(*ProofOfDelivery)(nil),                // 12: order.ProofOfDelivery
+	(*DeliveryAttempt)(nil),                // 13: order.DeliveryAttempt
+	(*Feedback)(nil),                       // 14: order.Feedback
+	(*RatingStats)(nil),                    // 15: order.RatingStats
+	(*MachineRatingStats)(nil),             // 16: order.MachineRatingStats
+	(*MachineTypeRatingStats)(nil),         // 17: order.MachineTypeRatingStats
+	(*CreateOrderRequest)(nil),             // 18: order.CreateOrderRequest
+	(*OrderResponse)(nil),                  // 19: order.OrderResponse
+	(*CancelOrderRequest)(nil),             // 20: order.CancelOrderRequest
+	(*CancelOrderResponse)(nil),            // 21: order.CancelOrderResponse
+	(*CreateScheduleRequest)(nil),          // 22: order.CreateScheduleRequest
+	(*ScheduleResponse)(nil),               // 23: order.ScheduleResponse
+	(*ListSchedulesRequest)(nil),           // 24: order.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),          // 25: order.ListSchedulesResponse
+	(*CancelScheduleRequest)(nil),          // 26: order.CancelScheduleRequest
+	(*CancelScheduleResponse)(nil),         // 27: order.CancelScheduleResponse
+	(*ListUpcomingDeliveriesRequest)(nil),  // 28: order.ListUpcomingDeliveriesRequest
+	(*ListUpcomingDeliveriesResponse)(nil), // 29: order.ListUpcomingDeliveriesResponse
+	(*PayOrderRequest)(nil),                // 30: order.PayOrderRequest
+	(*PaymentResponse)(nil),                // 31: order.PaymentResponse
+	(*AssignOrderRequest)(nil),             // 32: order.AssignOrderRequest
+	(*AssignTripRequest)(nil),              // 33: order.AssignTripRequest
+	(*TripResponse)(nil),                   // 34: order.TripResponse
+	(*ListOrderStopsRequest)(nil),          // 35: order.ListOrderStopsRequest
+	(*ListOrderStopsResponse)(nil),         // 36: order.ListOrderStopsResponse
+	(*GetOrderETARequest)(nil),             // 37: order.GetOrderETARequest
+	(*OrderETAResponse)(nil),               // 38: order.OrderETAResponse
+	(*RefundOrderRequest)(nil),             // 39: order.RefundOrderRequest
+	(*RefundResponse)(nil),                 // 40: order.RefundResponse
+	(*ConfirmPickupRequest)(nil),           // 41: order.ConfirmPickupRequest
+	(*SubmitProofOfDeliveryRequest)(nil),   // 42: order.SubmitProofOfDeliveryRequest
+	(*GetProofOfDeliveryRequest)(nil),      // 43: order.GetProofOfDeliveryRequest
+	(*ProofOfDeliveryResponse)(nil),        // 44: order.ProofOfDeliveryResponse
+	(*ReportFailedDeliveryRequest)(nil),    // 45: order.ReportFailedDeliveryRequest
+	(*DeliveryAttemptResponse)(nil),        // 46: order.DeliveryAttemptResponse
+	(*ConfirmReturnRequest)(nil),           // 47: order.ConfirmReturnRequest
+	(*ConfirmReturnResponse)(nil),          // 48: order.ConfirmReturnResponse
+	(*ListDeliveryAttemptsRequest)(nil),    // 49: order.ListDeliveryAttemptsRequest
+	(*ListDeliveryAttemptsResponse)(nil),   // 50: order.ListDeliveryAttemptsResponse
+	(*SubmitFeedbackRequest)(nil),          // 51: order.SubmitFeedbackRequest
+	(*FeedbackResponse)(nil),               // 52: order.FeedbackResponse
+	(*GetMachineRatingStatsRequest)(nil),   // 53: order.GetMachineRatingStatsRequest
+	(*GetMachineRatingStatsResponse)(nil),  // 54: order.GetMachineRatingStatsResponse
+	(*timestamppb.Timestamp)(nil),          // 55: google.protobuf.Timestamp
+}
+var file_order_order_proto_depIdxs = []int32{
+	6,  // 0: order.Order.dimensions:type_name -> order.Dimensions
+	7,  // 1: order.Order.items:type_name -> order.OrderItem
+	55, // 2: order.Order.created_at:type_name -> google.protobuf.Timestamp
+	55, // 3: order.Order.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 4: order.Order.pickup_window:type_name -> order.PickupWindow
+	1,  // 5: order.Order.stops:type_name -> order.Stop
+	55, // 6: order.Stop.delivered_at:type_name -> google.protobuf.Timestamp
+	55, // 7: order.StopETA.arrives_at:type_name -> google.protobuf.Timestamp
+	55, // 8: order.OrderETA.position_at:type_name -> google.protobuf.Timestamp
+	2,  // 9: order.OrderETA.stops:type_name -> order.StopETA
+	55, // 10: order.OrderETA.arrives_at:type_name -> google.protobuf.Timestamp
+	55, // 11: order.OrderETA.estimated_at:type_name -> google.protobuf.Timestamp
+	1,  // 12: order.Trip.stops:type_name -> order.Stop
+	55, // 13: order.Trip.created_at:type_name -> google.protobuf.Timestamp
+	55, // 14: order.PickupWindow.start:type_name -> google.protobuf.Timestamp
+	55, // 15: order.PickupWindow.end:type_name -> google.protobuf.Timestamp
+	6,  // 16: order.Schedule.dimensions:type_name -> order.Dimensions
+	7,  // 17: order.Schedule.items:type_name -> order.OrderItem
+	55, // 18: order.Schedule.starts_at:type_name -> google.protobuf.Timestamp
+	55, // 19: order.Schedule.created_at:type_name -> google.protobuf.Timestamp
+	55, // 20: order.Schedule.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 21: order.UpcomingDelivery.pickup_window:type_name -> order.PickupWindow
+	55, // 22: order.Payment.created_at:type_name -> google.protobuf.Timestamp
+	55, // 23: order.Payment.updated_at:type_name -> google.protobuf.Timestamp
+	55, // 24: order.Refund.created_at:type_name -> google.protobuf.Timestamp
+	55, // 25: order.Refund.updated_at:type_name -> google.protobuf.Timestamp
+	55, // 26: order.ProofOfDelivery.fix_recorded_at:type_name -> google.protobuf.Timestamp
+	55, // 27: order.ProofOfDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	55, // 28: order.ProofOfDelivery.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 29: order.DeliveryAttempt.reattempt_window:type_name -> order.PickupWindow
+	55, // 30: order.DeliveryAttempt.created_at:type_name -> google.protobuf.Timestamp
+	55, // 31: order.Feedback.created_at:type_name -> google.protobuf.Timestamp
+	55, // 32: order.Feedback.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 33: order.MachineRatingStats.stats:type_name -> order.RatingStats
+	15, // 34: order.MachineTypeRatingStats.stats:type_name -> order.RatingStats
+	6,  // 35: order.CreateOrderRequest.dimensions:type_name -> order.Dimensions
+	7,  // 36: order.CreateOrderRequest.items:type_name -> order.OrderItem
+	5,  // 37: order.CreateOrderRequest.pickup_window:type_name -> order.PickupWindow
+	0,  // 38: order.OrderResponse.order:type_name -> order.Order
+	11, // 39: order.CancelOrderResponse.refund:type_name -> order.Refund
+	6,  // 40: order.CreateScheduleRequest.dimensions:type_name -> order.Dimensions
+	7,  // 41: order.CreateScheduleRequest.items:type_name -> order.OrderItem
+	55, // 42: order.CreateScheduleRequest.starts_at:type_name -> google.protobuf.Timestamp
+	8,  // 43: order.ScheduleResponse.schedule:type_name -> order.Schedule
+	8,  // 44: order.ListSchedulesResponse.schedules:type_name -> order.Schedule
+	55, // 45: order.ListUpcomingDeliveriesRequest.from:type_name -> google.protobuf.Timestamp
+	55, // 46: order.ListUpcomingDeliveriesRequest.to:type_name -> google.protobuf.Timestamp
+	9,  // 47: order.ListUpcomingDeliveriesResponse.deliveries:type_name -> order.UpcomingDelivery
+	10, // 48: order.PaymentResponse.payment:type_name -> order.Payment
+	4,  // 49: order.TripResponse.trip:type_name -> order.Trip
+	1,  // 50: order.ListOrderStopsResponse.stops:type_name -> order.Stop
+	3,  // 51: order.OrderETAResponse.eta:type_name -> order.OrderETA
+	11, // 52: order.RefundResponse.refund:type_name -> order.Refund
+	55, // 53: order.SubmitProofOfDeliveryRequest.delivered_at:type_name -> google.protobuf.Timestamp
+	12, // 54: order.ProofOfDeliveryResponse.proof:type_name -> order.ProofOfDelivery
+	12, // 55: order.ProofOfDeliveryResponse.proofs:type_name -> order.ProofOfDelivery
+	13, // 56: order.DeliveryAttemptResponse.attempt:type_name -> order.DeliveryAttempt
+	11, // 57: order.ConfirmReturnResponse.refund:type_name -> order.Refund
+	13, // 58: order.ListDeliveryAttemptsResponse.attempts:type_name -> order.DeliveryAttempt
+	14, // 59: order.FeedbackResponse.feedback:type_name -> order.Feedback
+	55, // 60: order.GetMachineRatingStatsRequest.since:type_name -> google.protobuf.Timestamp
+	16, // 61: order.GetMachineRatingStatsResponse.machines:type_name -> order.MachineRatingStats
+	17, // 62: order.GetMachineRatingStatsResponse.machine_types:type_name -> order.MachineTypeRatingStats
+	18, // 63: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	20, // 64: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
+	22, // 65: order.OrderService.CreateSchedule:input_type -> order.CreateScheduleRequest
+	24, // 66: order.OrderService.ListSchedules:input_type -> order.ListSchedulesRequest
+	26, // 67: order.OrderService.CancelSchedule:input_type -> order.CancelScheduleRequest
+	28, // 68: order.OrderService.ListUpcomingDeliveries:input_type -> order.ListUpcomingDeliveriesRequest
+	30, // 69: order.OrderService.PayOrder:input_type -> order.PayOrderRequest
+	41, // 70: order.OrderService.ConfirmPickup:input_type -> order.ConfirmPickupRequest
+	42, // 71: order.OrderService.SubmitProofOfDelivery:input_type -> order.SubmitProofOfDeliveryRequest
+	43, // 72: order.OrderService.GetProofOfDelivery:input_type -> order.GetProofOfDeliveryRequest
+	45, // 73: order.OrderService.ReportFailedDelivery:input_type -> order.ReportFailedDeliveryRequest
+	47, // 74: order.OrderService.ConfirmReturn:input_type -> order.ConfirmReturnRequest
+	49, // 75: order.OrderService.ListDeliveryAttempts:input_type -> order.ListDeliveryAttemptsRequest
+	35, // 76: order.OrderService.ListOrderStops:input_type -> order.ListOrderStopsRequest
+	37, // 77: order.OrderService.GetOrderETA:input_type -> order.GetOrderETARequest
+	51, // 78: order.OrderService.SubmitFeedback:input_type -> order.SubmitFeedbackRequest
+	32, // 79: order.OrderService.AssignOrder:input_type -> order.AssignOrderRequest
+	33, // 80: order.OrderService.AssignTrip:input_type -> order.AssignTripRequest
+	39, // 81: order.OrderService.RefundOrder:input_type -> order.RefundOrderRequest
+	53, // 82: order.OrderService.GetMachineRatingStats:input_type -> order.GetMachineRatingStatsRequest
+	19, // 83: order.OrderService.CreateOrder:output_type -> order.OrderResponse
+	21, // 84: order.OrderService.CancelOrder:output_type -> order.CancelOrderResponse
+	23, // 85: order.OrderService.CreateSchedule:output_type -> order.ScheduleResponse
+	25, // 86: order.OrderService.ListSchedules:output_type -> order.ListSchedulesResponse
+	27, // 87: order.OrderService.CancelSchedule:output_type -> order.CancelScheduleResponse
+	29, // 88: order.OrderService.ListUpcomingDeliveries:output_type -> order.ListUpcomingDeliveriesResponse
+	31, // 89: order.OrderService.PayOrder:output_type -> order.PaymentResponse
+	19, // 90: order.OrderService.ConfirmPickup:output_type -> order.OrderResponse
+	44, // 91: order.OrderService.SubmitProofOfDelivery:output_type -> order.ProofOfDeliveryResponse
+	44, // 92: order.OrderService.GetProofOfDelivery:output_type -> order.ProofOfDeliveryResponse
+	46, // 93: order.OrderService.ReportFailedDelivery:output_type -> order.DeliveryAttemptResponse
+	48, // 94: order.OrderService.ConfirmReturn:output_type -> order.ConfirmReturnResponse
+	50, // 95: order.OrderService.ListDeliveryAttempts:output_type -> order.ListDeliveryAttemptsResponse
+	36, // 96: order.OrderService.ListOrderStops:output_type -> order.ListOrderStopsResponse
+	38, // 97: order.OrderService.GetOrderETA:output_type -> order.OrderETAResponse
+	52, // 98: order.OrderService.SubmitFeedback:output_type -> order.FeedbackResponse
+	19, // 99: order.OrderService.AssignOrder:output_type -> order.OrderResponse
+	34, // 100: order.OrderService.AssignTrip:output_type -> order.TripResponse
+	40, // 101: order.OrderService.RefundOrder:output_type -> order.RefundResponse
+	54, // 102: order.OrderService.GetMachineRatingStats:output_type -> order.GetMachineRatingStatsResponse
+	83, // [83:103] is the sub-list for method output_type
+	63, // [63:83] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
+}
+
+func init() { file_order_order_proto_init() }
+func file_order_order_proto_init() {
+	if File_order_order_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_order_proto_rawDesc), len(file_order_order_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   55,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_order_order_proto_goTypes,
+		DependencyIndexes: file_order_order_proto_depIdxs,
+		MessageInfos:      file_order_order_proto_msgTypes,
+	}.Build()
+	File_order_order_proto = out.File
+	file_order_order_proto_goTypes = nil
+	file_order_order_proto_depIdxs = nil
+}
@@ -0,0 +1,213 @@
+// Command dispatch-sim replays peak-hour dispatch rounds against an in-memory
+// fleet and compares the total pickup ETA of one-at-a-time (greedy) dispatch
+// with batch dispatch, which matches a whole round of orders to machines at
+// once. Both run the dispatch service itself; only the order queue and the
+// fleet are simulated, so no database is needed.
+//
+// Usage:
+//
+//	go run ./cmd/dispatch-sim -rounds 50 -orders 80 -machines 120
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"dispatch-and-delivery/internal/models"
+	"dispatch-and-delivery/internal/modules/dispatch"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	ordersDomain "dispatch-and-delivery/internal/modules/orders/domain"
+	"dispatch-and-delivery/pkg/geo"
+)
+
+func main() {
+	rounds := flag.Int("rounds", 50, "number of dispatch rounds to simulate")
+	orders := flag.Int("orders", 80, "orders queued per round")
+	machines := flag.Int("machines", 120, "idle machines per round")
+	spread := flag.Float64("spread", 0.03, "half-width of the simulated area in degrees")
+	seed := flag.Int64("seed", 1, "random seed")
+	flag.Parse()
+	if *rounds < 1 || *orders < 1 || *machines < 1 {
+		log.Fatal("-rounds, -orders and -machines must be at least 1")
+	}
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(*seed))
+	centre := geo.Point{Latitude: 37.7749, Longitude: -122.4194}
+	var greedy, batch result
+	var elapsedGreedy, elapsedBatch time.Duration
+	for range *rounds {
+		w := newWorld(rng, centre, *spread, *orders, *machines)
+
+		// Each mode gets a fresh copy of the round, with every machine idle.
+		g := w.clone()
+		start := time.Now()
+		run, err := dispatch.NewService(nil, g, g.fleet, nil, nil).DispatchPending(ctx, time.Now())
+		elapsedGreedy += time.Since(start)
+		if err != nil {
+			log.Fatalf("greedy dispatch failed: %v", err)
+		}
+		greedy.add(run)
+
+		b := w.clone()
+		start = time.Now()
+		run, err = dispatch.NewService(nil, b, b.fleet, nil, nil).DispatchBatch(ctx, time.Now())
+		elapsedBatch += time.Since(start)
+		if err != nil {
+			log.Fatalf("batch dispatch failed: %v", err)
+		}
+		batch.add(run)
+	}
+
+	fmt.Printf("%d rounds of %d orders over %d machines\n", *rounds, *orders, *machines)
+	fmt.Printf("%-7s %9s %16s %14s %10s\n", "mode", "assigned", "total pickup ETA", "mean per order", "run time")
+	greedy.print("greedy", elapsedGreedy/time.Duration(*rounds))
+	batch.print("batch", elapsedBatch/time.Duration(*rounds))
+	if greedy.eta > 0 {
+		fmt.Printf("batch total pickup ETA is %.1f%% of greedy\n", 100*batch.eta.Seconds()/greedy.eta.Seconds())
+	}
+}
+
+// result sums the assignments of the runs of one dispatch mode.
+type result struct {
+	assigned int
+	eta      time.Duration
+}
+
+func (r *result) add(run *domain.DispatchRun) {
+	for _, a := range run.Assignments {
+		r.assigned++
+		r.eta += a.PickupETA
+	}
+}
+
+func (r *result) print(mode string, runTime time.Duration) {
+	var mean time.Duration
+	if r.assigned > 0 {
+		mean = r.eta / time.Duration(r.assigned)
+	}
+	fmt.Printf("%-7s %9d %16v %14v %10v\n", mode, r.assigned,
+		r.eta.Round(time.Second), mean.Round(time.Second), runTime.Round(time.Microsecond))
+}
+
+// world is a simulated dispatch queue and fleet. It implements dispatch.Orders,
+// and its fleet implements dispatch.Fleet.
+type world struct {
+	jobs     []domain.Job
+	assigned map[string]string // Order ID to machine ID
+	fleet    *fleet
+}
+
+func newWorld(rng *rand.Rand, centre geo.Point, spread float64, orders, machines int) *world {
+	point := func() geo.Point {
+		return geo.Point{
+			Latitude:  centre.Latitude + (rng.Float64()*2-1)*spread,
+			Longitude: centre.Longitude + (rng.Float64()*2-1)*spread,
+		}
+	}
+	types := []string{fleetDomain.MachineTypeDrone, fleetDomain.MachineTypeRobot}
+
+	w := &world{assigned: make(map[string]string), fleet: &fleet{busy: make(map[string]bool)}}
+	queuedAt := time.Now().Add(-time.Duration(orders) * time.Second)
+	for i := range orders {
+		machineType := types[rng.Intn(len(types))]
+		w.jobs = append(w.jobs, domain.Job{
+			OrderID:     fmt.Sprintf("order-%04d", i),
+			MachineType: machineType,
+			WeightKg:    rng.Float64() * fleetDomain.MaxPayloadKg[machineType],
+			Pickup:      point(),
+			Dropoffs:    []geo.Point{point()},
+			QueuedAt:    queuedAt.Add(time.Duration(i) * time.Second),
+		})
+	}
+	for i := range machines {
+		p := point()
+		w.fleet.machines = append(w.fleet.machines, fleetDomain.Machine{
+			ID:           fmt.Sprintf("machine-%04d", i),
+			Type:         types[rng.Intn(len(types))],
+			Status:       fleetDomain.StatusIdle,
+			Latitude:     p.Latitude,
+			Longitude:    p.Longitude,
+			BatteryLevel: 40 + rng.Intn(61),
+		})
+	}
+	return w
+}
+
+// clone returns a copy of the world with nothing assigned yet.
+func (w *world) clone() *world {
+	return &world{
+		jobs:     w.jobs,
+		assigned: make(map[string]string),
+		fleet:    &fleet{machines: w.fleet.machines, busy: make(map[string]bool)},
+	}
+}
+
+func (w *world) ListDispatchQueue(_ context.Context, _ time.Time, limit int) ([]domain.Job, error) {
+	var jobs []domain.Job
+	for _, job := range w.jobs {
+		if _, ok := w.assigned[job.OrderID]; !ok && len(jobs) < limit {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (w *world) CountAssignedOrders(_ context.Context, _ []string) (map[string]int, error) {
+	return map[string]int{}, nil
+}
+
+func (w *world) DispatchOrder(_ context.Context, orderID, machineID string) (*ordersDomain.Order, error) {
+	if _, ok := w.assigned[orderID]; ok {
+		return nil, models.ErrOrderCannotBeAssigned
+	}
+	if w.fleet.busy[machineID] {
+		return nil, models.ErrMachineNotIdle
+	}
+	w.fleet.busy[machineID] = true
+	w.assigned[orderID] = machineID
+	return &ordersDomain.Order{ID: orderID, MachineID: &machineID, Status: ordersDomain.OrderStatusAssigned}, nil
+}
+
+// fleet answers nearby searches the way the fleet repository does, over the
+// idle machines of the simulated world.
+type fleet struct {
+	machines []fleetDomain.Machine
+	busy     map[string]bool
+}
+
+func (f *fleet) FindNearbyMachines(_ context.Context, q fleetDomain.NearbyMachinesQuery) ([]fleetDomain.NearbyMachine, error) {
+	radius := q.RadiusMeters
+	if radius <= 0 {
+		radius = fleetDomain.DefaultSearchRadiusMeters
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = fleetDomain.DefaultNearbyLimit
+	}
+	if maxKg, ok := fleetDomain.MaxPayloadKg[q.MachineType]; !ok || q.PayloadKg > maxKg {
+		return nil, nil
+	}
+
+	var nearby []fleetDomain.NearbyMachine
+	for _, m := range f.machines {
+		if f.busy[m.ID] || m.Type != q.MachineType || m.BatteryLevel < q.MinBatteryLevel {
+			continue
+		}
+		d := geo.DistanceMeters(q.Pickup, geo.Point{Latitude: m.Latitude, Longitude: m.Longitude})
+		if d <= radius {
+			nearby = append(nearby, fleetDomain.NearbyMachine{Machine: m, DistanceMeters: d})
+		}
+	}
+	sort.Slice(nearby, func(i, j int) bool { return nearby[i].DistanceMeters < nearby[j].DistanceMeters })
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	pb "dispatch-and-delivery/api/proto/fleet"
+	"dispatch-and-delivery/internal/config"
+	"dispatch-and-delivery/internal/database"
+	"dispatch-and-delivery/internal/middleware"
+	"dispatch-and-delivery/internal/modules/fleet"
+	"dispatch-and-delivery/pkg/pki"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func main() {
+	// 1. --- Configuration & Database ---
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+
+	dbPool, err := database.New(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer dbPool.Close()
+	log.Println("Database connection successful.")
+
+	// 2. --- Dependency Injection (Wiring) ---
+	// The machine CA issues a client certificate to every machine at registration.
+	var ca *pki.CA
+	var issuer fleet.CertificateIssuer
+	if cfg.MachineCACertFile != "" {
+		ca, err = pki.LoadOrCreateCA(cfg.MachineCACertFile, cfg.MachineCAKeyFile)
+		if err != nil {
+			log.Fatalf("failed to load machine CA: %v", err)
+		}
+		issuer = ca
+	} else {
+		log.Println("WARN: MACHINE_CA_CERT_FILE is not set, machines are registered without certificates and mutual TLS is off.")
+	}
+
+	machineRepo := fleet.NewRepository(dbPool)
+	fleetService := fleet.NewService(machineRepo, issuer, cfg.HeartbeatTimeout)
+	fleetGRPCHandler := fleet.NewGRPCHandler(fleetService)
+
+	// 3. --- gRPC Server Setup ---
+	lis, err := net.Listen("tcp", ":"+cfg.ServerPort) // e.g., ":50054"
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	// Registry changes are admin-only and status reports come from machines or
+	// admins; the auth interceptor enforces the roles. With a machine CA,
+	// machines authenticate by client certificate and humans by JWT.
+	var serverOpts []grpc.ServerOption
+	unary := []grpc.UnaryServerInterceptor{middleware.AuthInterceptor(cfg.JWTSecret)}
+	stream := []grpc.StreamServerInterceptor{middleware.AuthStreamInterceptor(cfg.JWTSecret)}
+	if ca != nil {
+		serverCert, err := pki.LoadServerCertificate(ca, cfg.TLSCertFile, cfg.TLSKeyFile, "localhost", "fleet-management-service")
+		if err != nil {
+			log.Fatalf("failed to load server certificate: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(pki.ServerTLSConfig(ca, serverCert))))
+		unary = append([]grpc.UnaryServerInterceptor{middleware.MachineIdentityInterceptor(machineRepo)}, unary...)
+		stream = append([]grpc.StreamServerInterceptor{middleware.MachineIdentityStreamInterceptor(machineRepo)}, stream...)
+	}
+	serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	grpcServer := grpc.NewServer(serverOpts...)
+
+	pb.RegisterFleetServiceServer(grpcServer, fleetGRPCHandler)
+	log.Printf("gRPC server listening at %v", lis.Addr())
+
+	// 4. --- Start Server with Graceful Shutdown ---
+	// Machines that stop sending heartbeats are marked offline, machines low
+	// on battery are sent to charge and due inspections are opened in the
+	// background.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go fleet.RunLivenessTracker(workerCtx, fleetService, fleetService.HeartbeatInterval())
+	go fleet.RunChargingScheduler(workerCtx, fleetService, time.Minute)
+	go fleet.RunMaintenanceScheduler(workerCtx, fleetService, time.Hour)
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down gRPC server...")
+	stopWorkers()
+	// Heartbeat streams never end on their own, so they are cut off after a grace period.
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		grpcServer.Stop()
+	}
+	log.Println("Server exiting.")
+}
@@ -0,0 +1,67 @@
+// Command geofence-import loads drone no-fly zones from a GeoJSON file into
+// the database. Every feature becomes one zone; see dispatch.ParseNoFlyZones
+// for the properties read. The file is imported all or nothing.
+//
+// Usage:
+//
+//	go run ./cmd/geofence-import -file zones.geojson
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"dispatch-and-delivery/internal/config"
+	"dispatch-and-delivery/internal/database"
+	"dispatch-and-delivery/internal/modules/dispatch"
+)
+
+func main() {
+	file := flag.String("file", "", "GeoJSON file with the no-fly zones")
+	flag.Parse()
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", *file, err)
+	}
+	zones, err := dispatch.ParseNoFlyZones(data)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %v", *file, err)
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+	pool, err := database.New(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := dispatch.NewRepository(pool)
+	tx, err := repo.BeginTx(ctx)
+	if err != nil {
+		log.Fatalf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+	txRepo := repo.WithTx(tx)
+
+	for i := range zones {
+		zone, err := txRepo.CreateNoFlyZone(ctx, &zones[i])
+		if err != nil {
+			log.Fatalf("failed to import zone %q: %v", zones[i].Name, err)
+		}
+		log.Printf("Imported no-fly zone %s (%s)", zone.Name, zone.ID)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		log.Fatalf("failed to commit: %v", err)
+	}
+	log.Printf("Imported %d no-fly zones", len(zones))
+}
@@ -0,0 +1,59 @@
+// Command inference-stub serves the inference engine contract with the
+// built-in heuristic scorer, so that dispatch can be run and tested locally
+// without the Python AI engine. Point the order service at it with
+// INFERENCE_ENGINE_ADDR. -latency and -failure-rate make it slow or flaky to
+// try out the client's deadlines and circuit breaker.
+//
+// Usage:
+//
+//	go run ./cmd/inference-stub -port 50060 -latency 50ms -failure-rate 0.1
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	pb "dispatch-and-delivery/api/proto/inference"
+	"dispatch-and-delivery/internal/modules/dispatch"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	port := flag.String("port", "50060", "port to listen on")
+	latency := flag.Duration("latency", 0, "delay added to every call")
+	failureRate := flag.Float64("failure-rate", 0, "share of calls answered with Unavailable, from 0 to 1")
+	flag.Parse()
+	if *failureRate < 0 || *failureRate > 1 {
+		log.Fatal("-failure-rate must be between 0 and 1")
+	}
+
+	stub := dispatch.NewInferenceStub(nil)
+	stub.Latency = *latency
+	stub.FailureRate = *failureRate
+
+	lis, err := net.Listen("tcp", ":"+*port)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterInferenceServiceServer(grpcServer, stub)
+
+	go func() {
+		log.Printf("Stub inference engine listening at %v", lis.Addr())
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down stub inference engine...")
+	grpcServer.GracefulStop()
+}
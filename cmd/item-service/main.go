@@ -0,0 +1,64 @@
+package main
+
+import (
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	pb "dispatch-and-delivery/api/proto/item"
+	"dispatch-and-delivery/internal/config"
+	"dispatch-and-delivery/internal/database"
+	"dispatch-and-delivery/internal/middleware"
+	"dispatch-and-delivery/internal/modules/items"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	// 1. --- Configuration & Database ---
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+
+	dbPool, err := database.New(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer dbPool.Close()
+	log.Println("Database connection successful.")
+
+	// 2. --- Dependency Injection (Wiring) ---
+	itemRepo := items.NewRepository(dbPool)
+	itemService := items.NewService(itemRepo)
+	itemGRPCHandler := items.NewGRPCHandler(itemService)
+
+	// 3. --- gRPC Server Setup ---
+	lis, err := net.Listen("tcp", ":"+cfg.ServerPort) // e.g., ":50053"
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	// Catalog changes are admin-only; the auth interceptor enforces the role.
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(middleware.AuthInterceptor(cfg.JWTSecret)))
+
+	pb.RegisterItemServiceServer(grpcServer, itemGRPCHandler)
+	log.Printf("gRPC server listening at %v", lis.Addr())
+
+	// 4. --- Start Server with Graceful Shutdown ---
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down gRPC server...")
+	grpcServer.GracefulStop()
+	log.Println("Server exiting.")
+}
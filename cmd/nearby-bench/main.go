@@ -0,0 +1,134 @@
+// Command nearby-bench seeds machines into the PostGIS database from
+// docker-compose.dev.yml and measures how long the nearest-machine search of
+// the fleet repository takes. The seeded machines are removed afterwards
+// unless -keep is set.
+//
+// Usage:
+//
+//	go run ./cmd/nearby-bench -machines 100000 -queries 1000
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"dispatch-and-delivery/internal/config"
+	"dispatch-and-delivery/internal/database"
+	"dispatch-and-delivery/internal/modules/fleet"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func main() {
+	machines := flag.Int("machines", 100000, "number of machines to seed")
+	queries := flag.Int("queries", 1000, "number of searches to run")
+	lat := flag.Float64("lat", 37.7749, "latitude of the centre of the seeded area")
+	lng := flag.Float64("lng", -122.4194, "longitude of the centre of the seeded area")
+	spread := flag.Float64("spread", 0.5, "half-width of the seeded area in degrees")
+	radius := flag.Float64("radius", domain.DefaultSearchRadiusMeters, "search radius in meters")
+	limit := flag.Int("limit", domain.DefaultNearbyLimit, "machines returned per search")
+	keep := flag.Bool("keep", false, "keep the seeded machines")
+	flag.Parse()
+	if *machines < 1 || *queries < 1 {
+		log.Fatal("-machines and -queries must be at least 1")
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+	pool, err := database.New(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1))
+	prefix := fmt.Sprintf("bench-%d-", time.Now().Unix())
+
+	// Most of the fleet is idle, the rest busy, so the status filter has work to do.
+	statuses := []string{domain.StatusIdle, domain.StatusIdle, domain.StatusIdle, domain.StatusInTransit, domain.StatusCharging}
+	types := []string{domain.MachineTypeDrone, domain.MachineTypeRobot}
+	rows := make([][]any, *machines)
+	for i := range rows {
+		rows[i] = []any{
+			fmt.Sprintf("%s%06d", prefix, i),
+			types[rng.Intn(len(types))],
+			statuses[rng.Intn(len(statuses))],
+			*lat + (rng.Float64()*2-1)**spread,
+			*lng + (rng.Float64()*2-1)**spread,
+			rng.Intn(101),
+		}
+	}
+
+	start := time.Now()
+	seeded, err := pool.CopyFrom(ctx, pgx.Identifier{"machines"},
+		[]string{"serial_number", "type", "status", "latitude", "longitude", "battery_level"}, pgx.CopyFromRows(rows))
+	if err != nil {
+		log.Fatalf("failed to seed machines: %v", err)
+	}
+	log.Printf("Seeded %d machines in %v", seeded, time.Since(start).Round(time.Millisecond))
+	if _, err := pool.Exec(ctx, `ANALYZE machines`); err != nil {
+		log.Printf("failed to analyze machines: %v", err)
+	}
+
+	err = search(ctx, fleet.NewRepository(pool), rng, *queries, seeded, geo.Point{Latitude: *lat, Longitude: *lng}, *spread, *radius, *limit)
+	if !*keep {
+		if _, err := pool.Exec(ctx, `DELETE FROM machines WHERE serial_number LIKE $1`, prefix+"%"); err != nil {
+			log.Printf("failed to remove the seeded machines: %v", err)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// search runs searches from random pickups around centre and prints their latency.
+func search(ctx context.Context, repo fleet.RepositoryInterface, rng *rand.Rand, queries int, seeded int64, centre geo.Point, spread, radius float64, limit int) error {
+	if _, err := repo.FindNearbyMachines(ctx, centre, radius, domain.TypesCarrying(0), 0, limit); err != nil {
+		return fmt.Errorf("warm-up search failed: %w", err)
+	}
+	types := []string{domain.MachineTypeDrone, domain.MachineTypeRobot}
+	latencies := make([]time.Duration, queries)
+	found := 0
+	for i := range latencies {
+		pickup := geo.Point{
+			Latitude:  centre.Latitude + (rng.Float64()*2-1)*spread,
+			Longitude: centre.Longitude + (rng.Float64()*2-1)*spread,
+		}
+		machineTypes := []string{types[rng.Intn(len(types))]}
+
+		start := time.Now()
+		nearby, err := repo.FindNearbyMachines(ctx, pickup, radius, machineTypes, domain.ChargeThreshold, limit)
+		latencies[i] = time.Since(start)
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		found += len(nearby)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+	percentile := func(p float64) time.Duration {
+		return latencies[int(p*float64(len(latencies)-1))]
+	}
+	fmt.Printf("searches: %d over %d machines, %.1f machines found per search\n", queries, seeded, float64(found)/float64(queries))
+	fmt.Printf("mean %v  p50 %v  p95 %v  p99 %v  max %v\n",
+		(total / time.Duration(len(latencies))).Round(time.Microsecond),
+		percentile(0.50).Round(time.Microsecond),
+		percentile(0.95).Round(time.Microsecond),
+		percentile(0.99).Round(time.Microsecond),
+		latencies[len(latencies)-1].Round(time.Microsecond))
+	return nil
+}
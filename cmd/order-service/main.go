@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	pb "dispatch-and-delivery/api/proto/order"
+	"dispatch-and-delivery/internal/config"
+	"dispatch-and-delivery/internal/database"
+	"dispatch-and-delivery/internal/middleware"
+	"dispatch-and-delivery/internal/modules/dispatch"
+	"dispatch-and-delivery/internal/modules/fleet"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/internal/modules/items"
+	"dispatch-and-delivery/internal/modules/orders"
+	"dispatch-and-delivery/pkg/email"
+	"dispatch-and-delivery/pkg/payments"
+	"dispatch-and-delivery/pkg/pki"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// newRouters sets up the routing of dispatched orders. Drones fly straight
+// lines around the stored no-fly zones; robots are routed over the offline
+// road graph when there is one, and over Google Directions where the graph
+// does not reach.
+func newRouters(cfg *config.Config, repo dispatch.RepositoryInterface) dispatch.Routers {
+	routers := dispatch.Routers{
+		fleetDomain.MachineTypeDrone: dispatch.NewAirspaceRouter(dispatch.NewGeofenceAirspace(repo)),
+	}
+
+	var robotRouters []dispatch.Router
+	if cfg.RoadGraphFile != "" {
+		graph, err := dispatch.LoadRoadGraph(cfg.RoadGraphFile)
+		if err != nil {
+			log.Fatalf("failed to load road graph: %v", err)
+		}
+		robotRouters = append(robotRouters, dispatch.NewGraphRouter(graph))
+	}
+	if cfg.GoogleMapsAPIKey != "" {
+		robotRouters = append(robotRouters, dispatch.NewGoogleRouter(cfg.GoogleMapsAPIKey, "walking"))
+	}
+	if len(robotRouters) > 0 {
+		routers[fleetDomain.MachineTypeRobot] = dispatch.Fallback(robotRouters...)
+	}
+	return routers
+}
+
+func main() {
+	// 1. --- Configuration & Database ---
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+
+	dbPool, err := database.New(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer dbPool.Close()
+	log.Println("Database connection successful.")
+
+	// 2. --- Dependency Injection (Wiring) ---
+	var paymentProvider payments.PaymentProvider
+	switch {
+	case cfg.StripeAPIKey != "":
+		paymentProvider = payments.NewStripeProvider(cfg.StripeAPIKey)
+	case cfg.FakePayments:
+		// The in-memory provider charges nothing; it is for local development only.
+		log.Println("WARN: FAKE_PAYMENTS is set, using the fake payment provider.")
+		paymentProvider = payments.NewFakeProvider()
+	default:
+		log.Fatalf("STRIPE_API_KEY must be set (or FAKE_PAYMENTS=true for local development)")
+	}
+
+	sesSender, err := email.NewSESV2Sender(context.Background(), cfg.AWSRegion, cfg.EmailFromAddress)
+	if err != nil {
+		log.Fatalf("Failed to create SES sender: %v", err)
+	}
+	templateManager, err := email.NewTemplateManager()
+	if err != nil {
+		log.Fatalf("Failed to parse email templates: %v", err)
+	}
+
+	// Stock is reserved in-process against the shared database; the item service
+	// exposes the same operations over gRPC for other callers.
+	inventory := items.NewService(items.NewRepository(dbPool))
+
+	// Arrival estimates start from the dispatch routers and are calibrated
+	// against the tracking history.
+	dispatchRepo := dispatch.NewRepository(dbPool)
+	routers := newRouters(cfg, dispatchRepo)
+	etaModel := dispatch.NewETAModel(dispatchRepo, routers)
+
+	// Machines are claimed for trips and released in-process, through the
+	// fleet service on the shared database.
+	fleetService := fleet.NewService(fleet.NewRepository(dbPool), nil, 0)
+
+	orderRepo := orders.NewRepository(dbPool)
+	if cfg.HandoffPINSecret == "" {
+		log.Fatalf("HANDOFF_PIN_SECRET must be set")
+	}
+	orderService := orders.NewService(orderRepo, inventory, fleetService, etaModel, paymentProvider, sesSender, templateManager, cfg.HandoffPINSecret)
+	orderGRPCHandler := orders.NewGRPCHandler(orderService)
+
+	// Paid orders are matched to machines in-process, with the fleet searched
+	// directly in the shared database.
+	// With an inference engine, it scores the candidate machines and the
+	// heuristic takes over whenever it does not answer in time.
+	var scorer dispatch.Scorer
+	if cfg.InferenceEngineAddr != "" {
+		conn, err := grpc.NewClient(cfg.InferenceEngineAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Fatalf("failed to set up inference engine client: %v", err)
+		}
+		defer conn.Close()
+		scorer = dispatch.NewInferenceScorer(conn, nil, cfg.InferenceTimeout)
+	}
+	dispatcher := dispatch.NewService(dispatchRepo, orderService, fleetService, scorer, routers)
+
+	// 3. --- gRPC Server Setup ---
+	lis, err := net.Listen("tcp", ":"+cfg.ServerPort) // e.g., ":50052"
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	// Every order RPC acts on behalf of a logged-in user, so all calls go through the JWT interceptor.
+	// Idempotency keys are scoped per user, so that interceptor runs after authentication.
+	// With a machine CA, delivery machines authenticate by client certificate
+	// instead, checked against the revocations recorded by the fleet service.
+	idempotencyStore := middleware.NewPostgresIdempotencyStore(dbPool, middleware.DefaultIdempotencyTTL)
+	var serverOpts []grpc.ServerOption
+	interceptors := []grpc.UnaryServerInterceptor{
+		middleware.AuthInterceptor(cfg.JWTSecret),
+		middleware.IdempotencyInterceptor(idempotencyStore),
+	}
+	if cfg.MachineCACertFile != "" {
+		ca, err := pki.LoadOrCreateCA(cfg.MachineCACertFile, cfg.MachineCAKeyFile)
+		if err != nil {
+			log.Fatalf("failed to load machine CA: %v", err)
+		}
+		serverCert, err := pki.LoadServerCertificate(ca, cfg.TLSCertFile, cfg.TLSKeyFile, "localhost", "order-service")
+		if err != nil {
+			log.Fatalf("failed to load server certificate: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(pki.ServerTLSConfig(ca, serverCert))))
+		interceptors = append([]grpc.UnaryServerInterceptor{middleware.MachineIdentityInterceptor(fleet.NewRepository(dbPool))}, interceptors...)
+	}
+	grpcServer := grpc.NewServer(append(serverOpts, grpc.ChainUnaryInterceptor(interceptors...))...)
+
+	pb.RegisterOrderServiceServer(grpcServer, orderGRPCHandler)
+	log.Printf("gRPC server listening at %v", lis.Addr())
+
+	// 4. --- Webhook HTTP Server Setup ---
+	// Payment providers deliver asynchronous payment results over plain HTTP.
+	mux := http.NewServeMux()
+	mux.Handle("/webhooks/stripe", orders.NewWebhookHandler(orderService, cfg.StripeWebhookSecret))
+	webhookServer := &http.Server{
+		Addr:              ":" + cfg.WebhookPort, // e.g., ":8082"
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// 5. --- Start Servers with Graceful Shutdown ---
+	// Recurring schedules are turned into concrete orders ahead of time in the background.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go orders.RunScheduleMaterializer(workerCtx, orderService, 15*time.Minute)
+	// Orders waiting for a machine that went offline are handed back for dispatch.
+	go orders.RunRedispatcher(workerCtx, orderService, time.Minute)
+	// ETAs are recalibrated as deliveries complete.
+	go dispatch.RunETACalibrator(workerCtx, etaModel, time.Hour)
+	// Paid orders are given to the best idle machine as soon as one is free or,
+	// with a batch window, matched to the machines together at its end.
+	if cfg.DispatchBatchWindow > 0 {
+		go dispatch.RunBatchDispatcher(workerCtx, dispatcher, cfg.DispatchBatchWindow)
+	} else {
+		go dispatch.RunDispatcher(workerCtx, dispatcher, 15*time.Second)
+	}
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-workerCtx.Done():
+				return
+			case <-ticker.C:
+				if _, err := idempotencyStore.PurgeExpired(workerCtx); err != nil {
+					log.Printf("ERROR: Failed to purge expired idempotency keys: %v", err)
+				}
+			}
+		}
+	}()
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	go func() {
+		log.Printf("Webhook server listening at %v", webhookServer.Addr)
+		if err := webhookServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("failed to serve webhooks: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	stopWorkers()
+
+	log.Println("Shutting down webhook server...")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := webhookServer.Shutdown(ctx); err != nil {
+		log.Printf("webhook server shutdown error: %v", err)
+	}
+
+	log.Println("Shutting down gRPC server...")
+	grpcServer.GracefulStop()
+	log.Println("Server exiting.")
+}
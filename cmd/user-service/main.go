@@ -10,6 +10,7 @@ import (
 
 	"dispatch-and-delivery/internal/config"
 	"dispatch-and-delivery/internal/database"
+	"dispatch-and-delivery/internal/middleware"
 	"dispatch-and-delivery/internal/modules/users"
 	"dispatch-and-delivery/pkg/email"
 	pb "dispatch-and-delivery/pkg/proto/user"
@@ -67,8 +68,13 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	// Create a new gRPC server
-	grpcServer := grpc.NewServer()
+	// Create a new gRPC server. Auth endpoints are public; everything else needs a
+	// token, and AddAddress honours idempotency keys scoped to the logged-in user.
+	idempotencyStore := middleware.NewPostgresIdempotencyStore(dbPool, middleware.DefaultIdempotencyTTL)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		middleware.AuthInterceptor(cfg.JWTSecret),
+		middleware.IdempotencyInterceptor(idempotencyStore),
+	))
 
 	// Register our handler implementation with the gRPC server
 	pb.RegisterUserServiceServer(grpcServer, userGRPCHandler)
@@ -1,4 +1,4 @@
-module laas
+module dispatch-and-delivery
 
 go 1.24.3
 
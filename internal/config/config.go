@@ -3,24 +3,39 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	ServerPort              string `mapstructure:"SERVER_PORT"`
-	DatabaseURL             string `mapstructure:"DATABASE_URL"`
-	JWTSecret               string `mapstructure:"JWT_SECRET"`
-	ClientOrigin            string `mapstructure:"CLIENT_ORIGIN"`
-	GoogleOAuthClientID     string `mapstructure:"GOOGLE_OAUTH_CLIENT_ID"`
-	GoogleOAuthClientSecret string `mapstructure:"GOOGLE_OAUTH_CLIENT_SECRET"`
-	GoogleOAuthRedirectURL  string `mapstructure:"GOOGLE_OAUTH_REDIRECT_URL"`
-	AWSRegion               string `mapstructure:"AWS_REGION"`
-	AWSAccessKeyID          string `mapstructure:"AWS_ACCESS_KEY_ID"`
-	AWSSecretAccessKey      string `mapstructure:"AWS_SECRET_ACCESS_KEY"`
-	EmailFromAddress        string `mapstructure:"EMAIL_FROM_ADDRESS"`
-	GoogleMapsAPIKey        string `mapstructure:"GOOGLE_MAPS_API_KEY"`
-	StripeAPIKey            string `mapstructure:"STRIPE_API_KEY"`
+	ServerPort              string        `mapstructure:"SERVER_PORT"`
+	DatabaseURL             string        `mapstructure:"DATABASE_URL"`
+	JWTSecret               string        `mapstructure:"JWT_SECRET"`
+	ClientOrigin            string        `mapstructure:"CLIENT_ORIGIN"`
+	GoogleOAuthClientID     string        `mapstructure:"GOOGLE_OAUTH_CLIENT_ID"`
+	GoogleOAuthClientSecret string        `mapstructure:"GOOGLE_OAUTH_CLIENT_SECRET"`
+	GoogleOAuthRedirectURL  string        `mapstructure:"GOOGLE_OAUTH_REDIRECT_URL"`
+	AWSRegion               string        `mapstructure:"AWS_REGION"`
+	AWSAccessKeyID          string        `mapstructure:"AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey      string        `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	EmailFromAddress        string        `mapstructure:"EMAIL_FROM_ADDRESS"`
+	GoogleMapsAPIKey        string        `mapstructure:"GOOGLE_MAPS_API_KEY"`
+	StripeAPIKey            string        `mapstructure:"STRIPE_API_KEY"`
+	StripeWebhookSecret     string        `mapstructure:"STRIPE_WEBHOOK_SECRET"`
+	FakePayments            bool          `mapstructure:"FAKE_PAYMENTS"` // Development only; accepts every payment when STRIPE_API_KEY is unset
+	WebhookPort             string        `mapstructure:"WEBHOOK_PORT"`
+	HandoffPINSecret        string        `mapstructure:"HANDOFF_PIN_SECRET"`   // Keys the HMAC of the PINs recipients give machines at handoff
+	MachineCACertFile       string        `mapstructure:"MACHINE_CA_CERT_FILE"` // Enables mutual TLS for machines
+	MachineCAKeyFile        string        `mapstructure:"MACHINE_CA_KEY_FILE"`
+	TLSCertFile             string        `mapstructure:"TLS_CERT_FILE"` // Issued by the machine CA when unset
+	TLSKeyFile              string        `mapstructure:"TLS_KEY_FILE"`
+	HeartbeatTimeout        time.Duration `mapstructure:"HEARTBEAT_TIMEOUT"`     // e.g. "30s"; machines silent for longer go offline
+	DispatchBatchWindow     time.Duration `mapstructure:"DISPATCH_BATCH_WINDOW"` // e.g. "10s"; dispatches orders in batches instead of one at a time
+	RoadGraphFile           string        `mapstructure:"ROAD_GRAPH_FILE"`       // OSM-derived road graph robots are routed over offline
+	InferenceEngineAddr     string        `mapstructure:"INFERENCE_ENGINE_ADDR"` // e.g. "localhost:50060"; dispatch scores machines with the AI engine
+	InferenceTimeout        time.Duration `mapstructure:"INFERENCE_TIMEOUT"`     // e.g. "300ms"; the heuristic scores jobs the engine does not answer in time
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -47,6 +62,9 @@ func LoadConfig(path string) (*Config, error) {
 	}
 
 	cfg.StripeAPIKey = os.Getenv("STRIPE_API_KEY")
+	if fake, err := strconv.ParseBool(os.Getenv("FAKE_PAYMENTS")); err == nil {
+		cfg.FakePayments = fake
+	}
 
 	return &cfg, nil
 }
@@ -2,6 +2,10 @@ package middleware
 
 import (
 	"context"
+	fleetpb "dispatch-and-delivery/api/proto/fleet"
+	itempb "dispatch-and-delivery/api/proto/item"
+	orderpb "dispatch-and-delivery/api/proto/order"
+	userpb "dispatch-and-delivery/api/proto/user"
 	"dispatch-and-delivery/internal/models"
 	"strings"
 
@@ -20,6 +24,67 @@ const (
 	UserRoleContextKey contextKey = "userRole"
 )
 
+// RoleAdmin is the JWT role required to call admin-only RPCs.
+const RoleAdmin = "ADMIN"
+
+// RoleMachine is the JWT role of delivery machines. Their user ID claim is the machine ID.
+const RoleMachine = "MACHINE"
+
+// adminOnlyMethods lists the full gRPC method names that require the admin role.
+var adminOnlyMethods = map[string]bool{
+	orderpb.OrderService_AssignOrder_FullMethodName:             true,
+	orderpb.OrderService_AssignTrip_FullMethodName:              true,
+	orderpb.OrderService_RefundOrder_FullMethodName:             true,
+	orderpb.OrderService_GetMachineRatingStats_FullMethodName:   true,
+	itempb.ItemService_CreateItem_FullMethodName:                true,
+	itempb.ItemService_AdjustStock_FullMethodName:               true,
+	fleetpb.FleetService_RegisterMachine_FullMethodName:         true,
+	fleetpb.FleetService_GetMachine_FullMethodName:              true,
+	fleetpb.FleetService_ListMachines_FullMethodName:            true,
+	fleetpb.FleetService_DecommissionMachine_FullMethodName:     true,
+	fleetpb.FleetService_GetMachineStatusHistory_FullMethodName: true,
+	fleetpb.FleetService_CreateChargingStation_FullMethodName:   true,
+	fleetpb.FleetService_ListChargingStations_FullMethodName:    true,
+	fleetpb.FleetService_CheckTripBattery_FullMethodName:        true,
+	fleetpb.FleetService_ListEligibleMachines_FullMethodName:    true,
+	fleetpb.FleetService_OpenWorkOrder_FullMethodName:           true,
+	fleetpb.FleetService_StartWorkOrder_FullMethodName:          true,
+	fleetpb.FleetService_CompleteWorkOrder_FullMethodName:       true,
+	fleetpb.FleetService_CancelWorkOrder_FullMethodName:         true,
+	fleetpb.FleetService_ListWorkOrders_FullMethodName:          true,
+	fleetpb.FleetService_CreateHub_FullMethodName:               true,
+	fleetpb.FleetService_ListHubs_FullMethodName:                true,
+	fleetpb.FleetService_AssignHomeHub_FullMethodName:           true,
+	fleetpb.FleetService_ListHubInventories_FullMethodName:      true,
+	fleetpb.FleetService_SuggestRebalancing_FullMethodName:      true,
+}
+
+// machineOnlyMethods lists the full gRPC method names that only delivery machines may call.
+var machineOnlyMethods = map[string]bool{
+	orderpb.OrderService_ConfirmPickup_FullMethodName:         true,
+	orderpb.OrderService_SubmitProofOfDelivery_FullMethodName: true,
+	orderpb.OrderService_ReportFailedDelivery_FullMethodName:  true,
+	orderpb.OrderService_ConfirmReturn_FullMethodName:         true,
+	fleetpb.FleetService_Heartbeat_FullMethodName:             true,
+}
+
+// machineOrAdminMethods lists the full gRPC method names that machines may call
+// about themselves and admins about any machine. Handlers enforce the former.
+var machineOrAdminMethods = map[string]bool{
+	fleetpb.FleetService_UpdateMachineStatus_FullMethodName: true,
+	fleetpb.FleetService_ReportFault_FullMethodName:         true,
+}
+
+// publicMethods lists the full gRPC method names that can be called without a token.
+var publicMethods = map[string]bool{
+	userpb.UserService_RegisterUser_FullMethodName:          true,
+	userpb.UserService_LoginUser_FullMethodName:             true,
+	userpb.UserService_ActivateAccount_FullMethodName:       true,
+	userpb.UserService_ResendActivationEmail_FullMethodName: true,
+	userpb.UserService_RequestPasswordReset_FullMethodName:  true,
+	userpb.UserService_ResetPassword_FullMethodName:         true,
+}
+
 // AuthInterceptor is a gRPC server-side interceptor for JWT authentication and authorization.
 func AuthInterceptor(jwtSecret string) grpc.UnaryServerInterceptor {
 	return func(
@@ -28,51 +93,117 @@ func AuthInterceptor(jwtSecret string) grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-
-		// Extract token from incoming gRPC metadata (the equivalent of HTTP headers)
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+		ctx, err := authenticate(ctx, info.FullMethod, jwtSecret)
+		if err != nil {
+			return nil, err
 		}
+		// Call the actual RPC handler with the enriched context
+		return handler(ctx, req)
+	}
+}
 
-		values := md.Get("authorization")
-		if len(values) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+// AuthStreamInterceptor is the streaming counterpart of AuthInterceptor.
+func AuthStreamInterceptor(jwtSecret string) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod, jwtSecret)
+		if err != nil {
+			return err
 		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		// The token is expected to be in the format "Bearer <token>"
-		authHeader := values[0]
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			return nil, status.Errorf(codes.Unauthenticated, "authorization token format is invalid")
-		}
-		tokenString := parts[1]
+// serverStream overrides the context of a stream with an enriched one.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-		// Parse and validate the token
-		claims := &models.JwtCustomClaims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
-			return []byte(jwtSecret), nil
-		})
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
 
-		if err != nil || !token.Valid {
-			return nil, status.Errorf(codes.Unauthenticated, "token is invalid: %v", err)
-		}
+// authenticate identifies the caller of method and checks that it may call
+// it. It returns ctx enriched with the caller's user ID and role.
+func authenticate(ctx context.Context, method, jwtSecret string) (context.Context, error) {
+	if publicMethods[method] {
+		return ctx, nil
+	}
 
-		// --- Authentication successful ---
-		// Inject user information into the context for downstream handlers to use.
-		ctx = context.WithValue(ctx, UserIDContextKey, claims.UserID)
-		ctx = context.WithValue(ctx, UserRoleContextKey, claims.Role)
-
-		// --- Authorization (Role Check) ---
-		// This is an example of how you could protect specific methods.
-		// A more robust solution might use a map of methods to required roles.
-		if info.FullMethod == "/admin.AdminService/SomeAdminAction" {
-			if claims.Role != "ADMIN" {
-				return nil, status.Errorf(codes.PermissionDenied, "you do not have permission to access this resource")
-			}
+	// Machines authenticated by MachineIdentityInterceptor need no token.
+	if machineID, ok := ctx.Value(MachineIDContextKey).(string); ok && machineID != "" {
+		if err := authorize(method, RoleMachine); err != nil {
+			return nil, err
 		}
+		ctx = context.WithValue(ctx, UserIDContextKey, machineID)
+		ctx = context.WithValue(ctx, UserRoleContextKey, RoleMachine)
+		return ctx, nil
+	}
 
-		// Call the actual RPC handler with the enriched context
-		return handler(ctx, req)
+	// Extract token from incoming gRPC metadata (the equivalent of HTTP headers)
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+	}
+
+	// The token is expected to be in the format "Bearer <token>"
+	authHeader := values[0]
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization token format is invalid")
+	}
+	tokenString := parts[1]
+
+	// Parse and validate the token
+	claims := &models.JwtCustomClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		return []byte(jwtSecret), nil
+	})
+
+	if err != nil || !token.Valid {
+		return nil, status.Errorf(codes.Unauthenticated, "token is invalid: %v", err)
+	}
+
+	// Servers that authenticate machines by certificate no longer accept
+	// machine tokens, so a decommissioned machine cannot fall back to one.
+	if claims.Role == RoleMachine && ctx.Value(machineCertsRequiredKey) != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "machines must authenticate with a client certificate")
+	}
+
+	// --- Authentication successful ---
+	// Inject user information into the context for downstream handlers to use.
+	ctx = context.WithValue(ctx, UserIDContextKey, claims.UserID)
+	ctx = context.WithValue(ctx, UserRoleContextKey, claims.Role)
+
+	// --- Authorization (Role Check) ---
+	if err := authorize(method, claims.Role); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// authorize checks that a caller with the given role may call method.
+func authorize(method, role string) error {
+	if adminOnlyMethods[method] && role != RoleAdmin {
+		return status.Errorf(codes.PermissionDenied, "you do not have permission to access this resource")
+	}
+	// Machines may only call machine RPCs, and only machines may call those.
+	if machineOrAdminMethods[method] {
+		if role != RoleMachine && role != RoleAdmin {
+			return status.Errorf(codes.PermissionDenied, "you do not have permission to access this resource")
+		}
+	} else if machineOnlyMethods[method] != (role == RoleMachine) {
+		return status.Errorf(codes.PermissionDenied, "you do not have permission to access this resource")
 	}
+	return nil
 }
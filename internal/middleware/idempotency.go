@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	orderpb "dispatch-and-delivery/api/proto/order"
+	userpb "dispatch-and-delivery/api/proto/user"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// IdempotencyKeyHeader is the gRPC metadata key clients use to make a call safe to retry.
+const IdempotencyKeyHeader = "idempotency-key"
+
+// maxIdempotencyKeyLength bounds the keys accepted from clients.
+const maxIdempotencyKeyLength = 255
+
+// idempotentMethods lists the full gRPC method names whose responses are
+// cached per idempotency key. Calls to other methods ignore the key.
+var idempotentMethods = map[string]bool{
+	orderpb.OrderService_CreateOrder_FullMethodName: true,
+	orderpb.OrderService_PayOrder_FullMethodName:    true,
+	userpb.UserService_AddAddress_FullMethodName:    true,
+}
+
+// IdempotencyRecord is a stored request/response pair for one idempotency key.
+type IdempotencyRecord struct {
+	RequestHash  []byte
+	ResponseType string // Full protobuf message name; empty while the request is in flight
+	Response     []byte
+}
+
+// IdempotencyStore persists the responses of idempotent calls.
+type IdempotencyStore interface {
+	// Reserve claims the key for a new request. If the key was claimed before,
+	// it returns the existing record instead and reserved is false.
+	Reserve(ctx context.Context, userID, method, key string, requestHash []byte) (existing *IdempotencyRecord, reserved bool, err error)
+	// Complete stores the response of a reserved key.
+	Complete(ctx context.Context, userID, method, key, responseType string, response []byte) error
+	// Release frees a reserved key after the call failed, so the client can retry.
+	Release(ctx context.Context, userID, method, key string) error
+}
+
+// IdempotencyInterceptor makes the methods in idempotentMethods safe to retry.
+// The first call with a given key runs normally and its response is stored;
+// replays with the same key and payload get the stored response back, and
+// replays with a different payload are rejected with codes.FailedPrecondition.
+// Keys are scoped per user and method, so it must run after AuthInterceptor.
+// Failed calls are not cached, so the client can retry them with the same key.
+func IdempotencyInterceptor(store IdempotencyStore) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if !idempotentMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(IdempotencyKeyHeader)
+		if len(values) == 0 || values[0] == "" {
+			return handler(ctx, req)
+		}
+		key := values[0]
+		if len(key) > maxIdempotencyKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)
+		}
+
+		userID, ok := ctx.Value(UserIDContextKey).(string)
+		if !ok || userID == "" {
+			return nil, status.Error(codes.Unauthenticated, "idempotency keys require an authenticated caller")
+		}
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		requestHash, err := hashRequest(msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to process idempotency key")
+		}
+
+		// 1. Claim the key, or find out what happened to the original request
+		existing, reserved, err := store.Reserve(ctx, userID, info.FullMethod, key, requestHash)
+		if err != nil {
+			log.Printf("ERROR: Failed to reserve idempotency key for %s: %v", info.FullMethod, err)
+			return nil, status.Error(codes.Internal, "failed to process idempotency key")
+		}
+		if !reserved {
+			return replayResponse(existing, requestHash)
+		}
+
+		// 2. Run the call and remember its outcome
+		resp, err := handler(ctx, req)
+		if err != nil {
+			if releaseErr := store.Release(context.WithoutCancel(ctx), userID, info.FullMethod, key); releaseErr != nil {
+				log.Printf("ERROR: Failed to release idempotency key for %s: %v", info.FullMethod, releaseErr)
+			}
+			return nil, err
+		}
+
+		if respMsg, ok := resp.(proto.Message); ok {
+			encoded, marshalErr := proto.Marshal(respMsg)
+			if marshalErr == nil {
+				marshalErr = store.Complete(context.WithoutCancel(ctx), userID, info.FullMethod, key,
+					string(respMsg.ProtoReflect().Descriptor().FullName()), encoded)
+			}
+			if marshalErr != nil {
+				// The call succeeded; replays are answered as in progress until the lock times out.
+				log.Printf("ERROR: Failed to store idempotent response for %s: %v", info.FullMethod, marshalErr)
+			}
+		}
+		return resp, nil
+	}
+}
+
+// hashRequest fingerprints a request using its deterministic protobuf encoding.
+func hashRequest(req proto.Message) ([]byte, error) {
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(encoded)
+	return sum[:], nil
+}
+
+// replayResponse returns the stored response of an earlier call with the same key.
+func replayResponse(record *IdempotencyRecord, requestHash []byte) (any, error) {
+	if string(record.RequestHash) != string(requestHash) {
+		return nil, status.Error(codes.FailedPrecondition, "idempotency key was already used with a different request")
+	}
+	if record.ResponseType == "" {
+		return nil, status.Error(codes.Aborted, "a request with this idempotency key is still in progress")
+	}
+
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(record.ResponseType))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to replay stored response")
+	}
+	resp := msgType.New().Interface()
+	if err := proto.Unmarshal(record.Response, resp); err != nil {
+		return nil, status.Error(codes.Internal, "failed to replay stored response")
+	}
+	return resp, nil
+}
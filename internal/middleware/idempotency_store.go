@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// DefaultIdempotencyTTL is how long responses are kept for replay.
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	// idempotencyLockTimeout is after how long an unfinished request is
+	// considered abandoned (e.g. the server crashed) and its key can be reused.
+	idempotencyLockTimeout = time.Minute
+)
+
+// PostgresIdempotencyStore is an IdempotencyStore backed by the idempotency_keys table.
+type PostgresIdempotencyStore struct {
+	db  *pgxpool.Pool
+	ttl time.Duration
+}
+
+// NewPostgresIdempotencyStore creates a store that keeps responses for ttl.
+func NewPostgresIdempotencyStore(db *pgxpool.Pool, ttl time.Duration) *PostgresIdempotencyStore {
+	return &PostgresIdempotencyStore{db: db, ttl: ttl}
+}
+
+// Reserve claims a key. Expired keys and keys abandoned mid-request are taken over.
+func (s *PostgresIdempotencyStore) Reserve(ctx context.Context, userID, method, key string, requestHash []byte) (*IdempotencyRecord, bool, error) {
+	query := `
+	INSERT INTO idempotency_keys (user_id, method, key, request_hash, expires_at)
+	VALUES ($1, $2, $3, $4, NOW() + make_interval(secs => $5))
+	ON CONFLICT (user_id, method, key) DO UPDATE
+	SET request_hash = EXCLUDED.request_hash,
+		response_type = NULL,
+		response = NULL,
+		locked_at = NOW(),
+		expires_at = EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at < NOW()
+		OR (idempotency_keys.response IS NULL AND idempotency_keys.locked_at < NOW() - make_interval(secs => $6))
+	RETURNING key
+	`
+	var claimed string
+	err := s.db.QueryRow(ctx, query, userID, method, key, requestHash,
+		s.ttl.Seconds(), idempotencyLockTimeout.Seconds(),
+	).Scan(&claimed)
+	if err == nil {
+		return nil, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, fmt.Errorf("idempotency.Reserve: %w", err)
+	}
+
+	// The key is taken: load what the original request stored.
+	var record IdempotencyRecord
+	var responseType *string
+	err = s.db.QueryRow(ctx,
+		`SELECT request_hash, response_type, response FROM idempotency_keys WHERE user_id = $1 AND method = $2 AND key = $3`,
+		userID, method, key,
+	).Scan(&record.RequestHash, &responseType, &record.Response)
+	if err != nil {
+		return nil, false, fmt.Errorf("idempotency.Reserve.Find: %w", err)
+	}
+	if responseType != nil {
+		record.ResponseType = *responseType
+	}
+	return &record, false, nil
+}
+
+func (s *PostgresIdempotencyStore) Complete(ctx context.Context, userID, method, key, responseType string, response []byte) error {
+	query := `
+	UPDATE idempotency_keys SET response_type = $1, response = $2
+	WHERE user_id = $3 AND method = $4 AND key = $5
+	`
+	if _, err := s.db.Exec(ctx, query, responseType, response, userID, method, key); err != nil {
+		return fmt.Errorf("idempotency.Complete: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresIdempotencyStore) Release(ctx context.Context, userID, method, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND method = $2 AND key = $3 AND response IS NULL`
+	if _, err := s.db.Exec(ctx, query, userID, method, key); err != nil {
+		return fmt.Errorf("idempotency.Release: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired deletes responses that can no longer be replayed.
+func (s *PostgresIdempotencyStore) PurgeExpired(ctx context.Context) (int64, error) {
+	cmdTag, err := s.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("idempotency.PurgeExpired: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	orderpb "dispatch-and-delivery/api/proto/order"
+	"errors"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// memoryIdempotencyStore is an in-memory IdempotencyStore without expiry.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*IdempotencyRecord)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, userID, method, key string, requestHash []byte) (*IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := userID + method + key
+	if record, ok := s.records[id]; ok {
+		copied := *record
+		return &copied, false, nil
+	}
+	s.records[id] = &IdempotencyRecord{RequestHash: requestHash}
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, userID, method, key, responseType string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := s.records[userID+method+key]
+	record.ResponseType = responseType
+	record.Response = response
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, userID, method, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, userID+method+key)
+	return nil
+}
+
+// idempotentCall runs the interceptor for a PayOrder call by user with the given key.
+func idempotentCall(interceptor grpc.UnaryServerInterceptor, user, key string, req proto.Message, handler grpc.UnaryHandler) (any, error) {
+	ctx := context.WithValue(context.Background(), UserIDContextKey, user)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(IdempotencyKeyHeader, key))
+	info := &grpc.UnaryServerInfo{FullMethod: orderpb.OrderService_PayOrder_FullMethodName}
+	return interceptor(ctx, req, info, handler)
+}
+
+func TestIdempotencyInterceptorReplaysResponse(t *testing.T) {
+	interceptor := IdempotencyInterceptor(newMemoryIdempotencyStore())
+	calls := 0
+	handler := func(ctx context.Context, req any) (any, error) {
+		calls++
+		return wrapperspb.String("payment-1"), nil
+	}
+
+	first, err := idempotentCall(interceptor, "alice", "key-1", wrapperspb.String("order-1"), handler)
+	if err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	replay, err := idempotentCall(interceptor, "alice", "key-1", wrapperspb.String("order-1"), handler)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want once", calls)
+	}
+	if !proto.Equal(first.(proto.Message), replay.(proto.Message)) {
+		t.Errorf("replay = %v, want the stored response %v", replay, first)
+	}
+
+	// Keys are scoped per user.
+	if _, err := idempotentCall(interceptor, "bob", "key-1", wrapperspb.String("order-1"), handler); err != nil {
+		t.Fatalf("call of another user: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want the other user's call to run", calls)
+	}
+
+	_, err = idempotentCall(interceptor, "alice", "key-1", wrapperspb.String("order-2"), handler)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("reuse with a different payload = %v, want FailedPrecondition", err)
+	}
+}
+
+func TestIdempotencyInterceptorReleasesFailedCalls(t *testing.T) {
+	interceptor := IdempotencyInterceptor(newMemoryIdempotencyStore())
+	req := wrapperspb.String("order-1")
+
+	failing := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.Unavailable, "provider down")
+	}
+	if _, err := idempotentCall(interceptor, "alice", "key-1", req, failing); status.Code(err) != codes.Unavailable {
+		t.Fatalf("failing call = %v, want the handler's error", err)
+	}
+
+	calls := 0
+	succeeding := func(ctx context.Context, req any) (any, error) {
+		calls++
+		return wrapperspb.String("payment-1"), nil
+	}
+	if _, err := idempotentCall(interceptor, "alice", "key-1", req, succeeding); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("retry after a failure ran the handler %d times, want once", calls)
+	}
+}
+
+func TestIdempotencyInterceptorInFlight(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	interceptor := IdempotencyInterceptor(store)
+	req := wrapperspb.String("order-1")
+
+	var replayErr error
+	handler := func(ctx context.Context, _ any) (any, error) {
+		// A concurrent retry arrives while the original call is still running.
+		_, replayErr = idempotentCall(interceptor, "alice", "key-1", req, func(context.Context, any) (any, error) {
+			return nil, errors.New("handler must not run for an in-flight key")
+		})
+		return wrapperspb.String("payment-1"), nil
+	}
+	if _, err := idempotentCall(interceptor, "alice", "key-1", req, handler); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if status.Code(replayErr) != codes.Aborted {
+		t.Errorf("in-flight replay = %v, want Aborted", replayErr)
+	}
+}
+
+func TestIdempotencyInterceptorPassThrough(t *testing.T) {
+	interceptor := IdempotencyInterceptor(newMemoryIdempotencyStore())
+	calls := 0
+	handler := func(ctx context.Context, req any) (any, error) {
+		calls++
+		return wrapperspb.String("ok"), nil
+	}
+
+	// Without a key, every call runs.
+	ctx := context.WithValue(context.Background(), UserIDContextKey, "alice")
+	info := &grpc.UnaryServerInfo{FullMethod: orderpb.OrderService_PayOrder_FullMethodName}
+	for i := 0; i < 2; i++ {
+		if _, err := interceptor(ctx, wrapperspb.String("order-1"), info, handler); err != nil {
+			t.Fatalf("call without key: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times without a key, want twice", calls)
+	}
+
+	// Methods that are not idempotent ignore the key.
+	info = &grpc.UnaryServerInfo{FullMethod: orderpb.OrderService_CancelOrder_FullMethodName}
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(IdempotencyKeyHeader, "key-1"))
+	for i := 0; i < 2; i++ {
+		if _, err := interceptor(ctx, wrapperspb.String("order-1"), info, handler); err != nil {
+			t.Fatalf("call of another method: %v", err)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("handler ran %d times, want the key ignored for other methods", calls)
+	}
+}
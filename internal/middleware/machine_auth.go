@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"dispatch-and-delivery/pkg/pki"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MachineIDContextKey holds the machine ID of callers authenticated by a
+	// client certificate. It is never set for token holders.
+	MachineIDContextKey contextKey = "machineID"
+
+	// machineCertsRequiredKey marks servers that authenticate machines by
+	// certificate, so AuthInterceptor stops accepting machine tokens.
+	machineCertsRequiredKey contextKey = "machineCertsRequired"
+)
+
+// CertificateRevocationChecker looks up whether a machine certificate was revoked.
+type CertificateRevocationChecker interface {
+	// IsCertificateRevoked reports whether the certificate with the given hex
+	// serial number was revoked. Unknown serial numbers count as revoked.
+	IsCertificateRevoked(ctx context.Context, serialNumber string) (bool, error)
+}
+
+// MachineIdentityInterceptor authenticates machines by the client certificate
+// they present over mutual TLS. The machine ID is taken from the certificate's
+// URI SAN and stored under MachineIDContextKey, and AuthInterceptor then treats
+// the caller as a machine without asking for a token. Callers without a client
+// certificate are passed on unchanged, so humans keep using JWTs.
+// It must run before AuthInterceptor, on a server whose TLS configuration
+// verifies client certificates against the machine CA.
+func MachineIdentityInterceptor(revocations CertificateRevocationChecker) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, err := identifyMachine(ctx, revocations)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// MachineIdentityStreamInterceptor is the streaming counterpart of
+// MachineIdentityInterceptor. It must run before AuthStreamInterceptor.
+func MachineIdentityStreamInterceptor(revocations CertificateRevocationChecker) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := identifyMachine(ss.Context(), revocations)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// identifyMachine returns ctx with the ID of the machine whose verified client
+// certificate the connection carries, if any.
+func identifyMachine(ctx context.Context, revocations CertificateRevocationChecker) (context.Context, error) {
+	ctx = context.WithValue(ctx, machineCertsRequiredKey, true)
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return ctx, nil
+	}
+
+	cert := tlsInfo.State.VerifiedChains[0][0]
+	machineID, ok := pki.MachineIDFromCertificate(cert)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "client certificate does not identify a machine")
+	}
+	revoked, err := revocations.IsCertificateRevoked(ctx, pki.SerialNumberHex(cert.SerialNumber))
+	if err != nil {
+		log.Printf("ERROR: Failed to check revocation of the certificate of machine %s: %v", machineID, err)
+		return nil, status.Error(codes.Unavailable, "failed to verify client certificate")
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "client certificate has been revoked")
+	}
+	return context.WithValue(ctx, MachineIDContextKey, machineID), nil
+}
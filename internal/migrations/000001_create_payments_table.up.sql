@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS payments (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id            UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    provider            VARCHAR(32) NOT NULL,
+    provider_payment_id VARCHAR(255),
+    idempotency_key     VARCHAR(255) NOT NULL UNIQUE,
+    amount_cents        BIGINT NOT NULL CHECK (amount_cents > 0),
+    currency            VARCHAR(3) NOT NULL DEFAULT 'usd',
+    status              VARCHAR(32) NOT NULL DEFAULT 'pending',
+    failure_reason      TEXT,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments (order_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_provider_payment_id ON payments (provider, provider_payment_id);
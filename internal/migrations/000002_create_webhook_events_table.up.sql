@@ -0,0 +1,7 @@
+-- Provider webhook events that have already been processed, used to drop redeliveries.
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id           VARCHAR(255) PRIMARY KEY,
+    provider     VARCHAR(32) NOT NULL,
+    type         VARCHAR(64) NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS refunds (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id         UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    order_id           UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    provider_refund_id VARCHAR(255),
+    idempotency_key    VARCHAR(255) NOT NULL UNIQUE,
+    amount_cents       BIGINT NOT NULL CHECK (amount_cents > 0),
+    currency           VARCHAR(3) NOT NULL DEFAULT 'usd',
+    reason             VARCHAR(32) NOT NULL,
+    status             VARCHAR(32) NOT NULL DEFAULT 'pending',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds (payment_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds (order_id);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS items (
+    sku               VARCHAR(64) PRIMARY KEY,
+    name              VARCHAR(255) NOT NULL,
+    unit_weight_kg    NUMERIC(10, 3) NOT NULL CHECK (unit_weight_kg > 0),
+    hazmat            BOOLEAN NOT NULL DEFAULT FALSE,
+    hazmat_class      VARCHAR(8) NOT NULL DEFAULT '', -- UN hazard class, empty when not hazardous
+    quantity_on_hand  INTEGER NOT NULL DEFAULT 0 CHECK (quantity_on_hand >= 0),
+    quantity_reserved INTEGER NOT NULL DEFAULT 0 CHECK (quantity_reserved >= 0),
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (quantity_reserved <= quantity_on_hand)
+);
+
+-- Reservations reference orders by ID only: the item service owns its own tables.
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id   UUID NOT NULL,
+    sku        VARCHAR(64) NOT NULL REFERENCES items(sku),
+    quantity   INTEGER NOT NULL CHECK (quantity > 0),
+    status     VARCHAR(32) NOT NULL DEFAULT 'reserved', -- reserved, released, fulfilled
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_order_id ON stock_reservations (order_id);
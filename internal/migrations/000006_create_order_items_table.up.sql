@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS order_items (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id       UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    sku            VARCHAR(64) NOT NULL,
+    name           VARCHAR(255) NOT NULL,
+    quantity       INTEGER NOT NULL CHECK (quantity > 0),
+    unit_weight_kg NUMERIC(10, 3) NOT NULL, -- Copied from the catalog when the order is placed
+    hazmat         BOOLEAN NOT NULL DEFAULT FALSE,
+    hazmat_class   VARCHAR(8) NOT NULL DEFAULT '',
+    declared_value NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    UNIQUE (order_id, sku)
+);
@@ -0,0 +1,19 @@
+-- Route options are delivery quotes priced by the dispatch service. An order
+-- is created from exactly one unexpired quote.
+CREATE TABLE IF NOT EXISTS route_options (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id            UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pickup_address_id  UUID NOT NULL REFERENCES addresses(id),
+    dropoff_address_id UUID NOT NULL REFERENCES addresses(id),
+    machine_type       VARCHAR(16) NOT NULL, -- DRONE or ROBOT
+    strategy           VARCHAR(16) NOT NULL, -- FASTEST or CHEAPEST
+    price              NUMERIC(10, 2) NOT NULL,
+    distance_meters    INTEGER NOT NULL DEFAULT 0,
+    duration_seconds   INTEGER NOT NULL DEFAULT 0,
+    polyline           TEXT NOT NULL DEFAULT '',
+    order_id           UUID REFERENCES orders(id) ON DELETE SET NULL, -- Set once the quote is used
+    expires_at         TIMESTAMPTZ NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_route_options_user_id ON route_options (user_id);
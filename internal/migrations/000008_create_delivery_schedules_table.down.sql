@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_orders_pickup_window;
+DROP INDEX IF EXISTS idx_orders_schedule_occurrence;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS schedule_id,
+    DROP COLUMN IF EXISTS pickup_window_end,
+    DROP COLUMN IF EXISTS pickup_window_start,
+    DROP COLUMN IF EXISTS machine_type;
+
+DROP TABLE IF EXISTS delivery_schedules;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS delivery_schedules (
+    id                   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id              UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pickup_address_id    UUID NOT NULL REFERENCES addresses(id),
+    dropoff_address_id   UUID NOT NULL REFERENCES addresses(id),
+    machine_type         VARCHAR(16) NOT NULL,
+    price                NUMERIC(10, 2) NOT NULL,
+    length_m             NUMERIC(10, 3) NOT NULL,
+    width_m              NUMERIC(10, 3) NOT NULL,
+    height_m             NUMERIC(10, 3) NOT NULL,
+    items                JSONB NOT NULL, -- Item manifest copied into every materialized order
+    rule                 VARCHAR(255) NOT NULL, -- Weekly RRULE
+    timezone             VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    starts_at            TIMESTAMPTZ NOT NULL,
+    window_minutes       INTEGER NOT NULL CHECK (window_minutes > 0),
+    active               BOOLEAN NOT NULL DEFAULT TRUE,
+    materialized_through TIMESTAMPTZ, -- Occurrences up to here have been turned into orders
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_schedules_user_id ON delivery_schedules (user_id);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS machine_type VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS pickup_window_start TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS pickup_window_end TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS schedule_id UUID REFERENCES delivery_schedules(id) ON DELETE SET NULL;
+
+-- Materializing a schedule twice must not create the same order twice.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_schedule_occurrence ON orders (schedule_id, pickup_window_start)
+    WHERE schedule_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_pickup_window ON orders (machine_type, pickup_window_start)
+    WHERE pickup_window_start IS NOT NULL;
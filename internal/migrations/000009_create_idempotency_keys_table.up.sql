@@ -0,0 +1,15 @@
+-- Responses of mutating RPCs, keyed by the client supplied idempotency key.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id       VARCHAR(64) NOT NULL,
+    method        VARCHAR(255) NOT NULL, -- Full gRPC method name
+    key           VARCHAR(255) NOT NULL,
+    request_hash  BYTEA NOT NULL, -- SHA-256 of the deterministic request encoding
+    response_type VARCHAR(255), -- NULL while the first request is still running
+    response      BYTEA,
+    locked_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, method, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
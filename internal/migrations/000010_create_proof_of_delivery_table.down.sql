@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS proof_of_delivery;
+DROP TABLE IF EXISTS tracking_events;
+
+ALTER TABLE addresses
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,30 @@
+-- Dropoff validation needs geocoded addresses. Existing rows stay NULL until geocoded.
+ALTER TABLE addresses
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id   UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    machine_id UUID NOT NULL,
+    latitude   DOUBLE PRECISION NOT NULL,
+    longitude  DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracking_events_order_id ON tracking_events (order_id, created_at);
+
+CREATE TABLE IF NOT EXISTS proof_of_delivery (
+    order_id          UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE, -- One proof per order
+    machine_id        UUID NOT NULL,
+    photo_ref         VARCHAR(1024) NOT NULL,
+    tracking_event_id UUID NOT NULL REFERENCES tracking_events(id),
+    latitude          DOUBLE PRECISION NOT NULL,
+    longitude         DOUBLE PRECISION NOT NULL,
+    fix_recorded_at   TIMESTAMPTZ NOT NULL,
+    distance_meters   DOUBLE PRECISION, -- NULL when the dropoff address is not geocoded
+    location_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    pin_verified      BOOLEAN NOT NULL DEFAULT FALSE,
+    delivered_at      TIMESTAMPTZ NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
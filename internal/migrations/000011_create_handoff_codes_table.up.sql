@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS handoff_codes (
+    order_id    UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    code_hash   VARCHAR(64) NOT NULL, -- Hex HMAC-SHA256 of the order ID and PIN, keyed with HANDOFF_PIN_SECRET
+    attempts    INTEGER NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    verified_at TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS delivery_attempts (
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id               UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    machine_id             UUID NOT NULL,
+    attempt_number         INTEGER NOT NULL,
+    reason                 VARCHAR(50) NOT NULL, -- recipient_unavailable, address_inaccessible, recipient_refused, handoff_locked, package_damaged or machine_fault
+    notes                  VARCHAR(500) NOT NULL DEFAULT '',
+    tracking_event_id      UUID NOT NULL REFERENCES tracking_events(id),
+    latitude               DOUBLE PRECISION NOT NULL,
+    longitude              DOUBLE PRECISION NOT NULL,
+    outcome                VARCHAR(20) NOT NULL, -- reattempt or return
+    fee                    NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    reattempt_window_start TIMESTAMPTZ,
+    reattempt_window_end   TIMESTAMPTZ,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (order_id, attempt_number)
+);
@@ -0,0 +1,16 @@
+ALTER TABLE tracking_events DROP COLUMN IF EXISTS stop_id;
+ALTER TABLE delivery_attempts DROP COLUMN IF EXISTS stop_id;
+
+-- Keeps the first proof of every order.
+DELETE FROM proof_of_delivery p
+USING order_stops s
+WHERE s.id = p.stop_id AND s.sequence > 1;
+ALTER TABLE proof_of_delivery
+    DROP CONSTRAINT IF EXISTS proof_of_delivery_pkey,
+    DROP COLUMN IF EXISTS stop_id,
+    ADD PRIMARY KEY (order_id);
+DROP INDEX IF EXISTS idx_proof_of_delivery_order_id;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS trip_id;
+DROP TABLE IF EXISTS order_stops;
+DROP TABLE IF EXISTS delivery_trips;
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS delivery_trips (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    machine_id        UUID NOT NULL,
+    pickup_address_id UUID NOT NULL REFERENCES addresses(id),
+    status            VARCHAR(20) NOT NULL DEFAULT 'active', -- active or completed
+    distance_meters   DOUBLE PRECISION, -- NULL when a stop is not geocoded
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_trips_machine_id ON delivery_trips (machine_id, status);
+
+CREATE TABLE IF NOT EXISTS order_stops (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id            UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    sequence            INTEGER NOT NULL, -- Position in the order as placed
+    address_id          UUID NOT NULL REFERENCES addresses(id),
+    status              VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, delivered or failed
+    trip_id             UUID REFERENCES delivery_trips(id),
+    trip_sequence       INTEGER, -- Visit position on the trip
+    leg_distance_meters DOUBLE PRECISION,
+    delivered_at        TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (order_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_stops_trip_id ON order_stops (trip_id, trip_sequence);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS trip_id UUID REFERENCES delivery_trips(id);
+
+-- Every order has at least one stop: its dropoff address.
+INSERT INTO order_stops (order_id, sequence, address_id, status, delivered_at)
+SELECT id, 1, dropoff_address_id,
+    CASE status WHEN 'delivered' THEN 'delivered' WHEN 'returned' THEN 'failed' ELSE 'pending' END,
+    CASE status WHEN 'delivered' THEN updated_at END
+FROM orders
+ON CONFLICT (order_id, sequence) DO NOTHING;
+
+-- Proofs of delivery are recorded per stop instead of per order.
+ALTER TABLE proof_of_delivery ADD COLUMN IF NOT EXISTS stop_id UUID REFERENCES order_stops(id);
+UPDATE proof_of_delivery p SET stop_id = s.id
+FROM order_stops s
+WHERE s.order_id = p.order_id AND s.sequence = 1 AND p.stop_id IS NULL;
+ALTER TABLE proof_of_delivery
+    DROP CONSTRAINT IF EXISTS proof_of_delivery_pkey,
+    ALTER COLUMN stop_id SET NOT NULL,
+    ADD PRIMARY KEY (stop_id);
+CREATE INDEX IF NOT EXISTS idx_proof_of_delivery_order_id ON proof_of_delivery (order_id);
+
+ALTER TABLE delivery_attempts ADD COLUMN IF NOT EXISTS stop_id UUID REFERENCES order_stops(id);
+
+-- The stop a machine was heading to when it reported its position.
+ALTER TABLE tracking_events ADD COLUMN IF NOT EXISTS stop_id UUID REFERENCES order_stops(id);
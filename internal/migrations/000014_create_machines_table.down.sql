@@ -0,0 +1,7 @@
+-- The machines table itself is left in place: other services read it.
+DROP INDEX IF EXISTS idx_machines_type_status;
+DROP INDEX IF EXISTS idx_machines_serial_number;
+
+ALTER TABLE machines
+    DROP COLUMN IF EXISTS decommissioned_at,
+    DROP COLUMN IF EXISTS serial_number;
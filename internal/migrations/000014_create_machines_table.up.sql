@@ -0,0 +1,19 @@
+-- The machines table predates the fleet service in some environments, so it is
+-- created only when missing and the registry columns are added on top.
+CREATE TABLE IF NOT EXISTS machines (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type          VARCHAR(32) NOT NULL,  -- DRONE, ROBOT
+    status        VARCHAR(32) NOT NULL DEFAULT 'IDLE', -- IDLE, IN_TRANSIT, CHARGING, MAINTENANCE, DECOMMISSIONED
+    latitude      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    longitude     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    battery_level INTEGER NOT NULL DEFAULT 100 CHECK (battery_level BETWEEN 0 AND 100),
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE machines
+    ADD COLUMN IF NOT EXISTS serial_number     VARCHAR(64), -- NULL for machines registered before the fleet service
+    ADD COLUMN IF NOT EXISTS decommissioned_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_machines_serial_number ON machines (serial_number);
+CREATE INDEX IF NOT EXISTS idx_machines_type_status ON machines (type, status);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS machine_certificates (
+    serial_number VARCHAR(40) PRIMARY KEY, -- Hex encoded
+    machine_id    UUID NOT NULL REFERENCES machines(id),
+    expires_at    TIMESTAMPTZ NOT NULL,
+    revoked_at    TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_machine_certificates_machine_id ON machine_certificates (machine_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS machine_status_events;
+
+ALTER TABLE machines
+    DROP COLUMN IF EXISTS health_flags,
+    DROP COLUMN IF EXISTS last_heartbeat_at;
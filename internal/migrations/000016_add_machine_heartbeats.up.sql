@@ -0,0 +1,15 @@
+ALTER TABLE machines
+    ADD COLUMN IF NOT EXISTS last_heartbeat_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS health_flags      TEXT[] NOT NULL DEFAULT '{}';
+
+-- Events are appended in the same transaction as the status change they record.
+CREATE TABLE IF NOT EXISTS machine_status_events (
+    id          BIGSERIAL PRIMARY KEY,
+    machine_id  UUID NOT NULL REFERENCES machines(id),
+    from_status VARCHAR(32) NOT NULL,
+    to_status   VARCHAR(32) NOT NULL,
+    reason      VARCHAR(32) NOT NULL, -- reported, heartbeat_timeout, heartbeat_resumed, decommissioned
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_machine_status_events_machine_id ON machine_status_events (machine_id, id);
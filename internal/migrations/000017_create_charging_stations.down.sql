@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS charging_reservations;
+DROP TABLE IF EXISTS charging_stations;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS charging_stations (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name         VARCHAR(255) NOT NULL,
+    machine_type VARCHAR(32) NOT NULL, -- DRONE pads or ROBOT docks
+    latitude     DOUBLE PRECISION NOT NULL,
+    longitude    DOUBLE PRECISION NOT NULL,
+    slots        INTEGER NOT NULL CHECK (slots > 0),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_charging_stations_machine_type ON charging_stations (machine_type);
+
+-- A station has as many active reservations as it has slots at most. The
+-- station row is locked while a slot is reserved.
+CREATE TABLE IF NOT EXISTS charging_reservations (
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    station_id             UUID NOT NULL REFERENCES charging_stations(id),
+    machine_id             UUID NOT NULL REFERENCES machines(id),
+    status                 VARCHAR(20) NOT NULL DEFAULT 'active', -- active, completed, cancelled
+    reason                 VARCHAR(32) NOT NULL, -- low_battery, upcoming_trip
+    battery_level_at_start INTEGER NOT NULL,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ended_at               TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_charging_reservations_active_machine ON charging_reservations (machine_id) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_charging_reservations_active_station ON charging_reservations (station_id) WHERE status = 'active';
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS maintenance_work_orders;
+
+ALTER TABLE machines
+    DROP COLUMN IF EXISTS odometer_meters,
+    DROP COLUMN IF EXISTS operating_seconds,
+    DROP COLUMN IF EXISTS last_inspected_at,
+    DROP COLUMN IF EXISTS inspected_odometer_meters,
+    DROP COLUMN IF EXISTS inspected_operating_seconds;
@@ -0,0 +1,35 @@
+-- Usage is counted from heartbeats while machines are in transit.
+ALTER TABLE machines
+    ADD COLUMN IF NOT EXISTS odometer_meters             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS operating_seconds           BIGINT NOT NULL DEFAULT 0, -- Flight time for drones
+    ADD COLUMN IF NOT EXISTS last_inspected_at           TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS inspected_odometer_meters   DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS inspected_operating_seconds BIGINT NOT NULL DEFAULT 0;
+
+-- Work orders are never deleted: they are the machine's maintenance log.
+CREATE TABLE IF NOT EXISTS maintenance_work_orders (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    machine_id        UUID NOT NULL REFERENCES machines(id),
+    kind              VARCHAR(20) NOT NULL, -- fault, inspection, repair
+    status            VARCHAR(20) NOT NULL DEFAULT 'open', -- open, in_progress, completed, cancelled
+    blocking          BOOLEAN NOT NULL DEFAULT TRUE, -- Keeps the machine in MAINTENANCE while open
+    fault_code        VARCHAR(64),
+    description       TEXT NOT NULL DEFAULT '',
+    technician_id     UUID,
+    technician_notes  TEXT NOT NULL DEFAULT '',
+    parts_replaced    JSONB NOT NULL DEFAULT '[]',
+    odometer_meters   DOUBLE PRECISION NOT NULL, -- Machine usage when opened
+    operating_seconds BIGINT NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at        TIMESTAMPTZ,
+    closed_at         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_work_orders_machine_id ON maintenance_work_orders (machine_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_maintenance_work_orders_open ON maintenance_work_orders (machine_id) WHERE status IN ('open', 'in_progress');
+
+-- A fault is ticketed, and an inspection scheduled, once until the work is done.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_maintenance_work_orders_open_fault
+    ON maintenance_work_orders (machine_id, fault_code) WHERE status IN ('open', 'in_progress') AND fault_code IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_maintenance_work_orders_open_inspection
+    ON maintenance_work_orders (machine_id) WHERE status IN ('open', 'in_progress') AND kind = 'inspection';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_machines_home_hub_id;
+ALTER TABLE machines DROP COLUMN IF EXISTS home_hub_id;
+
+DROP TABLE IF EXISTS hubs;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS hubs (
+    id                    UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name                  VARCHAR(255) NOT NULL,
+    latitude              DOUBLE PRECISION NOT NULL,
+    longitude             DOUBLE PRECISION NOT NULL,
+    capacity              INTEGER NOT NULL CHECK (capacity > 0), -- Machines that can be based here
+    service_radius_meters DOUBLE PRECISION NOT NULL CHECK (service_radius_meters > 0),
+    timezone              VARCHAR(64) NOT NULL DEFAULT 'UTC', -- IANA name the opening hours are in
+    opens_at              TIME, -- Both NULL when open around the clock
+    closes_at             TIME,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((opens_at IS NULL) = (closes_at IS NULL))
+);
+
+ALTER TABLE machines ADD COLUMN IF NOT EXISTS home_hub_id UUID REFERENCES hubs(id);
+
+CREATE INDEX IF NOT EXISTS idx_machines_home_hub_id ON machines (home_hub_id);
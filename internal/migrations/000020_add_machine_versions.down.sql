@@ -0,0 +1,8 @@
+ALTER TABLE machine_status_history
+    DROP COLUMN IF EXISTS actor_id,
+    DROP COLUMN IF EXISTS version;
+
+ALTER INDEX IF EXISTS idx_machine_status_history_machine_id RENAME TO idx_machine_status_events_machine_id;
+ALTER TABLE IF EXISTS machine_status_history RENAME TO machine_status_events;
+
+ALTER TABLE machines DROP COLUMN IF EXISTS version;
@@ -0,0 +1,10 @@
+-- Bumped on every change of status or home hub, so that updates made on a
+-- stale read of a machine can be refused.
+ALTER TABLE machines ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE IF EXISTS machine_status_events RENAME TO machine_status_history;
+ALTER INDEX IF EXISTS idx_machine_status_events_machine_id RENAME TO idx_machine_status_history_machine_id;
+
+ALTER TABLE machine_status_history
+    ADD COLUMN IF NOT EXISTS version  BIGINT, -- The machine's version after the change
+    ADD COLUMN IF NOT EXISTS actor_id UUID;   -- The machine or operator that reported it; NULL when made by the system
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_machines_location;
+ALTER TABLE machines DROP COLUMN IF EXISTS location;
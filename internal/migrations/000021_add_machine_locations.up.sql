@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+-- Derived from latitude/longitude so that every update keeps it current. The
+-- GiST index serves both ST_DWithin radius filters and KNN (<->) ordering.
+ALTER TABLE machines ADD COLUMN IF NOT EXISTS location GEOGRAPHY(Point, 4326)
+    GENERATED ALWAYS AS (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_machines_location ON machines USING GIST (location);
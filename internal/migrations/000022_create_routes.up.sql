@@ -0,0 +1,15 @@
+-- Routes are the paths dispatched orders travel, from the pickup through
+-- their stops, as computed by the router of the machine type. An order that
+-- is dispatched again gets a new route; the latest one is current.
+CREATE TABLE IF NOT EXISTS routes (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id         UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    machine_id       UUID NOT NULL,
+    provider         VARCHAR(16) NOT NULL, -- google, graph or airspace
+    polyline         TEXT NOT NULL, -- Google encoded polyline
+    distance_meters  INTEGER NOT NULL,
+    duration_seconds INTEGER NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_routes_order_id ON routes (order_id, created_at DESC);
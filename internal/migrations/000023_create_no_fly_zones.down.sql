@@ -0,0 +1 @@
+DROP TABLE IF EXISTS no_fly_zones;
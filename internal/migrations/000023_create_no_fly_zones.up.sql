@@ -0,0 +1,17 @@
+-- No-fly zones are airspace drones must not enter, imported from GeoJSON.
+-- Each covers the altitudes from its floor to its ceiling above ground, and a
+-- temporary restriction only applies between active_from and active_until.
+CREATE TABLE IF NOT EXISTS no_fly_zones (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name           VARCHAR(255) NOT NULL,
+    area           GEOMETRY(MultiPolygon, 4326) NOT NULL,
+    floor_meters   DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (floor_meters >= 0),
+    ceiling_meters DOUBLE PRECISION, -- NULL for no upper limit
+    active_from    TIMESTAMPTZ, -- Both NULL for a permanent zone
+    active_until   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ceiling_meters IS NULL OR ceiling_meters > floor_meters),
+    CHECK (active_from IS NULL OR active_until IS NULL OR active_until > active_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_no_fly_zones_area ON no_fly_zones USING GIST (area);
@@ -32,10 +32,95 @@ var (
 	// that is not in a 'pending' state.
 	ErrOrderCannotBePaid = errors.New("order is not in a state that can be paid for")
 
+	// ErrPaymentDeclined is returned when the payment provider refuses to charge
+	// the payment method supplied for an order.
+	ErrPaymentDeclined = errors.New("payment was declined")
+
+	// ErrOrderNotRefundable is returned when an order has no captured payment left
+	// to refund, or the requested amount exceeds what remains refundable.
+	ErrOrderNotRefundable = errors.New("order has no refundable amount")
+
+	// ErrPaymentInProgress is returned when an order is paid with another
+	// payment method while an earlier payment is still processing or has
+	// already been captured.
+	ErrPaymentInProgress = errors.New("another payment for this order is already in progress")
+
 	// ErrRouteOptionExpired is returned when the user tries to create an order
 	// with a route option ID that is expired or invalid.
 	ErrRouteOptionExpired = errors.New("the delivery quote has expired, please request a new one")
 
+	// ErrInvalidPickupWindow is returned when a scheduled pickup window is in the
+	// past, too far ahead, or does not end after it starts.
+	ErrInvalidPickupWindow = errors.New("pickup window must be in the future and end after it starts")
+
+	// ErrNoDeliveryCapacity is returned when every machine of the required type
+	// is already booked for the requested pickup window.
+	ErrNoDeliveryCapacity = errors.New("no machines are available for the requested pickup window")
+
+	// ErrOrderCannotBeAssigned is returned when a machine is assigned to an order
+	// that has not been paid or is already assigned.
+	ErrOrderCannotBeAssigned = errors.New("order is not ready for dispatch")
+
+	// ErrInvalidTrip is returned when orders that cannot travel together are
+	// batched onto one machine trip.
+	ErrInvalidTrip = errors.New("orders on a trip must share their pickup address and machine type")
+
+	// ErrNoRoute is returned when a router finds no way between two waypoints,
+	// e.g. when one lies outside its road graph.
+	ErrNoRoute = errors.New("no route found between the waypoints")
+
+	// ErrRestrictedAirspace is returned when a drone would have to take off or
+	// land inside a no-fly zone.
+	ErrRestrictedAirspace = errors.New("the address lies in restricted airspace for drones")
+
+	// ErrInvalidNoFlyZone is returned when no-fly zones cannot be read from
+	// GeoJSON or their limits make no sense.
+	ErrInvalidNoFlyZone = errors.New("invalid no-fly zone")
+
+	// ErrOrderNotInFlight is returned when the ETA of an order is asked for
+	// before it is dispatched or after its last stop was served.
+	ErrOrderNotInFlight = errors.New("order is not on its way")
+
+	// ErrNoMachinePosition is returned when the machine carrying an order has
+	// not reported where it is yet.
+	ErrNoMachinePosition = errors.New("the machine has not reported its position yet")
+
+	// ErrInvalidStops is returned when the dropoffs of a multi-stop order are
+	// repeated, too many, or not addresses of the customer.
+	ErrInvalidStops = errors.New("dropoffs must be distinct addresses of the customer")
+
+	// ErrStopNotPending is returned when a machine reports on a stop that was
+	// already delivered or failed.
+	ErrStopNotPending = errors.New("stop has already been completed")
+
+	// ErrInvalidHandoffPIN is returned when the recipient PIN reported by a
+	// machine does not match the order's handoff code.
+	ErrInvalidHandoffPIN = errors.New("handoff PIN is incorrect")
+
+	// ErrHandoffLocked is returned when too many wrong PINs were entered or the
+	// handoff code expired, so the order can no longer be released by PIN.
+	ErrHandoffLocked = errors.New("handoff is locked, please contact support")
+
+	// ErrOrderNotInTransit is returned when a machine reports a delivery for an
+	// order that it is not currently carrying.
+	ErrOrderNotInTransit = errors.New("order is not in transit")
+
+	// ErrOrderNotAssigned is returned when a machine confirms the pickup of an
+	// order that is not waiting to be collected.
+	ErrOrderNotAssigned = errors.New("order is not waiting for pickup")
+
+	// ErrOrderNotReturning is returned when a machine confirms the return of an
+	// order that is not being carried back to its pickup address.
+	ErrOrderNotReturning = errors.New("order is not being returned")
+
+	// ErrInvalidProofOfDelivery is returned when the evidence submitted for a
+	// delivery does not belong to the order or is out of date.
+	ErrInvalidProofOfDelivery = errors.New("proof of delivery does not match the order")
+
+	// ErrOutsideDropoffRadius is returned when the GPS fix of a delivery is too
+	// far away from the dropoff address.
+	ErrOutsideDropoffRadius = errors.New("delivery location is too far from the dropoff address")
+
 	// ErrCannotSubmitFeedback is returned when a user tries to submit feedback for an order
 	// that is not yet delivered.
 	ErrCannotSubmitFeedback = errors.New("feedback can only be submitted for delivered orders")
@@ -44,7 +129,60 @@ var (
 	// for an order that already has feedback.
 	ErrFeedbackAlreadySubmitted = errors.New("feedback has already been submitted for this order")
 
+	// ErrInsufficientStock is returned when an order asks for more of an item
+	// than is available in the inventory.
+	ErrInsufficientStock = errors.New("insufficient stock for one or more items")
+
+	// ErrHazmatNotAllowed is returned when hazardous materials are booked on a
+	// machine type that is not permitted to carry them.
+	ErrHazmatNotAllowed = errors.New("hazardous materials cannot be carried by this machine type")
+
+	// ErrMachineDecommissioned is returned when a decommissioned machine is
+	// updated or decommissioned again.
+	ErrMachineDecommissioned = errors.New("machine has been decommissioned")
+
+	// ErrMachineInUse is returned when a machine that is carrying orders is
+	// decommissioned.
+	ErrMachineInUse = errors.New("machine is in transit")
+
+	// ErrNoChargingSlot is returned when no charging station for the machine's
+	// type has a free slot.
+	ErrNoChargingSlot = errors.New("no charging slot is free for this machine type")
+
+	// ErrMachineNotIdle is returned when a machine that is busy is sent to charge.
+	ErrMachineNotIdle = errors.New("machine is not idle")
+
+	// ErrWorkOrderStarted is returned when work on a work order is started twice.
+	ErrWorkOrderStarted = errors.New("work order is already in progress")
+
+	// ErrWorkOrderClosed is returned when a completed or cancelled work order
+	// is started or closed again.
+	ErrWorkOrderClosed = errors.New("work order has already been closed")
+
+	// ErrInvalidTransition is returned when a machine is moved to a status it
+	// cannot reach from its current one, such as from IN_TRANSIT to CHARGING.
+	ErrInvalidTransition = errors.New("invalid machine status transition")
+
+	// ErrHubFull is returned when a machine is based at a hub that is at capacity.
+	ErrHubFull = errors.New("hub is at capacity")
+
+	// ErrMachineUnavailable is returned when orders are dispatched to a machine
+	// that is out of service, offline or kept in maintenance.
+	ErrMachineUnavailable = errors.New("machine is not available for dispatch")
+
 	// ErrPackageTooLarge indicates that the weight or dimensions of the requested
 	// delivery exceed what our machines can handle.
 	ErrPackageTooLarge = errors.New("package exceeds allowed weight or dimensions")
+
+	// ErrInvalidManifest is returned when an order or schedule has no items, or
+	// an item without a SKU, a positive quantity or a non-negative value.
+	ErrInvalidManifest = errors.New("every item needs a sku, a positive quantity and a non-negative declared value")
+
+	// ErrInvalidScheduleRule is returned when the recurrence rule of a schedule
+	// cannot be parsed or is not a supported weekly rule.
+	ErrInvalidScheduleRule = errors.New("invalid recurrence rule")
+
+	// ErrUnknownTimezone is returned when a schedule names a timezone that is
+	// not in the IANA database.
+	ErrUnknownTimezone = errors.New("unknown timezone")
 )
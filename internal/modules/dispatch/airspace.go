@@ -0,0 +1,269 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"fmt"
+	"math"
+	"time"
+)
+
+// airspaceMarginMeters is how far around a leg no-fly zones are looked up.
+// Zones reaching into that box are loaded whole, so detours around them can
+// go as far as they need to.
+const airspaceMarginMeters = 2000
+
+// GeofenceAirspace keeps drones out of the no-fly zones in force at cruise
+// altitude. It implements Airspace.
+type GeofenceAirspace struct {
+	repo RepositoryInterface
+}
+
+// NewGeofenceAirspace creates an Airspace over the stored no-fly zones.
+func NewGeofenceAirspace(repo RepositoryInterface) *GeofenceAirspace {
+	return &GeofenceAirspace{repo: repo}
+}
+
+// Detour returns the shortest way from one point to another that keeps out
+// of restricted airspace: the straight line when it is clear, otherwise a
+// line through the corners of the zones in the way, passed at
+// domain.DetourClearanceMeters. It returns models.ErrRestrictedAirspace when
+// either end lies inside a zone, and models.ErrNoRoute when the zones leave
+// no way through.
+func (a *GeofenceAirspace) Detour(ctx context.Context, from, to geo.Point) ([]geo.Point, error) {
+	zones, err := a.zones(ctx, []geo.Point{from, to}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range []geo.Point{from, to} {
+		if zone := zoneContaining(zones, p); zone != nil {
+			return nil, fmt.Errorf("%w: inside %s", models.ErrRestrictedAirspace, zone.Name)
+		}
+	}
+	return planDetour(zones, from, to)
+}
+
+// zones loads the zones in force at cruise altitude around points.
+func (a *GeofenceAirspace) zones(ctx context.Context, points []geo.Point, at time.Time) ([]domain.NoFlyZone, error) {
+	southWest, northEast := points[0], points[0]
+	for _, p := range points[1:] {
+		southWest.Latitude, southWest.Longitude = math.Min(southWest.Latitude, p.Latitude), math.Min(southWest.Longitude, p.Longitude)
+		northEast.Latitude, northEast.Longitude = math.Max(northEast.Latitude, p.Latitude), math.Max(northEast.Longitude, p.Longitude)
+	}
+	dLat := airspaceMarginMeters / metersPerDegree
+	dLng := dLat / math.Max(math.Cos(northEast.Latitude*math.Pi/180), 0.01)
+	southWest.Latitude, southWest.Longitude = southWest.Latitude-dLat, southWest.Longitude-dLng
+	northEast.Latitude, northEast.Longitude = northEast.Latitude+dLat, northEast.Longitude+dLng
+
+	all, err := a.repo.ListNoFlyZones(ctx, southWest, northEast, at)
+	if err != nil {
+		return nil, fmt.Errorf("airspace: %w", err)
+	}
+	zones := all[:0]
+	for _, zone := range all {
+		if zone.RestrictsAltitude(domain.DroneCruiseAltitudeMeters) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+func zoneContaining(zones []domain.NoFlyZone, p geo.Point) *domain.NoFlyZone {
+	for i := range zones {
+		if zones[i].Contains(p) {
+			return &zones[i]
+		}
+	}
+	return nil
+}
+
+// metersPerDegree is the length of a degree of latitude.
+const metersPerDegree = 111320.0
+
+// vec is a point projected onto a plane tangent to the Earth near a leg, in
+// meters east and north. Over the few kilometres of a delivery leg the
+// distortion is negligible.
+type vec struct{ x, y float64 }
+
+type projection struct {
+	origin geo.Point
+	cosLat float64
+}
+
+func newProjection(origin geo.Point) projection {
+	return projection{origin: origin, cosLat: math.Cos(origin.Latitude * math.Pi / 180)}
+}
+
+func (p projection) forward(g geo.Point) vec {
+	return vec{(g.Longitude - p.origin.Longitude) * metersPerDegree * p.cosLat, (g.Latitude - p.origin.Latitude) * metersPerDegree}
+}
+
+func (p projection) inverse(v vec) geo.Point {
+	return geo.Point{Latitude: p.origin.Latitude + v.y/metersPerDegree, Longitude: p.origin.Longitude + v.x/(metersPerDegree*p.cosLat)}
+}
+
+// planDetour finds the shortest path from one point to another around the
+// zones over a visibility graph: the two ends and the corners of every outer
+// ring, pushed outwards by the clearance, joined wherever the line between
+// them stays out of all zones. It returns the corners passed, in order.
+func planDetour(zones []domain.NoFlyZone, from, to geo.Point) ([]geo.Point, error) {
+	proj := newProjection(from)
+	var edges [][2]vec     // Every ring edge, holes included
+	var polygons [][][]vec // Outer ring first, then holes
+	for _, zone := range zones {
+		for _, polygon := range zone.Polygons {
+			rings := make([][]vec, len(polygon))
+			for i, ring := range polygon {
+				rings[i] = make([]vec, len(ring))
+				for k, p := range ring {
+					rings[i][k] = proj.forward(p)
+				}
+				for k := range rings[i] {
+					edges = append(edges, [2]vec{rings[i][k], rings[i][(k+1)%len(ring)]})
+				}
+			}
+			polygons = append(polygons, rings)
+		}
+	}
+
+	inside := func(v vec) bool {
+		for _, rings := range polygons {
+			if !vecRingContains(rings[0], v) {
+				continue
+			}
+			inHole := false
+			for _, hole := range rings[1:] {
+				inHole = inHole || vecRingContains(hole, v)
+			}
+			if !inHole {
+				return true
+			}
+		}
+		return false
+	}
+	visible := func(a, b vec) bool {
+		for _, e := range edges {
+			if segmentsCross(a, b, e[0], e[1]) {
+				return false
+			}
+		}
+		return !inside(vec{(a.x + b.x) / 2, (a.y + b.y) / 2})
+	}
+
+	start, end := proj.forward(from), proj.forward(to)
+	if visible(start, end) {
+		return nil, nil
+	}
+
+	// Nodes: 0 is the start, 1 the end, the rest corners outside every zone.
+	nodes := []vec{start, end}
+	for _, rings := range polygons {
+		for _, corner := range offsetRing(rings[0], domain.DetourClearanceMeters) {
+			if !inside(corner) {
+				nodes = append(nodes, corner)
+			}
+		}
+	}
+
+	// Dijkstra over the complete graph, testing visibility as edges are relaxed.
+	dist := make([]float64, len(nodes))
+	prev := make([]int, len(nodes))
+	done := make([]bool, len(nodes))
+	for i := range dist {
+		dist[i], prev[i] = math.Inf(1), -1
+	}
+	dist[0] = 0
+	for {
+		u := -1
+		for i := range nodes {
+			if !done[i] && !math.IsInf(dist[i], 1) && (u < 0 || dist[i] < dist[u]) {
+				u = i
+			}
+		}
+		if u < 0 {
+			return nil, fmt.Errorf("%w: no-fly zones block the way", models.ErrNoRoute)
+		}
+		if u == 1 {
+			break
+		}
+		done[u] = true
+		for v := range nodes {
+			if done[v] {
+				continue
+			}
+			d := dist[u] + math.Hypot(nodes[v].x-nodes[u].x, nodes[v].y-nodes[u].y)
+			if d < dist[v] && visible(nodes[u], nodes[v]) {
+				dist[v], prev[v] = d, u
+			}
+		}
+	}
+
+	var detour []geo.Point
+	for n := prev[1]; n > 0; n = prev[n] {
+		detour = append(detour, proj.inverse(nodes[n]))
+	}
+	for i, j := 0, len(detour)-1; i < j; i, j = i+1, j-1 {
+		detour[i], detour[j] = detour[j], detour[i]
+	}
+	return detour, nil
+}
+
+// offsetRing returns the corners of a ring pushed outwards along the
+// bisector of their edges, so that both edges are passed at clearance.
+func offsetRing(ring []vec, clearance float64) []vec {
+	// Outward normals point right of the edges of a counter-clockwise ring.
+	var area float64
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%len(ring)]
+		area += a.x*b.y - b.x*a.y
+	}
+	side := 1.0
+	if area < 0 {
+		side = -1
+	}
+	normal := func(a, b vec) vec {
+		dx, dy := b.x-a.x, b.y-a.y
+		l := math.Hypot(dx, dy)
+		if l == 0 {
+			return vec{}
+		}
+		return vec{side * dy / l, -side * dx / l}
+	}
+
+	corners := make([]vec, len(ring))
+	for i, p := range ring {
+		n1 := normal(ring[(i+len(ring)-1)%len(ring)], p)
+		n2 := normal(p, ring[(i+1)%len(ring)])
+		bx, by := n1.x+n2.x, n1.y+n2.y
+		l := math.Hypot(bx, by)
+		if l < 1e-9 {
+			bx, by, l = n1.x, n1.y, 1
+		}
+		// Along the bisector the clearance to each edge shrinks with the
+		// angle between them; stretch it back, within reason at sharp corners.
+		stretch := math.Min(2/l, 4)
+		corners[i] = vec{p.x + bx/l*clearance*stretch, p.y + by/l*clearance*stretch}
+	}
+	return corners
+}
+
+func vecRingContains(ring []vec, p vec) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.y > p.y) != (b.y > p.y) && p.x < (b.x-a.x)*(p.y-a.y)/(b.y-a.y)+a.x {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// segmentsCross reports whether segments ab and cd properly intersect.
+func segmentsCross(a, b, c, d vec) bool {
+	cross := func(o, p, q vec) float64 { return (p.x-o.x)*(q.y-o.y) - (p.y-o.y)*(q.x-o.x) }
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
@@ -0,0 +1,53 @@
+package dispatch
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"fmt"
+	"time"
+)
+
+// Airspace knows where drones may not fly.
+type Airspace interface {
+	// Detour returns the points to fly through between from and to to stay
+	// out of restricted airspace, or nil when the straight line is clear. It
+	// returns models.ErrNoRoute when there is no way around.
+	Detour(ctx context.Context, from, to geo.Point) ([]geo.Point, error)
+}
+
+// AirspaceRouter routes drones in straight lines between waypoints, bent
+// around restricted airspace. Each leg adds domain.DroneTakeoffAndLanding to
+// the flight time.
+type AirspaceRouter struct {
+	airspace Airspace
+}
+
+// NewAirspaceRouter creates a drone router. With a nil airspace, every
+// straight line is flown.
+func NewAirspaceRouter(airspace Airspace) *AirspaceRouter {
+	return &AirspaceRouter{airspace: airspace}
+}
+
+func (r *AirspaceRouter) Route(ctx context.Context, waypoints []geo.Point) (*domain.Path, error) {
+	if err := checkWaypoints(waypoints); err != nil {
+		return nil, err
+	}
+
+	points := []geo.Point{waypoints[0]}
+	for i := 1; i < len(waypoints); i++ {
+		if r.airspace != nil {
+			detour, err := r.airspace.Detour(ctx, waypoints[i-1], waypoints[i])
+			if err != nil {
+				return nil, fmt.Errorf("leg %d: %w", i, err)
+			}
+			points = append(points, detour...)
+		}
+		points = append(points, waypoints[i])
+	}
+
+	path := newPath(domain.ProviderAirspace, points, fleetDomain.CruiseSpeedMetersPerSecond[fleetDomain.MachineTypeDrone])
+	path.Duration += time.Duration(len(waypoints)-1) * domain.DroneTakeoffAndLanding
+	return path, nil
+}
@@ -0,0 +1,151 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"testing"
+	"time"
+)
+
+// box returns the ring of a rectangle between two corners.
+func box(south, west, north, east float64) []geo.Point {
+	return []geo.Point{
+		{Latitude: south, Longitude: west},
+		{Latitude: south, Longitude: east},
+		{Latitude: north, Longitude: east},
+		{Latitude: north, Longitude: west},
+	}
+}
+
+// squareZone is about 1.1 km north to south and 0.9 km east to west.
+var squareZone = domain.NoFlyZone{
+	Name:     "square",
+	Polygons: []domain.Polygon{{box(37.000, -122.005, 37.010, -121.995)}},
+}
+
+// ringZone is squareZone with a hole in the middle, which drones may be in
+// but cannot reach.
+var ringZone = domain.NoFlyZone{
+	Name:     "ring",
+	Polygons: []domain.Polygon{{box(37.000, -122.005, 37.010, -121.995), box(37.003, -122.002, 37.007, -121.998)}},
+}
+
+var (
+	westOfSquare  = geo.Point{Latitude: 37.005, Longitude: -122.010}
+	eastOfSquare  = geo.Point{Latitude: 37.005, Longitude: -121.990}
+	northOfSquare = geo.Point{Latitude: 37.015, Longitude: -122.010}
+	insideSquare  = geo.Point{Latitude: 37.005, Longitude: -122.000}
+)
+
+func TestPlanDetour(t *testing.T) {
+	tests := []struct {
+		name     string
+		zones    []domain.NoFlyZone
+		from, to geo.Point
+		clear    bool // The straight line is clear
+		wantErr  error
+	}{
+		{"no zones", nil, westOfSquare, eastOfSquare, true, nil},
+		{"zone beside the line", []domain.NoFlyZone{squareZone}, northOfSquare, geo.Point{Latitude: 37.015, Longitude: -121.990}, true, nil},
+		{"around a zone", []domain.NoFlyZone{squareZone}, westOfSquare, eastOfSquare, false, nil},
+		{"around a zone, backwards", []domain.NoFlyZone{squareZone}, eastOfSquare, westOfSquare, false, nil},
+		{"around a zone, diagonally", []domain.NoFlyZone{squareZone}, northOfSquare, geo.Point{Latitude: 36.995, Longitude: -121.990}, false, nil},
+		{"start inside a zone", []domain.NoFlyZone{squareZone}, insideSquare, eastOfSquare, false, models.ErrNoRoute},
+		{"end inside a zone", []domain.NoFlyZone{squareZone}, westOfSquare, insideSquare, false, models.ErrNoRoute},
+		{"both ends inside a zone", []domain.NoFlyZone{squareZone}, insideSquare, geo.Point{Latitude: 37.006, Longitude: -122.001}, false, models.ErrNoRoute},
+		{"end enclosed by a zone", []domain.NoFlyZone{ringZone}, westOfSquare, insideSquare, false, models.ErrNoRoute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detour, err := planDetour(tt.zones, tt.from, tt.to)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("planDetour() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("planDetour() error = %v", err)
+			}
+			if tt.clear {
+				if detour != nil {
+					t.Errorf("planDetour() = %v, want nil for a clear line", detour)
+				}
+				return
+			}
+			if len(detour) == 0 {
+				t.Fatal("planDetour() = nil, want a detour")
+			}
+			checkClearOf(t, tt.zones, append(append([]geo.Point{tt.from}, detour...), tt.to))
+		})
+	}
+}
+
+// checkClearOf fails the test when the line through points enters a zone.
+func checkClearOf(t *testing.T, zones []domain.NoFlyZone, points []geo.Point) {
+	t.Helper()
+	const samples = 200
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		for k := 0; k <= samples; k++ {
+			f := float64(k) / samples
+			p := geo.Point{Latitude: a.Latitude + f*(b.Latitude-a.Latitude), Longitude: a.Longitude + f*(b.Longitude-a.Longitude)}
+			if zone := zoneContaining(zones, p); zone != nil {
+				t.Fatalf("leg %d of %v enters %s at %v", i, points, zone.Name, p)
+			}
+		}
+	}
+}
+
+// fakeAirspaceRepo serves a fixed list of no-fly zones.
+type fakeAirspaceRepo struct {
+	RepositoryInterface
+	zones []domain.NoFlyZone
+	err   error
+}
+
+func (r *fakeAirspaceRepo) ListNoFlyZones(context.Context, geo.Point, geo.Point, time.Time) ([]domain.NoFlyZone, error) {
+	return r.zones, r.err
+}
+
+func TestGeofenceAirspaceDetour(t *testing.T) {
+	highZone := squareZone
+	highZone.FloorMeters = domain.DroneCruiseAltitudeMeters + 100
+	errDB := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		repo     *fakeAirspaceRepo
+		from, to geo.Point
+		detour   bool
+		wantErr  error
+	}{
+		{"clear", &fakeAirspaceRepo{}, westOfSquare, eastOfSquare, false, nil},
+		{"around a zone", &fakeAirspaceRepo{zones: []domain.NoFlyZone{squareZone}}, westOfSquare, eastOfSquare, true, nil},
+		{"zone above cruise altitude", &fakeAirspaceRepo{zones: []domain.NoFlyZone{highZone}}, westOfSquare, eastOfSquare, false, nil},
+		{"start inside a zone", &fakeAirspaceRepo{zones: []domain.NoFlyZone{squareZone}}, insideSquare, eastOfSquare, false, models.ErrRestrictedAirspace},
+		{"end inside a zone", &fakeAirspaceRepo{zones: []domain.NoFlyZone{squareZone}}, westOfSquare, insideSquare, false, models.ErrRestrictedAirspace},
+		{"end enclosed by a zone", &fakeAirspaceRepo{zones: []domain.NoFlyZone{ringZone}}, westOfSquare, insideSquare, false, models.ErrNoRoute},
+		{"zones not loaded", &fakeAirspaceRepo{err: errDB}, westOfSquare, eastOfSquare, false, errDB},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detour, err := NewGeofenceAirspace(tt.repo).Detour(context.Background(), tt.from, tt.to)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Detour() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Detour() error = %v", err)
+			}
+			if got := detour != nil; got != tt.detour {
+				t.Errorf("Detour() = %v, want a detour %v", detour, tt.detour)
+			}
+		})
+	}
+}
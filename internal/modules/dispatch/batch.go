@@ -0,0 +1,140 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+)
+
+// DispatchBatch gives the orders in the dispatch queue to machines all at
+// once, picking the pairs whose costs add up to the lowest total rather than
+// serving the oldest order first. At peak, when orders compete for the same
+// machines, this avoids an early order taking the machine a later one is
+// much closer to. Orders whose machine was taken by another dispatcher before
+// they could be assigned, and orders the matching left out, fall back to
+// DispatchPending's one-at-a-time search over the machines still free.
+func (s *Service) DispatchBatch(ctx context.Context, now time.Time) (*domain.DispatchRun, error) {
+	jobs, err := s.orders.ListDispatchQueue(ctx, now, domain.DispatchBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("service.DispatchBatch: %w", err)
+	}
+
+	// 1. Price every order against every machine any of them can use. A
+	// machine's pickup ETA and battery differ per order, so each order keeps
+	// its own candidates, keyed by the machine's column.
+	column := make(map[string]int)
+	jobCandidates := make([]map[int]domain.Candidate, len(jobs))
+	jobCosts := make([]map[int]float64, len(jobs))
+	jobPaths := make([]*domain.Path, len(jobs))
+	for i, job := range jobs {
+		candidates, costs, path, err := s.scoredCandidates(ctx, job, nil)
+		if err != nil {
+			return nil, err
+		}
+		jobPaths[i] = path
+		jobCandidates[i] = make(map[int]domain.Candidate, len(candidates))
+		jobCosts[i] = make(map[int]float64, len(candidates))
+		for k, c := range candidates {
+			j, ok := column[c.MachineID]
+			if !ok {
+				j = len(column)
+				column[c.MachineID] = j
+			}
+			jobCandidates[i][j] = c
+			jobCosts[i][j] = costs[k]
+		}
+	}
+	costs := make([][]float64, len(jobs))
+	for i := range costs {
+		costs[i] = make([]float64, len(column))
+		for j := range costs[i] {
+			costs[i][j] = math.Inf(1)
+		}
+		for j, cost := range jobCosts[i] {
+			costs[i][j] = cost
+		}
+	}
+
+	// 2. Assign the matched pairs, oldest order first
+	run := &domain.DispatchRun{}
+	claimed := make(map[string]bool)
+	var retry []domain.Job
+	attempted := make(map[string]bool) // Orders whose matched machine was taken
+	for i, j := range MatchOptimal(costs) {
+		job := jobs[i]
+		if len(jobCandidates[i]) == 0 {
+			// No machine can take it, or it cannot be routed; the fallback
+			// would find nothing either.
+			run.Unassigned = append(run.Unassigned, job.OrderID)
+			continue
+		}
+		if j < 0 {
+			retry = append(retry, job)
+			continue
+		}
+		c := jobCandidates[i][j]
+		assignment, err := s.assign(ctx, job, &c, costs[i][j], jobPaths[i])
+		if errors.Is(err, models.ErrOrderCannotBeAssigned) {
+			run.Unassigned = append(run.Unassigned, job.OrderID)
+			continue
+		}
+		if err != nil {
+			return run, err
+		}
+		if assignment == nil {
+			attempted[job.OrderID] = true
+			retry = append(retry, job)
+			continue
+		}
+		claimed[c.MachineID] = true
+		run.Assignments = append(run.Assignments, *assignment)
+	}
+
+	// 3. Fall back to one-at-a-time dispatch for the rest
+	for _, job := range retry {
+		assignment, err := s.dispatchJob(ctx, job, claimed)
+		if err != nil {
+			return run, err
+		}
+		if assignment == nil {
+			run.Unassigned = append(run.Unassigned, job.OrderID)
+			continue
+		}
+		if attempted[job.OrderID] {
+			assignment.Attempts++
+		}
+		claimed[assignment.MachineID] = true
+		run.Assignments = append(run.Assignments, *assignment)
+	}
+	return run, nil
+}
+
+// RunBatchDispatcher collects the orders that reach the dispatch queue during
+// each window and dispatches them together at its end, until ctx is
+// cancelled. A longer window gives the matching more orders to trade
+// machines between, at the cost of a longer wait for the first of them.
+func RunBatchDispatcher(ctx context.Context, s ServiceInterface, window time.Duration) {
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		run, err := s.DispatchBatch(ctx, time.Now())
+		if err != nil {
+			log.Printf("ERROR: Batch dispatch run failed: %v", err)
+		}
+		if run != nil && len(run.Assignments) > 0 {
+			log.Printf("INFO: Dispatched a batch of %d orders, %d left in the queue", len(run.Assignments), len(run.Unassigned))
+		}
+	}
+}
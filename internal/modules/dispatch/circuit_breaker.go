@@ -0,0 +1,73 @@
+package dispatch
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops calls to a dependency that keeps failing. After
+// threshold failures in a row it opens and refuses calls for cooldown; then it
+// lets a single call through, and closes again if that one succeeds.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int       // Consecutive failures
+	openUntil time.Time // Zero while closed
+	probing   bool      // A call is trying out the dependency after the cooldown
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow reports whether a call may go ahead. Every allowed call must be
+// followed by success or failure.
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return true
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// success records a call that went through and closes the breaker. It
+// reports whether the breaker was open.
+func (b *circuitBreaker) success() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasOpen := !b.openUntil.IsZero()
+	b.failures, b.openUntil, b.probing = 0, time.Time{}, false
+	return wasOpen
+}
+
+// abandon records a call the caller gave up on, which says nothing about the
+// dependency.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// failure records a failed call. It reports whether the breaker opened
+// because of it; a failed probe opens it for another cooldown.
+func (b *circuitBreaker) failure(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.probing || (b.openUntil.IsZero() && b.failures >= b.threshold) {
+		opened := b.openUntil.IsZero()
+		b.openUntil, b.probing = now.Add(b.cooldown), false
+		return opened
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"time"
+)
+
+// Limits of a dispatch run.
+const (
+	// DispatchLeadTime is how long before its pickup window opens a scheduled
+	// order is dispatched, so that the machine arrives in time.
+	DispatchLeadTime = 15 * time.Minute
+
+	// DispatchBatchSize is the most orders one dispatch run takes from the queue.
+	DispatchBatchSize = 100
+
+	// CandidateLimit is how many of the machines nearest to a pickup are scored.
+	CandidateLimit = 20
+
+	// MaxAssignAttempts is how many candidates are tried, best first, before an
+	// order is left in the queue for the next run.
+	MaxAssignAttempts = 3
+)
+
+// Job is a paid order waiting for a machine.
+type Job struct {
+	OrderID     string
+	MachineType string
+	WeightKg    float64
+	Pickup      geo.Point
+	Dropoffs    []geo.Point // The pending stops, in the order they were placed
+	QueuedAt    time.Time   // When the order became ready for dispatch; older jobs go first
+}
+
+// Candidate is a machine that can take a job, with what scoring needs to know
+// about it.
+type Candidate struct {
+	MachineID             string
+	MachineType           string
+	PickupDistanceMeters  float64
+	PickupETA             time.Duration
+	ProjectedBatteryLevel float64 // Percent left after the trip
+	MaxPayloadKg          float64
+	Load                  int // Orders the machine is already assigned
+}
+
+// ScoreWeights sets how much each factor adds to the cost of giving a job to
+// a candidate. The candidate with the lowest cost is tried first.
+type ScoreWeights struct {
+	PickupETAMinute float64 // Per minute to reach the pickup
+	BatteryUsed     float64 // Per percent of battery the machine ends the trip below full
+	UnusedPayload   float64 // Per share of the payload capacity left unused
+	Load            float64 // Per order the machine is already assigned
+}
+
+// DefaultScoreWeights favour the machine that reaches the pickup first. A
+// machine already carrying an order costs as much as five minutes of travel.
+var DefaultScoreWeights = ScoreWeights{
+	PickupETAMinute: 1,
+	BatteryUsed:     0.05,
+	UnusedPayload:   1,
+	Load:            5,
+}
+
+// Assignment records a job given to a machine by a dispatch run.
+type Assignment struct {
+	OrderID   string        `json:"order_id"`
+	MachineID string        `json:"machine_id"`
+	Cost      float64       `json:"cost"`
+	PickupETA time.Duration `json:"pickup_eta"`
+	Attempts  int           `json:"attempts"` // Candidates tried, including the one that took the job
+}
+
+// DispatchRun is the outcome of one pass over the dispatch queue.
+type DispatchRun struct {
+	Assignments []Assignment `json:"assignments"`
+	Unassigned  []string     `json:"unassigned"` // Orders left in the queue for the next run
+}
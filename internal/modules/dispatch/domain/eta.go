@@ -0,0 +1,77 @@
+package models
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ProviderDirect marks ETAs estimated over straight lines at cruise speed,
+// for machine types without a router or when routing failed.
+const ProviderDirect = "direct"
+
+// ETA calibration. Router durations are scaled by how much longer or shorter
+// past trips took than their routes said, per machine type, region and hour
+// of the week. Sparse buckets are shrunk towards the coarser one they belong
+// to: hour of the week towards the region, the region towards the machine
+// type, and the machine type towards no correction at all.
+const (
+	// ETACalibrationWindow is how far back trips are taken into account.
+	ETACalibrationWindow = 28 * 24 * time.Hour
+
+	// ETAPriorTrips is how many trips the coarser bucket counts for when a
+	// bucket is shrunk towards it.
+	ETAPriorTrips = 10
+
+	// ETARegionDegrees is the size of the grid cells ETA regions are made of,
+	// about 5 km across.
+	ETARegionDegrees = 0.05
+
+	// HoursPerWeek is the number of hour-of-week buckets.
+	HoursPerWeek = 7 * 24
+)
+
+// Trips that took less than MinETAFactor or more than MaxETAFactor times
+// their planned duration are clamped, so that a machine left standing or a
+// route recorded twice cannot drag a bucket along.
+const (
+	MinETAFactor = 0.5
+	MaxETAFactor = 4
+)
+
+// HourOfWeek returns the hour of the week t falls in, from 0 at midnight on
+// Monday to 167. Hours are taken in UTC; within a region the offset to local
+// time is the same for every trip, so the buckets still line up with rush
+// hours.
+func HourOfWeek(t time.Time) int {
+	t = t.UTC()
+	return (int(t.Weekday())+6)%7*24 + t.Hour()
+}
+
+// Region returns the name of the grid cell p lies in.
+func Region(p geo.Point) string {
+	return fmt.Sprintf("%d:%d", int(math.Floor(p.Latitude/ETARegionDegrees)), int(math.Floor(p.Longitude/ETARegionDegrees)))
+}
+
+// TripObservation is a completed delivery from the tracking history: how
+// long its route was planned to take and how long the machine took from
+// leaving the pickup to the last stop.
+type TripObservation struct {
+	MachineType     string
+	Start           geo.Point // First position reported after the pickup
+	StartedAt       time.Time
+	PlannedDuration time.Duration
+	ActualDuration  time.Duration
+}
+
+// ETA is the predicted travel time of a machine along a path.
+type ETA struct {
+	Provider       string // The router the path was computed by
+	DistanceMeters float64
+	RouterDuration time.Duration
+	Factor         float64 // Calibration the router duration was scaled by
+	Trips          int     // Past trips in the finest bucket the factor came from
+	Duration       time.Duration
+	Legs           []time.Duration // Duration of each leg between waypoints
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"time"
+)
+
+// DroneCruiseAltitudeMeters is the altitude above ground drones fly at
+// between takeoff and landing.
+const DroneCruiseAltitudeMeters = 100
+
+// DetourClearanceMeters is how far outside a no-fly zone detours pass its
+// corners.
+const DetourClearanceMeters = 50
+
+// Polygon is a closed outer ring followed by the rings of its holes. Rings do
+// not repeat their first point at the end.
+type Polygon [][]geo.Point
+
+// NoFlyZone is airspace drones must not enter, e.g. around an airport, a
+// prison or a stadium during an event. The restriction covers the altitudes
+// from FloorMeters to CeilingMeters above ground, and, when it is temporary,
+// only applies between ActiveFrom and ActiveUntil.
+type NoFlyZone struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Polygons      []Polygon  `json:"polygons"`
+	FloorMeters   float64    `json:"floor_meters"`
+	CeilingMeters *float64   `json:"ceiling_meters,omitempty"` // Unset for no upper limit
+	ActiveFrom    *time.Time `json:"active_from,omitempty"`
+	ActiveUntil   *time.Time `json:"active_until,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ActiveAt reports whether the restriction applies at t.
+func (z *NoFlyZone) ActiveAt(t time.Time) bool {
+	return (z.ActiveFrom == nil || !t.Before(*z.ActiveFrom)) && (z.ActiveUntil == nil || t.Before(*z.ActiveUntil))
+}
+
+// RestrictsAltitude reports whether the zone covers altitudeMeters.
+func (z *NoFlyZone) RestrictsAltitude(altitudeMeters float64) bool {
+	return altitudeMeters >= z.FloorMeters && (z.CeilingMeters == nil || altitudeMeters <= *z.CeilingMeters)
+}
+
+// Contains reports whether p lies inside the zone.
+func (z *NoFlyZone) Contains(p geo.Point) bool {
+	for _, polygon := range z.Polygons {
+		if polygon.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether p lies inside the outer ring and outside every
+// hole.
+func (pg Polygon) Contains(p geo.Point) bool {
+	if len(pg) == 0 || !ringContains(pg[0], p) {
+		return false
+	}
+	for _, hole := range pg[1:] {
+		if ringContains(hole, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains is the even-odd rule over a ring.
+func ringContains(ring []geo.Point, p geo.Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
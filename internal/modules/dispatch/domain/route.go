@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	usersDomain "dispatch-and-delivery/internal/modules/users/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"time"
+)
 
 // Strategy constants for different routing modes.
 const (
@@ -8,6 +12,9 @@ const (
 	CheapestStrategy = "CHEAPEST"
 )
 
+// Address is a saved address of a user. Addresses are owned by the users module.
+type Address = usersDomain.Address
+
 // Dimensions describes the package size in meters.
 type Dimensions struct {
 	Length float64 `json:"length_m" validate:"required,gt=0"`
@@ -46,8 +53,42 @@ type RouteOption struct {
 type Route struct {
 	ID              string    `json:"id"`
 	OrderID         string    `json:"order_id"`
+	MachineID       string    `json:"machine_id"`
+	Provider        string    `json:"provider"` // The router that computed it
 	Polyline        string    `json:"polyline"`
 	DistanceMeters  int       `json:"distance_meters"`
 	DurationSeconds int       `json:"duration_seconds"`
 	CreatedAt       time.Time `json:"created_at"`
 }
+
+// Routing providers.
+const (
+	ProviderGoogle   = "google"
+	ProviderGraph    = "graph"
+	ProviderAirspace = "airspace"
+)
+
+// DroneTakeoffAndLanding is the time a drone spends climbing to cruise
+// altitude and descending at each end of a leg.
+const DroneTakeoffAndLanding = 45 * time.Second
+
+// Path is a route computed by a router through a list of waypoints.
+type Path struct {
+	Provider       string
+	Points         []geo.Point // Every vertex, waypoints included
+	DistanceMeters float64
+	Duration       time.Duration
+	Polyline       string // Points in the encoded polyline format
+}
+
+// Route returns the route record for an order travelling along p.
+func (p *Path) Route(orderID, machineID string) *Route {
+	return &Route{
+		OrderID:         orderID,
+		MachineID:       machineID,
+		Provider:        p.Provider,
+		Polyline:        p.Polyline,
+		DistanceMeters:  int(p.DistanceMeters + 0.5),
+		DurationSeconds: int(p.Duration.Seconds() + 0.5),
+	}
+}
@@ -0,0 +1,225 @@
+package dispatch
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// ETAModel predicts travel times. It starts from the duration the router of
+// the machine type gives and scales it by a factor learned from how long
+// past trips took compared with their routes. Until it is calibrated, router
+// durations are returned as they are.
+type ETAModel struct {
+	repo    RepositoryInterface
+	routers Routers
+
+	mu          sync.RWMutex
+	calibration *etaCalibration
+}
+
+// NewETAModel creates an uncalibrated ETA model over the dispatch routers.
+func NewETAModel(repo RepositoryInterface, routers Routers) *ETAModel {
+	return &ETAModel{repo: repo, routers: routers, calibration: newETACalibration(nil)}
+}
+
+// Calibrate learns the factors from the trips completed within
+// domain.ETACalibrationWindow before now. It returns the number of trips
+// used.
+func (m *ETAModel) Calibrate(ctx context.Context, now time.Time) (int, error) {
+	trips, err := m.repo.ListTripObservations(ctx, now.Add(-domain.ETACalibrationWindow), now)
+	if err != nil {
+		return 0, fmt.Errorf("eta.Calibrate: %w", err)
+	}
+	calibration := newETACalibration(trips)
+
+	m.mu.Lock()
+	m.calibration = calibration
+	m.mu.Unlock()
+	return len(trips), nil
+}
+
+// Factor returns how much a router duration is scaled by for a trip of
+// machineType leaving start at the given time, and how many past trips the
+// finest bucket behind it holds.
+func (m *ETAModel) Factor(machineType string, start geo.Point, at time.Time) (float64, int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.calibration.factor(machineType, start, at)
+}
+
+// Adjust calibrates a duration computed by a router. It is what quotes set
+// RouteOption.EstimatedDuration from.
+func (m *ETAModel) Adjust(machineType string, start geo.Point, at time.Time, routerDuration time.Duration) time.Duration {
+	factor, _ := m.Factor(machineType, start, at)
+	return time.Duration(float64(routerDuration) * factor)
+}
+
+// EstimateETA predicts how long a machine of machineType leaving at the
+// given time takes through waypoints. When the machine type has no router or
+// routing fails, the path is estimated over straight lines at cruise speed.
+func (m *ETAModel) EstimateETA(ctx context.Context, machineType string, waypoints []geo.Point, at time.Time) (*domain.ETA, error) {
+	if err := checkWaypoints(waypoints); err != nil {
+		return nil, err
+	}
+	path, err := m.route(ctx, machineType, waypoints)
+	if err != nil {
+		return nil, err
+	}
+
+	factor, trips := m.Factor(machineType, waypoints[0], at)
+	eta := &domain.ETA{
+		Provider:       path.Provider,
+		DistanceMeters: path.DistanceMeters,
+		RouterDuration: path.Duration,
+		Factor:         factor,
+		Trips:          trips,
+		Duration:       time.Duration(float64(path.Duration) * factor),
+	}
+
+	// Routers only time the whole path; share it out over the legs by their
+	// straight-line length.
+	legs := make([]float64, len(waypoints)-1)
+	var total float64
+	for i := range legs {
+		legs[i] = geo.DistanceMeters(waypoints[i], waypoints[i+1])
+		total += legs[i]
+	}
+	eta.Legs = make([]time.Duration, len(legs))
+	for i, meters := range legs {
+		share := 1 / float64(len(legs))
+		if total > 0 {
+			share = meters / total
+		}
+		eta.Legs[i] = time.Duration(float64(eta.Duration) * share)
+	}
+	return eta, nil
+}
+
+// route computes the path of machineType through waypoints.
+func (m *ETAModel) route(ctx context.Context, machineType string, waypoints []geo.Point) (*domain.Path, error) {
+	if router, ok := m.routers[machineType]; ok {
+		path, err := router.Route(ctx, waypoints)
+		if err == nil {
+			return path, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		log.Printf("WARN: Routing failed, estimating the ETA over straight lines: %v", err)
+	}
+
+	path := newPath(domain.ProviderDirect, waypoints, fleetDomain.CruiseSpeedMetersPerSecond[machineType])
+	if machineType == fleetDomain.MachineTypeDrone {
+		path.Duration += time.Duration(len(waypoints)-1) * domain.DroneTakeoffAndLanding
+	}
+	return path, nil
+}
+
+// etaBucket sums the log factors of the trips in a bucket. Averaging logs
+// gives the geometric mean, so that a trip taking twice as long and one
+// taking half as long cancel out.
+type etaBucket struct {
+	sumLog float64
+	trips  int
+}
+
+// shrink returns the log factor of the bucket pulled towards prior, the log
+// factor of the coarser bucket it belongs to, by domain.ETAPriorTrips.
+func (b *etaBucket) shrink(prior float64) float64 {
+	if b == nil {
+		return prior
+	}
+	return (b.sumLog + domain.ETAPriorTrips*prior) / (float64(b.trips) + domain.ETAPriorTrips)
+}
+
+type etaRegionKey struct {
+	machineType string
+	region      string
+}
+
+type etaHourKey struct {
+	etaRegionKey
+	hour int
+}
+
+// etaCalibration holds the buckets learned from past trips, from the
+// coarsest to the finest.
+type etaCalibration struct {
+	types   map[string]*etaBucket
+	regions map[etaRegionKey]*etaBucket
+	hours   map[etaHourKey]*etaBucket
+}
+
+func newETACalibration(trips []domain.TripObservation) *etaCalibration {
+	c := &etaCalibration{
+		types:   make(map[string]*etaBucket),
+		regions: make(map[etaRegionKey]*etaBucket),
+		hours:   make(map[etaHourKey]*etaBucket),
+	}
+	add := func(b *etaBucket, logFactor float64) *etaBucket {
+		if b == nil {
+			b = &etaBucket{}
+		}
+		b.sumLog += logFactor
+		b.trips++
+		return b
+	}
+	for _, trip := range trips {
+		if trip.PlannedDuration <= 0 {
+			continue
+		}
+		ratio := float64(trip.ActualDuration) / float64(trip.PlannedDuration)
+		logFactor := math.Log(math.Min(math.Max(ratio, domain.MinETAFactor), domain.MaxETAFactor))
+
+		region := etaRegionKey{machineType: trip.MachineType, region: domain.Region(trip.Start)}
+		hour := etaHourKey{etaRegionKey: region, hour: domain.HourOfWeek(trip.StartedAt)}
+		c.types[trip.MachineType] = add(c.types[trip.MachineType], logFactor)
+		c.regions[region] = add(c.regions[region], logFactor)
+		c.hours[hour] = add(c.hours[hour], logFactor)
+	}
+	return c
+}
+
+func (c *etaCalibration) factor(machineType string, start geo.Point, at time.Time) (float64, int) {
+	region := etaRegionKey{machineType: machineType, region: domain.Region(start)}
+	hour := etaHourKey{etaRegionKey: region, hour: domain.HourOfWeek(at)}
+
+	logFactor := c.types[machineType].shrink(0)
+	logFactor = c.regions[region].shrink(logFactor)
+	logFactor = c.hours[hour].shrink(logFactor)
+
+	trips := 0
+	if b := c.hours[hour]; b != nil {
+		trips = b.trips
+	}
+	return math.Exp(logFactor), trips
+}
+
+// RunETACalibrator recalibrates the ETA model every interval until ctx is
+// cancelled, starting right away.
+func RunETACalibrator(ctx context.Context, m *ETAModel, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		trips, err := m.Calibrate(ctx, time.Now())
+		if err != nil {
+			log.Printf("ERROR: ETA calibration failed: %v", err)
+		} else {
+			log.Printf("INFO: Calibrated ETAs from %d trips", trips)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package dispatch
+
+import (
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// geoJSONGeometry is a GeoJSON Polygon or MultiPolygon.
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// geoJSONFeature is a GeoJSON Feature, or a FeatureCollection of them.
+type geoJSONFeature struct {
+	Type       string           `json:"type"`
+	Geometry   *geoJSONGeometry `json:"geometry"`
+	Properties struct {
+		Name          string     `json:"name"`
+		FloorMeters   float64    `json:"floor_m"`
+		CeilingMeters *float64   `json:"ceiling_m"`
+		ActiveFrom    *time.Time `json:"active_from"`
+		ActiveUntil   *time.Time `json:"active_until"`
+	} `json:"properties"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// ParseNoFlyZones reads no-fly zones from a GeoJSON FeatureCollection or
+// Feature with Polygon or MultiPolygon geometries. The properties of each
+// feature name the zone and set its limits:
+//
+//	{"name": "SFO", "floor_m": 0, "ceiling_m": 1200,
+//	 "active_from": "2026-07-04T18:00:00Z", "active_until": "2026-07-04T23:00:00Z"}
+//
+// Every property but the name is optional.
+func ParseNoFlyZones(data []byte) ([]domain.NoFlyZone, error) {
+	var root geoJSONFeature
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidNoFlyZone, err)
+	}
+	features := []geoJSONFeature{root}
+	if root.Type == "FeatureCollection" {
+		features = root.Features
+	}
+
+	zones := make([]domain.NoFlyZone, 0, len(features))
+	for i, f := range features {
+		if f.Type != "Feature" || f.Geometry == nil {
+			return nil, fmt.Errorf("%w: item %d is not a feature with a geometry", models.ErrInvalidNoFlyZone, i)
+		}
+		polygons, err := parseGeometry(f.Geometry.Type, f.Geometry.Coordinates)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		p := f.Properties
+		zone := domain.NoFlyZone{
+			Name:          p.Name,
+			Polygons:      polygons,
+			FloorMeters:   p.FloorMeters,
+			CeilingMeters: p.CeilingMeters,
+			ActiveFrom:    p.ActiveFrom,
+			ActiveUntil:   p.ActiveUntil,
+		}
+		if err := validateZone(&zone); err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		zones = append(zones, zone)
+	}
+	return zones, nil
+}
+
+// validateZone checks the limits of a zone.
+func validateZone(z *domain.NoFlyZone) error {
+	switch {
+	case z.Name == "":
+		return fmt.Errorf("%w: a zone needs a name", models.ErrInvalidNoFlyZone)
+	case len(z.Polygons) == 0:
+		return fmt.Errorf("%w: zone %q has no area", models.ErrInvalidNoFlyZone, z.Name)
+	case z.FloorMeters < 0 || (z.CeilingMeters != nil && *z.CeilingMeters <= z.FloorMeters):
+		return fmt.Errorf("%w: zone %q needs 0 <= floor_m < ceiling_m", models.ErrInvalidNoFlyZone, z.Name)
+	case z.ActiveFrom != nil && z.ActiveUntil != nil && !z.ActiveUntil.After(*z.ActiveFrom):
+		return fmt.Errorf("%w: zone %q ends before it starts", models.ErrInvalidNoFlyZone, z.Name)
+	}
+	return nil
+}
+
+// parseGeometry reads the coordinates of a GeoJSON Polygon or MultiPolygon.
+func parseGeometry(geometryType string, coordinates json.RawMessage) ([]domain.Polygon, error) {
+	var raw [][][][]float64
+	switch geometryType {
+	case "Polygon":
+		var polygon [][][]float64
+		if err := json.Unmarshal(coordinates, &polygon); err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidNoFlyZone, err)
+		}
+		raw = [][][][]float64{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(coordinates, &raw); err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidNoFlyZone, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported geometry %q", models.ErrInvalidNoFlyZone, geometryType)
+	}
+
+	polygons := make([]domain.Polygon, 0, len(raw))
+	for _, rawPolygon := range raw {
+		if len(rawPolygon) == 0 {
+			return nil, fmt.Errorf("%w: polygon without rings", models.ErrInvalidNoFlyZone)
+		}
+		polygon := make(domain.Polygon, 0, len(rawPolygon))
+		for _, rawRing := range rawPolygon {
+			// GeoJSON positions are [longitude, latitude], and rings are
+			// closed by repeating their first position.
+			ring := make([]geo.Point, 0, len(rawRing))
+			for _, position := range rawRing {
+				if len(position) < 2 {
+					return nil, fmt.Errorf("%w: position needs a longitude and a latitude", models.ErrInvalidNoFlyZone)
+				}
+				p := geo.Point{Latitude: position[1], Longitude: position[0]}
+				if !p.Valid() {
+					return nil, fmt.Errorf("%w: position out of range", models.ErrInvalidNoFlyZone)
+				}
+				ring = append(ring, p)
+			}
+			if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+				ring = ring[:len(ring)-1]
+			}
+			if len(ring) < 3 {
+				return nil, fmt.Errorf("%w: ring needs at least three distinct positions", models.ErrInvalidNoFlyZone)
+			}
+			polygon = append(polygon, ring)
+		}
+		polygons = append(polygons, polygon)
+	}
+	return polygons, nil
+}
+
+// geometryJSON returns polygons as a GeoJSON MultiPolygon.
+func geometryJSON(polygons []domain.Polygon) ([]byte, error) {
+	coordinates := make([][][][]float64, len(polygons))
+	for i, polygon := range polygons {
+		coordinates[i] = make([][][]float64, len(polygon))
+		for j, ring := range polygon {
+			positions := make([][]float64, 0, len(ring)+1)
+			for _, p := range ring {
+				positions = append(positions, []float64{p.Longitude, p.Latitude})
+			}
+			coordinates[i][j] = append(positions, positions[0])
+		}
+	}
+	return json.Marshal(struct {
+		Type        string          `json:"type"`
+		Coordinates [][][][]float64 `json:"coordinates"`
+	}{"MultiPolygon", coordinates})
+}
@@ -0,0 +1,132 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const googleDirectionsURL = "https://maps.googleapis.com/maps/api/directions/json"
+
+// googleMaxWaypoints is how many intermediate waypoints one Directions
+// request may carry.
+const googleMaxWaypoints = 25
+
+// GoogleRouter routes over the Google Directions API. Delivery robots travel
+// on sidewalks, so they are routed in walking mode.
+type GoogleRouter struct {
+	apiKey     string
+	mode       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewGoogleRouter creates a router for the Directions travel mode, e.g.
+// "walking".
+func NewGoogleRouter(apiKey, mode string) *GoogleRouter {
+	return &GoogleRouter{
+		apiKey:     apiKey,
+		mode:       mode,
+		baseURL:    googleDirectionsURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// googleDirectionsResponse is the subset of the Directions response we use.
+type googleDirectionsResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Routes       []struct {
+		OverviewPolyline struct {
+			Points string `json:"points"`
+		} `json:"overview_polyline"`
+		Legs []struct {
+			Distance struct {
+				Value float64 `json:"value"` // Meters
+			} `json:"distance"`
+			Duration struct {
+				Value float64 `json:"value"` // Seconds
+			} `json:"duration"`
+		} `json:"legs"`
+	} `json:"routes"`
+}
+
+func (r *GoogleRouter) Route(ctx context.Context, waypoints []geo.Point) (*domain.Path, error) {
+	if err := checkWaypoints(waypoints); err != nil {
+		return nil, err
+	}
+	if len(waypoints)-2 > googleMaxWaypoints {
+		return nil, fmt.Errorf("%w: more than %d waypoints", models.ErrNoRoute, googleMaxWaypoints)
+	}
+
+	query := url.Values{}
+	query.Set("origin", googleLatLng(waypoints[0]))
+	query.Set("destination", googleLatLng(waypoints[len(waypoints)-1]))
+	if via := waypoints[1 : len(waypoints)-1]; len(via) > 0 {
+		stops := make([]string, len(via))
+		for i, p := range via {
+			stops[i] = googleLatLng(p)
+		}
+		query.Set("waypoints", strings.Join(stops, "|"))
+	}
+	query.Set("mode", r.mode)
+	query.Set("key", r.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading response body: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("google directions: unexpected status %d", resp.StatusCode)
+	}
+	var directions googleDirectionsResponse
+	if err := json.Unmarshal(contents, &directions); err != nil {
+		return nil, fmt.Errorf("google directions: %w", err)
+	}
+
+	switch directions.Status {
+	case "OK":
+	case "ZERO_RESULTS", "NOT_FOUND":
+		return nil, models.ErrNoRoute
+	default:
+		return nil, fmt.Errorf("google directions error (%s): %s", directions.Status, directions.ErrorMessage)
+	}
+	if len(directions.Routes) == 0 {
+		return nil, models.ErrNoRoute
+	}
+
+	route := directions.Routes[0]
+	points, err := geo.DecodePolyline(route.OverviewPolyline.Points)
+	if err != nil {
+		return nil, fmt.Errorf("google directions: %w", err)
+	}
+	path := &domain.Path{Provider: domain.ProviderGoogle, Points: points, Polyline: route.OverviewPolyline.Points}
+	for _, leg := range route.Legs {
+		path.DistanceMeters += leg.Distance.Value
+		path.Duration += time.Duration(leg.Duration.Value * float64(time.Second))
+	}
+	return path, nil
+}
+
+func googleLatLng(p geo.Point) string {
+	return strconv.FormatFloat(p.Latitude, 'f', 6, 64) + "," + strconv.FormatFloat(p.Longitude, 'f', 6, 64)
+}
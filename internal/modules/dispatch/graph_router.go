@@ -0,0 +1,231 @@
+package dispatch
+
+import (
+	"container/heap"
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// MaxSnapMeters is how far a waypoint may lie from the nearest node of the
+// road graph. Waypoints farther away are outside the area the graph covers.
+const MaxSnapMeters = 250
+
+// graphCellDegrees is the size of the grid cells nodes are indexed by for
+// snapping waypoints.
+const graphCellDegrees = 0.002
+
+// RoadGraphFile is the JSON form of a road graph, as exported from the
+// sidewalks, footways and residential streets of an OpenStreetMap extract:
+// the nodes with their coordinates and the ways as lists of node IDs.
+type RoadGraphFile struct {
+	Nodes []struct {
+		ID  int64   `json:"id"`
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"nodes"`
+	Ways []struct {
+		Nodes  []int64 `json:"nodes"`
+		Oneway bool    `json:"oneway,omitempty"`
+	} `json:"ways"`
+}
+
+// RoadGraph is a road network that ground robots are routed over.
+type RoadGraph struct {
+	nodes []geo.Point
+	edges [][]graphEdge      // Outgoing edges of each node
+	cells map[[2]int][]int32 // Nodes in each grid cell
+}
+
+type graphEdge struct {
+	to     int32
+	meters float64
+}
+
+// LoadRoadGraph reads a road graph from a RoadGraphFile.
+func LoadRoadGraph(path string) (*RoadGraph, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file RoadGraphFile
+	if err := json.Unmarshal(contents, &file); err != nil {
+		return nil, fmt.Errorf("road graph %s: %w", path, err)
+	}
+	return NewRoadGraph(&file)
+}
+
+// NewRoadGraph builds a road graph. Ways that refer to unknown nodes are
+// rejected.
+func NewRoadGraph(file *RoadGraphFile) (*RoadGraph, error) {
+	g := &RoadGraph{
+		nodes: make([]geo.Point, len(file.Nodes)),
+		edges: make([][]graphEdge, len(file.Nodes)),
+		cells: make(map[[2]int][]int32),
+	}
+	index := make(map[int64]int32, len(file.Nodes))
+	for i, n := range file.Nodes {
+		index[n.ID] = int32(i)
+		g.nodes[i] = geo.Point{Latitude: n.Lat, Longitude: n.Lon}
+		cell := graphCell(g.nodes[i])
+		g.cells[cell] = append(g.cells[cell], int32(i))
+	}
+	for _, way := range file.Ways {
+		for i := 1; i < len(way.Nodes); i++ {
+			from, ok := index[way.Nodes[i-1]]
+			if !ok {
+				return nil, fmt.Errorf("road graph: unknown node %d", way.Nodes[i-1])
+			}
+			to, ok := index[way.Nodes[i]]
+			if !ok {
+				return nil, fmt.Errorf("road graph: unknown node %d", way.Nodes[i])
+			}
+			meters := geo.DistanceMeters(g.nodes[from], g.nodes[to])
+			g.edges[from] = append(g.edges[from], graphEdge{to: to, meters: meters})
+			if !way.Oneway {
+				g.edges[to] = append(g.edges[to], graphEdge{to: from, meters: meters})
+			}
+		}
+	}
+	return g, nil
+}
+
+func graphCell(p geo.Point) [2]int {
+	return [2]int{int(math.Floor(p.Latitude / graphCellDegrees)), int(math.Floor(p.Longitude / graphCellDegrees))}
+}
+
+// nearest returns the node closest to p within MaxSnapMeters, or -1.
+func (g *RoadGraph) nearest(p geo.Point) int32 {
+	// A cell is narrowest east to west; search enough cells around p to
+	// cover MaxSnapMeters in every direction.
+	cellMeters := graphCellDegrees * 111320 * math.Cos(p.Latitude*math.Pi/180)
+	rings := int(math.Ceil(MaxSnapMeters / math.Max(cellMeters, 1)))
+	centre := graphCell(p)
+
+	best, bestMeters := int32(-1), float64(MaxSnapMeters)
+	for dLat := -rings; dLat <= rings; dLat++ {
+		for dLng := -rings; dLng <= rings; dLng++ {
+			for _, n := range g.cells[[2]int{centre[0] + dLat, centre[1] + dLng}] {
+				if d := geo.DistanceMeters(p, g.nodes[n]); d <= bestMeters {
+					best, bestMeters = n, d
+				}
+			}
+		}
+	}
+	return best
+}
+
+// shortestPath returns the nodes of the shortest path from one node to
+// another, found with A* under the straight-line distance, or nil when the
+// nodes are not connected.
+func (g *RoadGraph) shortestPath(ctx context.Context, from, to int32) ([]int32, error) {
+	dist := map[int32]float64{from: 0}
+	prev := make(map[int32]int32)
+	closed := make(map[int32]bool)
+	open := &graphQueue{{node: from, priority: geo.DistanceMeters(g.nodes[from], g.nodes[to])}}
+
+	for steps := 0; open.Len() > 0; steps++ {
+		if steps%4096 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		current := heap.Pop(open).(graphQueueItem).node
+		if current == to {
+			path := []int32{to}
+			for n := to; n != from; {
+				n = prev[n]
+				path = append(path, n)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path, nil
+		}
+		if closed[current] {
+			continue
+		}
+		closed[current] = true
+
+		for _, e := range g.edges[current] {
+			d := dist[current] + e.meters
+			if known, ok := dist[e.to]; ok && known <= d {
+				continue
+			}
+			dist[e.to], prev[e.to] = d, current
+			heap.Push(open, graphQueueItem{node: e.to, priority: d + geo.DistanceMeters(g.nodes[e.to], g.nodes[to])})
+		}
+	}
+	return nil, nil
+}
+
+type graphQueueItem struct {
+	node     int32
+	priority float64
+}
+
+// graphQueue is a min-heap of nodes by priority.
+type graphQueue []graphQueueItem
+
+func (q graphQueue) Len() int           { return len(q) }
+func (q graphQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q graphQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *graphQueue) Push(x any)        { *q = append(*q, x.(graphQueueItem)) }
+func (q *graphQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// GraphRouter routes ground robots offline over a road graph. Each waypoint
+// is joined to the graph at its nearest node.
+type GraphRouter struct {
+	graph *RoadGraph
+}
+
+// NewGraphRouter creates a router over graph.
+func NewGraphRouter(graph *RoadGraph) *GraphRouter {
+	return &GraphRouter{graph: graph}
+}
+
+func (r *GraphRouter) Route(ctx context.Context, waypoints []geo.Point) (*domain.Path, error) {
+	if err := checkWaypoints(waypoints); err != nil {
+		return nil, err
+	}
+
+	snapped := make([]int32, len(waypoints))
+	for i, p := range waypoints {
+		if snapped[i] = r.graph.nearest(p); snapped[i] < 0 {
+			return nil, fmt.Errorf("%w: waypoint %d is off the road graph", models.ErrNoRoute, i)
+		}
+	}
+
+	points := []geo.Point{waypoints[0]}
+	for i := 1; i < len(waypoints); i++ {
+		nodes, err := r.graph.shortestPath(ctx, snapped[i-1], snapped[i])
+		if err != nil {
+			return nil, err
+		}
+		if nodes == nil {
+			return nil, fmt.Errorf("%w: leg %d is not connected", models.ErrNoRoute, i)
+		}
+		for _, n := range nodes {
+			points = appendPoint(points, r.graph.nodes[n])
+		}
+		points = appendPoint(points, waypoints[i])
+	}
+	return newPath(domain.ProviderGraph, points, fleetDomain.CruiseSpeedMetersPerSecond[fleetDomain.MachineTypeRobot]), nil
+}
+
+// appendPoint appends p unless it repeats the last point.
+func appendPoint(points []geo.Point, p geo.Point) []geo.Point {
+	if len(points) > 0 && points[len(points)-1] == p {
+		return points
+	}
+	return append(points, p)
+}
@@ -0,0 +1,137 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// Nodes of the test road graph, about 111 m apart north to south.
+var (
+	nodeA = geo.Point{Latitude: 37.000, Longitude: -122.000}
+	nodeB = geo.Point{Latitude: 37.001, Longitude: -122.000}
+	nodeC = geo.Point{Latitude: 37.002, Longitude: -122.000}
+	nodeD = geo.Point{Latitude: 37.001, Longitude: -121.999} // East of B, on the long way from A to C
+	nodeE = geo.Point{Latitude: 37.010, Longitude: -122.000} // E and F are cut off from the rest
+	nodeF = geo.Point{Latitude: 37.011, Longitude: -122.000}
+	nodeG = geo.Point{Latitude: 37.020, Longitude: -122.000} // G to H is one way
+	nodeH = geo.Point{Latitude: 37.021, Longitude: -122.000}
+)
+
+func newTestRoadGraph(t *testing.T) *RoadGraph {
+	t.Helper()
+
+	var file RoadGraphFile
+	for i, p := range []geo.Point{nodeA, nodeB, nodeC, nodeD, nodeE, nodeF, nodeG, nodeH} {
+		file.Nodes = append(file.Nodes, struct {
+			ID  int64   `json:"id"`
+			Lat float64 `json:"lat"`
+			Lon float64 `json:"lon"`
+		}{ID: int64(i + 1), Lat: p.Latitude, Lon: p.Longitude})
+	}
+	for _, way := range []struct {
+		nodes  []int64
+		oneway bool
+	}{
+		{[]int64{1, 2, 3}, false},
+		{[]int64{1, 4, 3}, false},
+		{[]int64{5, 6}, false},
+		{[]int64{7, 8}, true},
+	} {
+		file.Ways = append(file.Ways, struct {
+			Nodes  []int64 `json:"nodes"`
+			Oneway bool    `json:"oneway,omitempty"`
+		}{Nodes: way.nodes, Oneway: way.oneway})
+	}
+
+	graph, err := NewRoadGraph(&file)
+	if err != nil {
+		t.Fatalf("NewRoadGraph: %v", err)
+	}
+	return graph
+}
+
+func TestGraphRouterRoute(t *testing.T) {
+	nearA := geo.Point{Latitude: 37.000, Longitude: -122.0003} // About 27 m west of A
+	tests := []struct {
+		name      string
+		waypoints []geo.Point
+		want      []geo.Point
+		wantErr   error
+	}{
+		{"shortest way", []geo.Point{nodeA, nodeC}, []geo.Point{nodeA, nodeB, nodeC}, nil},
+		{"back the same way", []geo.Point{nodeC, nodeA}, []geo.Point{nodeC, nodeB, nodeA}, nil},
+		{"waypoint snapped to its nearest node", []geo.Point{nearA, nodeB}, []geo.Point{nearA, nodeA, nodeB}, nil},
+		{"through several stops", []geo.Point{nodeA, nodeD, nodeC, nodeB}, []geo.Point{nodeA, nodeD, nodeC, nodeB}, nil},
+		{"same node", []geo.Point{nodeA, nodeA}, []geo.Point{nodeA}, nil},
+		{"along a one-way street", []geo.Point{nodeG, nodeH}, []geo.Point{nodeG, nodeH}, nil},
+		{"against a one-way street", []geo.Point{nodeH, nodeG}, nil, models.ErrNoRoute},
+		{"unreachable target", []geo.Point{nodeA, nodeF}, nil, models.ErrNoRoute},
+		{"unreachable later leg", []geo.Point{nodeA, nodeC, nodeE}, nil, models.ErrNoRoute},
+		{"off the graph", []geo.Point{nodeA, {Latitude: 37.5, Longitude: -122}}, nil, models.ErrNoRoute},
+		{"single waypoint", []geo.Point{nodeA}, nil, models.ErrNoRoute},
+	}
+	router := NewGraphRouter(newTestRoadGraph(t))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := router.Route(context.Background(), tt.waypoints)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Route() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Route() error = %v", err)
+			}
+			if !slices.Equal(path.Points, tt.want) {
+				t.Errorf("Route() points = %v, want %v", path.Points, tt.want)
+			}
+			if path.Provider != domain.ProviderGraph {
+				t.Errorf("Route() provider = %q, want %q", path.Provider, domain.ProviderGraph)
+			}
+		})
+	}
+}
+
+func TestGraphRouterRouteDistance(t *testing.T) {
+	router := NewGraphRouter(newTestRoadGraph(t))
+
+	path, err := router.Route(context.Background(), []geo.Point{nodeA, nodeC})
+	if err != nil {
+		t.Fatalf("Route() error = %v", err)
+	}
+	want := geo.DistanceMeters(nodeA, nodeB) + geo.DistanceMeters(nodeB, nodeC)
+	if path.DistanceMeters < want-0.01 || path.DistanceMeters > want+0.01 {
+		t.Errorf("Route() distance = %v, want %v", path.DistanceMeters, want)
+	}
+	if path.Duration <= 0 {
+		t.Errorf("Route() duration = %v, want it positive", path.Duration)
+	}
+}
+
+func TestGraphRouterRouteCancelled(t *testing.T) {
+	router := NewGraphRouter(newTestRoadGraph(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := router.Route(ctx, []geo.Point{nodeA, nodeC}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Route() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestNewRoadGraphRejectsUnknownNodes(t *testing.T) {
+	file := &RoadGraphFile{}
+	file.Ways = append(file.Ways, struct {
+		Nodes  []int64 `json:"nodes"`
+		Oneway bool    `json:"oneway,omitempty"`
+	}{Nodes: []int64{1, 2}})
+
+	if _, err := NewRoadGraph(file); err == nil {
+		t.Error("NewRoadGraph() accepted a way over unknown nodes")
+	}
+}
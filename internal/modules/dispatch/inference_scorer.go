@@ -0,0 +1,174 @@
+package dispatch
+
+import (
+	"context"
+	pb "dispatch-and-delivery/api/proto/inference"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Calls to the inference engine.
+const (
+	// DefaultInferenceTimeout is how long a dispatch run waits for the engine
+	// to score a job before the heuristic takes over.
+	DefaultInferenceTimeout = 300 * time.Millisecond
+
+	// InferenceFailureThreshold is how many calls in a row must fail for the
+	// engine to be left alone for InferenceCooldown.
+	InferenceFailureThreshold = 5
+
+	// InferenceCooldown is how long the engine is left alone once it keeps
+	// failing, before a single call tries it again.
+	InferenceCooldown = 30 * time.Second
+)
+
+// InferenceScorer prices candidates with the external inference engine. When
+// the engine fails, answers late or makes no sense, the job is priced by the
+// fallback scorer instead; after InferenceFailureThreshold failures in a row
+// the engine is skipped for InferenceCooldown.
+type InferenceScorer struct {
+	client   pb.InferenceServiceClient
+	fallback Scorer
+	timeout  time.Duration
+	breaker  *circuitBreaker
+}
+
+// NewInferenceScorer creates a scorer over a connection to the inference
+// engine. Each call is given timeout, or DefaultInferenceTimeout when it is
+// zero. Jobs the engine cannot score are priced by fallback, or by a
+// HeuristicScorer when it is nil.
+func NewInferenceScorer(conn grpc.ClientConnInterface, fallback Scorer, timeout time.Duration) *InferenceScorer {
+	if fallback == nil {
+		fallback = NewHeuristicScorer()
+	}
+	if timeout <= 0 {
+		timeout = DefaultInferenceTimeout
+	}
+	return &InferenceScorer{
+		client:   pb.NewInferenceServiceClient(conn),
+		fallback: fallback,
+		timeout:  timeout,
+		breaker:  newCircuitBreaker(InferenceFailureThreshold, InferenceCooldown),
+	}
+}
+
+func (s *InferenceScorer) Score(ctx context.Context, job domain.Job, candidates []domain.Candidate) ([]float64, error) {
+	if !s.breaker.allow(time.Now()) {
+		return s.fallback.Score(ctx, job, candidates)
+	}
+
+	costs, err := s.score(ctx, job, candidates)
+	if err != nil {
+		if ctx.Err() != nil {
+			s.breaker.abandon()
+			return nil, ctx.Err()
+		}
+		if s.breaker.failure(time.Now()) {
+			log.Printf("ERROR: Inference engine keeps failing, using the heuristic for %v: %v", InferenceCooldown, err)
+		} else {
+			log.Printf("WARN: Inference engine failed to score order %s, using the heuristic: %v", job.OrderID, err)
+		}
+		return s.fallback.Score(ctx, job, candidates)
+	}
+	if s.breaker.success() {
+		log.Printf("INFO: Inference engine is back")
+	}
+	return costs, nil
+}
+
+func (s *InferenceScorer) score(ctx context.Context, job domain.Job, candidates []domain.Candidate) ([]float64, error) {
+	callCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	resp, err := s.client.ScoreCandidates(callCtx, &pb.ScoreCandidatesRequest{
+		Job:        toPBJob(job),
+		Candidates: toPBCandidates(candidates),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Costs) != len(candidates) {
+		return nil, fmt.Errorf("got %d costs for %d candidates", len(resp.Costs), len(candidates))
+	}
+	for i, cost := range resp.Costs {
+		if math.IsNaN(cost) || math.IsInf(cost, 0) || cost < 0 {
+			return nil, fmt.Errorf("cost of candidate %d is %v, not a finite non-negative number", i, cost)
+		}
+	}
+	return resp.Costs, nil
+}
+
+func toPBLocation(p geo.Point) *pb.Location {
+	return &pb.Location{Latitude: p.Latitude, Longitude: p.Longitude}
+}
+
+func fromPBLocation(l *pb.Location) geo.Point {
+	return geo.Point{Latitude: l.GetLatitude(), Longitude: l.GetLongitude()}
+}
+
+func toPBJob(job domain.Job) *pb.Job {
+	res := &pb.Job{
+		OrderId:     job.OrderID,
+		MachineType: job.MachineType,
+		WeightKg:    job.WeightKg,
+		Pickup:      toPBLocation(job.Pickup),
+		QueuedAt:    timestamppb.New(job.QueuedAt),
+	}
+	for _, p := range job.Dropoffs {
+		res.Dropoffs = append(res.Dropoffs, toPBLocation(p))
+	}
+	return res
+}
+
+func fromPBJob(job *pb.Job) domain.Job {
+	res := domain.Job{
+		OrderID:     job.GetOrderId(),
+		MachineType: job.GetMachineType(),
+		WeightKg:    job.GetWeightKg(),
+		Pickup:      fromPBLocation(job.GetPickup()),
+		QueuedAt:    job.GetQueuedAt().AsTime(),
+	}
+	for _, l := range job.GetDropoffs() {
+		res.Dropoffs = append(res.Dropoffs, fromPBLocation(l))
+	}
+	return res
+}
+
+func toPBCandidates(candidates []domain.Candidate) []*pb.Candidate {
+	res := make([]*pb.Candidate, len(candidates))
+	for i, c := range candidates {
+		res[i] = &pb.Candidate{
+			MachineId:             c.MachineID,
+			MachineType:           c.MachineType,
+			PickupDistanceMeters:  c.PickupDistanceMeters,
+			PickupEtaSeconds:      c.PickupETA.Seconds(),
+			ProjectedBatteryLevel: c.ProjectedBatteryLevel,
+			MaxPayloadKg:          c.MaxPayloadKg,
+			Load:                  int32(c.Load),
+		}
+	}
+	return res
+}
+
+func fromPBCandidates(candidates []*pb.Candidate) []domain.Candidate {
+	res := make([]domain.Candidate, len(candidates))
+	for i, c := range candidates {
+		res[i] = domain.Candidate{
+			MachineID:             c.GetMachineId(),
+			MachineType:           c.GetMachineType(),
+			PickupDistanceMeters:  c.GetPickupDistanceMeters(),
+			PickupETA:             time.Duration(c.GetPickupEtaSeconds() * float64(time.Second)),
+			ProjectedBatteryLevel: c.GetProjectedBatteryLevel(),
+			MaxPayloadKg:          c.GetMaxPayloadKg(),
+			Load:                  int(c.GetLoad()),
+		}
+	}
+	return res
+}
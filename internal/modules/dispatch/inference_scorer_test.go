@@ -0,0 +1,212 @@
+package dispatch
+
+import (
+	"context"
+	pb "dispatch-and-delivery/api/proto/inference"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"math"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// scorerFunc adapts a function to the Scorer interface.
+type scorerFunc func(ctx context.Context, job domain.Job, candidates []domain.Candidate) ([]float64, error)
+
+func (f scorerFunc) Score(ctx context.Context, job domain.Job, candidates []domain.Candidate) ([]float64, error) {
+	return f(ctx, job, candidates)
+}
+
+// fallbackCost is what the fallback scorer of the tests prices every
+// candidate at, to tell its answers from the engine's.
+const fallbackCost = 1000
+
+var fallbackScorer = scorerFunc(func(_ context.Context, _ domain.Job, candidates []domain.Candidate) ([]float64, error) {
+	costs := make([]float64, len(candidates))
+	for i := range costs {
+		costs[i] = fallbackCost
+	}
+	return costs, nil
+})
+
+// fakeEngine answers through an InferenceStub with the costs it is set to,
+// and counts the calls that reach it.
+type fakeEngine struct {
+	stub  *InferenceStub
+	costs []float64
+	calls int
+}
+
+// newTestInferenceScorer serves a fake engine over an in-memory connection
+// and returns a scorer calling it with the given timeout.
+func newTestInferenceScorer(t *testing.T, timeout time.Duration) (*InferenceScorer, *fakeEngine) {
+	t.Helper()
+
+	engine := &fakeEngine{costs: []float64{3, 1}}
+	engine.stub = NewInferenceStub(scorerFunc(func(context.Context, domain.Job, []domain.Candidate) ([]float64, error) {
+		engine.calls++
+		return engine.costs, nil
+	}))
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterInferenceServiceServer(srv, engine.stub)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial stub engine: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return NewInferenceScorer(conn, fallbackScorer, timeout), engine
+}
+
+func testJob() (domain.Job, []domain.Candidate) {
+	job := domain.Job{
+		OrderID:     "order-1",
+		MachineType: "ROBOT",
+		WeightKg:    2,
+		Pickup:      geo.Point{Latitude: 37.77, Longitude: -122.42},
+		Dropoffs:    []geo.Point{{Latitude: 37.78, Longitude: -122.41}},
+		QueuedAt:    time.Now(),
+	}
+	candidates := []domain.Candidate{
+		{MachineID: "m-1", MachineType: "ROBOT", PickupDistanceMeters: 300},
+		{MachineID: "m-2", MachineType: "ROBOT", PickupDistanceMeters: 900},
+	}
+	return job, candidates
+}
+
+func score(t *testing.T, s *InferenceScorer) []float64 {
+	t.Helper()
+	job, candidates := testJob()
+	costs, err := s.Score(context.Background(), job, candidates)
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	return costs
+}
+
+func TestInferenceScorerUsesEngine(t *testing.T) {
+	s, engine := newTestInferenceScorer(t, time.Second)
+
+	if got := score(t, s); !slices.Equal(got, engine.costs) {
+		t.Errorf("costs = %v, want the engine's %v", got, engine.costs)
+	}
+}
+
+func TestInferenceScorerFallsBackOnTimeout(t *testing.T) {
+	s, engine := newTestInferenceScorer(t, 20*time.Millisecond)
+	engine.stub.Latency = time.Second
+
+	start := time.Now()
+	got := score(t, s)
+	if !slices.Equal(got, []float64{fallbackCost, fallbackCost}) {
+		t.Errorf("costs = %v, want the fallback's", got)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Score took %v, want it cut off by the timeout", elapsed)
+	}
+}
+
+func TestInferenceScorerRejectsBadCosts(t *testing.T) {
+	tests := []struct {
+		name  string
+		costs []float64
+	}{
+		{"too few costs", []float64{1}},
+		{"too many costs", []float64{1, 2, 3}},
+		{"NaN", []float64{1, math.NaN()}},
+		{"positive infinity", []float64{math.Inf(1), 2}},
+		{"negative infinity", []float64{1, math.Inf(-1)}},
+		{"negative", []float64{-1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, engine := newTestInferenceScorer(t, time.Second)
+			engine.costs = tt.costs
+
+			if got := score(t, s); !slices.Equal(got, []float64{fallbackCost, fallbackCost}) {
+				t.Errorf("costs = %v, want the fallback's", got)
+			}
+			if s.breaker.failures != 1 {
+				t.Errorf("breaker failures = %d, want 1", s.breaker.failures)
+			}
+		})
+	}
+}
+
+func TestInferenceScorerOpensBreaker(t *testing.T) {
+	s, engine := newTestInferenceScorer(t, time.Second)
+	engine.stub.FailureRate = 1
+
+	for i := 0; i < InferenceFailureThreshold; i++ {
+		score(t, s)
+	}
+	if s.breaker.openUntil.IsZero() {
+		t.Fatalf("breaker closed after %d failures, want it open", InferenceFailureThreshold)
+	}
+
+	// While open, the engine is not called even once it recovers.
+	engine.stub.FailureRate = 0
+	if got := score(t, s); !slices.Equal(got, []float64{fallbackCost, fallbackCost}) {
+		t.Errorf("costs = %v, want the fallback's", got)
+	}
+	if engine.calls != 0 {
+		t.Errorf("engine scored %d jobs while the breaker was open, want 0", engine.calls)
+	}
+}
+
+func TestInferenceScorerProbesAfterCooldown(t *testing.T) {
+	tests := []struct {
+		name       string
+		recovered  bool
+		wantClosed bool
+	}{
+		{"engine recovered", true, true},
+		{"engine still failing", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, engine := newTestInferenceScorer(t, time.Second)
+			engine.stub.FailureRate = 1
+			for i := 0; i < InferenceFailureThreshold; i++ {
+				score(t, s)
+			}
+			if s.breaker.openUntil.IsZero() {
+				t.Fatalf("breaker closed after %d failures, want it open", InferenceFailureThreshold)
+			}
+
+			// Let the cooldown run out.
+			s.breaker.openUntil = time.Now().Add(-time.Second)
+			if tt.recovered {
+				engine.stub.FailureRate = 0
+			}
+
+			got := score(t, s)
+			if tt.recovered && !slices.Equal(got, engine.costs) {
+				t.Errorf("costs = %v, want the engine's %v", got, engine.costs)
+			}
+			if !tt.recovered && !slices.Equal(got, []float64{fallbackCost, fallbackCost}) {
+				t.Errorf("costs = %v, want the fallback's", got)
+			}
+			if closed := s.breaker.openUntil.IsZero(); closed != tt.wantClosed {
+				t.Errorf("breaker closed = %v, want %v", closed, tt.wantClosed)
+			}
+			if !tt.wantClosed && !s.breaker.openUntil.After(time.Now()) {
+				t.Errorf("breaker open until %v, want another cooldown", s.breaker.openUntil)
+			}
+		})
+	}
+}
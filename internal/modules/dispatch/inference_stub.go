@@ -0,0 +1,57 @@
+package dispatch
+
+import (
+	"context"
+	pb "dispatch-and-delivery/api/proto/inference"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StubModelVersion is the model version the stub inference engine reports.
+const StubModelVersion = "stub-heuristic"
+
+// InferenceStub is a stand-in for the inference engine that prices
+// candidates with a Scorer, for running dispatch locally without the Python
+// service. Latency and FailureRate let the client's deadlines and circuit
+// breaker be tried out.
+type InferenceStub struct {
+	pb.UnimplementedInferenceServiceServer
+
+	scorer      Scorer
+	Latency     time.Duration // Added to every call
+	FailureRate float64       // Share of calls answered with Unavailable, from 0 to 1
+}
+
+// NewInferenceStub creates a stub engine over scorer, or over a
+// HeuristicScorer when it is nil.
+func NewInferenceStub(scorer Scorer) *InferenceStub {
+	if scorer == nil {
+		scorer = NewHeuristicScorer()
+	}
+	return &InferenceStub{scorer: scorer}
+}
+
+func (s *InferenceStub) ScoreCandidates(ctx context.Context, req *pb.ScoreCandidatesRequest) (*pb.ScoreCandidatesResponse, error) {
+	if req.Job == nil {
+		return nil, status.Error(codes.InvalidArgument, "job is required")
+	}
+	if s.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(s.Latency):
+		}
+	}
+	if s.FailureRate > 0 && rand.Float64() < s.FailureRate {
+		return nil, status.Error(codes.Unavailable, "stub failure")
+	}
+
+	costs, err := s.scorer.Score(ctx, fromPBJob(req.Job), fromPBCandidates(req.Candidates))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ScoreCandidatesResponse{Costs: costs, ModelVersion: StubModelVersion}, nil
+}
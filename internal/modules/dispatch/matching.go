@@ -0,0 +1,139 @@
+package dispatch
+
+import "math"
+
+// infeasibleCost stands in for a pair that cannot be matched while solving,
+// since the solver needs finite costs. It dwarfs any real cost, so such a
+// pair is only chosen when nothing else is left for its row.
+const infeasibleCost = 1e12
+
+// MatchOptimal pairs the rows of a cost matrix with its columns so that the
+// total cost is the lowest possible, using the Hungarian algorithm. A cost of
+// +Inf marks a pair that cannot be matched. It returns, for each row, the
+// index of its column, or -1 when the row is left unmatched. Each column is
+// used at most once.
+//
+// The solver takes O(n²m) time for n rows and m columns with n <= m, so a
+// batch of a hundred orders over a few thousand machines is solved in
+// milliseconds.
+func MatchOptimal(costs [][]float64) []int {
+	rows := len(costs)
+	if rows == 0 {
+		return nil
+	}
+	cols := len(costs[0])
+	if rows > cols {
+		// The solver needs at least as many columns as rows.
+		return invert(MatchOptimal(transpose(costs)), rows)
+	}
+
+	// Hungarian algorithm with potentials, over 1-based indexes; column 0 is
+	// the free column each row search starts from.
+	u := make([]float64, rows+1)
+	v := make([]float64, cols+1)
+	rowOf := make([]int, cols+1) // Row matched to each column, 0 when free
+	way := make([]int, cols+1)
+	for i := 1; i <= rows; i++ {
+		rowOf[0] = i
+		j0 := 0
+		minv := make([]float64, cols+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		used := make([]bool, cols+1)
+		for rowOf[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := rowOf[j0], math.Inf(1), 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := solverCost(costs[i0-1][j-1]) - u[i0] - v[j]; cur < minv[j] {
+					minv[j], way[j] = cur, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[rowOf[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		// Flip the augmenting path back to the free column.
+		for j0 != 0 {
+			j1 := way[j0]
+			rowOf[j0] = rowOf[j1]
+			j0 = j1
+		}
+	}
+
+	match := make([]int, rows)
+	for i := range match {
+		match[i] = -1
+	}
+	for j := 1; j <= cols; j++ {
+		if i := rowOf[j]; i != 0 && !math.IsInf(costs[i-1][j-1], 1) {
+			match[i-1] = j - 1
+		}
+	}
+	return match
+}
+
+// MatchGreedy pairs each row, in order, with the cheapest column no earlier
+// row took. It is what dispatching orders one at a time amounts to, and
+// returns its result in the same form as MatchOptimal.
+func MatchGreedy(costs [][]float64) []int {
+	match := make([]int, len(costs))
+	taken := make(map[int]bool)
+	for i, row := range costs {
+		match[i] = -1
+		best := math.Inf(1)
+		for j, cost := range row {
+			if !taken[j] && cost < best {
+				match[i], best = j, cost
+			}
+		}
+		if match[i] >= 0 {
+			taken[match[i]] = true
+		}
+	}
+	return match
+}
+
+func solverCost(cost float64) float64 {
+	if math.IsInf(cost, 1) {
+		return infeasibleCost
+	}
+	return cost
+}
+
+func transpose(costs [][]float64) [][]float64 {
+	t := make([][]float64, len(costs[0]))
+	for j := range t {
+		t[j] = make([]float64, len(costs))
+		for i := range costs {
+			t[j][i] = costs[i][j]
+		}
+	}
+	return t
+}
+
+// invert turns a column-to-row match into a row-to-column one.
+func invert(match []int, rows int) []int {
+	inverted := make([]int, rows)
+	for i := range inverted {
+		inverted[i] = -1
+	}
+	for j, i := range match {
+		if i >= 0 {
+			inverted[i] = j
+		}
+	}
+	return inverted
+}
@@ -0,0 +1,227 @@
+package dispatch
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var inf = math.Inf(1)
+
+func TestMatchOptimal(t *testing.T) {
+	tests := []struct {
+		name  string
+		costs [][]float64
+		want  []int
+	}{
+		{"empty", nil, nil},
+		{"single pair", [][]float64{{5}}, []int{0}},
+		{
+			"beats greedy",
+			[][]float64{
+				{1, 2},
+				{1, 100},
+			},
+			[]int{1, 0},
+		},
+		{
+			"more columns than rows",
+			[][]float64{
+				{9, 1, 8},
+				{1, 9, 8},
+			},
+			[]int{1, 0},
+		},
+		{
+			"more rows than columns",
+			[][]float64{
+				{4, 1},
+				{1, 4},
+				{2, 2},
+			},
+			[]int{1, 0, -1},
+		},
+		{
+			"more rows than columns, cheapest row left out",
+			[][]float64{
+				{1, 10},
+				{10, 10},
+				{10, 1},
+			},
+			[]int{0, -1, 1},
+		},
+		{"no columns", [][]float64{{}, {}}, []int{-1, -1}},
+		{
+			"infeasible pair avoided",
+			[][]float64{
+				{1, inf},
+				{2, 3},
+			},
+			[]int{0, 1},
+		},
+		{
+			"all infeasible row",
+			[][]float64{
+				{inf, inf},
+				{1, 2},
+			},
+			[]int{-1, 0},
+		},
+		{
+			"all infeasible row, more rows than columns",
+			[][]float64{
+				{inf},
+				{3},
+				{inf},
+			},
+			[]int{-1, 0, -1},
+		},
+		{
+			"rows competing for the only feasible column",
+			[][]float64{
+				{5, inf},
+				{1, inf},
+			},
+			[]int{-1, 0},
+		},
+		{
+			"all infeasible",
+			[][]float64{
+				{inf, inf},
+				{inf, inf},
+			},
+			[]int{-1, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchOptimal(tt.costs); !slices.Equal(got, tt.want) {
+				t.Errorf("MatchOptimal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchGreedy(t *testing.T) {
+	tests := []struct {
+		name  string
+		costs [][]float64
+		want  []int
+	}{
+		{"empty", nil, []int{}},
+		{
+			"first row takes the cheapest",
+			[][]float64{
+				{1, 2},
+				{1, 100},
+			},
+			[]int{0, 1},
+		},
+		{
+			"more rows than columns",
+			[][]float64{
+				{4, 1},
+				{1, 4},
+				{2, 2},
+			},
+			[]int{1, 0, -1},
+		},
+		{
+			"all infeasible row",
+			[][]float64{
+				{inf, inf},
+				{1, 2},
+			},
+			[]int{-1, 0},
+		},
+		{
+			"only feasible column taken",
+			[][]float64{
+				{5, inf},
+				{1, inf},
+			},
+			[]int{0, -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchGreedy(tt.costs); !slices.Equal(got, tt.want) {
+				t.Errorf("MatchGreedy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMatchOptimalIsOptimal compares MatchOptimal against every possible
+// matching of small random matrices, some of their pairs infeasible.
+func TestMatchOptimalIsOptimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 300; n++ {
+		rows, cols := 1+rng.Intn(5), 1+rng.Intn(5)
+		costs := make([][]float64, rows)
+		for i := range costs {
+			costs[i] = make([]float64, cols)
+			for j := range costs[i] {
+				if rng.Float64() < 0.2 {
+					costs[i][j] = inf
+				} else {
+					costs[i][j] = float64(rng.Intn(100))
+				}
+			}
+		}
+
+		match := MatchOptimal(costs)
+		gotPairs, gotCost := matchValue(t, costs, match)
+		wantPairs, wantCost := bestMatch(costs, 0, make([]bool, cols))
+		if gotPairs != wantPairs || gotCost != wantCost {
+			t.Fatalf("MatchOptimal(%v) = %v with %d pairs costing %v, want %d pairs costing %v",
+				costs, match, gotPairs, gotCost, wantPairs, wantCost)
+		}
+	}
+}
+
+// matchValue checks that match is a valid matching of costs and returns its
+// number of pairs and total cost.
+func matchValue(t *testing.T, costs [][]float64, match []int) (int, float64) {
+	t.Helper()
+	if len(match) != len(costs) {
+		t.Fatalf("match has %d rows, want %d", len(match), len(costs))
+	}
+	used := make(map[int]bool)
+	pairs, total := 0, 0.0
+	for i, j := range match {
+		if j < 0 {
+			continue
+		}
+		if used[j] || math.IsInf(costs[i][j], 1) {
+			t.Fatalf("match %v reuses column %d or takes an infeasible pair", match, j)
+		}
+		used[j] = true
+		pairs++
+		total += costs[i][j]
+	}
+	return pairs, total
+}
+
+// bestMatch returns the most pairs rows from row on can be matched in, and
+// the lowest cost of doing so, by trying every matching.
+func bestMatch(costs [][]float64, row int, used []bool) (int, float64) {
+	if row == len(costs) {
+		return 0, 0
+	}
+	bestPairs, bestCost := bestMatch(costs, row+1, used)
+	for j, cost := range costs[row] {
+		if used[j] || math.IsInf(cost, 1) {
+			continue
+		}
+		used[j] = true
+		pairs, total := bestMatch(costs, row+1, used)
+		used[j] = false
+		pairs, total = pairs+1, total+cost
+		if pairs > bestPairs || (pairs == bestPairs && total < bestCost) {
+			bestPairs, bestCost = pairs, total
+		}
+	}
+	return bestPairs, bestCost
+}
@@ -0,0 +1,242 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	ordersDomain "dispatch-and-delivery/internal/modules/orders/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RepositoryInterface defines methods for interacting with dispatch storage.
+type RepositoryInterface interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	WithTx(tx pgx.Tx) *Repository
+
+	CreateRoute(ctx context.Context, route *domain.Route) (*domain.Route, error)
+	FindLatestRoute(ctx context.Context, orderID string) (*domain.Route, error)
+
+	CreateNoFlyZone(ctx context.Context, zone *domain.NoFlyZone) (*domain.NoFlyZone, error)
+	ListNoFlyZones(ctx context.Context, southWest, northEast geo.Point, at time.Time) ([]domain.NoFlyZone, error)
+
+	ListTripObservations(ctx context.Context, from, to time.Time) ([]domain.TripObservation, error)
+}
+
+type DBExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db       *pgxpool.Pool
+	executor DBExecutor
+}
+
+func NewRepository(db *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		db:       db,
+		executor: db,
+	}
+}
+
+// BeginTx starts a new database transaction.
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.db.Begin(ctx)
+}
+
+// WithTx returns a new instance of the Repository that is "scoped" to the provided transaction.
+func (r *Repository) WithTx(tx pgx.Tx) *Repository {
+	return &Repository{
+		db:       r.db,
+		executor: tx,
+	}
+}
+
+const routeColumns = `id, order_id, machine_id, provider, polyline, distance_meters, duration_seconds, created_at`
+
+func (r *Repository) scanRoute(row pgx.Row) (*domain.Route, error) {
+	var route domain.Route
+	err := row.Scan(
+		&route.ID,
+		&route.OrderID,
+		&route.MachineID,
+		&route.Provider,
+		&route.Polyline,
+		&route.DistanceMeters,
+		&route.DurationSeconds,
+		&route.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &route, nil
+}
+
+// CreateRoute stores the route of a dispatched order.
+func (r *Repository) CreateRoute(ctx context.Context, route *domain.Route) (*domain.Route, error) {
+	query := `
+	INSERT INTO routes (order_id, machine_id, provider, polyline, distance_meters, duration_seconds)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING ` + routeColumns
+
+	created, err := r.scanRoute(r.executor.QueryRow(ctx, query,
+		route.OrderID, route.MachineID, route.Provider, route.Polyline, route.DistanceMeters, route.DurationSeconds))
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateRoute: %w", err)
+	}
+	return created, nil
+}
+
+// FindLatestRoute returns the route an order was last dispatched on.
+func (r *Repository) FindLatestRoute(ctx context.Context, orderID string) (*domain.Route, error) {
+	query := `SELECT ` + routeColumns + ` FROM routes WHERE order_id = $1 ORDER BY created_at DESC LIMIT 1`
+
+	route, err := r.scanRoute(r.executor.QueryRow(ctx, query, orderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindLatestRoute: %w", err)
+	}
+	return route, nil
+}
+
+const noFlyZoneColumns = `id, name, ST_AsGeoJSON(area), floor_meters, ceiling_meters, active_from, active_until, created_at`
+
+func (r *Repository) scanNoFlyZone(row pgx.Row) (*domain.NoFlyZone, error) {
+	var zone domain.NoFlyZone
+	var area []byte
+	err := row.Scan(
+		&zone.ID,
+		&zone.Name,
+		&area,
+		&zone.FloorMeters,
+		&zone.CeilingMeters,
+		&zone.ActiveFrom,
+		&zone.ActiveUntil,
+		&zone.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(area, &geometry); err != nil {
+		return nil, err
+	}
+	if zone.Polygons, err = parseGeometry(geometry.Type, geometry.Coordinates); err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// CreateNoFlyZone stores a no-fly zone.
+func (r *Repository) CreateNoFlyZone(ctx context.Context, zone *domain.NoFlyZone) (*domain.NoFlyZone, error) {
+	area, err := geometryJSON(zone.Polygons)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateNoFlyZone: %w", err)
+	}
+
+	query := `
+	INSERT INTO no_fly_zones (name, area, floor_meters, ceiling_meters, active_from, active_until)
+	VALUES ($1, ST_Multi(ST_SetSRID(ST_GeomFromGeoJSON($2), 4326)), $3, $4, $5, $6)
+	RETURNING ` + noFlyZoneColumns
+
+	created, err := r.scanNoFlyZone(r.executor.QueryRow(ctx, query,
+		zone.Name, string(area), zone.FloorMeters, zone.CeilingMeters, zone.ActiveFrom, zone.ActiveUntil))
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateNoFlyZone: %w", err)
+	}
+	return created, nil
+}
+
+// ListNoFlyZones returns the zones in force at that reach into the box
+// between southWest and northEast.
+func (r *Repository) ListNoFlyZones(ctx context.Context, southWest, northEast geo.Point, at time.Time) ([]domain.NoFlyZone, error) {
+	query := `
+	SELECT ` + noFlyZoneColumns + ` FROM no_fly_zones
+	WHERE area && ST_MakeEnvelope($1, $2, $3, $4, 4326)
+		AND (active_from IS NULL OR active_from <= $5)
+		AND (active_until IS NULL OR active_until > $5)
+	ORDER BY id
+	`
+	rows, err := r.executor.Query(ctx, query, southWest.Longitude, southWest.Latitude, northEast.Longitude, northEast.Latitude, at)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListNoFlyZones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []domain.NoFlyZone
+	for rows.Next() {
+		zone, err := r.scanNoFlyZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListNoFlyZones.Scan: %w", err)
+		}
+		zones = append(zones, *zone)
+	}
+	return zones, rows.Err()
+}
+
+// ListTripObservations returns the delivered orders dispatched between from
+// and to, timed from the tracking history: from the first position the
+// machine reported on its way to a stop, after leaving the pickup, to the
+// delivery at the last stop. Each is compared with the latest route of the
+// order. Orders that shared their trip with others are left out, since the
+// machine also served stops their route does not cover.
+func (r *Repository) ListTripObservations(ctx context.Context, from, to time.Time) ([]domain.TripObservation, error) {
+	query := `
+	WITH latest AS (
+		SELECT DISTINCT ON (order_id) order_id, machine_id, duration_seconds, created_at
+		FROM routes
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY order_id, created_at DESC
+	)
+	SELECT m.type, t.latitude, t.longitude, t.created_at, r.duration_seconds,
+		EXTRACT(EPOCH FROM s.delivered_at - t.created_at)
+	FROM latest r
+	JOIN orders o ON o.id = r.order_id AND o.status = $3
+	JOIN machines m ON m.id = r.machine_id
+	JOIN LATERAL (
+		SELECT latitude, longitude, created_at FROM tracking_events
+		WHERE order_id = r.order_id AND machine_id = r.machine_id AND stop_id IS NOT NULL AND created_at >= r.created_at
+		ORDER BY created_at
+		LIMIT 1
+	) t ON TRUE
+	JOIN LATERAL (
+		SELECT MAX(delivered_at) AS delivered_at FROM order_stops WHERE order_id = r.order_id
+	) s ON s.delivered_at > t.created_at
+	WHERE r.duration_seconds > 0
+		AND NOT EXISTS (SELECT 1 FROM orders other WHERE other.trip_id = o.trip_id AND other.id <> o.id)
+	`
+	rows, err := r.executor.Query(ctx, query, from, to, ordersDomain.OrderStatusDelivered)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListTripObservations: %w", err)
+	}
+	defer rows.Close()
+
+	var trips []domain.TripObservation
+	for rows.Next() {
+		var trip domain.TripObservation
+		var plannedSeconds int
+		var actualSeconds float64
+		if err := rows.Scan(&trip.MachineType, &trip.Start.Latitude, &trip.Start.Longitude, &trip.StartedAt,
+			&plannedSeconds, &actualSeconds); err != nil {
+			return nil, fmt.Errorf("repository.ListTripObservations.Scan: %w", err)
+		}
+		trip.PlannedDuration = time.Duration(plannedSeconds) * time.Second
+		trip.ActualDuration = time.Duration(actualSeconds * float64(time.Second))
+		trips = append(trips, trip)
+	}
+	return trips, rows.Err()
+}
@@ -0,0 +1,64 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// Router computes the path a machine travels through waypoints, in the
+// order given. There must be at least two waypoints.
+type Router interface {
+	Route(ctx context.Context, waypoints []geo.Point) (*domain.Path, error)
+}
+
+// Routers holds the router of each machine type.
+type Routers map[string]Router
+
+// Fallback returns a router that asks each router in turn and returns the
+// first path found. It is used to put an offline router in front of a paid
+// online one, which only serves what the offline one cannot.
+func Fallback(routers ...Router) Router {
+	return fallbackRouter(routers)
+}
+
+type fallbackRouter []Router
+
+func (f fallbackRouter) Route(ctx context.Context, waypoints []geo.Point) (*domain.Path, error) {
+	err := models.ErrNoRoute
+	for _, r := range f {
+		var path *domain.Path
+		if path, err = r.Route(ctx, waypoints); err == nil {
+			return path, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if !errors.Is(err, models.ErrNoRoute) {
+			log.Printf("WARN: Router failed, trying the next one: %v", err)
+		}
+	}
+	return nil, err
+}
+
+// newPath completes a path over points with its distance, travel time at
+// speed and encoded polyline.
+func newPath(provider string, points []geo.Point, speedMetersPerSecond float64) *domain.Path {
+	path := &domain.Path{Provider: provider, Points: points, Polyline: geo.EncodePolyline(points)}
+	for i := 1; i < len(points); i++ {
+		path.DistanceMeters += geo.DistanceMeters(points[i-1], points[i])
+	}
+	path.Duration = travelDuration(path.DistanceMeters, speedMetersPerSecond)
+	return path
+}
+
+func checkWaypoints(waypoints []geo.Point) error {
+	if len(waypoints) < 2 {
+		return fmt.Errorf("%w: at least two waypoints are needed", models.ErrNoRoute)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package dispatch
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+)
+
+// Scorer prices giving a job to each of its candidate machines. Lower costs
+// are better.
+type Scorer interface {
+	Score(ctx context.Context, job domain.Job, candidates []domain.Candidate) ([]float64, error)
+}
+
+// HeuristicScorer prices candidates as a weighted sum of the time they need to
+// reach the pickup, the battery the trip uses up, the payload capacity left
+// unused and the orders they already carry.
+type HeuristicScorer struct {
+	Weights domain.ScoreWeights
+}
+
+// NewHeuristicScorer returns a HeuristicScorer with domain.DefaultScoreWeights.
+func NewHeuristicScorer() *HeuristicScorer {
+	return &HeuristicScorer{Weights: domain.DefaultScoreWeights}
+}
+
+func (h *HeuristicScorer) Score(_ context.Context, job domain.Job, candidates []domain.Candidate) ([]float64, error) {
+	costs := make([]float64, len(candidates))
+	for i := range candidates {
+		costs[i] = h.cost(job, &candidates[i])
+	}
+	return costs, nil
+}
+
+func (h *HeuristicScorer) cost(job domain.Job, c *domain.Candidate) float64 {
+	w := h.Weights
+	cost := w.PickupETAMinute * c.PickupETA.Minutes()
+	cost += w.BatteryUsed * (100 - c.ProjectedBatteryLevel)
+	if c.MaxPayloadKg > 0 {
+		cost += w.UnusedPayload * (1 - job.WeightKg/c.MaxPayloadKg)
+	}
+	cost += w.Load * float64(c.Load)
+	return cost
+}
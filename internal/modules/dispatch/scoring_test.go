@@ -0,0 +1,112 @@
+package dispatch
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHeuristicScorerCost(t *testing.T) {
+	weights := domain.ScoreWeights{PickupETAMinute: 1, BatteryUsed: 0.5, UnusedPayload: 10, Load: 5}
+	tests := []struct {
+		name      string
+		weightKg  float64
+		candidate domain.Candidate
+		want      float64
+	}{
+		{
+			"full battery, full load",
+			10,
+			domain.Candidate{ProjectedBatteryLevel: 100, MaxPayloadKg: 10},
+			0,
+		},
+		{
+			"pickup ETA in minutes",
+			10,
+			domain.Candidate{PickupETA: 90 * time.Second, ProjectedBatteryLevel: 100, MaxPayloadKg: 10},
+			1.5,
+		},
+		{
+			"battery used below full",
+			10,
+			domain.Candidate{ProjectedBatteryLevel: 60, MaxPayloadKg: 10},
+			20,
+		},
+		{
+			"unused payload share",
+			2.5,
+			domain.Candidate{ProjectedBatteryLevel: 100, MaxPayloadKg: 10},
+			7.5,
+		},
+		{
+			"unknown payload capacity",
+			2.5,
+			domain.Candidate{ProjectedBatteryLevel: 100},
+			0,
+		},
+		{
+			"orders already carried",
+			10,
+			domain.Candidate{ProjectedBatteryLevel: 100, MaxPayloadKg: 10, Load: 2},
+			10,
+		},
+		{
+			"all factors",
+			5,
+			domain.Candidate{PickupETA: 3 * time.Minute, ProjectedBatteryLevel: 80, MaxPayloadKg: 10, Load: 1},
+			3 + 10 + 5 + 5,
+		},
+	}
+	h := &HeuristicScorer{Weights: weights}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := h.cost(domain.Job{WeightKg: tt.weightKg}, &tt.candidate)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("cost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeuristicScorerRanksCloserMachineFirst(t *testing.T) {
+	job := domain.Job{WeightKg: 2}
+	candidates := []domain.Candidate{
+		{MachineID: "far", PickupETA: 12 * time.Minute, ProjectedBatteryLevel: 90, MaxPayloadKg: 10},
+		{MachineID: "near", PickupETA: 2 * time.Minute, ProjectedBatteryLevel: 90, MaxPayloadKg: 10},
+		{MachineID: "near-busy", PickupETA: 2 * time.Minute, ProjectedBatteryLevel: 90, MaxPayloadKg: 10, Load: 3},
+	}
+
+	costs, err := NewHeuristicScorer().Score(context.Background(), job, candidates)
+	if err != nil {
+		t.Fatalf("Score: %v", err)
+	}
+	if len(costs) != len(candidates) {
+		t.Fatalf("got %d costs for %d candidates", len(costs), len(candidates))
+	}
+	if !(costs[1] < costs[0] && costs[1] < costs[2]) {
+		t.Errorf("costs = %v, want the idle machine near the pickup cheapest", costs)
+	}
+}
+
+func TestTravelDuration(t *testing.T) {
+	tests := []struct {
+		name   string
+		meters float64
+		speed  float64
+		want   time.Duration
+	}{
+		{"cruise", 1000, 10, 100 * time.Second},
+		{"no distance", 0, 10, 0},
+		{"unknown speed", 1000, 0, 0},
+		{"negative speed", 1000, -5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := travelDuration(tt.meters, tt.speed); got != tt.want {
+				t.Errorf("travelDuration(%v, %v) = %v, want %v", tt.meters, tt.speed, got, tt.want)
+			}
+		})
+	}
+}
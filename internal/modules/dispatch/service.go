@@ -0,0 +1,292 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	ordersDomain "dispatch-and-delivery/internal/modules/orders/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// ServiceInterface is the dispatch engine.
+type ServiceInterface interface {
+	DispatchPending(ctx context.Context, now time.Time) (*domain.DispatchRun, error)
+	DispatchBatch(ctx context.Context, now time.Time) (*domain.DispatchRun, error)
+}
+
+// Orders is the dispatch queue. It is implemented by the orders module.
+type Orders interface {
+	ListDispatchQueue(ctx context.Context, now time.Time, limit int) ([]domain.Job, error)
+	CountAssignedOrders(ctx context.Context, machineIDs []string) (map[string]int, error)
+	DispatchOrder(ctx context.Context, orderID, machineID string) (*ordersDomain.Order, error)
+}
+
+// Fleet finds the machines that can take a job. It is implemented by the
+// fleet module.
+type Fleet interface {
+	FindNearbyMachines(ctx context.Context, q fleetDomain.NearbyMachinesQuery) ([]fleetDomain.NearbyMachine, error)
+}
+
+type Service struct {
+	repo    RepositoryInterface
+	orders  Orders
+	fleet   Fleet
+	scorer  Scorer
+	routers Routers
+}
+
+// NewService creates the dispatch engine. Candidates are priced by scorer,
+// or by a HeuristicScorer when it is nil. Orders are routed by the router of
+// their machine type before they are dispatched, and not routed when it has
+// none.
+func NewService(repo RepositoryInterface, orders Orders, fleet Fleet, scorer Scorer, routers Routers) ServiceInterface {
+	if scorer == nil {
+		scorer = NewHeuristicScorer()
+	}
+	return &Service{repo: repo, orders: orders, fleet: fleet, scorer: scorer, routers: routers}
+}
+
+// DispatchPending gives the orders in the dispatch queue to machines, oldest
+// first. Each order goes to the cheapest of the idle machines around its
+// pickup that can carry it and finish the trip above the battery safety
+// reserve. When the assignment fails because another dispatcher took the
+// machine first, the next cheapest machine is tried, up to
+// domain.MaxAssignAttempts. Orders that find no machine stay in the queue.
+func (s *Service) DispatchPending(ctx context.Context, now time.Time) (*domain.DispatchRun, error) {
+	jobs, err := s.orders.ListDispatchQueue(ctx, now, domain.DispatchBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("service.DispatchPending: %w", err)
+	}
+
+	run := &domain.DispatchRun{}
+	claimed := make(map[string]bool) // Machines given an order during this run
+	for _, job := range jobs {
+		assignment, err := s.dispatchJob(ctx, job, claimed)
+		if err != nil {
+			return run, err
+		}
+		if assignment == nil {
+			run.Unassigned = append(run.Unassigned, job.OrderID)
+			continue
+		}
+		claimed[assignment.MachineID] = true
+		run.Assignments = append(run.Assignments, *assignment)
+	}
+	return run, nil
+}
+
+// dispatchJob assigns a job to its best candidate that is still free. It
+// returns nil when no candidate took the job.
+func (s *Service) dispatchJob(ctx context.Context, job domain.Job, claimed map[string]bool) (*domain.Assignment, error) {
+	candidates, costs, path, err := s.scoredCandidates(ctx, job, claimed)
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+
+	ranked := make([]int, len(candidates))
+	for i := range ranked {
+		ranked[i] = i
+	}
+	sort.SliceStable(ranked, func(a, b int) bool { return costs[ranked[a]] < costs[ranked[b]] })
+
+	for attempt, idx := range ranked[:min(len(ranked), domain.MaxAssignAttempts)] {
+		assignment, err := s.assign(ctx, job, &candidates[idx], costs[idx], path)
+		if err != nil {
+			if errors.Is(err, models.ErrOrderCannotBeAssigned) {
+				return nil, nil // Assigned, cancelled or refunded since it was queued
+			}
+			return nil, err
+		}
+		if assignment != nil {
+			assignment.Attempts = attempt + 1
+			return assignment, nil
+		}
+	}
+	return nil, nil
+}
+
+// assign gives a job to candidate c and stores the route it was priced with,
+// if any. It returns nil when the machine was taken by another dispatcher or
+// went out of service since the search, and models.ErrOrderCannotBeAssigned
+// when the order left the queue.
+func (s *Service) assign(ctx context.Context, job domain.Job, c *domain.Candidate, cost float64, path *domain.Path) (*domain.Assignment, error) {
+	_, err := s.orders.DispatchOrder(ctx, job.OrderID, c.MachineID)
+	switch {
+	case err == nil:
+		if path != nil {
+			if _, err := s.repo.CreateRoute(ctx, path.Route(job.OrderID, c.MachineID)); err != nil {
+				log.Printf("ERROR: Failed to store the route of order %s: %v", job.OrderID, err)
+			}
+		}
+		return &domain.Assignment{
+			OrderID:   job.OrderID,
+			MachineID: c.MachineID,
+			Cost:      cost,
+			PickupETA: c.PickupETA,
+			Attempts:  1,
+		}, nil
+	case errors.Is(err, models.ErrMachineNotIdle), errors.Is(err, models.ErrMachineUnavailable),
+		errors.Is(err, models.ErrNotFound):
+		log.Printf("WARN: Machine %s could not take order %s: %v", c.MachineID, job.OrderID, err)
+		return nil, nil
+	case errors.Is(err, models.ErrOrderCannotBeAssigned):
+		return nil, err
+	default:
+		return nil, fmt.Errorf("service.assign.DispatchOrder: %w", err)
+	}
+}
+
+// routeJob routes a job from its pickup through its stops, in the order
+// SequenceStops visits them. It returns nil when the job's machine type has
+// no router.
+func (s *Service) routeJob(ctx context.Context, job domain.Job) (*domain.Path, error) {
+	router, ok := s.routers[job.MachineType]
+	if !ok || len(job.Dropoffs) == 0 {
+		return nil, nil
+	}
+	order, _ := SequenceStops(job.Pickup, job.Dropoffs)
+	waypoints := []geo.Point{job.Pickup}
+	for _, idx := range order {
+		waypoints = append(waypoints, job.Dropoffs[idx])
+	}
+	return router.Route(ctx, waypoints)
+}
+
+// scoredCandidates returns a job's candidates along with their costs and the
+// route of the job. A job that cannot be routed, because its stops are not
+// connected or lie in restricted airspace, has no candidates and stays in the
+// queue. So does a drone job whose routing failed otherwise, until a later
+// run: a drone is never sent along a way that was not checked against the
+// no-fly zones. Other jobs are dispatched without a route when their router
+// fails, on the straight-line estimate their candidates were checked with.
+func (s *Service) scoredCandidates(ctx context.Context, job domain.Job, claimed map[string]bool) ([]domain.Candidate, []float64, *domain.Path, error) {
+	candidates, err := s.candidates(ctx, job, claimed)
+	if err != nil || len(candidates) == 0 {
+		return nil, nil, nil, err
+	}
+	path, err := s.routeJob(ctx, job)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, nil, ctx.Err()
+		}
+		switch {
+		case errors.Is(err, models.ErrNoRoute) || errors.Is(err, models.ErrRestrictedAirspace):
+			log.Printf("WARN: Order %s cannot be routed, leaving it queued: %v", job.OrderID, err)
+			return nil, nil, nil, nil
+		case job.MachineType == fleetDomain.MachineTypeDrone:
+			log.Printf("ERROR: Failed to route order %s, leaving it queued: %v", job.OrderID, err)
+			return nil, nil, nil, nil
+		}
+		log.Printf("WARN: Failed to route order %s, dispatching it on the straight-line estimate: %v", job.OrderID, err)
+		path = nil
+	}
+	costs, err := s.scorer.Score(ctx, job, candidates)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("service.scoredCandidates.Score: %w", err)
+	}
+	if len(costs) != len(candidates) {
+		return nil, nil, nil, fmt.Errorf("service.scoredCandidates.Score: got %d costs for %d candidates", len(costs), len(candidates))
+	}
+	return candidates, costs, path, nil
+}
+
+// candidates returns the idle machines near the job's pickup that can carry
+// it and keep the battery safety reserve over the whole trip.
+func (s *Service) candidates(ctx context.Context, job domain.Job, claimed map[string]bool) ([]domain.Candidate, error) {
+	nearby, err := s.fleet.FindNearbyMachines(ctx, fleetDomain.NearbyMachinesQuery{
+		Pickup:          job.Pickup,
+		MachineType:     job.MachineType,
+		MinBatteryLevel: fleetDomain.SafetyReserve,
+		PayloadKg:       job.WeightKg,
+		Limit:           domain.CandidateLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.candidates.FindNearbyMachines: %w", err)
+	}
+
+	var tripMeters float64
+	_, legs := SequenceStops(job.Pickup, job.Dropoffs)
+	for _, leg := range legs {
+		tripMeters += leg
+	}
+
+	candidates := make([]domain.Candidate, 0, len(nearby))
+	machineIDs := make([]string, 0, len(nearby))
+	for i := range nearby {
+		m := &nearby[i].Machine
+		if claimed[m.ID] {
+			continue
+		}
+		check := fleetDomain.CheckTrip(m, fleetDomain.TripEnergyRequest{
+			DistanceMeters: tripMeters,
+			PayloadKg:      job.WeightKg,
+			Pickup:         &job.Pickup,
+		})
+		if !check.Feasible {
+			continue
+		}
+		candidates = append(candidates, domain.Candidate{
+			MachineID:             m.ID,
+			MachineType:           m.Type,
+			PickupDistanceMeters:  check.PickupDistanceMeters,
+			PickupETA:             travelTime(m.Type, check.PickupDistanceMeters),
+			ProjectedBatteryLevel: check.ProjectedBatteryLevel,
+			MaxPayloadKg:          fleetDomain.MaxPayloadKg[m.Type],
+		})
+		machineIDs = append(machineIDs, m.ID)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	loads, err := s.orders.CountAssignedOrders(ctx, machineIDs)
+	if err != nil {
+		return nil, fmt.Errorf("service.candidates.CountAssignedOrders: %w", err)
+	}
+	for i := range candidates {
+		candidates[i].Load = loads[candidates[i].MachineID]
+	}
+	return candidates, nil
+}
+
+// travelTime is how long a machine of machineType takes to cover meters at
+// its cruise speed.
+func travelTime(machineType string, meters float64) time.Duration {
+	return travelDuration(meters, fleetDomain.CruiseSpeedMetersPerSecond[machineType])
+}
+
+func travelDuration(meters, speedMetersPerSecond float64) time.Duration {
+	if speedMetersPerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(meters / speedMetersPerSecond * float64(time.Second))
+}
+
+// RunDispatcher dispatches the queued orders every interval until ctx is
+// cancelled.
+func RunDispatcher(ctx context.Context, s ServiceInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := s.DispatchPending(ctx, time.Now())
+		if err != nil {
+			log.Printf("ERROR: Dispatch run failed: %v", err)
+		}
+		if run != nil && len(run.Assignments) > 0 {
+			log.Printf("INFO: Dispatched %d orders, %d left in the queue", len(run.Assignments), len(run.Unassigned))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
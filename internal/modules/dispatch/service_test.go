@@ -0,0 +1,81 @@
+package dispatch
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeFleet finds one fully charged machine of the job's type next to its pickup.
+type fakeFleet struct{}
+
+func (fakeFleet) FindNearbyMachines(_ context.Context, q fleetDomain.NearbyMachinesQuery) ([]fleetDomain.NearbyMachine, error) {
+	return []fleetDomain.NearbyMachine{{Machine: fleetDomain.Machine{
+		ID: "machine-1", Type: q.MachineType, Status: fleetDomain.StatusIdle, BatteryLevel: 100,
+		Latitude: q.Pickup.Latitude, Longitude: q.Pickup.Longitude + 0.001,
+	}}}, nil
+}
+
+// fakeOrders has no orders assigned to any machine.
+type fakeOrders struct {
+	Orders
+}
+
+func (fakeOrders) CountAssignedOrders(context.Context, []string) (map[string]int, error) {
+	return nil, nil
+}
+
+type routerFunc func(ctx context.Context, waypoints []geo.Point) (*domain.Path, error)
+
+func (f routerFunc) Route(ctx context.Context, waypoints []geo.Point) (*domain.Path, error) {
+	return f(ctx, waypoints)
+}
+
+func TestScoredCandidatesRoutingFailures(t *testing.T) {
+	outage := errors.New("routing service: 503 Service Unavailable")
+	tests := []struct {
+		name        string
+		machineType string
+		routeErr    error
+		dispatched  bool
+	}{
+		{"robot during a router outage", fleetDomain.MachineTypeRobot, outage, true},
+		{"robot without a route", fleetDomain.MachineTypeRobot, models.ErrNoRoute, false},
+		{"drone during a router outage", fleetDomain.MachineTypeDrone, outage, false},
+		{"drone into restricted airspace", fleetDomain.MachineTypeDrone, models.ErrRestrictedAirspace, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failing := routerFunc(func(context.Context, []geo.Point) (*domain.Path, error) { return nil, tt.routeErr })
+			s := &Service{
+				orders:  fakeOrders{},
+				fleet:   fakeFleet{},
+				scorer:  NewHeuristicScorer(),
+				routers: Routers{tt.machineType: failing},
+			}
+			job := domain.Job{
+				OrderID:     "order-1",
+				MachineType: tt.machineType,
+				Pickup:      geo.Point{Latitude: 52.5200, Longitude: 13.4050},
+				Dropoffs:    []geo.Point{{Latitude: 52.5250, Longitude: 13.4100}},
+				QueuedAt:    time.Now(),
+			}
+
+			candidates, costs, path, err := s.scoredCandidates(context.Background(), job, nil)
+			if err != nil {
+				t.Fatalf("scoredCandidates: %v", err)
+			}
+			if path != nil {
+				t.Errorf("path = %+v, want none from a failing router", path)
+			}
+			if got := len(candidates) > 0 && len(costs) == len(candidates); got != tt.dispatched {
+				t.Errorf("got %d candidates with %d costs, want dispatched = %v", len(candidates), len(costs), tt.dispatched)
+			}
+		})
+	}
+}
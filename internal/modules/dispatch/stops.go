@@ -0,0 +1,76 @@
+// Package dispatch decides which machine serves which order and in what order
+// the stops of a trip are visited.
+package dispatch
+
+import "dispatch-and-delivery/pkg/geo"
+
+// SequenceStops returns the order in which to visit stops when starting at
+// start, as indexes into stops, and the straight-line length of each leg.
+// It builds a nearest-neighbour tour and improves it with 2-opt moves, which
+// is close to optimal for the handful of stops a single machine trip carries.
+// The trip ends at the last stop; the way back is not part of the route.
+func SequenceStops(start geo.Point, stops []geo.Point) (order []int, legs []float64) {
+	order = nearestNeighbourTour(start, stops)
+	twoOpt(start, stops, order)
+
+	legs = make([]float64, len(order))
+	prev := start
+	for i, idx := range order {
+		legs[i] = geo.DistanceMeters(prev, stops[idx])
+		prev = stops[idx]
+	}
+	return order, legs
+}
+
+func nearestNeighbourTour(start geo.Point, stops []geo.Point) []int {
+	visited := make([]bool, len(stops))
+	order := make([]int, 0, len(stops))
+	current := start
+	for range stops {
+		next, best := -1, 0.0
+		for i, p := range stops {
+			if visited[i] {
+				continue
+			}
+			if d := geo.DistanceMeters(current, p); next == -1 || d < best {
+				next, best = i, d
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+		current = stops[next]
+	}
+	return order
+}
+
+// twoOpt reverses segments of the open tour in place for as long as that
+// shortens it.
+func twoOpt(start geo.Point, stops []geo.Point, order []int) {
+	at := func(i int) geo.Point {
+		if i < 0 {
+			return start
+		}
+		return stops[order[i]]
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				// Replace the edges (i-1, i) and (j, j+1) with (i-1, j) and (i, j+1).
+				before := geo.DistanceMeters(at(i-1), at(i))
+				after := geo.DistanceMeters(at(i-1), at(j))
+				if j+1 < len(order) {
+					before += geo.DistanceMeters(at(j), at(j+1))
+					after += geo.DistanceMeters(at(i), at(j+1))
+				}
+				if after < before-1e-6 {
+					for l, r := i, j; l < r; l, r = l+1, r-1 {
+						order[l], order[r] = order[r], order[l]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package dispatch
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"math"
+	"slices"
+	"testing"
+)
+
+// tourLength returns the length of visiting stops in order from start.
+func tourLength(start geo.Point, stops []geo.Point, order []int) float64 {
+	total := 0.0
+	prev := start
+	for _, i := range order {
+		total += geo.DistanceMeters(prev, stops[i])
+		prev = stops[i]
+	}
+	return total
+}
+
+func TestSequenceStopsVisitsAlongTheLine(t *testing.T) {
+	start := geo.Point{Latitude: 52.50, Longitude: 13.40}
+	stops := []geo.Point{
+		{Latitude: 52.53, Longitude: 13.40},
+		{Latitude: 52.51, Longitude: 13.40},
+		{Latitude: 52.54, Longitude: 13.40},
+		{Latitude: 52.52, Longitude: 13.40},
+	}
+
+	order, legs := SequenceStops(start, stops)
+	if want := []int{1, 3, 0, 2}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if len(legs) != len(stops) {
+		t.Fatalf("got %d legs, want one per stop", len(legs))
+	}
+	total := 0.0
+	for _, leg := range legs {
+		total += leg
+	}
+	if want := geo.DistanceMeters(start, stops[2]); math.Abs(total-want) > 1 {
+		t.Errorf("total leg length = %.0f m, want the straight line of %.0f m", total, want)
+	}
+}
+
+func TestSequenceStopsImprovesNearestNeighbour(t *testing.T) {
+	// Nearest neighbour goes east to the close stop first and has to come back
+	// across the start; 2-opt must not end up longer than that tour.
+	start := geo.Point{Latitude: 0, Longitude: 0}
+	stops := []geo.Point{
+		{Latitude: 0, Longitude: 0.010},
+		{Latitude: 0, Longitude: -0.011},
+		{Latitude: 0, Longitude: -0.020},
+		{Latitude: 0.005, Longitude: 0.015},
+	}
+
+	nn := nearestNeighbourTour(start, stops)
+	order, _ := SequenceStops(start, stops)
+
+	seen := slices.Clone(order)
+	slices.Sort(seen)
+	if !slices.Equal(seen, []int{0, 1, 2, 3}) {
+		t.Fatalf("order = %v, want every stop visited once", order)
+	}
+	if got, limit := tourLength(start, stops, order), tourLength(start, stops, nn); got > limit+1e-6 {
+		t.Errorf("sequenced tour is %.0f m, longer than the nearest-neighbour tour of %.0f m", got, limit)
+	}
+}
+
+func TestSequenceStopsEmpty(t *testing.T) {
+	order, legs := SequenceStops(geo.Point{}, nil)
+	if len(order) != 0 || len(legs) != 0 {
+		t.Errorf("SequenceStops without stops = %v, %v, want nothing", order, legs)
+	}
+}
@@ -0,0 +1,219 @@
+package fleet
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+func (s *Service) CreateChargingStation(ctx context.Context, req domain.CreateChargingStationRequest) (*domain.ChargingStation, error) {
+	station, err := s.machineRepo.CreateChargingStation(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("service.CreateChargingStation: %w", err)
+	}
+	return station, nil
+}
+
+// ListChargingStations returns the charging stations with their slots in use,
+// only those for machineType when it is set.
+func (s *Service) ListChargingStations(ctx context.Context, machineType string) ([]domain.ChargingStation, error) {
+	stations, err := s.machineRepo.ListChargingStations(ctx, machineType)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListChargingStations: %w", err)
+	}
+	return stations, nil
+}
+
+// ActiveChargingReservation returns the charging slot a machine holds.
+func (s *Service) ActiveChargingReservation(ctx context.Context, machineID string) (*domain.ChargingReservation, error) {
+	reservation, err := s.machineRepo.FindActiveChargingReservation(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ActiveChargingReservation: %w", err)
+	}
+	return reservation, nil
+}
+
+// SendToCharge reserves a slot at the nearest charging station for the
+// machine's type that has one free, and sets the machine CHARGING. The machine
+// learns where to go from its heartbeat responses. Only idle machines are sent;
+// others yield models.ErrMachineNotIdle.
+func (s *Service) SendToCharge(ctx context.Context, machineID, reason string) (*domain.ChargingReservation, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	machine, err := txRepo.FindByIDForUpdate(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.SendToCharge.FindByIDForUpdate: %w", err)
+	}
+	if machine.Status != domain.StatusIdle {
+		return nil, models.ErrMachineNotIdle
+	}
+
+	stations, err := txRepo.ListChargingStations(ctx, machine.Type)
+	if err != nil {
+		return nil, fmt.Errorf("service.SendToCharge.ListChargingStations: %w", err)
+	}
+	position := geo.Point{Latitude: machine.Latitude, Longitude: machine.Longitude}
+	sort.Slice(stations, func(i, j int) bool {
+		return geo.DistanceMeters(position, geo.Point{Latitude: stations[i].Latitude, Longitude: stations[i].Longitude}) <
+			geo.DistanceMeters(position, geo.Point{Latitude: stations[j].Latitude, Longitude: stations[j].Longitude})
+	})
+
+	var reservation *domain.ChargingReservation
+	for i := range stations {
+		if stations[i].SlotsInUse >= stations[i].Slots {
+			continue
+		}
+		candidate := &domain.ChargingReservation{
+			StationID:           stations[i].ID,
+			MachineID:           machine.ID,
+			Reason:              reason,
+			BatteryLevelAtStart: machine.BatteryLevel,
+		}
+		err := txRepo.ReserveChargingSlot(ctx, candidate)
+		if errors.Is(err, models.ErrNoChargingSlot) { // Taken since it was listed
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("service.SendToCharge.ReserveChargingSlot: %w", err)
+		}
+		stations[i].SlotsInUse++
+		candidate.Station = &stations[i]
+		reservation = candidate
+		break
+	}
+	if reservation == nil {
+		return nil, models.ErrNoChargingSlot
+	}
+
+	if _, err := txRepo.SetStatus(ctx, machine.ID, domain.StatusCharging); err != nil {
+		return nil, fmt.Errorf("service.SendToCharge.SetStatus: %w", err)
+	}
+	if err := s.recordStatusChange(ctx, txRepo, machine, domain.StatusCharging, domain.StatusReasonChargingScheduled, ""); err != nil {
+		return nil, fmt.Errorf("service.SendToCharge: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("INFO: Sent machine %s (battery %d%%) to charge at station %s", machine.ID, machine.BatteryLevel, reservation.StationID)
+	return reservation, nil
+}
+
+// ScheduleCharging sends every idle machine whose battery is below
+// domain.ChargeThreshold to charge and returns the slots reserved. Machines
+// for which no slot is free are retried on the next run.
+func (s *Service) ScheduleCharging(ctx context.Context) ([]domain.ChargingReservation, error) {
+	machines, err := s.machineRepo.ListMachinesNeedingCharge(ctx, domain.ChargeThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("service.ScheduleCharging: %w", err)
+	}
+
+	var reservations []domain.ChargingReservation
+	for _, machine := range machines {
+		reservation, err := s.SendToCharge(ctx, machine.ID, domain.ChargingReasonLowBattery)
+		switch {
+		case err == nil:
+			reservations = append(reservations, *reservation)
+		case errors.Is(err, models.ErrNoChargingSlot):
+			log.Printf("WARN: No charging slot free for machine %s (battery %d%%)", machine.ID, machine.BatteryLevel)
+		case errors.Is(err, models.ErrMachineNotIdle), errors.Is(err, models.ErrConflict):
+			// Dispatched or sent to charge since it was listed
+		default:
+			log.Printf("ERROR: Failed to send machine %s to charge: %v", machine.ID, err)
+		}
+	}
+	return reservations, nil
+}
+
+// CheckTripBattery predicts the battery a machine has left after a trip. When
+// the machine would end below the safety reserve and chargeIfNeeded is set,
+// an idle machine is sent to charge before taking the trip.
+func (s *Service) CheckTripBattery(ctx context.Context, machineID string, trip domain.TripEnergyRequest, chargeIfNeeded bool) (*domain.TripBatteryCheck, error) {
+	machine, err := s.machineRepo.FindByID(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.CheckTripBattery: %w", err)
+	}
+
+	check := domain.CheckTrip(machine, trip)
+	if !check.Feasible && chargeIfNeeded {
+		reservation, err := s.SendToCharge(ctx, machineID, domain.ChargingReasonUpcomingTrip)
+		if err != nil {
+			return nil, fmt.Errorf("service.CheckTripBattery: %w", err)
+		}
+		check.ChargingReservation = reservation
+	}
+	return check, nil
+}
+
+// ListEligibleMachines returns the idle machines of a type that can make a
+// trip and still keep the safety reserve. Machines closest to the pickup come
+// first when it is given, those with the most battery left otherwise.
+func (s *Service) ListEligibleMachines(ctx context.Context, machineType string, trip domain.TripEnergyRequest, limit int) ([]domain.TripBatteryCheck, error) {
+	machines, err := s.machineRepo.ListIdleMachines(ctx, machineType)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListEligibleMachines: %w", err)
+	}
+
+	var eligible []domain.TripBatteryCheck
+	for i := range machines {
+		if check := domain.CheckTrip(&machines[i], trip); check.Feasible {
+			eligible = append(eligible, *check)
+		}
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		if trip.Pickup != nil {
+			return eligible[i].PickupDistanceMeters < eligible[j].PickupDistanceMeters
+		}
+		return eligible[i].ProjectedBatteryLevel > eligible[j].ProjectedBatteryLevel
+	})
+	if limit > 0 && len(eligible) > limit {
+		eligible = eligible[:limit]
+	}
+	return eligible, nil
+}
+
+// releaseChargingSlot frees the charging slot a machine that stops charging
+// holds. The reservation counts as completed when the machine got charged.
+func (s *Service) releaseChargingSlot(ctx context.Context, repo RepositoryInterface, machineID string, batteryLevel int) error {
+	status := domain.ChargingStatusCancelled
+	if batteryLevel >= domain.ChargeTarget {
+		status = domain.ChargingStatusCompleted
+	}
+	if _, err := repo.EndChargingReservation(ctx, machineID, status); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RunChargingScheduler sends machines low on battery to charge every interval
+// until ctx is cancelled.
+func RunChargingScheduler(ctx context.Context, s ServiceInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		reservations, err := s.ScheduleCharging(ctx)
+		if err != nil {
+			log.Printf("ERROR: Charging scheduler failed: %v", err)
+		} else if len(reservations) > 0 {
+			log.Printf("INFO: Sent %d machine(s) to charge", len(reservations))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
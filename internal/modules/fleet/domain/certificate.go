@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// MachineCertificate records a client certificate issued to a machine. Only
+// the serial number is kept; the private key never leaves the registration
+// response.
+type MachineCertificate struct {
+	SerialNumber string     `json:"serial_number"` // Hex encoded
+	MachineID    string     `json:"machine_id"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// MachineCredentials is what a newly registered machine needs to authenticate
+// over mutual TLS.
+type MachineCredentials struct {
+	SerialNumber     string    `json:"serial_number"`
+	CertificatePEM   string    `json:"certificate_pem"`
+	PrivateKeyPEM    string    `json:"private_key_pem"`
+	CACertificatePEM string    `json:"ca_certificate_pem"` // To verify the servers
+	ExpiresAt        time.Time `json:"expires_at"`
+}
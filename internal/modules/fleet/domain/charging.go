@@ -0,0 +1,121 @@
+package models
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"time"
+)
+
+// Battery thresholds, in percent.
+const (
+	// ChargeThreshold is the level below which idle machines are sent to charge.
+	ChargeThreshold = 25
+
+	// SafetyReserve is the least battery a machine must have left at the end
+	// of a trip. Machines projected to end lower are not dispatched.
+	SafetyReserve = 15
+
+	// ChargeTarget is the level at which a charging machine is released.
+	ChargeTarget = 95
+)
+
+// BatteryProfile models how fast a machine type drains and charges its battery.
+type BatteryProfile struct {
+	PercentPerKm           float64 // Drain when empty
+	PercentPerKgKm         float64 // Extra drain per kg of payload
+	ChargePercentPerMinute float64
+}
+
+// BatteryProfiles holds the battery profile of each machine type.
+var BatteryProfiles = map[string]BatteryProfile{
+	MachineTypeDrone: {PercentPerKm: 4, PercentPerKgKm: 0.6, ChargePercentPerMinute: 2},
+	MachineTypeRobot: {PercentPerKm: 1, PercentPerKgKm: 0.02, ChargePercentPerMinute: 1},
+}
+
+// PredictConsumption returns the battery percentage a machine of the given
+// type uses to travel distanceMeters carrying payloadKg.
+func PredictConsumption(machineType string, distanceMeters, payloadKg float64) float64 {
+	profile := BatteryProfiles[machineType]
+	km := distanceMeters / 1000
+	return km * (profile.PercentPerKm + payloadKg*profile.PercentPerKgKm)
+}
+
+// TripEnergyRequest describes a trip whose battery use is predicted. When
+// Pickup is set, the way from the machine's position to it is included.
+type TripEnergyRequest struct {
+	DistanceMeters float64
+	PayloadKg      float64
+	Pickup         *geo.Point
+}
+
+// TripBatteryCheck is the predicted battery use of a machine on a trip.
+type TripBatteryCheck struct {
+	MachineID             string               `json:"machine_id"`
+	PickupDistanceMeters  float64              `json:"pickup_distance_meters"`
+	Consumption           float64              `json:"consumption"`             // Percent
+	ProjectedBatteryLevel float64              `json:"projected_battery_level"` // Percent left at the end of the trip
+	Feasible              bool                 `json:"feasible"`                // The safety reserve is kept
+	Machine               *Machine             `json:"machine,omitempty"`
+	ChargingReservation   *ChargingReservation `json:"charging_reservation,omitempty"` // Set when the machine was sent to charge
+}
+
+// CheckTrip predicts the battery a machine has left after a trip.
+func CheckTrip(m *Machine, trip TripEnergyRequest) *TripBatteryCheck {
+	check := &TripBatteryCheck{MachineID: m.ID, Machine: m}
+	if trip.Pickup != nil {
+		check.PickupDistanceMeters = geo.DistanceMeters(geo.Point{Latitude: m.Latitude, Longitude: m.Longitude}, *trip.Pickup)
+	}
+	// The way to the pickup is travelled empty.
+	check.Consumption = PredictConsumption(m.Type, check.PickupDistanceMeters, 0) +
+		PredictConsumption(m.Type, trip.DistanceMeters, trip.PayloadKg)
+	check.ProjectedBatteryLevel = float64(m.BatteryLevel) - check.Consumption
+	check.Feasible = check.ProjectedBatteryLevel >= SafetyReserve
+	return check
+}
+
+// ChargingStation is a place where machines of one type recharge.
+type ChargingStation struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	MachineType string    `json:"machine_type"`
+	Latitude    float64   `json:"latitude"`
+	Longitude   float64   `json:"longitude"`
+	Slots       int       `json:"slots"`
+	SlotsInUse  int       `json:"slots_in_use"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateChargingStationRequest contains the fields for adding a charging station.
+type CreateChargingStationRequest struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	MachineType string  `json:"machine_type" validate:"required"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Slots       int     `json:"slots" validate:"min=1"`
+}
+
+// Charging reservation status constants.
+const (
+	ChargingStatusActive    = "active"
+	ChargingStatusCompleted = "completed"
+	ChargingStatusCancelled = "cancelled" // The machine left the station before it was charged
+)
+
+// Reasons a machine is sent to charge.
+const (
+	ChargingReasonLowBattery   = "low_battery"
+	ChargingReasonUpcomingTrip = "upcoming_trip"
+)
+
+// ChargingReservation holds a slot of a charging station for a machine.
+type ChargingReservation struct {
+	ID                  string           `json:"id"`
+	StationID           string           `json:"station_id"`
+	MachineID           string           `json:"machine_id"`
+	Status              string           `json:"status"`
+	Reason              string           `json:"reason"`
+	BatteryLevelAtStart int              `json:"battery_level_at_start"`
+	Station             *ChargingStation `json:"station,omitempty"`
+	CreatedAt           time.Time        `json:"created_at"`
+	EndedAt             *time.Time       `json:"ended_at,omitempty"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"math"
+	"testing"
+)
+
+func TestPredictConsumption(t *testing.T) {
+	tests := []struct {
+		name        string
+		machineType string
+		distance    float64
+		payloadKg   float64
+		want        float64
+	}{
+		{"empty drone", MachineTypeDrone, 2500, 0, 10},
+		{"loaded drone", MachineTypeDrone, 2500, 2, 13},
+		{"loaded robot", MachineTypeRobot, 10_000, 20, 14},
+		{"no distance", MachineTypeRobot, 0, 40, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PredictConsumption(tt.machineType, tt.distance, tt.payloadKg)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("PredictConsumption(%s, %.0f, %.0f) = %v, want %v", tt.machineType, tt.distance, tt.payloadKg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTrip(t *testing.T) {
+	drone := &Machine{ID: "drone-1", Type: MachineTypeDrone, BatteryLevel: 40}
+
+	check := CheckTrip(drone, TripEnergyRequest{DistanceMeters: 2500, PayloadKg: 2})
+	if check.ProjectedBatteryLevel != 27 || !check.Feasible {
+		t.Errorf("short trip projects %v%% (feasible %v), want 27%% and feasible", check.ProjectedBatteryLevel, check.Feasible)
+	}
+
+	// The way to the pickup, about 3.3 km, is flown empty and tips the drone
+	// below the safety reserve.
+	pickup := geo.Point{Latitude: drone.Latitude + 0.03, Longitude: drone.Longitude}
+	check = CheckTrip(drone, TripEnergyRequest{DistanceMeters: 2500, PayloadKg: 2, Pickup: &pickup})
+	if math.Abs(check.PickupDistanceMeters-3336) > 1 {
+		t.Errorf("pickup distance = %.0f m, want about 3336 m", check.PickupDistanceMeters)
+	}
+	if check.ProjectedBatteryLevel >= SafetyReserve || check.Feasible {
+		t.Errorf("trip with pickup projects %.1f%% (feasible %v), want below the %d%% reserve", check.ProjectedBatteryLevel, check.Feasible, SafetyReserve)
+	}
+}
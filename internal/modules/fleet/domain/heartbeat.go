@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// DefaultHeartbeatTimeout is how long a machine may stay silent before the
+// liveness tracker marks it offline.
+const DefaultHeartbeatTimeout = 30 * time.Second
+
+// Health flags a machine reports with its heartbeats.
+const (
+	HealthFlagGPSDegraded   = "GPS_DEGRADED"
+	HealthFlagCommsDegraded = "COMMS_DEGRADED"
+	HealthFlagMotorFault    = "MOTOR_FAULT"
+	HealthFlagSensorFault   = "SENSOR_FAULT"
+	HealthFlagOverheating   = "OVERHEATING"
+)
+
+// ValidHealthFlag reports whether flag is a known health flag.
+func ValidHealthFlag(flag string) bool {
+	switch flag {
+	case HealthFlagGPSDegraded, HealthFlagCommsDegraded, HealthFlagMotorFault, HealthFlagSensorFault, HealthFlagOverheating:
+		return true
+	}
+	return false
+}
+
+// Heartbeat is a periodic sign of life from a machine.
+type Heartbeat struct {
+	Latitude     float64  `json:"latitude"`
+	Longitude    float64  `json:"longitude"`
+	BatteryLevel int      `json:"battery_level"`
+	HealthFlags  []string `json:"health_flags,omitempty"`
+}
+
+// Reasons recorded with machine status events.
+const (
+	StatusReasonReported          = "reported"          // UpdateMachineStatus
+	StatusReasonHeartbeatTimeout  = "heartbeat_timeout" // Marked offline by the liveness tracker
+	StatusReasonHeartbeatResumed  = "heartbeat_resumed" // Back online after being offline
+	StatusReasonDecommissioned    = "decommissioned"
+	StatusReasonChargingScheduled = "charging_scheduled" // Sent to a charging station by the scheduler
+	StatusReasonCharged           = "charged"            // Released from its charging slot
+	StatusReasonDispatched        = "dispatched"         // Claimed by the dispatch engine for a trip
+	StatusReasonTripEnded         = "trip_ended"         // Released once its last trip was delivered, failed or cancelled
+)
+
+// MachineStatusEvent records a change of a machine's status. Together, the
+// events of a machine make up its status history. Other services read them to
+// react to machines going offline or out of service.
+type MachineStatusEvent struct {
+	ID         int64     `json:"id"`
+	MachineID  string    `json:"machine_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	Version    int64     `json:"version"`            // The machine's version after the change
+	ActorID    *string   `json:"actor_id,omitempty"` // Unset when made by the system
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"fmt"
+	"time"
+)
+
+// HubArrivalRadiusMeters is how close to a hub a machine must be to count as
+// parked at it.
+const HubArrivalRadiusMeters = 150
+
+// DemandLookback is how far back orders are counted to forecast demand.
+const DemandLookback = 4 * 7 * 24 * time.Hour
+
+// DefaultForecastHorizon is the period rebalancing plans for when none is given.
+const DefaultForecastHorizon = 2 * time.Hour
+
+// Hub is a depot machines are based at, serve orders around, and are parked at
+// while idle.
+type Hub struct {
+	ID                  string    `json:"id"`
+	Name                string    `json:"name"`
+	Latitude            float64   `json:"latitude"`
+	Longitude           float64   `json:"longitude"`
+	Capacity            int       `json:"capacity"` // Machines that can be based here
+	ServiceRadiusMeters float64   `json:"service_radius_meters"`
+	Timezone            string    `json:"timezone"`  // IANA name the opening hours are in
+	OpensAt             string    `json:"opens_at"`  // HH:MM; empty when open around the clock
+	ClosesAt            string    `json:"closes_at"` // HH:MM; before OpensAt when open overnight
+	HomeBasedMachines   int       `json:"home_based_machines"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// Location returns the position of the hub.
+func (h *Hub) Location() geo.Point {
+	return geo.Point{Latitude: h.Latitude, Longitude: h.Longitude}
+}
+
+// Covers reports whether p lies within the hub's service radius.
+func (h *Hub) Covers(p geo.Point) bool {
+	return geo.DistanceMeters(h.Location(), p) <= h.ServiceRadiusMeters
+}
+
+// IsOpen reports whether the hub is open at t.
+func (h *Hub) IsOpen(t time.Time) bool {
+	if h.OpensAt == "" || h.ClosesAt == "" {
+		return true
+	}
+	opens, err := ParseClock(h.OpensAt)
+	if err != nil {
+		return false
+	}
+	closes, err := ParseClock(h.ClosesAt)
+	if err != nil {
+		return false
+	}
+	if loc, err := time.LoadLocation(h.Timezone); err == nil {
+		t = t.In(loc)
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	if opens <= closes {
+		return minute >= opens && minute < closes
+	}
+	return minute >= opens || minute < closes // Open overnight
+}
+
+// ParseClock parses a HH:MM time of day into minutes after midnight.
+func ParseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, want HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// CreateHubRequest contains the fields for adding a hub.
+type CreateHubRequest struct {
+	Name                string  `json:"name" validate:"required,max=255"`
+	Latitude            float64 `json:"latitude"`
+	Longitude           float64 `json:"longitude"`
+	Capacity            int     `json:"capacity" validate:"min=1"`
+	ServiceRadiusMeters float64 `json:"service_radius_meters" validate:"gt=0"`
+	Timezone            string  `json:"timezone" validate:"required"`
+	OpensAt             string  `json:"opens_at"`
+	ClosesAt            string  `json:"closes_at"`
+}
+
+// HubStock counts the machines of one type at a hub.
+type HubStock struct {
+	MachineType     string   `json:"machine_type"`
+	HomeBased       int      `json:"home_based"`
+	IdleMachineIDs  []string `json:"idle_machine_ids"` // Idle and parked at the hub, fullest battery first
+	ForecastDemand  float64  `json:"forecast_demand"`  // Orders expected over the forecast horizon
+	TargetIdleCount int      `json:"target_idle_count"`
+}
+
+// HubInventory is the stock of machines at a hub.
+type HubInventory struct {
+	Hub    Hub        `json:"hub"`
+	Open   bool       `json:"open"`
+	Stocks []HubStock `json:"stocks"`
+}
+
+// PickupDemand counts the orders picked up at a location within an hour.
+type PickupDemand struct {
+	Location    geo.Point
+	MachineType string
+	Hour        time.Time
+	Orders      int
+}
+
+// RebalancingMove suggests moving idle machines from one hub to another.
+type RebalancingMove struct {
+	MachineType    string   `json:"machine_type"`
+	FromHubID      string   `json:"from_hub_id"`
+	ToHubID        string   `json:"to_hub_id"`
+	MachineIDs     []string `json:"machine_ids"`
+	DistanceMeters float64  `json:"distance_meters"`
+}
+
+// RebalancingPlan is a set of suggested moves, along with the inventory of
+// every hub it was computed from.
+type RebalancingPlan struct {
+	Horizon     time.Duration     `json:"horizon"`
+	Inventories []HubInventory    `json:"inventories"`
+	Moves       []RebalancingMove `json:"moves"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHubIsOpen(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 10, hour, minute, 0, 0, berlin)
+	}
+
+	day := &Hub{Timezone: "Europe/Berlin", OpensAt: "08:00", ClosesAt: "20:00"}
+	night := &Hub{Timezone: "Europe/Berlin", OpensAt: "22:00", ClosesAt: "06:00"}
+	always := &Hub{Timezone: "Europe/Berlin"}
+
+	tests := []struct {
+		name string
+		hub  *Hub
+		t    time.Time
+		want bool
+	}{
+		{"day hub at opening", day, at(8, 0), true},
+		{"day hub at closing", day, at(20, 0), false},
+		{"day hub before opening", day, at(7, 59), false},
+		{"day hub checked in UTC", day, at(19, 30).UTC(), true},
+		{"night hub after midnight", night, at(2, 0), true},
+		{"night hub at noon", night, at(12, 0), false},
+		{"hub without hours", always, at(3, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hub.IsOpen(tt.t); got != tt.want {
+				t.Errorf("IsOpen(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
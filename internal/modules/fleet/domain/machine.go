@@ -10,30 +10,150 @@ const (
 	MachineTypeRobot = "ROBOT"
 )
 
+// MaxPayloadKg is the heaviest load each machine type can carry.
+var MaxPayloadKg = map[string]float64{
+	MachineTypeDrone: 5,
+	MachineTypeRobot: 40,
+}
+
+// CruiseSpeedMetersPerSecond is the average speed of each machine type
+// between stops, used to estimate travel times.
+var CruiseSpeedMetersPerSecond = map[string]float64{
+	MachineTypeDrone: 15,
+	MachineTypeRobot: 1.5,
+}
+
+// CanCarryHazmat reports whether a machine type may carry hazardous materials.
+// Drones are excluded because a crash could disperse the load over a wide area.
+func CanCarryHazmat(machineType string) bool {
+	return machineType != MachineTypeDrone
+}
+
 // Machine status constants used throughout the application.
 const (
 	StatusIdle        = "IDLE"
 	StatusInTransit   = "IN_TRANSIT"
 	StatusCharging    = "CHARGING"
 	StatusMaintenance = "MAINTENANCE"
+
+	// StatusOffline is set by the liveness tracker when a machine stops
+	// sending heartbeats. The next heartbeat brings the machine back as idle.
+	StatusOffline = "OFFLINE"
+
+	// StatusDecommissioned is final: the machine is kept for its history only.
+	StatusDecommissioned = "DECOMMISSIONED"
 )
 
+// ValidMachineType reports whether machineType is a known machine category.
+func ValidMachineType(machineType string) bool {
+	_, ok := MaxPayloadKg[machineType]
+	return ok
+}
+
+// ValidStatus reports whether status is a known machine status.
+func ValidStatus(status string) bool {
+	switch status {
+	case StatusIdle, StatusInTransit, StatusCharging, StatusMaintenance, StatusOffline, StatusDecommissioned:
+		return true
+	}
+	return false
+}
+
+// transitions lists the statuses a machine can move to from each status. A
+// machine in transit has to arrive, and report idle, before it can charge or
+// go into maintenance, one that comes back online mid-trip resumes it, and
+// nothing leaves DECOMMISSIONED.
+var transitions = map[string][]string{
+	StatusIdle:           {StatusInTransit, StatusCharging, StatusMaintenance, StatusOffline, StatusDecommissioned},
+	StatusInTransit:      {StatusIdle, StatusOffline},
+	StatusCharging:       {StatusIdle, StatusMaintenance, StatusOffline, StatusDecommissioned},
+	StatusMaintenance:    {StatusIdle, StatusDecommissioned},
+	StatusOffline:        {StatusIdle, StatusInTransit, StatusMaintenance, StatusDecommissioned},
+	StatusDecommissioned: {},
+}
+
+// CanTransition reports whether a machine can move from one status to
+// another. Staying in the same status is always allowed, except once
+// decommissioned.
+func CanTransition(from, to string) bool {
+	if from == to {
+		return from != StatusDecommissioned
+	}
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
 // Machine represents a delivery machine such as a drone or ground robot.
 type Machine struct {
-	ID           string    `json:"id"`
-	Type         string    `json:"type"`
-	Status       string    `json:"status"`
-	Latitude     float64   `json:"latitude"`
-	Longitude    float64   `json:"longitude"`
-	BatteryLevel int       `json:"battery_level"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID               string     `json:"id"`
+	SerialNumber     string     `json:"serial_number"`
+	Type             string     `json:"type"`
+	Status           string     `json:"status"`
+	Latitude         float64    `json:"latitude"`
+	Longitude        float64    `json:"longitude"`
+	BatteryLevel     int        `json:"battery_level"`          // Percent
+	HealthFlags      []string   `json:"health_flags,omitempty"` // Faults reported with the last heartbeat
+	LastHeartbeatAt  *time.Time `json:"last_heartbeat_at,omitempty"`
+	DecommissionedAt *time.Time `json:"decommissioned_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// Usage, counted from heartbeats while in transit, and the usage at the
+	// last inspection.
+	OdometerMeters            float64    `json:"odometer_meters"`
+	OperatingSeconds          int64      `json:"operating_seconds"` // Flight time for drones
+	LastInspectedAt           *time.Time `json:"last_inspected_at,omitempty"`
+	InspectedOdometerMeters   float64    `json:"-"`
+	InspectedOperatingSeconds int64      `json:"-"`
+
+	HomeHubID *string `json:"home_hub_id,omitempty"` // The hub the machine is based at
+
+	// Version is bumped on every change of status or home hub. Updates that
+	// name the version they were made against are refused once it moved on.
+	Version int64 `json:"version"`
+}
+
+// RegisterMachineRequest contains the fields for adding a machine to the fleet.
+// New machines start out idle.
+type RegisterMachineRequest struct {
+	SerialNumber string  `json:"serial_number" validate:"required,max=64"`
+	Type         string  `json:"type" validate:"required"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	BatteryLevel int     `json:"battery_level" validate:"min=0,max=100"`
+}
+
+// BoundingBox is a latitude/longitude rectangle. A box whose MinLongitude is
+// greater than its MaxLongitude crosses the antimeridian.
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// ListMachinesFilter narrows down the machines returned by ListMachines.
+// Decommissioned machines are only listed when asked for by status.
+type ListMachinesFilter struct {
+	Type        string
+	Status      string
+	BoundingBox *BoundingBox
 }
 
 // MachineStatusUpdateRequest contains fields for updating a machine's
 // status and current location.
 type MachineStatusUpdateRequest struct {
-	Status    string  `json:"status"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	Status       string  `json:"status"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	BatteryLevel *int    `json:"battery_level,omitempty"` // Unchanged when unset
+
+	// ExpectedVersion, when set, refuses the update with models.ErrConflict if
+	// the machine changed since the reporter read it.
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
+	ActorID         string `json:"-"` // The machine or operator reporting
 }
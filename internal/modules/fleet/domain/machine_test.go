@@ -0,0 +1,45 @@
+package models
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StatusIdle, StatusInTransit, true},
+		{StatusIdle, StatusCharging, true},
+		{StatusIdle, StatusIdle, true},
+		{StatusInTransit, StatusIdle, true},
+		{StatusInTransit, StatusOffline, true},
+		{StatusInTransit, StatusCharging, false},
+		{StatusInTransit, StatusMaintenance, false},
+		{StatusInTransit, StatusDecommissioned, false},
+		{StatusCharging, StatusInTransit, false},
+		{StatusMaintenance, StatusInTransit, false},
+		{StatusMaintenance, StatusIdle, true},
+		{StatusOffline, StatusIdle, true},
+		{StatusOffline, StatusInTransit, true},
+		{StatusDecommissioned, StatusIdle, false},
+		{StatusDecommissioned, StatusDecommissioned, false},
+		{"FLYING", StatusIdle, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestTransitionsCoverEveryStatus(t *testing.T) {
+	for _, status := range []string{StatusIdle, StatusInTransit, StatusCharging, StatusMaintenance, StatusOffline, StatusDecommissioned} {
+		if _, ok := transitions[status]; !ok {
+			t.Errorf("no transitions listed for %s", status)
+		}
+		for _, to := range transitions[status] {
+			if !ValidStatus(to) {
+				t.Errorf("%s can move to unknown status %s", status, to)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Work order kind constants.
+const (
+	WorkOrderKindFault      = "fault"      // Opened for a fault code a machine reported
+	WorkOrderKindInspection = "inspection" // Opened when a scheduled inspection falls due
+	WorkOrderKindRepair     = "repair"     // Opened by an operator
+)
+
+// ValidWorkOrderKind reports whether kind is a known work order kind.
+func ValidWorkOrderKind(kind string) bool {
+	switch kind {
+	case WorkOrderKindFault, WorkOrderKindInspection, WorkOrderKindRepair:
+		return true
+	}
+	return false
+}
+
+// Work order status constants.
+const (
+	WorkOrderStatusOpen       = "open"
+	WorkOrderStatusInProgress = "in_progress"
+	WorkOrderStatusCompleted  = "completed"
+	WorkOrderStatusCancelled  = "cancelled"
+)
+
+// ValidWorkOrderStatus reports whether status is a known work order status.
+func ValidWorkOrderStatus(status string) bool {
+	switch status {
+	case WorkOrderStatusOpen, WorkOrderStatusInProgress, WorkOrderStatusCompleted, WorkOrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Reasons recorded with the status events of maintenance.
+const (
+	StatusReasonMaintenanceRequired  = "maintenance_required"  // A blocking work order was opened
+	StatusReasonMaintenanceCompleted = "maintenance_completed" // The last blocking work order was closed
+)
+
+// IsFaultFlag reports whether a health flag reports a fault that takes the
+// machine out of service until it is repaired. Degraded GPS or comms do not.
+func IsFaultFlag(flag string) bool {
+	switch flag {
+	case HealthFlagMotorFault, HealthFlagSensorFault, HealthFlagOverheating:
+		return true
+	}
+	return false
+}
+
+// InspectionPolicy sets how often machines of a type are inspected. An
+// inspection falls due when any of the limits is reached; zero limits are
+// not checked.
+type InspectionPolicy struct {
+	OperatingHours float64 // Flight hours for drones
+	OdometerKm     float64
+	Interval       time.Duration
+}
+
+// InspectionPolicies holds the inspection policy of each machine type.
+var InspectionPolicies = map[string]InspectionPolicy{
+	MachineTypeDrone: {OperatingHours: 50, Interval: 90 * 24 * time.Hour},
+	MachineTypeRobot: {OdometerKm: 1000, Interval: 180 * 24 * time.Hour},
+}
+
+// InspectionDue returns why the machine is due for inspection, or "" when it
+// is not.
+func (m *Machine) InspectionDue(now time.Time) string {
+	policy, ok := InspectionPolicies[m.Type]
+	if !ok {
+		return ""
+	}
+	hours := float64(m.OperatingSeconds-m.InspectedOperatingSeconds) / 3600
+	km := (m.OdometerMeters - m.InspectedOdometerMeters) / 1000
+	since := m.CreatedAt
+	if m.LastInspectedAt != nil {
+		since = *m.LastInspectedAt
+	}
+
+	switch {
+	case policy.OperatingHours > 0 && hours >= policy.OperatingHours:
+		return fmt.Sprintf("%.1f operating hours since the last inspection", hours)
+	case policy.OdometerKm > 0 && km >= policy.OdometerKm:
+		return fmt.Sprintf("%.0f km since the last inspection", km)
+	case policy.Interval > 0 && now.Sub(since) >= policy.Interval:
+		return fmt.Sprintf("%d days since the last inspection", int(now.Sub(since).Hours()/24))
+	}
+	return ""
+}
+
+// UsageDelta is the use a machine got since its previous heartbeat.
+type UsageDelta struct {
+	Meters  float64
+	Seconds int64
+}
+
+// ReplacedPart is a part fitted to a machine during maintenance.
+type ReplacedPart struct {
+	PartNumber string `json:"part_number"`
+	Name       string `json:"name"`
+	Quantity   int    `json:"quantity"`
+}
+
+// WorkOrder is a maintenance ticket for a machine. Together, the work orders of
+// a machine make up its service history. While a blocking work order is open
+// the machine is kept in MAINTENANCE and cannot be dispatched.
+type WorkOrder struct {
+	ID               string         `json:"id"`
+	MachineID        string         `json:"machine_id"`
+	Kind             string         `json:"kind"`
+	Status           string         `json:"status"`
+	Blocking         bool           `json:"blocking"`
+	FaultCode        *string        `json:"fault_code,omitempty"`
+	Description      string         `json:"description"`
+	TechnicianID     *string        `json:"technician_id,omitempty"` // Who started or closed the work
+	TechnicianNotes  string         `json:"technician_notes"`
+	PartsReplaced    []ReplacedPart `json:"parts_replaced"`
+	OdometerMeters   float64        `json:"odometer_meters"`   // Machine usage when the work order was opened
+	OperatingSeconds int64          `json:"operating_seconds"` // Machine usage when the work order was opened
+	CreatedAt        time.Time      `json:"created_at"`
+	StartedAt        *time.Time     `json:"started_at,omitempty"`
+	ClosedAt         *time.Time     `json:"closed_at,omitempty"`
+}
+
+// IsOpen reports whether work on the work order is still outstanding.
+func (w *WorkOrder) IsOpen() bool {
+	return w.Status == WorkOrderStatusOpen || w.Status == WorkOrderStatusInProgress
+}
+
+// CreateWorkOrderRequest contains the fields for opening a work order.
+type CreateWorkOrderRequest struct {
+	MachineID   string  `json:"machine_id" validate:"required"`
+	Kind        string  `json:"kind" validate:"required"`
+	Blocking    bool    `json:"blocking"`
+	FaultCode   *string `json:"fault_code,omitempty" validate:"omitempty,max=64"`
+	Description string  `json:"description"`
+}
+
+// CloseWorkOrderRequest contains the technician's record of the work done.
+type CloseWorkOrderRequest struct {
+	TechnicianID    string         `json:"technician_id"`
+	TechnicianNotes string         `json:"technician_notes"`
+	PartsReplaced   []ReplacedPart `json:"parts_replaced"`
+}
+
+// ListWorkOrdersFilter narrows down the work orders to list. Empty fields
+// match everything.
+type ListWorkOrdersFilter struct {
+	MachineID string
+	Kind      string
+	Status    string
+}
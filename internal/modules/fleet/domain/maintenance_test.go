@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInspectionDue(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	inspected := now.Add(-30 * 24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		machine Machine
+		due     bool
+	}{
+		{
+			name:    "recently inspected drone",
+			machine: Machine{Type: MachineTypeDrone, OperatingSeconds: 40 * 3600, InspectedOperatingSeconds: 10 * 3600, LastInspectedAt: &inspected},
+		},
+		{
+			name:    "drone over its flight hours",
+			machine: Machine{Type: MachineTypeDrone, OperatingSeconds: 70 * 3600, InspectedOperatingSeconds: 10 * 3600, LastInspectedAt: &inspected},
+			due:     true,
+		},
+		{
+			name:    "robot over its odometer limit",
+			machine: Machine{Type: MachineTypeRobot, OdometerMeters: 1_250_000, InspectedOdometerMeters: 200_000, LastInspectedAt: &inspected},
+			due:     true,
+		},
+		{
+			// Robots are not inspected by operating hours.
+			name:    "robot with many operating hours",
+			machine: Machine{Type: MachineTypeRobot, OperatingSeconds: 500 * 3600, LastInspectedAt: &inspected},
+		},
+		{
+			name:    "never inspected robot past the interval",
+			machine: Machine{Type: MachineTypeRobot, CreatedAt: now.Add(-200 * 24 * time.Hour)},
+			due:     true,
+		},
+		{
+			name:    "unknown machine type",
+			machine: Machine{Type: "BOAT", CreatedAt: now.Add(-1000 * 24 * time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := tt.machine.InspectionDue(now)
+			if (reason != "") != tt.due {
+				t.Errorf("InspectionDue = %q, want due %v", reason, tt.due)
+			}
+		})
+	}
+}
+
+func TestIsFaultFlag(t *testing.T) {
+	for _, flag := range []string{HealthFlagMotorFault, HealthFlagSensorFault, HealthFlagOverheating} {
+		if !IsFaultFlag(flag) {
+			t.Errorf("IsFaultFlag(%s) = false, want true", flag)
+		}
+	}
+	for _, flag := range []string{HealthFlagGPSDegraded, HealthFlagCommsDegraded} {
+		if IsFaultFlag(flag) {
+			t.Errorf("IsFaultFlag(%s) = true, want a degraded flag to keep the machine in service", flag)
+		}
+	}
+}
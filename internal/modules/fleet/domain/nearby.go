@@ -0,0 +1,46 @@
+package models
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"sort"
+)
+
+// Limits of a nearby machine search.
+const (
+	DefaultSearchRadiusMeters = 5000
+	MaxSearchRadiusMeters     = 50000
+	DefaultNearbyLimit        = 10
+	MaxNearbyLimit            = 100
+)
+
+// NearbyMachinesQuery describes the machines dispatch is looking for around a
+// pickup: idle, within the radius, charged to at least MinBatteryLevel and able
+// to carry the payload.
+type NearbyMachinesQuery struct {
+	Pickup          geo.Point
+	RadiusMeters    float64 // DefaultSearchRadiusMeters when zero
+	MachineType     string  // Any type able to carry the payload when empty
+	MinBatteryLevel int
+	PayloadKg       float64
+	Limit           int // DefaultNearbyLimit when zero
+}
+
+// NearbyMachine is a machine found by a nearby search, with its distance from
+// the pickup.
+type NearbyMachine struct {
+	Machine
+	DistanceMeters float64 `json:"distance_meters"`
+}
+
+// TypesCarrying returns the machine types that can carry payloadKg, in a
+// stable order.
+func TypesCarrying(payloadKg float64) []string {
+	var types []string
+	for machineType, maxKg := range MaxPayloadKg {
+		if payloadKg <= maxKg {
+			types = append(types, machineType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,887 @@
+package fleet
+
+import (
+	"context"
+	pb "dispatch-and-delivery/api/proto/fleet"
+	"dispatch-and-delivery/internal/middleware"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"dispatch-and-delivery/pkg/utils"
+	"errors"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCHandler is the gRPC handler for the fleet service.
+// It implements the FleetServiceServer interface generated by protoc.
+type GRPCHandler struct {
+	pb.UnimplementedFleetServiceServer
+
+	service ServiceInterface
+}
+
+// NewGRPCHandler creates a new gRPC handler for the fleet service.
+func NewGRPCHandler(s ServiceInterface) *GRPCHandler {
+	return &GRPCHandler{service: s}
+}
+
+// RegisterMachine handles the admin gRPC request for adding a machine to the
+// fleet. The response carries the machine's client certificate and key.
+func (h *GRPCHandler) RegisterMachine(ctx context.Context, req *pb.RegisterMachineRequest) (*pb.MachineResponse, error) {
+	if req.SerialNumber == "" || len(req.SerialNumber) > 64 {
+		return nil, status.Error(codes.InvalidArgument, "serial_number is required and must be at most 64 characters")
+	}
+	if !domain.ValidMachineType(req.Type) {
+		return nil, status.Error(codes.InvalidArgument, "type must be DRONE or ROBOT")
+	}
+	if !(geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}).Valid() {
+		return nil, status.Error(codes.InvalidArgument, "latitude and longitude are out of range")
+	}
+	if req.BatteryLevel < 0 || req.BatteryLevel > 100 {
+		return nil, status.Error(codes.InvalidArgument, "battery_level must be between 0 and 100")
+	}
+
+	machine, credentials, err := h.service.RegisterMachine(ctx, domain.RegisterMachineRequest{
+		SerialNumber: req.SerialNumber,
+		Type:         req.Type,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		BatteryLevel: int(req.BatteryLevel),
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			return nil, status.Error(codes.AlreadyExists, "a machine with this serial number is already registered")
+		}
+		return nil, status.Error(codes.Internal, "failed to register machine")
+	}
+
+	res := &pb.MachineResponse{Machine: toPBMachine(machine)}
+	if credentials != nil {
+		res.Credentials = &pb.MachineCredentials{
+			SerialNumber:     credentials.SerialNumber,
+			CertificatePem:   credentials.CertificatePEM,
+			PrivateKeyPem:    credentials.PrivateKeyPEM,
+			CaCertificatePem: credentials.CACertificatePEM,
+			ExpiresAt:        timestamppb.New(credentials.ExpiresAt),
+		}
+	}
+	return res, nil
+}
+
+// GetMachine handles the admin gRPC request for looking up a machine.
+func (h *GRPCHandler) GetMachine(ctx context.Context, req *pb.GetMachineRequest) (*pb.MachineResponse, error) {
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id is required")
+	}
+
+	machine, err := h.service.GetMachine(ctx, req.MachineId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "machine not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve machine")
+	}
+	return &pb.MachineResponse{Machine: toPBMachine(machine)}, nil
+}
+
+// ListMachines handles the admin gRPC request for paging through the fleet.
+func (h *GRPCHandler) ListMachines(ctx context.Context, req *pb.ListMachinesRequest) (*pb.ListMachinesResponse, error) {
+	if req.Type != "" && !domain.ValidMachineType(req.Type) {
+		return nil, status.Error(codes.InvalidArgument, "type must be DRONE or ROBOT")
+	}
+	if req.Status != "" && !domain.ValidStatus(req.Status) {
+		return nil, status.Error(codes.InvalidArgument, "status is not a known machine status")
+	}
+	filter := domain.ListMachinesFilter{Type: req.Type, Status: req.Status}
+
+	if b := req.BoundingBox; b != nil {
+		southWest := geo.Point{Latitude: b.MinLatitude, Longitude: b.MinLongitude}
+		northEast := geo.Point{Latitude: b.MaxLatitude, Longitude: b.MaxLongitude}
+		if !southWest.Valid() || !northEast.Valid() || b.MinLatitude > b.MaxLatitude {
+			return nil, status.Error(codes.InvalidArgument, "bounding_box is out of range or its latitudes are reversed")
+		}
+		filter.BoundingBox = &domain.BoundingBox{
+			MinLatitude:  b.MinLatitude,
+			MinLongitude: b.MinLongitude,
+			MaxLatitude:  b.MaxLatitude,
+			MaxLongitude: b.MaxLongitude,
+		}
+	}
+
+	page, limit := utils.GetPaginationParams(req.Page, req.Limit)
+	machines, total, err := h.service.ListMachines(ctx, filter, page, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list machines")
+	}
+
+	res := &pb.ListMachinesResponse{Total: int32(total)}
+	for i := range machines {
+		res.Machines = append(res.Machines, toPBMachine(&machines[i]))
+	}
+	return res, nil
+}
+
+// UpdateMachineStatus handles the gRPC request for reporting a machine's status
+// and position. Machines may only report on themselves; admins on any machine.
+func (h *GRPCHandler) UpdateMachineStatus(ctx context.Context, req *pb.UpdateMachineStatusRequest) (*pb.MachineResponse, error) {
+	callerID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role, err := utils.GetUserRoleFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id is required")
+	}
+	if role == middleware.RoleMachine && req.MachineId != callerID {
+		return nil, status.Error(codes.PermissionDenied, "machines can only update their own status")
+	}
+	if !domain.ValidStatus(req.Status) || req.Status == domain.StatusOffline || req.Status == domain.StatusDecommissioned {
+		return nil, status.Error(codes.InvalidArgument, "status must be IDLE, IN_TRANSIT, CHARGING or MAINTENANCE")
+	}
+	if !(geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}).Valid() {
+		return nil, status.Error(codes.InvalidArgument, "latitude and longitude are out of range")
+	}
+
+	update := domain.MachineStatusUpdateRequest{
+		Status:          req.Status,
+		Latitude:        req.Latitude,
+		Longitude:       req.Longitude,
+		ExpectedVersion: req.ExpectedVersion,
+		ActorID:         callerID,
+	}
+	if req.BatteryLevel != nil {
+		if *req.BatteryLevel < 0 || *req.BatteryLevel > 100 {
+			return nil, status.Error(codes.InvalidArgument, "battery_level must be between 0 and 100")
+		}
+		level := int(*req.BatteryLevel)
+		update.BatteryLevel = &level
+	}
+
+	machine, err := h.service.UpdateMachineStatus(ctx, req.MachineId, update)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "machine not found")
+		case errors.Is(err, models.ErrMachineDecommissioned), errors.Is(err, models.ErrInvalidTransition):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, models.ErrConflict):
+			return nil, status.Error(codes.Aborted, "machine was changed concurrently, please reload and retry")
+		}
+		return nil, status.Error(codes.Internal, "failed to update machine status")
+	}
+	return &pb.MachineResponse{Machine: toPBMachine(machine)}, nil
+}
+
+// DecommissionMachine handles the admin gRPC request for taking a machine out of service.
+func (h *GRPCHandler) DecommissionMachine(ctx context.Context, req *pb.DecommissionMachineRequest) (*pb.MachineResponse, error) {
+	callerID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id is required")
+	}
+
+	machine, err := h.service.DecommissionMachine(ctx, req.MachineId, callerID, req.ExpectedVersion)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "machine not found")
+		case errors.Is(err, models.ErrMachineDecommissioned), errors.Is(err, models.ErrMachineInUse):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, models.ErrConflict):
+			return nil, status.Error(codes.Aborted, "machine status changed, please retry")
+		}
+		return nil, status.Error(codes.Internal, "failed to decommission machine")
+	}
+	return &pb.MachineResponse{Machine: toPBMachine(machine)}, nil
+}
+
+// GetMachineStatusHistory handles the admin gRPC request for paging through the
+// status changes of a machine.
+func (h *GRPCHandler) GetMachineStatusHistory(ctx context.Context, req *pb.GetMachineStatusHistoryRequest) (*pb.GetMachineStatusHistoryResponse, error) {
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id is required")
+	}
+
+	page, limit := utils.GetPaginationParams(req.Page, req.Limit)
+	events, total, err := h.service.GetMachineStatusHistory(ctx, req.MachineId, page, limit)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "machine not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get machine status history")
+	}
+
+	res := &pb.GetMachineStatusHistoryResponse{Total: int32(total)}
+	for i := range events {
+		res.Events = append(res.Events, toPBStatusEvent(&events[i]))
+	}
+	return res, nil
+}
+
+// Heartbeat handles the stream of heartbeats a machine sends while it is
+// powered on. Every heartbeat is acknowledged with the machine's status and
+// when to send the next one.
+func (h *GRPCHandler) Heartbeat(stream grpc.BidiStreamingServer[pb.HeartbeatRequest, pb.HeartbeatResponse]) error {
+	ctx := stream.Context()
+	machineID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	interval := int32(h.service.HeartbeatInterval().Seconds())
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !(geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}).Valid() {
+			return status.Error(codes.InvalidArgument, "latitude and longitude are out of range")
+		}
+		if req.BatteryLevel < 0 || req.BatteryLevel > 100 {
+			return status.Error(codes.InvalidArgument, "battery_level must be between 0 and 100")
+		}
+		for _, flag := range req.HealthFlags {
+			if !domain.ValidHealthFlag(flag) {
+				return status.Errorf(codes.InvalidArgument, "unknown health flag %q", flag)
+			}
+		}
+
+		machine, err := h.service.RecordHeartbeat(ctx, machineID, domain.Heartbeat{
+			Latitude:     req.Latitude,
+			Longitude:    req.Longitude,
+			BatteryLevel: int(req.BatteryLevel),
+			HealthFlags:  req.HealthFlags,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, models.ErrNotFound):
+				return status.Error(codes.NotFound, "machine not found")
+			case errors.Is(err, models.ErrMachineDecommissioned):
+				return status.Error(codes.FailedPrecondition, err.Error())
+			}
+			return status.Error(codes.Internal, "failed to record heartbeat")
+		}
+
+		res := &pb.HeartbeatResponse{
+			Status:                   machine.Status,
+			ServerTime:               timestamppb.Now(),
+			HeartbeatIntervalSeconds: interval,
+		}
+		if machine.Status == domain.StatusCharging {
+			reservation, err := h.service.ActiveChargingReservation(ctx, machineID)
+			switch {
+			case err == nil:
+				res.Charging = toPBChargingReservation(reservation)
+			case !errors.Is(err, models.ErrNotFound): // Charging at a place of its own choosing
+				return status.Error(codes.Internal, "failed to look up charging slot")
+			}
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+// CreateChargingStation handles the admin gRPC request for adding a charging station.
+func (h *GRPCHandler) CreateChargingStation(ctx context.Context, req *pb.CreateChargingStationRequest) (*pb.ChargingStationResponse, error) {
+	if req.Name == "" || len(req.Name) > 255 {
+		return nil, status.Error(codes.InvalidArgument, "name is required and must be at most 255 characters")
+	}
+	if !domain.ValidMachineType(req.MachineType) {
+		return nil, status.Error(codes.InvalidArgument, "machine_type must be DRONE or ROBOT")
+	}
+	if !(geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}).Valid() {
+		return nil, status.Error(codes.InvalidArgument, "latitude and longitude are out of range")
+	}
+	if req.Slots < 1 {
+		return nil, status.Error(codes.InvalidArgument, "slots must be at least 1")
+	}
+
+	station, err := h.service.CreateChargingStation(ctx, domain.CreateChargingStationRequest{
+		Name:        req.Name,
+		MachineType: req.MachineType,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Slots:       int(req.Slots),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create charging station")
+	}
+	return &pb.ChargingStationResponse{Station: toPBChargingStation(station)}, nil
+}
+
+// ListChargingStations handles the admin gRPC request for listing charging stations.
+func (h *GRPCHandler) ListChargingStations(ctx context.Context, req *pb.ListChargingStationsRequest) (*pb.ListChargingStationsResponse, error) {
+	if req.MachineType != "" && !domain.ValidMachineType(req.MachineType) {
+		return nil, status.Error(codes.InvalidArgument, "machine_type must be DRONE or ROBOT")
+	}
+
+	stations, err := h.service.ListChargingStations(ctx, req.MachineType)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list charging stations")
+	}
+
+	res := &pb.ListChargingStationsResponse{}
+	for i := range stations {
+		res.Stations = append(res.Stations, toPBChargingStation(&stations[i]))
+	}
+	return res, nil
+}
+
+// CheckTripBattery handles the admin gRPC request for predicting whether a
+// machine has the battery for a trip.
+func (h *GRPCHandler) CheckTripBattery(ctx context.Context, req *pb.CheckTripBatteryRequest) (*pb.CheckTripBatteryResponse, error) {
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id is required")
+	}
+	trip, err := toTripEnergyRequest(req.DistanceMeters, req.PayloadKg, req.Pickup)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := h.service.CheckTripBattery(ctx, req.MachineId, trip, req.ChargeIfNeeded)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "machine not found")
+		case errors.Is(err, models.ErrNoChargingSlot), errors.Is(err, models.ErrMachineNotIdle):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, models.ErrConflict):
+			return nil, status.Error(codes.Aborted, "machine status changed, please retry")
+		}
+		return nil, status.Error(codes.Internal, "failed to check trip battery")
+	}
+
+	res := &pb.CheckTripBatteryResponse{Check: toPBTripBatteryCheck(check)}
+	if check.ChargingReservation != nil {
+		res.Charging = toPBChargingReservation(check.ChargingReservation)
+	}
+	return res, nil
+}
+
+// ListEligibleMachines handles the admin gRPC request for finding the idle
+// machines that can make a trip without dipping into the safety reserve.
+func (h *GRPCHandler) ListEligibleMachines(ctx context.Context, req *pb.ListEligibleMachinesRequest) (*pb.ListEligibleMachinesResponse, error) {
+	if !domain.ValidMachineType(req.MachineType) {
+		return nil, status.Error(codes.InvalidArgument, "machine_type must be DRONE or ROBOT")
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	trip, err := toTripEnergyRequest(req.DistanceMeters, req.PayloadKg, req.Pickup)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := h.service.ListEligibleMachines(ctx, req.MachineType, trip, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list eligible machines")
+	}
+
+	res := &pb.ListEligibleMachinesResponse{}
+	for i := range checks {
+		res.Machines = append(res.Machines, toPBTripBatteryCheck(&checks[i]))
+	}
+	return res, nil
+}
+
+// FindNearbyMachines handles the admin gRPC request for the idle machines
+// nearest to a pickup that can take an order.
+func (h *GRPCHandler) FindNearbyMachines(ctx context.Context, req *pb.FindNearbyMachinesRequest) (*pb.FindNearbyMachinesResponse, error) {
+	pickup := geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}
+	if !pickup.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "latitude and longitude are out of range")
+	}
+	if req.MachineType != "" && !domain.ValidMachineType(req.MachineType) {
+		return nil, status.Error(codes.InvalidArgument, "machine_type must be DRONE or ROBOT")
+	}
+	if req.RadiusMeters < 0 || req.RadiusMeters > domain.MaxSearchRadiusMeters {
+		return nil, status.Errorf(codes.InvalidArgument, "radius_meters must be between 0 and %d", domain.MaxSearchRadiusMeters)
+	}
+	if req.MinBatteryLevel < 0 || req.MinBatteryLevel > 100 {
+		return nil, status.Error(codes.InvalidArgument, "min_battery_level must be between 0 and 100")
+	}
+	if req.PayloadKg < 0 {
+		return nil, status.Error(codes.InvalidArgument, "payload_kg must not be negative")
+	}
+	if req.Limit < 0 || req.Limit > domain.MaxNearbyLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", domain.MaxNearbyLimit)
+	}
+
+	machines, err := h.service.FindNearbyMachines(ctx, domain.NearbyMachinesQuery{
+		Pickup:          pickup,
+		RadiusMeters:    req.RadiusMeters,
+		MachineType:     req.MachineType,
+		MinBatteryLevel: int(req.MinBatteryLevel),
+		PayloadKg:       req.PayloadKg,
+		Limit:           int(req.Limit),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to find nearby machines")
+	}
+
+	res := &pb.FindNearbyMachinesResponse{}
+	for i := range machines {
+		res.Machines = append(res.Machines, &pb.NearbyMachine{
+			Machine:        toPBMachine(&machines[i].Machine),
+			DistanceMeters: machines[i].DistanceMeters,
+		})
+	}
+	return res, nil
+}
+
+// ReportFault handles the gRPC request for reporting a fault code. Machines
+// may only report on themselves; admins on any machine.
+func (h *GRPCHandler) ReportFault(ctx context.Context, req *pb.ReportFaultRequest) (*pb.WorkOrderResponse, error) {
+	callerID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role, err := utils.GetUserRoleFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id is required")
+	}
+	if role == middleware.RoleMachine && req.MachineId != callerID {
+		return nil, status.Error(codes.PermissionDenied, "machines can only report their own faults")
+	}
+	if req.FaultCode == "" || len(req.FaultCode) > 64 {
+		return nil, status.Error(codes.InvalidArgument, "fault_code is required and must be at most 64 characters")
+	}
+
+	workOrder, err := h.service.ReportFault(ctx, req.MachineId, req.FaultCode, req.Description)
+	if err != nil {
+		return nil, workOrderError(err, "failed to report fault")
+	}
+	return &pb.WorkOrderResponse{WorkOrder: toPBWorkOrder(workOrder)}, nil
+}
+
+// OpenWorkOrder handles the admin gRPC request for opening an inspection or
+// repair work order.
+func (h *GRPCHandler) OpenWorkOrder(ctx context.Context, req *pb.OpenWorkOrderRequest) (*pb.WorkOrderResponse, error) {
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id is required")
+	}
+	if req.Kind != domain.WorkOrderKindInspection && req.Kind != domain.WorkOrderKindRepair {
+		return nil, status.Error(codes.InvalidArgument, "kind must be inspection or repair; faults are reported with ReportFault")
+	}
+
+	workOrder, err := h.service.OpenWorkOrder(ctx, domain.CreateWorkOrderRequest{
+		MachineID:   req.MachineId,
+		Kind:        req.Kind,
+		Blocking:    req.Blocking,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, workOrderError(err, "failed to open work order")
+	}
+	return &pb.WorkOrderResponse{WorkOrder: toPBWorkOrder(workOrder)}, nil
+}
+
+// StartWorkOrder handles the admin gRPC request for starting work on a work
+// order. The caller is recorded as the technician.
+func (h *GRPCHandler) StartWorkOrder(ctx context.Context, req *pb.StartWorkOrderRequest) (*pb.WorkOrderResponse, error) {
+	technicianID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.WorkOrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "work_order_id is required")
+	}
+
+	workOrder, err := h.service.StartWorkOrder(ctx, req.WorkOrderId, technicianID)
+	if err != nil {
+		return nil, workOrderError(err, "failed to start work order")
+	}
+	return &pb.WorkOrderResponse{WorkOrder: toPBWorkOrder(workOrder)}, nil
+}
+
+// CompleteWorkOrder handles the admin gRPC request for signing off a work order.
+func (h *GRPCHandler) CompleteWorkOrder(ctx context.Context, req *pb.CloseWorkOrderRequest) (*pb.WorkOrderResponse, error) {
+	return h.closeWorkOrder(ctx, req, h.service.CompleteWorkOrder, "failed to complete work order")
+}
+
+// CancelWorkOrder handles the admin gRPC request for cancelling a work order.
+func (h *GRPCHandler) CancelWorkOrder(ctx context.Context, req *pb.CloseWorkOrderRequest) (*pb.WorkOrderResponse, error) {
+	return h.closeWorkOrder(ctx, req, h.service.CancelWorkOrder, "failed to cancel work order")
+}
+
+func (h *GRPCHandler) closeWorkOrder(
+	ctx context.Context,
+	req *pb.CloseWorkOrderRequest,
+	closer func(context.Context, string, domain.CloseWorkOrderRequest) (*domain.WorkOrder, error),
+	failure string,
+) (*pb.WorkOrderResponse, error) {
+	technicianID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.WorkOrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "work_order_id is required")
+	}
+
+	closeReq := domain.CloseWorkOrderRequest{TechnicianID: technicianID, TechnicianNotes: req.TechnicianNotes}
+	for _, part := range req.PartsReplaced {
+		if part.PartNumber == "" || part.Quantity < 1 {
+			return nil, status.Error(codes.InvalidArgument, "replaced parts need a part_number and a quantity of at least 1")
+		}
+		closeReq.PartsReplaced = append(closeReq.PartsReplaced, domain.ReplacedPart{
+			PartNumber: part.PartNumber,
+			Name:       part.Name,
+			Quantity:   int(part.Quantity),
+		})
+	}
+
+	workOrder, err := closer(ctx, req.WorkOrderId, closeReq)
+	if err != nil {
+		return nil, workOrderError(err, failure)
+	}
+	return &pb.WorkOrderResponse{WorkOrder: toPBWorkOrder(workOrder)}, nil
+}
+
+// ListWorkOrders handles the admin gRPC request for paging through work orders,
+// such as the maintenance log of one machine.
+func (h *GRPCHandler) ListWorkOrders(ctx context.Context, req *pb.ListWorkOrdersRequest) (*pb.ListWorkOrdersResponse, error) {
+	if req.Kind != "" && !domain.ValidWorkOrderKind(req.Kind) {
+		return nil, status.Error(codes.InvalidArgument, "kind must be fault, inspection or repair")
+	}
+	if req.Status != "" && !domain.ValidWorkOrderStatus(req.Status) {
+		return nil, status.Error(codes.InvalidArgument, "status must be open, in_progress, completed or cancelled")
+	}
+
+	page, limit := utils.GetPaginationParams(req.Page, req.Limit)
+	workOrders, total, err := h.service.ListWorkOrders(ctx, domain.ListWorkOrdersFilter{
+		MachineID: req.MachineId,
+		Kind:      req.Kind,
+		Status:    req.Status,
+	}, page, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list work orders")
+	}
+
+	res := &pb.ListWorkOrdersResponse{Total: int32(total)}
+	for i := range workOrders {
+		res.WorkOrders = append(res.WorkOrders, toPBWorkOrder(&workOrders[i]))
+	}
+	return res, nil
+}
+
+// CreateHub handles the admin gRPC request for adding a hub.
+func (h *GRPCHandler) CreateHub(ctx context.Context, req *pb.CreateHubRequest) (*pb.HubResponse, error) {
+	if req.Name == "" || len(req.Name) > 255 {
+		return nil, status.Error(codes.InvalidArgument, "name is required and must be at most 255 characters")
+	}
+	if !(geo.Point{Latitude: req.Latitude, Longitude: req.Longitude}).Valid() {
+		return nil, status.Error(codes.InvalidArgument, "latitude and longitude are out of range")
+	}
+	if req.Capacity < 1 || req.ServiceRadiusMeters <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "capacity must be at least 1 and service_radius_meters positive")
+	}
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown timezone %q", timezone)
+	}
+	if (req.OpensAt == "") != (req.ClosesAt == "") {
+		return nil, status.Error(codes.InvalidArgument, "opens_at and closes_at must be set together")
+	}
+	if req.OpensAt != "" {
+		if _, err := domain.ParseClock(req.OpensAt); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if _, err := domain.ParseClock(req.ClosesAt); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	hub, err := h.service.CreateHub(ctx, domain.CreateHubRequest{
+		Name:                req.Name,
+		Latitude:            req.Latitude,
+		Longitude:           req.Longitude,
+		Capacity:            int(req.Capacity),
+		ServiceRadiusMeters: req.ServiceRadiusMeters,
+		Timezone:            timezone,
+		OpensAt:             req.OpensAt,
+		ClosesAt:            req.ClosesAt,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create hub")
+	}
+	return &pb.HubResponse{Hub: toPBHub(hub)}, nil
+}
+
+// ListHubs handles the admin gRPC request for listing hubs.
+func (h *GRPCHandler) ListHubs(ctx context.Context, req *pb.ListHubsRequest) (*pb.ListHubsResponse, error) {
+	hubs, err := h.service.ListHubs(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list hubs")
+	}
+
+	res := &pb.ListHubsResponse{}
+	for i := range hubs {
+		res.Hubs = append(res.Hubs, toPBHub(&hubs[i]))
+	}
+	return res, nil
+}
+
+// AssignHomeHub handles the admin gRPC request for basing a machine at a hub.
+func (h *GRPCHandler) AssignHomeHub(ctx context.Context, req *pb.AssignHomeHubRequest) (*pb.MachineResponse, error) {
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id is required")
+	}
+
+	machine, err := h.service.AssignHomeHub(ctx, req.MachineId, req.HubId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "machine or hub not found")
+		case errors.Is(err, models.ErrHubFull), errors.Is(err, models.ErrMachineDecommissioned):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to assign home hub")
+	}
+	return &pb.MachineResponse{Machine: toPBMachine(machine)}, nil
+}
+
+// ListHubInventories handles the admin gRPC request for the machines at each hub.
+func (h *GRPCHandler) ListHubInventories(ctx context.Context, req *pb.ListHubInventoriesRequest) (*pb.ListHubInventoriesResponse, error) {
+	inventories, err := h.service.ListHubInventories(ctx, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list hub inventories")
+	}
+
+	res := &pb.ListHubInventoriesResponse{}
+	for i := range inventories {
+		res.Inventories = append(res.Inventories, toPBHubInventory(&inventories[i]))
+	}
+	return res, nil
+}
+
+// SuggestRebalancing handles the admin gRPC request for suggested moves of
+// idle machines between hubs.
+func (h *GRPCHandler) SuggestRebalancing(ctx context.Context, req *pb.SuggestRebalancingRequest) (*pb.SuggestRebalancingResponse, error) {
+	if req.MachineType != "" && !domain.ValidMachineType(req.MachineType) {
+		return nil, status.Error(codes.InvalidArgument, "machine_type must be DRONE or ROBOT")
+	}
+	if req.HorizonMinutes < 0 || req.HorizonMinutes > 7*24*60 {
+		return nil, status.Error(codes.InvalidArgument, "horizon_minutes must be between 0 and one week")
+	}
+
+	horizon := time.Duration(req.HorizonMinutes) * time.Minute
+	plan, err := h.service.SuggestRebalancing(ctx, req.MachineType, horizon, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to suggest rebalancing")
+	}
+
+	res := &pb.SuggestRebalancingResponse{}
+	for _, move := range plan.Moves {
+		res.Moves = append(res.Moves, &pb.RebalancingMove{
+			MachineType:    move.MachineType,
+			FromHubId:      move.FromHubID,
+			ToHubId:        move.ToHubID,
+			MachineIds:     move.MachineIDs,
+			DistanceMeters: move.DistanceMeters,
+		})
+	}
+	for i := range plan.Inventories {
+		res.Inventories = append(res.Inventories, toPBHubInventory(&plan.Inventories[i]))
+	}
+	return res, nil
+}
+
+// workOrderError maps the errors of the work order RPCs to gRPC status errors.
+func workOrderError(err error, failure string) error {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		return status.Error(codes.NotFound, "machine or work order not found")
+	case errors.Is(err, models.ErrConflict):
+		return status.Error(codes.AlreadyExists, "an open work order already covers this fault or inspection")
+	case errors.Is(err, models.ErrMachineDecommissioned), errors.Is(err, models.ErrWorkOrderStarted), errors.Is(err, models.ErrWorkOrderClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, failure)
+}
+
+func toTripEnergyRequest(distanceMeters, payloadKg float64, pickup *pb.Location) (domain.TripEnergyRequest, error) {
+	if distanceMeters < 0 || payloadKg < 0 {
+		return domain.TripEnergyRequest{}, status.Error(codes.InvalidArgument, "distance_meters and payload_kg must not be negative")
+	}
+	trip := domain.TripEnergyRequest{DistanceMeters: distanceMeters, PayloadKg: payloadKg}
+	if pickup != nil {
+		point := geo.Point{Latitude: pickup.Latitude, Longitude: pickup.Longitude}
+		if !point.Valid() {
+			return domain.TripEnergyRequest{}, status.Error(codes.InvalidArgument, "pickup is out of range")
+		}
+		trip.Pickup = &point
+	}
+	return trip, nil
+}
+
+func toPBMachine(m *domain.Machine) *pb.Machine {
+	machine := &pb.Machine{
+		Id:               m.ID,
+		SerialNumber:     m.SerialNumber,
+		Type:             m.Type,
+		Status:           m.Status,
+		Latitude:         m.Latitude,
+		Longitude:        m.Longitude,
+		BatteryLevel:     int32(m.BatteryLevel),
+		HealthFlags:      m.HealthFlags,
+		OdometerMeters:   m.OdometerMeters,
+		OperatingSeconds: m.OperatingSeconds,
+		Version:          m.Version,
+		CreatedAt:        timestamppb.New(m.CreatedAt),
+		UpdatedAt:        timestamppb.New(m.UpdatedAt),
+	}
+	if m.LastHeartbeatAt != nil {
+		machine.LastHeartbeatAt = timestamppb.New(*m.LastHeartbeatAt)
+	}
+	if m.DecommissionedAt != nil {
+		machine.DecommissionedAt = timestamppb.New(*m.DecommissionedAt)
+	}
+	if m.LastInspectedAt != nil {
+		machine.LastInspectedAt = timestamppb.New(*m.LastInspectedAt)
+	}
+	if m.HomeHubID != nil {
+		machine.HomeHubId = *m.HomeHubID
+	}
+	return machine
+}
+
+func toPBStatusEvent(e *domain.MachineStatusEvent) *pb.MachineStatusEvent {
+	event := &pb.MachineStatusEvent{
+		Id:         e.ID,
+		MachineId:  e.MachineID,
+		FromStatus: e.FromStatus,
+		ToStatus:   e.ToStatus,
+		Reason:     e.Reason,
+		Version:    e.Version,
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+	}
+	if e.ActorID != nil {
+		event.ActorId = *e.ActorID
+	}
+	return event
+}
+
+func toPBChargingStation(s *domain.ChargingStation) *pb.ChargingStation {
+	return &pb.ChargingStation{
+		Id:          s.ID,
+		Name:        s.Name,
+		MachineType: s.MachineType,
+		Latitude:    s.Latitude,
+		Longitude:   s.Longitude,
+		Slots:       int32(s.Slots),
+		SlotsInUse:  int32(s.SlotsInUse),
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+	}
+}
+
+func toPBChargingReservation(r *domain.ChargingReservation) *pb.ChargingReservation {
+	reservation := &pb.ChargingReservation{
+		Id:                  r.ID,
+		MachineId:           r.MachineID,
+		Status:              r.Status,
+		Reason:              r.Reason,
+		BatteryLevelAtStart: int32(r.BatteryLevelAtStart),
+		CreatedAt:           timestamppb.New(r.CreatedAt),
+	}
+	if r.Station != nil {
+		reservation.Station = toPBChargingStation(r.Station)
+	}
+	return reservation
+}
+
+func toPBTripBatteryCheck(c *domain.TripBatteryCheck) *pb.TripBatteryCheck {
+	return &pb.TripBatteryCheck{
+		Machine:               toPBMachine(c.Machine),
+		PickupDistanceMeters:  c.PickupDistanceMeters,
+		Consumption:           c.Consumption,
+		ProjectedBatteryLevel: c.ProjectedBatteryLevel,
+		Feasible:              c.Feasible,
+	}
+}
+
+func toPBWorkOrder(w *domain.WorkOrder) *pb.WorkOrder {
+	workOrder := &pb.WorkOrder{
+		Id:               w.ID,
+		MachineId:        w.MachineID,
+		Kind:             w.Kind,
+		Status:           w.Status,
+		Blocking:         w.Blocking,
+		Description:      w.Description,
+		TechnicianNotes:  w.TechnicianNotes,
+		OdometerMeters:   w.OdometerMeters,
+		OperatingSeconds: w.OperatingSeconds,
+		CreatedAt:        timestamppb.New(w.CreatedAt),
+	}
+	if w.FaultCode != nil {
+		workOrder.FaultCode = *w.FaultCode
+	}
+	if w.TechnicianID != nil {
+		workOrder.TechnicianId = *w.TechnicianID
+	}
+	for _, part := range w.PartsReplaced {
+		workOrder.PartsReplaced = append(workOrder.PartsReplaced, &pb.ReplacedPart{
+			PartNumber: part.PartNumber,
+			Name:       part.Name,
+			Quantity:   int32(part.Quantity),
+		})
+	}
+	if w.StartedAt != nil {
+		workOrder.StartedAt = timestamppb.New(*w.StartedAt)
+	}
+	if w.ClosedAt != nil {
+		workOrder.ClosedAt = timestamppb.New(*w.ClosedAt)
+	}
+	return workOrder
+}
+
+func toPBHub(h *domain.Hub) *pb.Hub {
+	return &pb.Hub{
+		Id:                  h.ID,
+		Name:                h.Name,
+		Latitude:            h.Latitude,
+		Longitude:           h.Longitude,
+		Capacity:            int32(h.Capacity),
+		ServiceRadiusMeters: h.ServiceRadiusMeters,
+		Timezone:            h.Timezone,
+		OpensAt:             h.OpensAt,
+		ClosesAt:            h.ClosesAt,
+		HomeBasedMachines:   int32(h.HomeBasedMachines),
+		CreatedAt:           timestamppb.New(h.CreatedAt),
+	}
+}
+
+func toPBHubInventory(inv *domain.HubInventory) *pb.HubInventory {
+	inventory := &pb.HubInventory{Hub: toPBHub(&inv.Hub), Open: inv.Open}
+	for _, stock := range inv.Stocks {
+		inventory.Stocks = append(inventory.Stocks, &pb.HubStock{
+			MachineType:     stock.MachineType,
+			HomeBased:       int32(stock.HomeBased),
+			IdleMachineIds:  stock.IdleMachineIDs,
+			ForecastDemand:  stock.ForecastDemand,
+			TargetIdleCount: int32(stock.TargetIdleCount),
+		})
+	}
+	return inventory
+}
@@ -0,0 +1,313 @@
+package fleet
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+func (s *Service) CreateHub(ctx context.Context, req domain.CreateHubRequest) (*domain.Hub, error) {
+	hub, err := s.machineRepo.CreateHub(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("service.CreateHub: %w", err)
+	}
+	return hub, nil
+}
+
+// ListHubs returns every hub along with the number of machines based at it.
+func (s *Service) ListHubs(ctx context.Context) ([]domain.Hub, error) {
+	hubs, err := s.machineRepo.ListHubs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListHubs: %w", err)
+	}
+	return hubs, nil
+}
+
+// AssignHomeHub bases a machine at a hub, or at none when hubID is empty. It
+// returns models.ErrHubFull when the hub is at capacity.
+func (s *Service) AssignHomeHub(ctx context.Context, machineID, hubID string) (*domain.Machine, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	machine, err := txRepo.FindByIDForUpdate(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.AssignHomeHub.FindByIDForUpdate: %w", err)
+	}
+	if machine.Status == domain.StatusDecommissioned {
+		return nil, models.ErrMachineDecommissioned
+	}
+
+	var home *string
+	if hubID != "" {
+		if machine.HomeHubID != nil && *machine.HomeHubID == hubID {
+			return machine, nil
+		}
+		// The hub row lock serializes assignments to the hub.
+		hub, err := txRepo.FindHubForUpdate(ctx, hubID)
+		if err != nil {
+			return nil, fmt.Errorf("service.AssignHomeHub.FindHubForUpdate: %w", err)
+		}
+		based, err := txRepo.CountHomeBasedMachines(ctx, hubID)
+		if err != nil {
+			return nil, fmt.Errorf("service.AssignHomeHub: %w", err)
+		}
+		if based >= hub.Capacity {
+			return nil, models.ErrHubFull
+		}
+		home = &hubID
+	}
+
+	machine, err = txRepo.SetHomeHub(ctx, machineID, home)
+	if err != nil {
+		return nil, fmt.Errorf("service.AssignHomeHub: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return machine, nil
+}
+
+// ListHubInventories returns, for every hub, the machines based at it and the
+// idle machines parked at it, by machine type.
+func (s *Service) ListHubInventories(ctx context.Context, now time.Time) ([]domain.HubInventory, error) {
+	hubs, err := s.machineRepo.ListHubs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListHubInventories: %w", err)
+	}
+	homeBased, err := s.machineRepo.CountHomeBasedMachinesByType(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListHubInventories: %w", err)
+	}
+
+	inventories := make([]domain.HubInventory, len(hubs))
+	for i := range hubs {
+		inventories[i] = domain.HubInventory{Hub: hubs[i], Open: hubs[i].IsOpen(now)}
+	}
+	for _, machineType := range machineTypes() {
+		// Listed fullest battery first, so the stocks are ordered the same way.
+		idle, err := s.machineRepo.ListIdleMachines(ctx, machineType)
+		if err != nil {
+			return nil, fmt.Errorf("service.ListHubInventories: %w", err)
+		}
+		stocks := make([]domain.HubStock, len(hubs))
+		for i := range hubs {
+			stocks[i] = domain.HubStock{MachineType: machineType, HomeBased: homeBased[hubs[i].ID][machineType]}
+		}
+		for _, machine := range idle {
+			if i := parkedAt(hubs, geo.Point{Latitude: machine.Latitude, Longitude: machine.Longitude}); i >= 0 {
+				stocks[i].IdleMachineIDs = append(stocks[i].IdleMachineIDs, machine.ID)
+			}
+		}
+		for i := range inventories {
+			inventories[i].Stocks = append(inventories[i].Stocks, stocks[i])
+		}
+	}
+	return inventories, nil
+}
+
+// SuggestRebalancing suggests moving idle machines between open hubs so that
+// each holds a share of the idle machines in proportion to the orders it is
+// expected to see over the horizon. Demand is forecast from the orders picked
+// up around each hub at the same hours of the week over domain.DemandLookback.
+// When machineType is empty, every machine type is planned.
+func (s *Service) SuggestRebalancing(ctx context.Context, machineType string, horizon time.Duration, now time.Time) (*domain.RebalancingPlan, error) {
+	if horizon <= 0 {
+		horizon = domain.DefaultForecastHorizon
+	}
+	inventories, err := s.ListHubInventories(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("service.SuggestRebalancing: %w", err)
+	}
+	demand, err := s.machineRepo.ListPickupDemand(ctx, now.Add(-domain.DemandLookback))
+	if err != nil {
+		return nil, fmt.Errorf("service.SuggestRebalancing: %w", err)
+	}
+
+	hubs := make([]domain.Hub, len(inventories))
+	for i := range inventories {
+		hubs[i] = inventories[i].Hub
+	}
+	forecast := forecastHubDemand(hubs, demand, now, horizon)
+
+	plan := &domain.RebalancingPlan{Horizon: horizon, Inventories: inventories}
+	for t, mt := range machineTypes() {
+		for i := range inventories {
+			stock := &inventories[i].Stocks[t]
+			stock.ForecastDemand = forecast[i][mt]
+			stock.TargetIdleCount = len(stock.IdleMachineIDs)
+		}
+		if machineType != "" && mt != machineType {
+			continue
+		}
+		setIdleTargets(inventories, t)
+		plan.Moves = append(plan.Moves, planMoves(inventories, t)...)
+	}
+	return plan, nil
+}
+
+// forecastHubDemand returns the orders each hub is expected to see over the
+// horizon, by machine type. Orders are credited to the nearest hub covering
+// their pickup.
+func forecastHubDemand(hubs []domain.Hub, demand []domain.PickupDemand, now time.Time, horizon time.Duration) []map[string]float64 {
+	// How much of each hour of the week the horizon covers.
+	weights := make(map[int]float64)
+	end := now.Add(horizon)
+	for hour := now.Truncate(time.Hour); hour.Before(end); hour = hour.Add(time.Hour) {
+		from, to := hour, hour.Add(time.Hour)
+		if from.Before(now) {
+			from = now
+		}
+		if to.After(end) {
+			to = end
+		}
+		weights[hourOfWeek(hour)] += to.Sub(from).Hours()
+	}
+	weeks := domain.DemandLookback.Hours() / (7 * 24)
+
+	forecast := make([]map[string]float64, len(hubs))
+	for i := range forecast {
+		forecast[i] = make(map[string]float64)
+	}
+	for _, d := range demand {
+		weight := weights[hourOfWeek(d.Hour)]
+		if weight == 0 {
+			continue
+		}
+		best, bestDistance := -1, math.Inf(1)
+		for i := range hubs {
+			distance := geo.DistanceMeters(hubs[i].Location(), d.Location)
+			if distance <= hubs[i].ServiceRadiusMeters && distance < bestDistance {
+				best, bestDistance = i, distance
+			}
+		}
+		if best >= 0 {
+			forecast[best][d.MachineType] += float64(d.Orders) * weight / weeks
+		}
+	}
+	return forecast
+}
+
+// setIdleTargets shares the idle machines of stock t at the open hubs out in
+// proportion to their forecast demand, capped at the hubs' capacity. Closed
+// hubs keep what they have.
+func setIdleTargets(inventories []domain.HubInventory, t int) {
+	var open []int
+	var idle int
+	var demand float64
+	for i := range inventories {
+		if inventories[i].Open {
+			open = append(open, i)
+			idle += len(inventories[i].Stocks[t].IdleMachineIDs)
+			demand += inventories[i].Stocks[t].ForecastDemand
+		}
+	}
+	if demand == 0 {
+		return
+	}
+
+	// Largest remainder, so the targets add up to the idle machines.
+	remainders := make([]float64, len(open))
+	assigned := 0
+	for k, i := range open {
+		share := float64(idle) * inventories[i].Stocks[t].ForecastDemand / demand
+		target := int(share)
+		inventories[i].Stocks[t].TargetIdleCount = target
+		remainders[k] = share - float64(target)
+		assigned += target
+	}
+	order := make([]int, len(open))
+	for k := range order {
+		order[k] = k
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for _, k := range order[:idle-assigned] {
+		inventories[open[k]].Stocks[t].TargetIdleCount++
+	}
+
+	for _, i := range open {
+		if stock := &inventories[i].Stocks[t]; stock.TargetIdleCount > inventories[i].Hub.Capacity {
+			stock.TargetIdleCount = inventories[i].Hub.Capacity
+		}
+	}
+}
+
+// planMoves pairs the hubs holding more idle machines of stock t than their
+// target with those holding fewer, closest pairs first.
+func planMoves(inventories []domain.HubInventory, t int) []domain.RebalancingMove {
+	surplus := make(map[int][]string)
+	deficit := make(map[int]int)
+	for i := range inventories {
+		stock := inventories[i].Stocks[t]
+		switch diff := len(stock.IdleMachineIDs) - stock.TargetIdleCount; {
+		case diff > 0:
+			surplus[i] = stock.IdleMachineIDs[:diff] // Fullest batteries travel
+		case diff < 0:
+			deficit[i] = -diff
+		}
+	}
+
+	var moves []domain.RebalancingMove
+	for len(surplus) > 0 && len(deficit) > 0 {
+		from, to, best := -1, -1, math.Inf(1)
+		for i := range surplus {
+			for j := range deficit {
+				d := geo.DistanceMeters(inventories[i].Hub.Location(), inventories[j].Hub.Location())
+				if d < best || (d == best && (i < from || (i == from && j < to))) {
+					from, to, best = i, j, d
+				}
+			}
+		}
+
+		n := min(len(surplus[from]), deficit[to])
+		moves = append(moves, domain.RebalancingMove{
+			MachineType:    inventories[from].Stocks[t].MachineType,
+			FromHubID:      inventories[from].Hub.ID,
+			ToHubID:        inventories[to].Hub.ID,
+			MachineIDs:     surplus[from][:n],
+			DistanceMeters: best,
+		})
+		if surplus[from] = surplus[from][n:]; len(surplus[from]) == 0 {
+			delete(surplus, from)
+		}
+		if deficit[to] -= n; deficit[to] == 0 {
+			delete(deficit, to)
+		}
+	}
+	return moves
+}
+
+// parkedAt returns the index of the hub a machine at p is parked at, or -1.
+func parkedAt(hubs []domain.Hub, p geo.Point) int {
+	best, bestDistance := -1, float64(domain.HubArrivalRadiusMeters)
+	for i := range hubs {
+		if d := geo.DistanceMeters(hubs[i].Location(), p); d <= bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+	return best
+}
+
+// hourOfWeek returns the UTC hour of the week t falls in, from 0 on Sunday.
+func hourOfWeek(t time.Time) int {
+	t = t.UTC()
+	return int(t.Weekday())*24 + t.Hour()
+}
+
+// machineTypes returns the known machine types in a stable order.
+func machineTypes() []string {
+	types := make([]string, 0, len(domain.MaxPayloadKg))
+	for machineType := range domain.MaxPayloadKg {
+		types = append(types, machineType)
+	}
+	sort.Strings(types)
+	return types
+}
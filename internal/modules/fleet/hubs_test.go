@@ -0,0 +1,66 @@
+package fleet
+
+import (
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"slices"
+	"testing"
+)
+
+// hubInventory returns the inventory of an open hub with a single stock.
+func hubInventory(id string, lat float64, capacity int, demand float64, idle ...string) domain.HubInventory {
+	return domain.HubInventory{
+		Hub:  domain.Hub{ID: id, Latitude: lat, Longitude: 13.40, Capacity: capacity},
+		Open: true,
+		Stocks: []domain.HubStock{{
+			MachineType:    domain.MachineTypeRobot,
+			IdleMachineIDs: idle,
+			ForecastDemand: demand,
+		}},
+	}
+}
+
+func TestSetIdleTargets(t *testing.T) {
+	inventories := []domain.HubInventory{
+		hubInventory("north", 52.55, 10, 1, "r1", "r2", "r3", "r4", "r5"),
+		hubInventory("centre", 52.52, 10, 3),
+		hubInventory("south", 52.49, 1, 6),
+	}
+	closed := hubInventory("closed", 52.60, 10, 50, "r6")
+	closed.Open = false
+	inventories = append(inventories, closed)
+
+	setIdleTargets(inventories, 0)
+
+	var got []int
+	for _, inv := range inventories {
+		got = append(got, inv.Stocks[0].TargetIdleCount)
+	}
+	// Five idle machines at open hubs are shared 0.5 : 1.5 : 3, with south
+	// capped at its capacity; the closed hub keeps its machine.
+	if want := []int{1, 1, 1, 0}; !slices.Equal(got, want) {
+		t.Errorf("targets = %v, want %v", got, want)
+	}
+}
+
+func TestPlanMoves(t *testing.T) {
+	inventories := []domain.HubInventory{
+		hubInventory("north", 52.55, 10, 0, "r1", "r2", "r3"),
+		hubInventory("centre", 52.52, 10, 0),
+		hubInventory("south", 52.49, 10, 0),
+	}
+	inventories[0].Stocks[0].TargetIdleCount = 1
+	inventories[1].Stocks[0].TargetIdleCount = 1
+	inventories[2].Stocks[0].TargetIdleCount = 1
+
+	moves := planMoves(inventories, 0)
+	if len(moves) != 2 {
+		t.Fatalf("got %d moves, want 2: %+v", len(moves), moves)
+	}
+	// The nearest hub is served first, with the fullest battery.
+	if m := moves[0]; m.FromHubID != "north" || m.ToHubID != "centre" || !slices.Equal(m.MachineIDs, []string{"r1"}) {
+		t.Errorf("first move = %+v, want r1 from north to centre", m)
+	}
+	if m := moves[1]; m.FromHubID != "north" || m.ToHubID != "south" || !slices.Equal(m.MachineIDs, []string{"r2"}) {
+		t.Errorf("second move = %+v, want r2 from north to south", m)
+	}
+}
@@ -0,0 +1,206 @@
+package fleet
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// HeartbeatInterval is how often machines are asked to send a heartbeat, so
+// that a couple of lost heartbeats do not mark a machine offline.
+func (s *Service) HeartbeatInterval() time.Duration {
+	return s.heartbeatTimeout / 3
+}
+
+// RecordHeartbeat stores a machine's heartbeat and counts the distance and
+// time it travelled since the previous one. A machine that was marked
+// offline comes back in transit while it still has an active trip, and idle
+// otherwise; the orders it had not picked up yet were handed to other
+// machines in the meantime. A charging machine that reached domain.ChargeTarget is released
+// from its charging slot and becomes idle. Fault flags open a blocking work
+// order, and a machine with one open goes into MAINTENANCE instead of idle.
+func (s *Service) RecordHeartbeat(ctx context.Context, machineID string, hb domain.Heartbeat) (*domain.Machine, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	current, err := txRepo.FindByIDForUpdate(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.RecordHeartbeat.FindByIDForUpdate: %w", err)
+	}
+	if current.Status == domain.StatusDecommissioned {
+		return nil, models.ErrMachineDecommissioned
+	}
+
+	// Each fault is ticketed once, until its work order is closed.
+	var faults []string
+	for _, flag := range hb.HealthFlags {
+		if !domain.IsFaultFlag(flag) {
+			continue
+		}
+		code := flag
+		err := txRepo.CreateWorkOrder(ctx, &domain.WorkOrder{
+			MachineID:        machineID,
+			Kind:             domain.WorkOrderKindFault,
+			Blocking:         true,
+			FaultCode:        &code,
+			Description:      "Reported with a heartbeat",
+			OdometerMeters:   current.OdometerMeters,
+			OperatingSeconds: current.OperatingSeconds,
+		})
+		if err != nil && !errors.Is(err, models.ErrConflict) {
+			return nil, fmt.Errorf("service.RecordHeartbeat.CreateWorkOrder: %w", err)
+		}
+		if err == nil {
+			faults = append(faults, code)
+		}
+	}
+
+	status, reason := current.Status, ""
+	switch {
+	case status == domain.StatusOffline:
+		if status, err = s.resumedStatus(ctx, txRepo, machineID); err != nil {
+			return nil, fmt.Errorf("service.RecordHeartbeat: %w", err)
+		}
+		reason = domain.StatusReasonHeartbeatResumed
+	case status == domain.StatusCharging && hb.BatteryLevel >= domain.ChargeTarget:
+		status, reason = domain.StatusIdle, domain.StatusReasonCharged
+	}
+	if len(faults) > 0 || status != current.Status {
+		resolved, err := s.maintenanceStatus(ctx, txRepo, machineID, status)
+		if err != nil {
+			return nil, fmt.Errorf("service.RecordHeartbeat: %w", err)
+		}
+		if resolved != status {
+			status, reason = resolved, domain.StatusReasonMaintenanceRequired
+		}
+	}
+	if current.Status == domain.StatusCharging && status != domain.StatusCharging {
+		if err := s.releaseChargingSlot(ctx, txRepo, machineID, hb.BatteryLevel); err != nil {
+			return nil, fmt.Errorf("service.RecordHeartbeat.releaseChargingSlot: %w", err)
+		}
+	}
+
+	machine, err := txRepo.RecordHeartbeat(ctx, machineID, status, hb, s.usageSince(current, hb, time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("service.RecordHeartbeat: %w", err)
+	}
+	if err := s.recordStatusChange(ctx, txRepo, current, status, reason, machineID); err != nil {
+		return nil, fmt.Errorf("service.RecordHeartbeat: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, fault := range faults {
+		log.Printf("WARN: Machine %s reported %s, a work order was opened", machineID, fault)
+	}
+	switch reason {
+	case domain.StatusReasonHeartbeatResumed:
+		log.Printf("INFO: Machine %s is back online", machineID)
+	case domain.StatusReasonCharged:
+		log.Printf("INFO: Machine %s finished charging at %d%%", machineID, hb.BatteryLevel)
+	case domain.StatusReasonMaintenanceRequired:
+		log.Printf("INFO: Machine %s went into maintenance", machineID)
+	}
+	return machine, nil
+}
+
+// resumedStatus returns the status a machine that was offline comes back in:
+// in transit while it is still on an active trip, idle otherwise.
+func (s *Service) resumedStatus(ctx context.Context, repo RepositoryInterface, machineID string) (string, error) {
+	active, err := repo.HasActiveTrip(ctx, machineID)
+	if err != nil {
+		return "", err
+	}
+	if active {
+		return domain.StatusInTransit, nil
+	}
+	return domain.StatusIdle, nil
+}
+
+// usageSince returns the distance and time a machine travelled between its
+// previous heartbeat and hb. Only time in transit counts, and a gap in the
+// heartbeats counts for the heartbeat timeout at most.
+func (s *Service) usageSince(m *domain.Machine, hb domain.Heartbeat, now time.Time) domain.UsageDelta {
+	if m.Status != domain.StatusInTransit || m.LastHeartbeatAt == nil {
+		return domain.UsageDelta{}
+	}
+	elapsed := now.Sub(*m.LastHeartbeatAt)
+	if elapsed > s.heartbeatTimeout {
+		elapsed = s.heartbeatTimeout
+	}
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return domain.UsageDelta{
+		Meters: geo.DistanceMeters(
+			geo.Point{Latitude: m.Latitude, Longitude: m.Longitude},
+			geo.Point{Latitude: hb.Latitude, Longitude: hb.Longitude},
+		),
+		Seconds: int64(elapsed.Seconds()),
+	}
+}
+
+// MarkSilentMachinesOffline marks machines that have not sent a heartbeat
+// within the heartbeat timeout offline, and records an event for each. The
+// order service re-dispatches the orders assigned to them, and the charging
+// slots they held are freed.
+func (s *Service) MarkSilentMachinesOffline(ctx context.Context, now time.Time) ([]domain.MachineStatusEvent, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	events, err := txRepo.MarkSilentMachinesOffline(ctx, now.Add(-s.heartbeatTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("service.MarkSilentMachinesOffline: %w", err)
+	}
+	for i := range events {
+		if err := txRepo.CreateStatusEvent(ctx, &events[i]); err != nil {
+			return nil, fmt.Errorf("service.MarkSilentMachinesOffline: %w", err)
+		}
+		if events[i].FromStatus == domain.StatusCharging {
+			if _, err := txRepo.EndChargingReservation(ctx, events[i].MachineID, domain.ChargingStatusCancelled); err != nil {
+				return nil, fmt.Errorf("service.MarkSilentMachinesOffline: %w", err)
+			}
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// RunLivenessTracker marks silent machines offline every interval until ctx
+// is cancelled.
+func RunLivenessTracker(ctx context.Context, s ServiceInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := s.MarkSilentMachinesOffline(ctx, time.Now())
+		if err != nil {
+			log.Printf("ERROR: Liveness check failed: %v", err)
+		}
+		for _, event := range events {
+			log.Printf("WARN: Machine %s went offline (was %s)", event.MachineID, event.FromStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
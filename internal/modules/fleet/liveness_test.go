@@ -0,0 +1,30 @@
+package fleet
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"testing"
+)
+
+// tripRepo knows which machines are on an active trip.
+type tripRepo struct {
+	RepositoryInterface
+	onTrip map[string]bool
+}
+
+func (r *tripRepo) HasActiveTrip(_ context.Context, machineID string) (bool, error) {
+	return r.onTrip[machineID], nil
+}
+
+func TestResumedStatus(t *testing.T) {
+	s := &Service{}
+	repo := &tripRepo{onTrip: map[string]bool{"robot-1": true}}
+
+	// A machine that lost its connection mid-trip is still carrying orders.
+	if got, err := s.resumedStatus(context.Background(), repo, "robot-1"); err != nil || got != domain.StatusInTransit {
+		t.Errorf("resumedStatus on a trip = %q, %v, want %s", got, err, domain.StatusInTransit)
+	}
+	if got, err := s.resumedStatus(context.Background(), repo, "robot-2"); err != nil || got != domain.StatusIdle {
+		t.Errorf("resumedStatus without a trip = %q, %v, want %s", got, err, domain.StatusIdle)
+	}
+}
@@ -0,0 +1,276 @@
+package fleet
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// OpenWorkOrder opens a maintenance work order for a machine. A blocking work
+// order takes an idle or charging machine into MAINTENANCE at once; a machine
+// in transit finishes its trip first and goes into MAINTENANCE when it
+// reports back idle. It returns models.ErrConflict when the fault or
+// inspection is already ticketed.
+func (s *Service) OpenWorkOrder(ctx context.Context, req domain.CreateWorkOrderRequest) (*domain.WorkOrder, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	machine, err := txRepo.FindByIDForUpdate(ctx, req.MachineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.OpenWorkOrder.FindByIDForUpdate: %w", err)
+	}
+	workOrder, err := s.openWorkOrder(ctx, txRepo, machine, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("INFO: Opened %s work order %s for machine %s", workOrder.Kind, workOrder.ID, machine.ID)
+	return workOrder, nil
+}
+
+// openWorkOrder opens a work order for a machine whose row the caller holds locked.
+func (s *Service) openWorkOrder(ctx context.Context, repo RepositoryInterface, machine *domain.Machine, req domain.CreateWorkOrderRequest) (*domain.WorkOrder, error) {
+	if machine.Status == domain.StatusDecommissioned {
+		return nil, models.ErrMachineDecommissioned
+	}
+
+	workOrder := &domain.WorkOrder{
+		MachineID:        machine.ID,
+		Kind:             req.Kind,
+		Blocking:         req.Blocking,
+		FaultCode:        req.FaultCode,
+		Description:      req.Description,
+		OdometerMeters:   machine.OdometerMeters,
+		OperatingSeconds: machine.OperatingSeconds,
+	}
+	if err := repo.CreateWorkOrder(ctx, workOrder); err != nil {
+		return nil, fmt.Errorf("service.openWorkOrder.CreateWorkOrder: %w", err)
+	}
+
+	if workOrder.Blocking && (machine.Status == domain.StatusIdle || machine.Status == domain.StatusCharging) {
+		if _, err := repo.SetStatus(ctx, machine.ID, domain.StatusMaintenance); err != nil {
+			return nil, fmt.Errorf("service.openWorkOrder.SetStatus: %w", err)
+		}
+		if err := s.recordStatusChange(ctx, repo, machine, domain.StatusMaintenance, domain.StatusReasonMaintenanceRequired, ""); err != nil {
+			return nil, fmt.Errorf("service.openWorkOrder: %w", err)
+		}
+		if machine.Status == domain.StatusCharging {
+			if err := s.releaseChargingSlot(ctx, repo, machine.ID, machine.BatteryLevel); err != nil {
+				return nil, fmt.Errorf("service.openWorkOrder.releaseChargingSlot: %w", err)
+			}
+		}
+		machine.Status = domain.StatusMaintenance
+	}
+	return workOrder, nil
+}
+
+// ReportFault opens a blocking work order for a fault code a machine reported.
+func (s *Service) ReportFault(ctx context.Context, machineID, faultCode, description string) (*domain.WorkOrder, error) {
+	return s.OpenWorkOrder(ctx, domain.CreateWorkOrderRequest{
+		MachineID:   machineID,
+		Kind:        domain.WorkOrderKindFault,
+		Blocking:    true,
+		FaultCode:   &faultCode,
+		Description: description,
+	})
+}
+
+// StartWorkOrder records that a technician started working on an open work order.
+func (s *Service) StartWorkOrder(ctx context.Context, id, technicianID string) (*domain.WorkOrder, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	current, err := txRepo.FindWorkOrderForUpdate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.StartWorkOrder.FindWorkOrderForUpdate: %w", err)
+	}
+	switch current.Status {
+	case domain.WorkOrderStatusInProgress:
+		return nil, models.ErrWorkOrderStarted
+	case domain.WorkOrderStatusCompleted, domain.WorkOrderStatusCancelled:
+		return nil, models.ErrWorkOrderClosed
+	}
+
+	workOrder, err := txRepo.StartWorkOrder(ctx, id, technicianID)
+	if err != nil {
+		return nil, fmt.Errorf("service.StartWorkOrder: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return workOrder, nil
+}
+
+// CompleteWorkOrder closes a work order as done. A completed inspection resets
+// the machine's inspection counters.
+func (s *Service) CompleteWorkOrder(ctx context.Context, id string, req domain.CloseWorkOrderRequest) (*domain.WorkOrder, error) {
+	return s.closeWorkOrder(ctx, id, domain.WorkOrderStatusCompleted, req)
+}
+
+// CancelWorkOrder closes a work order that turned out to be unnecessary.
+func (s *Service) CancelWorkOrder(ctx context.Context, id string, req domain.CloseWorkOrderRequest) (*domain.WorkOrder, error) {
+	return s.closeWorkOrder(ctx, id, domain.WorkOrderStatusCancelled, req)
+}
+
+// closeWorkOrder closes a work order and returns a machine in MAINTENANCE to
+// service when no blocking work order is left open.
+func (s *Service) closeWorkOrder(ctx context.Context, id, status string, req domain.CloseWorkOrderRequest) (*domain.WorkOrder, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	current, err := txRepo.FindWorkOrderForUpdate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.closeWorkOrder.FindWorkOrderForUpdate: %w", err)
+	}
+	if !current.IsOpen() {
+		return nil, models.ErrWorkOrderClosed
+	}
+	machine, err := txRepo.FindByIDForUpdate(ctx, current.MachineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.closeWorkOrder.FindByIDForUpdate: %w", err)
+	}
+
+	workOrder, err := txRepo.CloseWorkOrder(ctx, id, status, req)
+	if err != nil {
+		return nil, fmt.Errorf("service.closeWorkOrder: %w", err)
+	}
+	if workOrder.Kind == domain.WorkOrderKindInspection && status == domain.WorkOrderStatusCompleted {
+		if err := txRepo.RecordInspection(ctx, machine.ID); err != nil {
+			return nil, fmt.Errorf("service.closeWorkOrder.RecordInspection: %w", err)
+		}
+	}
+
+	released := false
+	if machine.Status == domain.StatusMaintenance {
+		blocking, err := txRepo.CountBlockingWorkOrders(ctx, machine.ID)
+		if err != nil {
+			return nil, fmt.Errorf("service.closeWorkOrder: %w", err)
+		}
+		if blocking == 0 {
+			if _, err := txRepo.SetStatus(ctx, machine.ID, domain.StatusIdle); err != nil {
+				return nil, fmt.Errorf("service.closeWorkOrder.SetStatus: %w", err)
+			}
+			if err := s.recordStatusChange(ctx, txRepo, machine, domain.StatusIdle, domain.StatusReasonMaintenanceCompleted, ""); err != nil {
+				return nil, fmt.Errorf("service.closeWorkOrder: %w", err)
+			}
+			released = true
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	if released {
+		log.Printf("INFO: Machine %s is back in service after maintenance", machine.ID)
+	}
+	return workOrder, nil
+}
+
+// ListWorkOrders returns a page of the work orders matching filter, newest
+// first, along with the total number of matching work orders. Listed for one
+// machine, they make up its service history.
+func (s *Service) ListWorkOrders(ctx context.Context, filter domain.ListWorkOrdersFilter, page, limit int32) ([]domain.WorkOrder, int, error) {
+	workOrders, total, err := s.machineRepo.ListWorkOrders(ctx, filter, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service.ListWorkOrders: %w", err)
+	}
+	return workOrders, total, nil
+}
+
+// ScheduleInspections opens a blocking inspection work order for every machine
+// that reached a limit of its type's inspection policy.
+func (s *Service) ScheduleInspections(ctx context.Context, now time.Time) ([]domain.WorkOrder, error) {
+	machineTypes := make([]string, 0, len(domain.InspectionPolicies))
+	for machineType := range domain.InspectionPolicies {
+		machineTypes = append(machineTypes, machineType)
+	}
+	sort.Strings(machineTypes)
+
+	var workOrders []domain.WorkOrder
+	for _, machineType := range machineTypes {
+		machines, err := s.machineRepo.ListMachinesDueForInspection(ctx, machineType, domain.InspectionPolicies[machineType], now)
+		if err != nil {
+			return workOrders, fmt.Errorf("service.ScheduleInspections: %w", err)
+		}
+		for i := range machines {
+			reason := machines[i].InspectionDue(now)
+			if reason == "" {
+				continue
+			}
+			workOrder, err := s.OpenWorkOrder(ctx, domain.CreateWorkOrderRequest{
+				MachineID:   machines[i].ID,
+				Kind:        domain.WorkOrderKindInspection,
+				Blocking:    true,
+				Description: "Scheduled inspection: " + reason,
+			})
+			switch {
+			case err == nil:
+				workOrders = append(workOrders, *workOrder)
+			case errors.Is(err, models.ErrConflict), errors.Is(err, models.ErrMachineDecommissioned):
+				// Opened or decommissioned since it was listed
+			default:
+				log.Printf("ERROR: Failed to schedule the inspection of machine %s: %v", machines[i].ID, err)
+			}
+		}
+	}
+	return workOrders, nil
+}
+
+// maintenanceStatus returns the status a machine that would become idle or
+// start charging takes instead while blocking work orders are open.
+func (s *Service) maintenanceStatus(ctx context.Context, repo RepositoryInterface, machineID, status string) (string, error) {
+	if status != domain.StatusIdle && status != domain.StatusCharging {
+		return status, nil
+	}
+	blocking, err := repo.CountBlockingWorkOrders(ctx, machineID)
+	if err != nil {
+		return "", err
+	}
+	if blocking > 0 {
+		return domain.StatusMaintenance, nil
+	}
+	return status, nil
+}
+
+// RunMaintenanceScheduler opens the inspections that fell due every interval
+// until ctx is cancelled.
+func RunMaintenanceScheduler(ctx context.Context, s ServiceInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		workOrders, err := s.ScheduleInspections(ctx, time.Now())
+		if err != nil {
+			log.Printf("ERROR: Maintenance scheduler failed: %v", err)
+		}
+		if len(workOrders) > 0 {
+			log.Printf("INFO: Scheduled %d inspection(s)", len(workOrders))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
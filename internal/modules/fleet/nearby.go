@@ -0,0 +1,36 @@
+package fleet
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"fmt"
+	"slices"
+)
+
+// FindNearbyMachines returns the idle machines closest to a pickup that are
+// charged to the battery threshold and can carry the payload, nearest first.
+func (s *Service) FindNearbyMachines(ctx context.Context, q domain.NearbyMachinesQuery) ([]domain.NearbyMachine, error) {
+	if q.RadiusMeters <= 0 {
+		q.RadiusMeters = domain.DefaultSearchRadiusMeters
+	}
+	if q.Limit <= 0 {
+		q.Limit = domain.DefaultNearbyLimit
+	}
+
+	machineTypes := domain.TypesCarrying(q.PayloadKg)
+	if q.MachineType != "" {
+		if !slices.Contains(machineTypes, q.MachineType) {
+			return nil, nil
+		}
+		machineTypes = []string{q.MachineType}
+	}
+	if len(machineTypes) == 0 {
+		return nil, nil
+	}
+
+	machines, err := s.machineRepo.FindNearbyMachines(ctx, q.Pickup, q.RadiusMeters, machineTypes, q.MinBatteryLevel, q.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("service.FindNearbyMachines: %w", err)
+	}
+	return machines, nil
+}
@@ -0,0 +1,59 @@
+package fleet
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"slices"
+	"testing"
+)
+
+// nearbyRepo records the search FindNearbyMachines runs.
+type nearbyRepo struct {
+	RepositoryInterface
+	searched     bool
+	radius       float64
+	machineTypes []string
+	limit        int
+}
+
+func (r *nearbyRepo) FindNearbyMachines(_ context.Context, _ geo.Point, radiusMeters float64, machineTypes []string, _ int, limit int) ([]domain.NearbyMachine, error) {
+	r.searched = true
+	r.radius, r.machineTypes, r.limit = radiusMeters, machineTypes, limit
+	return nil, nil
+}
+
+func TestFindNearbyMachines(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     domain.NearbyMachinesQuery
+		wantTypes []string // nil when no search should run
+	}{
+		{"light payload", domain.NearbyMachinesQuery{PayloadKg: 2}, []string{domain.MachineTypeDrone, domain.MachineTypeRobot}},
+		{"heavy payload", domain.NearbyMachinesQuery{PayloadKg: 20}, []string{domain.MachineTypeRobot}},
+		{"requested type", domain.NearbyMachinesQuery{PayloadKg: 2, MachineType: domain.MachineTypeDrone}, []string{domain.MachineTypeDrone}},
+		{"requested type too small", domain.NearbyMachinesQuery{PayloadKg: 20, MachineType: domain.MachineTypeDrone}, nil},
+		{"too heavy for any machine", domain.NearbyMachinesQuery{PayloadKg: 100}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &nearbyRepo{}
+			s := &Service{machineRepo: repo}
+			if _, err := s.FindNearbyMachines(context.Background(), tt.query); err != nil {
+				t.Fatalf("FindNearbyMachines: %v", err)
+			}
+			if tt.wantTypes == nil {
+				if repo.searched {
+					t.Errorf("searched for %v, want no search", repo.machineTypes)
+				}
+				return
+			}
+			if !slices.Equal(repo.machineTypes, tt.wantTypes) {
+				t.Errorf("searched for %v, want %v", repo.machineTypes, tt.wantTypes)
+			}
+			if repo.radius != domain.DefaultSearchRadiusMeters || repo.limit != domain.DefaultNearbyLimit {
+				t.Errorf("searched %.0f m for %d machines, want the defaults", repo.radius, repo.limit)
+			}
+		})
+	}
+}
@@ -0,0 +1,1072 @@
+package fleet
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RepositoryInterface defines methods for interacting with machine storage.
+type RepositoryInterface interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	WithTx(tx pgx.Tx) *Repository
+
+	Create(ctx context.Context, req domain.RegisterMachineRequest) (*domain.Machine, error)
+	FindByID(ctx context.Context, id string) (*domain.Machine, error)
+	FindByIDForUpdate(ctx context.Context, id string) (*domain.Machine, error)
+	List(ctx context.Context, filter domain.ListMachinesFilter, limit, offset int32) ([]domain.Machine, int, error)
+	UpdateStatus(ctx context.Context, id string, req domain.MachineStatusUpdateRequest) (*domain.Machine, error)
+	Decommission(ctx context.Context, id string, expectedVersion *int64) (*domain.Machine, error)
+	RecordHeartbeat(ctx context.Context, id, status string, hb domain.Heartbeat, usage domain.UsageDelta) (*domain.Machine, error)
+	MarkSilentMachinesOffline(ctx context.Context, silentSince time.Time) ([]domain.MachineStatusEvent, error)
+	CreateStatusEvent(ctx context.Context, event *domain.MachineStatusEvent) error
+	ListStatusHistory(ctx context.Context, machineID string, limit, offset int32) ([]domain.MachineStatusEvent, int, error)
+
+	SetStatus(ctx context.Context, id, status string) (*domain.Machine, error)
+	HasActiveTrip(ctx context.Context, machineID string) (bool, error)
+	ListMachinesNeedingCharge(ctx context.Context, threshold int) ([]domain.Machine, error)
+	ListIdleMachines(ctx context.Context, machineType string) ([]domain.Machine, error)
+	FindNearbyMachines(ctx context.Context, pickup geo.Point, radiusMeters float64, machineTypes []string, minBatteryLevel, limit int) ([]domain.NearbyMachine, error)
+
+	CreateChargingStation(ctx context.Context, req domain.CreateChargingStationRequest) (*domain.ChargingStation, error)
+	ListChargingStations(ctx context.Context, machineType string) ([]domain.ChargingStation, error)
+	ReserveChargingSlot(ctx context.Context, reservation *domain.ChargingReservation) error
+	FindActiveChargingReservation(ctx context.Context, machineID string) (*domain.ChargingReservation, error)
+	EndChargingReservation(ctx context.Context, machineID, status string) (bool, error)
+
+	CreateWorkOrder(ctx context.Context, workOrder *domain.WorkOrder) error
+	FindWorkOrderForUpdate(ctx context.Context, id string) (*domain.WorkOrder, error)
+	StartWorkOrder(ctx context.Context, id, technicianID string) (*domain.WorkOrder, error)
+	CloseWorkOrder(ctx context.Context, id, status string, req domain.CloseWorkOrderRequest) (*domain.WorkOrder, error)
+	CountBlockingWorkOrders(ctx context.Context, machineID string) (int, error)
+	ListWorkOrders(ctx context.Context, filter domain.ListWorkOrdersFilter, limit, offset int32) ([]domain.WorkOrder, int, error)
+	RecordInspection(ctx context.Context, machineID string) error
+	ListMachinesDueForInspection(ctx context.Context, machineType string, policy domain.InspectionPolicy, now time.Time) ([]domain.Machine, error)
+
+	CreateHub(ctx context.Context, req domain.CreateHubRequest) (*domain.Hub, error)
+	FindHubForUpdate(ctx context.Context, id string) (*domain.Hub, error)
+	ListHubs(ctx context.Context) ([]domain.Hub, error)
+	CountHomeBasedMachines(ctx context.Context, hubID string) (int, error)
+	CountHomeBasedMachinesByType(ctx context.Context) (map[string]map[string]int, error)
+	SetHomeHub(ctx context.Context, machineID string, hubID *string) (*domain.Machine, error)
+	ListPickupDemand(ctx context.Context, since time.Time) ([]domain.PickupDemand, error)
+
+	CreateCertificate(ctx context.Context, cert *domain.MachineCertificate) error
+	RevokeCertificates(ctx context.Context, machineID string) (int64, error)
+	IsCertificateRevoked(ctx context.Context, serialNumber string) (bool, error)
+}
+
+// DBExecutor represents anything that can execute a SQL query,
+// which includes both a connection pool and a transaction.
+type DBExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db       *pgxpool.Pool
+	executor DBExecutor
+}
+
+func NewRepository(db *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		db:       db,
+		executor: db,
+	}
+}
+
+// BeginTx starts a new database transaction.
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.db.Begin(ctx)
+}
+
+// WithTx returns a new instance of the Repository that is "scoped" to the provided transaction.
+func (r *Repository) WithTx(tx pgx.Tx) *Repository {
+	return &Repository{
+		db:       r.db,
+		executor: tx,
+	}
+}
+
+// Machines registered before the fleet service have no serial number.
+const machineColumns = `id, COALESCE(serial_number, ''), type, status, latitude, longitude, battery_level, health_flags, last_heartbeat_at, decommissioned_at, created_at, updated_at,
+	odometer_meters, operating_seconds, last_inspected_at, inspected_odometer_meters, inspected_operating_seconds, home_hub_id, version`
+
+func (r *Repository) scanMachine(row pgx.Row) (*domain.Machine, error) {
+	var m domain.Machine
+	if err := row.Scan(machineScanTargets(&m)...); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// machineScanTargets returns the destinations of machineColumns in m, for
+// queries that select more than the machine.
+func machineScanTargets(m *domain.Machine) []any {
+	return []any{
+		&m.ID,
+		&m.SerialNumber,
+		&m.Type,
+		&m.Status,
+		&m.Latitude,
+		&m.Longitude,
+		&m.BatteryLevel,
+		&m.HealthFlags,
+		&m.LastHeartbeatAt,
+		&m.DecommissionedAt,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&m.OdometerMeters,
+		&m.OperatingSeconds,
+		&m.LastInspectedAt,
+		&m.InspectedOdometerMeters,
+		&m.InspectedOperatingSeconds,
+		&m.HomeHubID,
+		&m.Version,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, req domain.RegisterMachineRequest) (*domain.Machine, error) {
+	query := `
+	INSERT INTO machines (serial_number, type, status, latitude, longitude, battery_level)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING ` + machineColumns
+
+	row := r.executor.QueryRow(ctx, query, req.SerialNumber, req.Type, domain.StatusIdle, req.Latitude, req.Longitude, req.BatteryLevel)
+	machine, err := r.scanMachine(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation on serial_number
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.Create: %w", err)
+	}
+	return machine, nil
+}
+
+func (r *Repository) FindByID(ctx context.Context, id string) (*domain.Machine, error) {
+	query := `SELECT ` + machineColumns + ` FROM machines WHERE id = $1`
+
+	machine, err := r.scanMachine(r.executor.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindByID: %w", err)
+	}
+	return machine, nil
+}
+
+// FindByIDForUpdate loads a machine and locks its row until the surrounding transaction ends.
+func (r *Repository) FindByIDForUpdate(ctx context.Context, id string) (*domain.Machine, error) {
+	query := `SELECT ` + machineColumns + ` FROM machines WHERE id = $1 FOR UPDATE`
+
+	machine, err := r.scanMachine(r.executor.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindByIDForUpdate: %w", err)
+	}
+	return machine, nil
+}
+
+// buildMachinesWhere builds the WHERE clause for listing machines.
+func buildMachinesWhere(filter domain.ListMachinesFilter) (string, []any) {
+	var whereClauses []string
+	var args []any
+	argIdx := 1
+
+	if filter.Type != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("type = $%d", argIdx))
+		args = append(args, filter.Type)
+		argIdx++
+	}
+	if filter.Status != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", argIdx))
+		args = append(args, filter.Status)
+		argIdx++
+	} else {
+		whereClauses = append(whereClauses, fmt.Sprintf("status <> '%s'", domain.StatusDecommissioned))
+	}
+	if box := filter.BoundingBox; box != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("latitude BETWEEN $%d AND $%d", argIdx, argIdx+1))
+		args = append(args, box.MinLatitude, box.MaxLatitude)
+		argIdx += 2
+
+		lngOp := "AND"
+		if box.MinLongitude > box.MaxLongitude { // Crosses the antimeridian
+			lngOp = "OR"
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("(longitude >= $%d %s longitude <= $%d)", argIdx, lngOp, argIdx+1))
+		args = append(args, box.MinLongitude, box.MaxLongitude)
+		argIdx += 2
+	}
+
+	return strings.Join(whereClauses, " AND "), args
+}
+
+// List returns a page of the machines matching filter ordered by registration,
+// along with the total number of matching machines.
+func (r *Repository) List(ctx context.Context, filter domain.ListMachinesFilter, limit, offset int32) ([]domain.Machine, int, error) {
+	where, args := buildMachinesWhere(filter)
+
+	var total int
+	if err := r.executor.QueryRow(ctx, `SELECT COUNT(*) FROM machines WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("repository.List.Count: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM machines WHERE %s ORDER BY created_at, id LIMIT $%d OFFSET $%d`,
+		machineColumns, where, len(args)+1, len(args)+2)
+	rows, err := r.executor.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repository.List: %w", err)
+	}
+	defer rows.Close()
+
+	var machines []domain.Machine
+	for rows.Next() {
+		machine, err := r.scanMachine(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("repository.List.Scan: %w", err)
+		}
+		machines = append(machines, *machine)
+	}
+	return machines, total, rows.Err()
+}
+
+// UpdateStatus records a machine's status and position, and its battery level
+// when reported. Decommissioned machines cannot be updated and yield
+// models.ErrMachineDecommissioned; an update made against a version the
+// machine moved on from yields models.ErrConflict.
+func (r *Repository) UpdateStatus(ctx context.Context, id string, req domain.MachineStatusUpdateRequest) (*domain.Machine, error) {
+	query := `
+	UPDATE machines
+	SET status = $1, latitude = $2, longitude = $3, battery_level = COALESCE($4, battery_level),
+		version = version + (status <> $1)::int, updated_at = NOW()
+	WHERE id = $5 AND status <> $6 AND ($7::bigint IS NULL OR version = $7)
+	RETURNING ` + machineColumns
+
+	row := r.executor.QueryRow(ctx, query, req.Status, req.Latitude, req.Longitude, req.BatteryLevel, id, domain.StatusDecommissioned, req.ExpectedVersion)
+	machine, err := r.scanMachine(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			current, findErr := r.FindByID(ctx, id)
+			if findErr != nil {
+				return nil, findErr
+			}
+			if current.Status == domain.StatusDecommissioned {
+				return nil, models.ErrMachineDecommissioned
+			}
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.UpdateStatus: %w", err)
+	}
+	return machine, nil
+}
+
+// Decommission takes a machine out of service for good. It returns
+// models.ErrConflict if the machine was decommissioned or went into transit
+// concurrently, or moved on from expectedVersion when it is set.
+func (r *Repository) Decommission(ctx context.Context, id string, expectedVersion *int64) (*domain.Machine, error) {
+	query := `
+	UPDATE machines
+	SET status = $1, decommissioned_at = NOW(), version = version + 1, updated_at = NOW()
+	WHERE id = $2 AND status NOT IN ($1, $3) AND ($4::bigint IS NULL OR version = $4)
+	RETURNING ` + machineColumns
+
+	machine, err := r.scanMachine(r.executor.QueryRow(ctx, query, domain.StatusDecommissioned, id, domain.StatusInTransit, expectedVersion))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.Decommission: %w", err)
+	}
+	return machine, nil
+}
+
+// RecordHeartbeat stores the position, battery level and health flags a
+// machine reported with a heartbeat, adds usage to its counters and sets its
+// status.
+func (r *Repository) RecordHeartbeat(ctx context.Context, id, status string, hb domain.Heartbeat, usage domain.UsageDelta) (*domain.Machine, error) {
+	query := `
+	UPDATE machines
+	SET status = $1, latitude = $2, longitude = $3, battery_level = $4, health_flags = $5,
+		odometer_meters = odometer_meters + $7, operating_seconds = operating_seconds + $8,
+		version = version + (status <> $1)::int, last_heartbeat_at = NOW(), updated_at = NOW()
+	WHERE id = $6
+	RETURNING ` + machineColumns
+
+	flags := hb.HealthFlags
+	if flags == nil {
+		flags = []string{}
+	}
+	machine, err := r.scanMachine(r.executor.QueryRow(ctx, query, status, hb.Latitude, hb.Longitude, hb.BatteryLevel, flags, id, usage.Meters, usage.Seconds))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.RecordHeartbeat: %w", err)
+	}
+	return machine, nil
+}
+
+// MarkSilentMachinesOffline marks every machine that has not sent a heartbeat
+// since silentSince offline and returns the status changes. Machines that
+// never sent one count from their last update. Machines under maintenance are
+// not expected to report and are left alone.
+func (r *Repository) MarkSilentMachinesOffline(ctx context.Context, silentSince time.Time) ([]domain.MachineStatusEvent, error) {
+	query := `
+	UPDATE machines m
+	SET status = $1, version = m.version + 1, updated_at = NOW()
+	FROM (
+		SELECT id, status FROM machines
+		WHERE status NOT IN ($1, $2, $3) AND COALESCE(last_heartbeat_at, updated_at) < $4
+		FOR UPDATE SKIP LOCKED
+	) silent
+	WHERE m.id = silent.id
+	RETURNING m.id, silent.status, m.version
+	`
+	rows, err := r.executor.Query(ctx, query, domain.StatusOffline, domain.StatusMaintenance, domain.StatusDecommissioned, silentSince)
+	if err != nil {
+		return nil, fmt.Errorf("repository.MarkSilentMachinesOffline: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.MachineStatusEvent
+	for rows.Next() {
+		event := domain.MachineStatusEvent{ToStatus: domain.StatusOffline, Reason: domain.StatusReasonHeartbeatTimeout}
+		if err := rows.Scan(&event.MachineID, &event.FromStatus, &event.Version); err != nil {
+			return nil, fmt.Errorf("repository.MarkSilentMachinesOffline.Scan: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *Repository) CreateStatusEvent(ctx context.Context, event *domain.MachineStatusEvent) error {
+	query := `
+	INSERT INTO machine_status_history (machine_id, from_status, to_status, reason, version, actor_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at
+	`
+	err := r.executor.QueryRow(ctx, query, event.MachineID, event.FromStatus, event.ToStatus, event.Reason, event.Version, event.ActorID).
+		Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("repository.CreateStatusEvent: %w", err)
+	}
+	return nil
+}
+
+// ListStatusHistory returns a page of a machine's status events, newest first,
+// along with the total number of events. Events recorded before machines were
+// versioned have version 0.
+func (r *Repository) ListStatusHistory(ctx context.Context, machineID string, limit, offset int32) ([]domain.MachineStatusEvent, int, error) {
+	var total int
+	if err := r.executor.QueryRow(ctx, `SELECT COUNT(*) FROM machine_status_history WHERE machine_id = $1`, machineID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("repository.ListStatusHistory.Count: %w", err)
+	}
+
+	query := `
+	SELECT id, machine_id, from_status, to_status, reason, COALESCE(version, 0), actor_id, created_at
+	FROM machine_status_history
+	WHERE machine_id = $1
+	ORDER BY id DESC
+	LIMIT $2 OFFSET $3
+	`
+	rows, err := r.executor.Query(ctx, query, machineID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repository.ListStatusHistory: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.MachineStatusEvent
+	for rows.Next() {
+		var e domain.MachineStatusEvent
+		if err := rows.Scan(&e.ID, &e.MachineID, &e.FromStatus, &e.ToStatus, &e.Reason, &e.Version, &e.ActorID, &e.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("repository.ListStatusHistory.Scan: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, total, rows.Err()
+}
+
+// SetStatus sets the status of a machine that is not decommissioned.
+func (r *Repository) SetStatus(ctx context.Context, id, status string) (*domain.Machine, error) {
+	query := `
+	UPDATE machines SET status = $1, version = version + (status <> $1)::int, updated_at = NOW()
+	WHERE id = $2 AND status <> $3
+	RETURNING ` + machineColumns
+
+	machine, err := r.scanMachine(r.executor.QueryRow(ctx, query, status, id, domain.StatusDecommissioned))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.SetStatus: %w", err)
+	}
+	return machine, nil
+}
+
+// HasActiveTrip reports whether a machine is still on a delivery trip that
+// has stops left to serve.
+func (r *Repository) HasActiveTrip(ctx context.Context, machineID string) (bool, error) {
+	var active bool
+	query := `SELECT EXISTS (SELECT 1 FROM delivery_trips WHERE machine_id = $1 AND status = 'active')`
+
+	if err := r.executor.QueryRow(ctx, query, machineID).Scan(&active); err != nil {
+		return false, fmt.Errorf("repository.HasActiveTrip: %w", err)
+	}
+	return active, nil
+}
+
+// ListMachinesNeedingCharge returns the idle machines whose battery is below
+// threshold and that hold no charging slot yet, emptiest first.
+func (r *Repository) ListMachinesNeedingCharge(ctx context.Context, threshold int) ([]domain.Machine, error) {
+	query := `
+	SELECT ` + machineColumns + ` FROM machines m
+	WHERE status = $1 AND battery_level < $2
+		AND NOT EXISTS (SELECT 1 FROM charging_reservations r WHERE r.machine_id = m.id AND r.status = $3)
+	ORDER BY battery_level, id
+	`
+	rows, err := r.executor.Query(ctx, query, domain.StatusIdle, threshold, domain.ChargingStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListMachinesNeedingCharge: %w", err)
+	}
+	return r.collectMachines(rows, "repository.ListMachinesNeedingCharge")
+}
+
+// ListIdleMachines returns the idle machines of a type, fullest battery first.
+func (r *Repository) ListIdleMachines(ctx context.Context, machineType string) ([]domain.Machine, error) {
+	query := `SELECT ` + machineColumns + ` FROM machines WHERE status = $1 AND type = $2 ORDER BY battery_level DESC, id`
+
+	rows, err := r.executor.Query(ctx, query, domain.StatusIdle, machineType)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListIdleMachines: %w", err)
+	}
+	return r.collectMachines(rows, "repository.ListIdleMachines")
+}
+
+// FindNearbyMachines returns up to limit idle machines of the given types
+// within radiusMeters of pickup and charged to at least minBatteryLevel,
+// nearest first. The radius filter and the KNN ordering both run on the GiST
+// index of machines.location.
+func (r *Repository) FindNearbyMachines(ctx context.Context, pickup geo.Point, radiusMeters float64, machineTypes []string, minBatteryLevel, limit int) ([]domain.NearbyMachine, error) {
+	// The status is inlined so the planner sees it in every plan.
+	const point = `ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography`
+	query := fmt.Sprintf(`
+	SELECT %s, ST_Distance(location, %s)
+	FROM machines
+	WHERE status = '%s' AND type = ANY($3) AND battery_level >= $4 AND ST_DWithin(location, %s, $5)
+	ORDER BY location <-> %s
+	LIMIT $6
+	`, machineColumns, point, domain.StatusIdle, point, point)
+
+	rows, err := r.executor.Query(ctx, query, pickup.Longitude, pickup.Latitude, machineTypes, minBatteryLevel, radiusMeters, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository.FindNearbyMachines: %w", err)
+	}
+	defer rows.Close()
+
+	var machines []domain.NearbyMachine
+	for rows.Next() {
+		var m domain.NearbyMachine
+		if err := rows.Scan(append(machineScanTargets(&m.Machine), &m.DistanceMeters)...); err != nil {
+			return nil, fmt.Errorf("repository.FindNearbyMachines.Scan: %w", err)
+		}
+		machines = append(machines, m)
+	}
+	return machines, rows.Err()
+}
+
+func (r *Repository) collectMachines(rows pgx.Rows, op string) ([]domain.Machine, error) {
+	defer rows.Close()
+
+	var machines []domain.Machine
+	for rows.Next() {
+		machine, err := r.scanMachine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s.Scan: %w", op, err)
+		}
+		machines = append(machines, *machine)
+	}
+	return machines, rows.Err()
+}
+
+// Slots in use are counted from the active reservations of a station.
+const chargingStationColumns = `s.id, s.name, s.machine_type, s.latitude, s.longitude, s.slots,
+	(SELECT COUNT(*) FROM charging_reservations r WHERE r.station_id = s.id AND r.status = 'active'),
+	s.created_at, s.updated_at`
+
+func scanChargingStation(row pgx.Row) (*domain.ChargingStation, error) {
+	var s domain.ChargingStation
+	err := row.Scan(&s.ID, &s.Name, &s.MachineType, &s.Latitude, &s.Longitude, &s.Slots, &s.SlotsInUse, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *Repository) CreateChargingStation(ctx context.Context, req domain.CreateChargingStationRequest) (*domain.ChargingStation, error) {
+	query := `
+	INSERT INTO charging_stations AS s (name, machine_type, latitude, longitude, slots)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING ` + chargingStationColumns
+
+	station, err := scanChargingStation(r.executor.QueryRow(ctx, query, req.Name, req.MachineType, req.Latitude, req.Longitude, req.Slots))
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateChargingStation: %w", err)
+	}
+	return station, nil
+}
+
+// ListChargingStations returns the charging stations, only those for
+// machineType when it is set.
+func (r *Repository) ListChargingStations(ctx context.Context, machineType string) ([]domain.ChargingStation, error) {
+	query := `SELECT ` + chargingStationColumns + ` FROM charging_stations s WHERE $1 = '' OR s.machine_type = $1 ORDER BY s.name, s.id`
+
+	rows, err := r.executor.Query(ctx, query, machineType)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListChargingStations: %w", err)
+	}
+	defer rows.Close()
+
+	var stations []domain.ChargingStation
+	for rows.Next() {
+		station, err := scanChargingStation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListChargingStations.Scan: %w", err)
+		}
+		stations = append(stations, *station)
+	}
+	return stations, rows.Err()
+}
+
+// ReserveChargingSlot reserves a slot of reservation.StationID for the
+// machine. The station row stays locked until the surrounding transaction
+// ends, so slots cannot be overbooked. It returns models.ErrNoChargingSlot
+// when every slot is taken and models.ErrNotFound for unknown stations.
+func (r *Repository) ReserveChargingSlot(ctx context.Context, reservation *domain.ChargingReservation) error {
+	var slots int
+	err := r.executor.QueryRow(ctx, `SELECT slots FROM charging_stations WHERE id = $1 FOR UPDATE`, reservation.StationID).Scan(&slots)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrNotFound
+		}
+		return fmt.Errorf("repository.ReserveChargingSlot.Lock: %w", err)
+	}
+
+	// Counted after the lock is held, so reservations committed meanwhile are seen.
+	var inUse int
+	query := `SELECT COUNT(*) FROM charging_reservations WHERE station_id = $1 AND status = $2`
+	if err := r.executor.QueryRow(ctx, query, reservation.StationID, domain.ChargingStatusActive).Scan(&inUse); err != nil {
+		return fmt.Errorf("repository.ReserveChargingSlot.Count: %w", err)
+	}
+	if inUse >= slots {
+		return models.ErrNoChargingSlot
+	}
+
+	query = `
+	INSERT INTO charging_reservations (station_id, machine_id, status, reason, battery_level_at_start)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, status, created_at
+	`
+	err = r.executor.QueryRow(ctx, query, reservation.StationID, reservation.MachineID, domain.ChargingStatusActive,
+		reservation.Reason, reservation.BatteryLevelAtStart).Scan(&reservation.ID, &reservation.Status, &reservation.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // The machine already holds a slot
+			return models.ErrConflict
+		}
+		return fmt.Errorf("repository.ReserveChargingSlot: %w", err)
+	}
+	return nil
+}
+
+// FindActiveChargingReservation returns the slot a machine holds, along with
+// its station.
+func (r *Repository) FindActiveChargingReservation(ctx context.Context, machineID string) (*domain.ChargingReservation, error) {
+	query := `
+	SELECT cr.id, cr.station_id, cr.machine_id, cr.status, cr.reason, cr.battery_level_at_start, cr.created_at, cr.ended_at,
+		` + chargingStationColumns + `
+	FROM charging_reservations cr
+	JOIN charging_stations s ON s.id = cr.station_id
+	WHERE cr.machine_id = $1 AND cr.status = $2
+	`
+	var res domain.ChargingReservation
+	var s domain.ChargingStation
+	err := r.executor.QueryRow(ctx, query, machineID, domain.ChargingStatusActive).Scan(
+		&res.ID, &res.StationID, &res.MachineID, &res.Status, &res.Reason, &res.BatteryLevelAtStart, &res.CreatedAt, &res.EndedAt,
+		&s.ID, &s.Name, &s.MachineType, &s.Latitude, &s.Longitude, &s.Slots, &s.SlotsInUse, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindActiveChargingReservation: %w", err)
+	}
+	res.Station = &s
+	return &res, nil
+}
+
+// EndChargingReservation frees the slot a machine holds, if any, and reports
+// whether there was one.
+func (r *Repository) EndChargingReservation(ctx context.Context, machineID, status string) (bool, error) {
+	query := `UPDATE charging_reservations SET status = $1, ended_at = NOW() WHERE machine_id = $2 AND status = $3`
+
+	cmdTag, err := r.executor.Exec(ctx, query, status, machineID, domain.ChargingStatusActive)
+	if err != nil {
+		return false, fmt.Errorf("repository.EndChargingReservation: %w", err)
+	}
+	return cmdTag.RowsAffected() > 0, nil
+}
+
+const workOrderColumns = `id, machine_id, kind, status, blocking, fault_code, description, technician_id, technician_notes,
+	parts_replaced, odometer_meters, operating_seconds, created_at, started_at, closed_at`
+
+func scanWorkOrder(row pgx.Row) (*domain.WorkOrder, error) {
+	var w domain.WorkOrder
+	var parts []byte
+	err := row.Scan(
+		&w.ID,
+		&w.MachineID,
+		&w.Kind,
+		&w.Status,
+		&w.Blocking,
+		&w.FaultCode,
+		&w.Description,
+		&w.TechnicianID,
+		&w.TechnicianNotes,
+		&parts,
+		&w.OdometerMeters,
+		&w.OperatingSeconds,
+		&w.CreatedAt,
+		&w.StartedAt,
+		&w.ClosedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(parts, &w.PartsReplaced); err != nil {
+		return nil, fmt.Errorf("decoding replaced parts: %w", err)
+	}
+	return &w, nil
+}
+
+// CreateWorkOrder opens a work order. It returns models.ErrConflict when the
+// machine already has an open work order for the same fault, or an open
+// inspection when an inspection is opened.
+func (r *Repository) CreateWorkOrder(ctx context.Context, workOrder *domain.WorkOrder) error {
+	query := `
+	INSERT INTO maintenance_work_orders (machine_id, kind, status, blocking, fault_code, description, odometer_meters, operating_seconds)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT DO NOTHING
+	RETURNING ` + workOrderColumns
+
+	created, err := scanWorkOrder(r.executor.QueryRow(ctx, query, workOrder.MachineID, workOrder.Kind, domain.WorkOrderStatusOpen,
+		workOrder.Blocking, workOrder.FaultCode, workOrder.Description, workOrder.OdometerMeters, workOrder.OperatingSeconds))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrConflict
+		}
+		return fmt.Errorf("repository.CreateWorkOrder: %w", err)
+	}
+	*workOrder = *created
+	return nil
+}
+
+// FindWorkOrderForUpdate loads a work order and locks its row until the surrounding transaction ends.
+func (r *Repository) FindWorkOrderForUpdate(ctx context.Context, id string) (*domain.WorkOrder, error) {
+	query := `SELECT ` + workOrderColumns + ` FROM maintenance_work_orders WHERE id = $1 FOR UPDATE`
+
+	workOrder, err := scanWorkOrder(r.executor.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindWorkOrderForUpdate: %w", err)
+	}
+	return workOrder, nil
+}
+
+// StartWorkOrder marks an open work order as in progress. It returns
+// models.ErrConflict if the work order is no longer open.
+func (r *Repository) StartWorkOrder(ctx context.Context, id, technicianID string) (*domain.WorkOrder, error) {
+	query := `
+	UPDATE maintenance_work_orders
+	SET status = $1, technician_id = $2, started_at = NOW()
+	WHERE id = $3 AND status = $4
+	RETURNING ` + workOrderColumns
+
+	workOrder, err := scanWorkOrder(r.executor.QueryRow(ctx, query, domain.WorkOrderStatusInProgress, technicianID, id, domain.WorkOrderStatusOpen))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.StartWorkOrder: %w", err)
+	}
+	return workOrder, nil
+}
+
+// CloseWorkOrder completes or cancels a work order that is open or in
+// progress, recording the technician's notes and the parts replaced. It
+// returns models.ErrConflict if the work order was already closed.
+func (r *Repository) CloseWorkOrder(ctx context.Context, id, status string, req domain.CloseWorkOrderRequest) (*domain.WorkOrder, error) {
+	parts := req.PartsReplaced
+	if parts == nil {
+		parts = []domain.ReplacedPart{}
+	}
+	encoded, err := json.Marshal(parts)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CloseWorkOrder: %w", err)
+	}
+
+	query := `
+	UPDATE maintenance_work_orders
+	SET status = $1, technician_id = COALESCE($2, technician_id), technician_notes = $3, parts_replaced = $4, closed_at = NOW()
+	WHERE id = $5 AND status IN ($6, $7)
+	RETURNING ` + workOrderColumns
+
+	var technicianID *string
+	if req.TechnicianID != "" {
+		technicianID = &req.TechnicianID
+	}
+	workOrder, err := scanWorkOrder(r.executor.QueryRow(ctx, query, status, technicianID, req.TechnicianNotes, encoded, id,
+		domain.WorkOrderStatusOpen, domain.WorkOrderStatusInProgress))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.CloseWorkOrder: %w", err)
+	}
+	return workOrder, nil
+}
+
+// CountBlockingWorkOrders counts the open work orders that keep a machine in maintenance.
+func (r *Repository) CountBlockingWorkOrders(ctx context.Context, machineID string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM maintenance_work_orders WHERE machine_id = $1 AND blocking AND status IN ($2, $3)`
+
+	err := r.executor.QueryRow(ctx, query, machineID, domain.WorkOrderStatusOpen, domain.WorkOrderStatusInProgress).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository.CountBlockingWorkOrders: %w", err)
+	}
+	return count, nil
+}
+
+// ListWorkOrders returns a page of the work orders matching filter, newest
+// first, along with the total number of matching work orders.
+func (r *Repository) ListWorkOrders(ctx context.Context, filter domain.ListWorkOrdersFilter, limit, offset int32) ([]domain.WorkOrder, int, error) {
+	whereClauses := []string{"TRUE"}
+	var args []any
+	argIdx := 1
+
+	if filter.MachineID != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("machine_id = $%d", argIdx))
+		args = append(args, filter.MachineID)
+		argIdx++
+	}
+	if filter.Kind != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("kind = $%d", argIdx))
+		args = append(args, filter.Kind)
+		argIdx++
+	}
+	if filter.Status != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", argIdx))
+		args = append(args, filter.Status)
+		argIdx++
+	}
+	where := strings.Join(whereClauses, " AND ")
+
+	var total int
+	if err := r.executor.QueryRow(ctx, `SELECT COUNT(*) FROM maintenance_work_orders WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("repository.ListWorkOrders.Count: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM maintenance_work_orders WHERE %s ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d`,
+		workOrderColumns, where, argIdx, argIdx+1)
+	rows, err := r.executor.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repository.ListWorkOrders: %w", err)
+	}
+	defer rows.Close()
+
+	var workOrders []domain.WorkOrder
+	for rows.Next() {
+		workOrder, err := scanWorkOrder(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("repository.ListWorkOrders.Scan: %w", err)
+		}
+		workOrders = append(workOrders, *workOrder)
+	}
+	return workOrders, total, rows.Err()
+}
+
+// RecordInspection marks a machine inspected now, at its current usage.
+func (r *Repository) RecordInspection(ctx context.Context, machineID string) error {
+	query := `
+	UPDATE machines
+	SET last_inspected_at = NOW(), inspected_odometer_meters = odometer_meters,
+		inspected_operating_seconds = operating_seconds, updated_at = NOW()
+	WHERE id = $1
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, machineID)
+	if err != nil {
+		return fmt.Errorf("repository.RecordInspection: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// ListMachinesDueForInspection returns the machines of a type in service that
+// reached a limit of policy and have no inspection open yet.
+func (r *Repository) ListMachinesDueForInspection(ctx context.Context, machineType string, policy domain.InspectionPolicy, now time.Time) ([]domain.Machine, error) {
+	var limits []string
+	args := []any{machineType, domain.StatusDecommissioned, domain.WorkOrderKindInspection, domain.WorkOrderStatusOpen, domain.WorkOrderStatusInProgress}
+	argIdx := len(args) + 1
+
+	if policy.OperatingHours > 0 {
+		limits = append(limits, fmt.Sprintf("operating_seconds - inspected_operating_seconds >= $%d", argIdx))
+		args = append(args, int64(policy.OperatingHours*3600))
+		argIdx++
+	}
+	if policy.OdometerKm > 0 {
+		limits = append(limits, fmt.Sprintf("odometer_meters - inspected_odometer_meters >= $%d", argIdx))
+		args = append(args, policy.OdometerKm*1000)
+		argIdx++
+	}
+	if policy.Interval > 0 {
+		limits = append(limits, fmt.Sprintf("COALESCE(last_inspected_at, created_at) <= $%d", argIdx))
+		args = append(args, now.Add(-policy.Interval))
+		argIdx++
+	}
+	if len(limits) == 0 {
+		return nil, nil
+	}
+
+	query := `
+	SELECT ` + machineColumns + ` FROM machines m
+	WHERE type = $1 AND status <> $2 AND (` + strings.Join(limits, " OR ") + `)
+		AND NOT EXISTS (
+			SELECT 1 FROM maintenance_work_orders w
+			WHERE w.machine_id = m.id AND w.kind = $3 AND w.status IN ($4, $5)
+		)
+	ORDER BY id
+	`
+	rows, err := r.executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListMachinesDueForInspection: %w", err)
+	}
+	return r.collectMachines(rows, "repository.ListMachinesDueForInspection")
+}
+
+// Opening hours are read back as HH:MM; home-based machines are counted.
+const hubColumns = `h.id, h.name, h.latitude, h.longitude, h.capacity, h.service_radius_meters, h.timezone,
+	COALESCE(to_char(h.opens_at, 'HH24:MI'), ''), COALESCE(to_char(h.closes_at, 'HH24:MI'), ''),
+	(SELECT COUNT(*) FROM machines m WHERE m.home_hub_id = h.id AND m.status <> 'DECOMMISSIONED'),
+	h.created_at, h.updated_at`
+
+func scanHub(row pgx.Row) (*domain.Hub, error) {
+	var h domain.Hub
+	err := row.Scan(
+		&h.ID,
+		&h.Name,
+		&h.Latitude,
+		&h.Longitude,
+		&h.Capacity,
+		&h.ServiceRadiusMeters,
+		&h.Timezone,
+		&h.OpensAt,
+		&h.ClosesAt,
+		&h.HomeBasedMachines,
+		&h.CreatedAt,
+		&h.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *Repository) CreateHub(ctx context.Context, req domain.CreateHubRequest) (*domain.Hub, error) {
+	query := `
+	INSERT INTO hubs AS h (name, latitude, longitude, capacity, service_radius_meters, timezone, opens_at, closes_at)
+	VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::time, NULLIF($8, '')::time)
+	RETURNING ` + hubColumns
+
+	hub, err := scanHub(r.executor.QueryRow(ctx, query, req.Name, req.Latitude, req.Longitude, req.Capacity,
+		req.ServiceRadiusMeters, req.Timezone, req.OpensAt, req.ClosesAt))
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateHub: %w", err)
+	}
+	return hub, nil
+}
+
+// FindHubForUpdate loads a hub and locks its row until the surrounding transaction ends.
+func (r *Repository) FindHubForUpdate(ctx context.Context, id string) (*domain.Hub, error) {
+	query := `SELECT ` + hubColumns + ` FROM hubs h WHERE h.id = $1 FOR UPDATE OF h`
+
+	hub, err := scanHub(r.executor.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindHubForUpdate: %w", err)
+	}
+	return hub, nil
+}
+
+func (r *Repository) ListHubs(ctx context.Context) ([]domain.Hub, error) {
+	rows, err := r.executor.Query(ctx, `SELECT `+hubColumns+` FROM hubs h ORDER BY h.name, h.id`)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListHubs: %w", err)
+	}
+	defer rows.Close()
+
+	var hubs []domain.Hub
+	for rows.Next() {
+		hub, err := scanHub(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListHubs.Scan: %w", err)
+		}
+		hubs = append(hubs, *hub)
+	}
+	return hubs, rows.Err()
+}
+
+// CountHomeBasedMachines counts the machines in service based at a hub.
+func (r *Repository) CountHomeBasedMachines(ctx context.Context, hubID string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM machines WHERE home_hub_id = $1 AND status <> $2`
+
+	if err := r.executor.QueryRow(ctx, query, hubID, domain.StatusDecommissioned).Scan(&count); err != nil {
+		return 0, fmt.Errorf("repository.CountHomeBasedMachines: %w", err)
+	}
+	return count, nil
+}
+
+// CountHomeBasedMachinesByType counts the machines in service based at each
+// hub, by hub ID and machine type.
+func (r *Repository) CountHomeBasedMachinesByType(ctx context.Context) (map[string]map[string]int, error) {
+	query := `
+	SELECT home_hub_id, type, COUNT(*) FROM machines
+	WHERE home_hub_id IS NOT NULL AND status <> $1
+	GROUP BY home_hub_id, type
+	`
+	rows, err := r.executor.Query(ctx, query, domain.StatusDecommissioned)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CountHomeBasedMachinesByType: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]map[string]int)
+	for rows.Next() {
+		var hubID, machineType string
+		var count int
+		if err := rows.Scan(&hubID, &machineType, &count); err != nil {
+			return nil, fmt.Errorf("repository.CountHomeBasedMachinesByType.Scan: %w", err)
+		}
+		if counts[hubID] == nil {
+			counts[hubID] = make(map[string]int)
+		}
+		counts[hubID][machineType] = count
+	}
+	return counts, rows.Err()
+}
+
+// SetHomeHub bases a machine at a hub, or at none when hubID is nil.
+func (r *Repository) SetHomeHub(ctx context.Context, machineID string, hubID *string) (*domain.Machine, error) {
+	query := `
+	UPDATE machines
+	SET home_hub_id = $1, version = version + (home_hub_id IS DISTINCT FROM $1::uuid)::int, updated_at = NOW()
+	WHERE id = $2
+	RETURNING ` + machineColumns
+
+	machine, err := r.scanMachine(r.executor.QueryRow(ctx, query, hubID, machineID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.SetHomeHub: %w", err)
+	}
+	return machine, nil
+}
+
+// ListPickupDemand counts the orders placed since then by pickup location,
+// machine type and hour. Cancelled orders count too: they show demand.
+func (r *Repository) ListPickupDemand(ctx context.Context, since time.Time) ([]domain.PickupDemand, error) {
+	query := `
+	SELECT a.latitude, a.longitude, o.machine_type, date_trunc('hour', o.created_at) AS hour, COUNT(*)
+	FROM orders o
+	JOIN addresses a ON a.id = o.pickup_address_id
+	WHERE o.created_at >= $1 AND a.latitude IS NOT NULL AND a.longitude IS NOT NULL
+	GROUP BY a.latitude, a.longitude, o.machine_type, hour
+	`
+	rows, err := r.executor.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListPickupDemand: %w", err)
+	}
+	defer rows.Close()
+
+	var demand []domain.PickupDemand
+	for rows.Next() {
+		var d domain.PickupDemand
+		if err := rows.Scan(&d.Location.Latitude, &d.Location.Longitude, &d.MachineType, &d.Hour, &d.Orders); err != nil {
+			return nil, fmt.Errorf("repository.ListPickupDemand.Scan: %w", err)
+		}
+		demand = append(demand, d)
+	}
+	return demand, rows.Err()
+}
+
+func (r *Repository) CreateCertificate(ctx context.Context, cert *domain.MachineCertificate) error {
+	query := `
+	INSERT INTO machine_certificates (serial_number, machine_id, expires_at)
+	VALUES ($1, $2, $3)
+	RETURNING created_at
+	`
+	if err := r.executor.QueryRow(ctx, query, cert.SerialNumber, cert.MachineID, cert.ExpiresAt).Scan(&cert.CreatedAt); err != nil {
+		return fmt.Errorf("repository.CreateCertificate: %w", err)
+	}
+	return nil
+}
+
+// RevokeCertificates revokes every certificate of a machine that is not
+// revoked yet and returns how many were revoked.
+func (r *Repository) RevokeCertificates(ctx context.Context, machineID string) (int64, error) {
+	query := `UPDATE machine_certificates SET revoked_at = NOW() WHERE machine_id = $1 AND revoked_at IS NULL`
+
+	cmdTag, err := r.executor.Exec(ctx, query, machineID)
+	if err != nil {
+		return 0, fmt.Errorf("repository.RevokeCertificates: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// IsCertificateRevoked reports whether a certificate was revoked. Serial
+// numbers we have no record of count as revoked.
+func (r *Repository) IsCertificateRevoked(ctx context.Context, serialNumber string) (bool, error) {
+	var revoked bool
+	query := `SELECT revoked_at IS NOT NULL FROM machine_certificates WHERE serial_number = $1`
+
+	if err := r.executor.QueryRow(ctx, query, serialNumber).Scan(&revoked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return true, nil
+		}
+		return false, fmt.Errorf("repository.IsCertificateRevoked: %w", err)
+	}
+	return revoked, nil
+}
@@ -0,0 +1,305 @@
+package fleet
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"dispatch-and-delivery/pkg/pki"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ServiceInterface defines methods for fleet business logic.
+type ServiceInterface interface {
+	RegisterMachine(ctx context.Context, req domain.RegisterMachineRequest) (*domain.Machine, *domain.MachineCredentials, error)
+	GetMachine(ctx context.Context, id string) (*domain.Machine, error)
+	ListMachines(ctx context.Context, filter domain.ListMachinesFilter, page, limit int32) ([]domain.Machine, int, error)
+	UpdateMachineStatus(ctx context.Context, id string, req domain.MachineStatusUpdateRequest) (*domain.Machine, error)
+	DecommissionMachine(ctx context.Context, id, actorID string, expectedVersion *int64) (*domain.Machine, error)
+	GetMachineStatusHistory(ctx context.Context, machineID string, page, limit int32) ([]domain.MachineStatusEvent, int, error)
+
+	ClaimMachine(ctx context.Context, machineID string) (*domain.Machine, error)
+	CheckMachineDispatchable(ctx context.Context, machineID string) error
+	ReleaseMachine(ctx context.Context, machineID string) (*domain.Machine, error)
+
+	RecordHeartbeat(ctx context.Context, machineID string, hb domain.Heartbeat) (*domain.Machine, error)
+	MarkSilentMachinesOffline(ctx context.Context, now time.Time) ([]domain.MachineStatusEvent, error)
+	HeartbeatInterval() time.Duration
+
+	CreateChargingStation(ctx context.Context, req domain.CreateChargingStationRequest) (*domain.ChargingStation, error)
+	ListChargingStations(ctx context.Context, machineType string) ([]domain.ChargingStation, error)
+	ActiveChargingReservation(ctx context.Context, machineID string) (*domain.ChargingReservation, error)
+	SendToCharge(ctx context.Context, machineID, reason string) (*domain.ChargingReservation, error)
+	ScheduleCharging(ctx context.Context) ([]domain.ChargingReservation, error)
+	CheckTripBattery(ctx context.Context, machineID string, trip domain.TripEnergyRequest, chargeIfNeeded bool) (*domain.TripBatteryCheck, error)
+	ListEligibleMachines(ctx context.Context, machineType string, trip domain.TripEnergyRequest, limit int) ([]domain.TripBatteryCheck, error)
+	FindNearbyMachines(ctx context.Context, q domain.NearbyMachinesQuery) ([]domain.NearbyMachine, error)
+
+	OpenWorkOrder(ctx context.Context, req domain.CreateWorkOrderRequest) (*domain.WorkOrder, error)
+	ReportFault(ctx context.Context, machineID, faultCode, description string) (*domain.WorkOrder, error)
+	StartWorkOrder(ctx context.Context, id, technicianID string) (*domain.WorkOrder, error)
+	CompleteWorkOrder(ctx context.Context, id string, req domain.CloseWorkOrderRequest) (*domain.WorkOrder, error)
+	CancelWorkOrder(ctx context.Context, id string, req domain.CloseWorkOrderRequest) (*domain.WorkOrder, error)
+	ListWorkOrders(ctx context.Context, filter domain.ListWorkOrdersFilter, page, limit int32) ([]domain.WorkOrder, int, error)
+	ScheduleInspections(ctx context.Context, now time.Time) ([]domain.WorkOrder, error)
+
+	CreateHub(ctx context.Context, req domain.CreateHubRequest) (*domain.Hub, error)
+	ListHubs(ctx context.Context) ([]domain.Hub, error)
+	AssignHomeHub(ctx context.Context, machineID, hubID string) (*domain.Machine, error)
+	ListHubInventories(ctx context.Context, now time.Time) ([]domain.HubInventory, error)
+	SuggestRebalancing(ctx context.Context, machineType string, horizon time.Duration, now time.Time) (*domain.RebalancingPlan, error)
+}
+
+// CertificateIssuer issues the client certificates machines authenticate with.
+type CertificateIssuer interface {
+	IssueMachineCertificate(machineID string) (*pki.IssuedCertificate, error)
+	CertificatePEM() []byte
+}
+
+type Service struct {
+	machineRepo      RepositoryInterface
+	issuer           CertificateIssuer
+	heartbeatTimeout time.Duration
+}
+
+// NewService creates the fleet service. Without an issuer, machines are
+// registered without credentials and must authenticate with tokens.
+// Machines that stay silent for heartbeatTimeout are marked offline; zero
+// means domain.DefaultHeartbeatTimeout.
+func NewService(machineRepo RepositoryInterface, issuer CertificateIssuer, heartbeatTimeout time.Duration) ServiceInterface {
+	if heartbeatTimeout <= 0 {
+		heartbeatTimeout = domain.DefaultHeartbeatTimeout
+	}
+	return &Service{machineRepo: machineRepo, issuer: issuer, heartbeatTimeout: heartbeatTimeout}
+}
+
+// RegisterMachine adds a machine to the fleet and issues the client
+// certificate it authenticates with. The private key is only returned here.
+// It returns models.ErrConflict if a machine with the same serial number is
+// already registered.
+func (s *Service) RegisterMachine(ctx context.Context, req domain.RegisterMachineRequest) (*domain.Machine, *domain.MachineCredentials, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	machine, err := txRepo.Create(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.RegisterMachine.Create: %w", err)
+	}
+
+	var credentials *domain.MachineCredentials
+	if s.issuer != nil {
+		issued, err := s.issuer.IssueMachineCertificate(machine.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("service.RegisterMachine.IssueMachineCertificate: %w", err)
+		}
+		err = txRepo.CreateCertificate(ctx, &domain.MachineCertificate{
+			SerialNumber: issued.SerialNumber,
+			MachineID:    machine.ID,
+			ExpiresAt:    issued.ExpiresAt,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("service.RegisterMachine.CreateCertificate: %w", err)
+		}
+		credentials = &domain.MachineCredentials{
+			SerialNumber:     issued.SerialNumber,
+			CertificatePEM:   string(issued.CertificatePEM),
+			PrivateKeyPEM:    string(issued.PrivateKeyPEM),
+			CACertificatePEM: string(s.issuer.CertificatePEM()),
+			ExpiresAt:        issued.ExpiresAt,
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return machine, credentials, nil
+}
+
+func (s *Service) GetMachine(ctx context.Context, id string) (*domain.Machine, error) {
+	machine, err := s.machineRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetMachine: %w", err)
+	}
+	return machine, nil
+}
+
+// ListMachines returns a page of the machines matching filter along with the
+// total number of matching machines.
+func (s *Service) ListMachines(ctx context.Context, filter domain.ListMachinesFilter, page, limit int32) ([]domain.Machine, int, error) {
+	machines, total, err := s.machineRepo.List(ctx, filter, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service.ListMachines: %w", err)
+	}
+	return machines, total, nil
+}
+
+// UpdateMachineStatus records a machine's reported status and position.
+// Machines go offline through the liveness tracker and out of service with
+// DecommissionMachine only, and a machine with blocking work orders open stays
+// in MAINTENANCE when it reports idle or charging. A change of status is recorded as an
+// event, and a machine that stops charging gives up its charging slot.
+//
+// Changes the state machine does not allow yield models.ErrInvalidTransition,
+// and reports made against a version the machine moved on from yield
+// models.ErrConflict.
+func (s *Service) UpdateMachineStatus(ctx context.Context, id string, req domain.MachineStatusUpdateRequest) (*domain.Machine, error) {
+	if req.Status == domain.StatusOffline || req.Status == domain.StatusDecommissioned {
+		return nil, fmt.Errorf("service.UpdateMachineStatus: status %s cannot be reported", req.Status)
+	}
+
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	current, err := txRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.UpdateMachineStatus.FindByIDForUpdate: %w", err)
+	}
+	if current.Status == domain.StatusDecommissioned {
+		return nil, models.ErrMachineDecommissioned
+	}
+	if req.ExpectedVersion != nil && *req.ExpectedVersion != current.Version {
+		return nil, models.ErrConflict
+	}
+	if !domain.CanTransition(current.Status, req.Status) {
+		return nil, invalidTransition(current.Status, req.Status)
+	}
+
+	// A machine with blocking work orders open goes into maintenance instead.
+	// One that cannot go there straight away, such as a machine arriving from a
+	// trip, is recorded as arriving first.
+	reason := domain.StatusReasonReported
+	resolved, err := s.maintenanceStatus(ctx, txRepo, id, req.Status)
+	if err != nil {
+		return nil, fmt.Errorf("service.UpdateMachineStatus: %w", err)
+	}
+	if resolved != req.Status && domain.CanTransition(current.Status, resolved) {
+		req.Status, reason = resolved, domain.StatusReasonMaintenanceRequired
+	}
+
+	machine, err := txRepo.UpdateStatus(ctx, id, req)
+	if err != nil {
+		return nil, fmt.Errorf("service.UpdateMachineStatus: %w", err)
+	}
+	if err := s.recordStatusChange(ctx, txRepo, current, machine.Status, reason, req.ActorID); err != nil {
+		return nil, fmt.Errorf("service.UpdateMachineStatus: %w", err)
+	}
+	if machine.Status != resolved {
+		arrived := machine
+		if machine, err = txRepo.SetStatus(ctx, id, resolved); err != nil {
+			return nil, fmt.Errorf("service.UpdateMachineStatus.SetStatus: %w", err)
+		}
+		if err := s.recordStatusChange(ctx, txRepo, arrived, resolved, domain.StatusReasonMaintenanceRequired, ""); err != nil {
+			return nil, fmt.Errorf("service.UpdateMachineStatus: %w", err)
+		}
+	}
+	if current.Status == domain.StatusCharging && machine.Status != domain.StatusCharging {
+		if err := s.releaseChargingSlot(ctx, txRepo, id, machine.BatteryLevel); err != nil {
+			return nil, fmt.Errorf("service.UpdateMachineStatus.releaseChargingSlot: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return machine, nil
+}
+
+// DecommissionMachine takes a machine out of service for good and revokes its
+// certificates. Machines that are carrying orders must finish their trip first.
+// When expectedVersion is set and the machine moved on from it,
+// models.ErrConflict is returned.
+func (s *Service) DecommissionMachine(ctx context.Context, id, actorID string, expectedVersion *int64) (*domain.Machine, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	current, err := txRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.DecommissionMachine.FindByIDForUpdate: %w", err)
+	}
+	switch current.Status {
+	case domain.StatusDecommissioned:
+		return nil, models.ErrMachineDecommissioned
+	case domain.StatusInTransit:
+		return nil, models.ErrMachineInUse
+	}
+	if expectedVersion != nil && *expectedVersion != current.Version {
+		return nil, models.ErrConflict
+	}
+
+	machine, err := txRepo.Decommission(ctx, id, expectedVersion)
+	if err != nil {
+		return nil, fmt.Errorf("service.DecommissionMachine: %w", err)
+	}
+	revoked, err := txRepo.RevokeCertificates(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("service.DecommissionMachine.RevokeCertificates: %w", err)
+	}
+	if err := s.recordStatusChange(ctx, txRepo, current, machine.Status, domain.StatusReasonDecommissioned, actorID); err != nil {
+		return nil, fmt.Errorf("service.DecommissionMachine: %w", err)
+	}
+	if current.Status == domain.StatusCharging {
+		if err := s.releaseChargingSlot(ctx, txRepo, id, machine.BatteryLevel); err != nil {
+			return nil, fmt.Errorf("service.DecommissionMachine.releaseChargingSlot: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	log.Printf("INFO: Decommissioned machine %s and revoked %d certificate(s)", id, revoked)
+	return machine, nil
+}
+
+// GetMachineStatusHistory returns a page of a machine's status changes, newest
+// first, along with the total number of changes.
+func (s *Service) GetMachineStatusHistory(ctx context.Context, machineID string, page, limit int32) ([]domain.MachineStatusEvent, int, error) {
+	if _, err := s.machineRepo.FindByID(ctx, machineID); err != nil {
+		return nil, 0, fmt.Errorf("service.GetMachineStatusHistory: %w", err)
+	}
+	events, total, err := s.machineRepo.ListStatusHistory(ctx, machineID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service.GetMachineStatusHistory: %w", err)
+	}
+	return events, total, nil
+}
+
+// recordStatusChange appends a status event when a machine's status changed
+// from what it was in before, made by actorID unless it is empty. It refuses
+// changes the state machine does not allow, rolling back the transaction that
+// made them. That transaction bumped the machine's version once.
+func (s *Service) recordStatusChange(ctx context.Context, repo RepositoryInterface, before *domain.Machine, toStatus, reason, actorID string) error {
+	if before.Status == toStatus {
+		return nil
+	}
+	if !domain.CanTransition(before.Status, toStatus) {
+		return invalidTransition(before.Status, toStatus)
+	}
+
+	event := &domain.MachineStatusEvent{
+		MachineID:  before.ID,
+		FromStatus: before.Status,
+		ToStatus:   toStatus,
+		Reason:     reason,
+		Version:    before.Version + 1,
+	}
+	if actorID != "" {
+		event.ActorID = &actorID
+	}
+	return repo.CreateStatusEvent(ctx, event)
+}
+
+func invalidTransition(from, to string) error {
+	return fmt.Errorf("%w from %s to %s", models.ErrInvalidTransition, from, to)
+}
@@ -0,0 +1,27 @@
+package fleet
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"testing"
+)
+
+func TestUpdateMachineStatusRefusesUnreportableStatus(t *testing.T) {
+	s := &Service{}
+
+	// Machines go offline by staying silent and are decommissioned by an admin.
+	for _, status := range []string{domain.StatusOffline, domain.StatusDecommissioned} {
+		req := domain.MachineStatusUpdateRequest{Status: status}
+		if _, err := s.UpdateMachineStatus(context.Background(), "robot-1", req); err == nil {
+			t.Errorf("UpdateMachineStatus to %s succeeded, want an error", status)
+		}
+	}
+}
+
+func TestHeartbeatInterval(t *testing.T) {
+	s := &Service{heartbeatTimeout: domain.DefaultHeartbeatTimeout}
+	// Two heartbeats may be lost before the machine is marked offline.
+	if got := s.HeartbeatInterval(); 3*got > domain.DefaultHeartbeatTimeout {
+		t.Errorf("HeartbeatInterval = %v, want at most a third of %v", got, domain.DefaultHeartbeatTimeout)
+	}
+}
@@ -0,0 +1,129 @@
+package fleet
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"fmt"
+	"time"
+)
+
+// ClaimMachine takes an idle machine for a trip by moving it into transit.
+// Machines that are out of service yield models.ErrMachineUnavailable, and of
+// two dispatchers racing for the same machine only one succeeds; the other
+// gets models.ErrMachineNotIdle.
+func (s *Service) ClaimMachine(ctx context.Context, machineID string) (*domain.Machine, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	current, err := txRepo.FindByIDForUpdate(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ClaimMachine.FindByIDForUpdate: %w", err)
+	}
+	if err := s.checkDispatchable(ctx, txRepo, current, time.Now()); err != nil {
+		return nil, err
+	}
+	if current.Status != domain.StatusIdle {
+		return nil, models.ErrMachineNotIdle
+	}
+
+	machine, err := txRepo.SetStatus(ctx, machineID, domain.StatusInTransit)
+	if err != nil {
+		return nil, fmt.Errorf("service.ClaimMachine.SetStatus: %w", err)
+	}
+	if err := s.recordStatusChange(ctx, txRepo, current, machine.Status, domain.StatusReasonDispatched, ""); err != nil {
+		return nil, fmt.Errorf("service.ClaimMachine: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return machine, nil
+}
+
+// CheckMachineDispatchable returns models.ErrMachineUnavailable if a machine
+// is out of service, offline, has a blocking maintenance work order open, or
+// is past a limit of its inspection policy that the maintenance scheduler has
+// not ticketed yet.
+func (s *Service) CheckMachineDispatchable(ctx context.Context, machineID string) error {
+	machine, err := s.machineRepo.FindByID(ctx, machineID)
+	if err != nil {
+		return fmt.Errorf("service.CheckMachineDispatchable.FindByID: %w", err)
+	}
+	return s.checkDispatchable(ctx, s.machineRepo, machine, time.Now())
+}
+
+// ReleaseMachine hands a machine in transit back to the fleet once it has no
+// active trip left. It becomes idle, or goes into maintenance when a blocking
+// work order was opened during the trip. Machines in any other status are
+// left alone.
+func (s *Service) ReleaseMachine(ctx context.Context, machineID string) (*domain.Machine, error) {
+	tx, err := s.machineRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.machineRepo.WithTx(tx)
+
+	current, err := txRepo.FindByIDForUpdate(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReleaseMachine.FindByIDForUpdate: %w", err)
+	}
+	if current.Status != domain.StatusInTransit {
+		return current, nil
+	}
+	active, err := txRepo.HasActiveTrip(ctx, machineID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReleaseMachine: %w", err)
+	}
+	if active {
+		return current, nil
+	}
+
+	resolved, err := s.maintenanceStatus(ctx, txRepo, machineID, domain.StatusIdle)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReleaseMachine: %w", err)
+	}
+	machine, err := txRepo.SetStatus(ctx, machineID, domain.StatusIdle)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReleaseMachine.SetStatus: %w", err)
+	}
+	if err := s.recordStatusChange(ctx, txRepo, current, machine.Status, domain.StatusReasonTripEnded, ""); err != nil {
+		return nil, fmt.Errorf("service.ReleaseMachine: %w", err)
+	}
+	// A machine cannot go from a trip into maintenance directly, so it is
+	// recorded as arriving first.
+	if resolved != machine.Status {
+		arrived := machine
+		if machine, err = txRepo.SetStatus(ctx, machineID, resolved); err != nil {
+			return nil, fmt.Errorf("service.ReleaseMachine.SetStatus: %w", err)
+		}
+		if err := s.recordStatusChange(ctx, txRepo, arrived, resolved, domain.StatusReasonMaintenanceRequired, ""); err != nil {
+			return nil, fmt.Errorf("service.ReleaseMachine: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return machine, nil
+}
+
+// checkDispatchable returns models.ErrMachineUnavailable unless a machine in
+// service could take a trip at now.
+func (s *Service) checkDispatchable(ctx context.Context, repo RepositoryInterface, machine *domain.Machine, now time.Time) error {
+	switch machine.Status {
+	case domain.StatusMaintenance, domain.StatusOffline, domain.StatusDecommissioned:
+		return models.ErrMachineUnavailable
+	}
+	resolved, err := s.maintenanceStatus(ctx, repo, machine.ID, domain.StatusIdle)
+	if err != nil {
+		return fmt.Errorf("service.checkDispatchable: %w", err)
+	}
+	if resolved != domain.StatusIdle || machine.InspectionDue(now) != "" {
+		return models.ErrMachineUnavailable
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package fleet
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/fleet/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+// workOrderRepo counts the blocking work orders open on each machine.
+type workOrderRepo struct {
+	RepositoryInterface
+	blocking map[string]int
+}
+
+func (r *workOrderRepo) CountBlockingWorkOrders(_ context.Context, machineID string) (int, error) {
+	return r.blocking[machineID], nil
+}
+
+func TestCheckDispatchable(t *testing.T) {
+	now := time.Now()
+	inspected := now.Add(-time.Hour)
+	machine := func(id, status string) *domain.Machine {
+		return &domain.Machine{ID: id, Type: domain.MachineTypeRobot, Status: status, CreatedAt: now, LastInspectedAt: &inspected}
+	}
+	overdue := machine("overdue", domain.StatusIdle)
+	overdue.OdometerMeters = domain.InspectionPolicies[domain.MachineTypeRobot].OdometerKm * 1000
+
+	tests := []struct {
+		name    string
+		machine *domain.Machine
+		ok      bool
+	}{
+		{"idle", machine("idle", domain.StatusIdle), true},
+		{"in transit", machine("busy", domain.StatusInTransit), true},
+		{"in maintenance", machine("idle", domain.StatusMaintenance), false},
+		{"offline", machine("idle", domain.StatusOffline), false},
+		{"blocking work order", machine("faulty", domain.StatusIdle), false},
+		{"inspection due", overdue, false},
+	}
+	s := &Service{}
+	repo := &workOrderRepo{blocking: map[string]int{"faulty": 1}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.checkDispatchable(context.Background(), repo, tt.machine, now)
+			if tt.ok && err != nil {
+				t.Errorf("checkDispatchable = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, models.ErrMachineUnavailable) {
+				t.Errorf("checkDispatchable = %v, want ErrMachineUnavailable", err)
+			}
+		})
+	}
+}
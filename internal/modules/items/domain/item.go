@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Reservation status constants.
+const (
+	ReservationStatusReserved  = "reserved"
+	ReservationStatusReleased  = "released"
+	ReservationStatusFulfilled = "fulfilled"
+)
+
+// Item is a stock keeping unit in the inventory catalog.
+type Item struct {
+	SKU              string    `json:"sku"`
+	Name             string    `json:"name"`
+	UnitWeightKg     float64   `json:"unit_weight_kg"`
+	Hazmat           bool      `json:"hazmat"`
+	HazmatClass      string    `json:"hazmat_class,omitempty"` // UN hazard class, e.g. "3" for flammable liquids
+	QuantityOnHand   int       `json:"quantity_on_hand"`
+	QuantityReserved int       `json:"quantity_reserved"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Available returns the quantity that can still be reserved.
+func (i *Item) Available() int {
+	return i.QuantityOnHand - i.QuantityReserved
+}
+
+// Reservation holds stock of one SKU for an order until it is picked up or cancelled.
+type Reservation struct {
+	ID        string    `json:"id"`
+	OrderID   string    `json:"order_id"`
+	SKU       string    `json:"sku"`
+	Quantity  int       `json:"quantity"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReservationLine is a request to reserve a quantity of one SKU.
+type ReservationLine struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+}
+
+// CreateItemRequest defines the data needed to add an item to the catalog.
+type CreateItemRequest struct {
+	SKU            string  `json:"sku" validate:"required"`
+	Name           string  `json:"name" validate:"required"`
+	UnitWeightKg   float64 `json:"unit_weight_kg" validate:"required,gt=0"`
+	Hazmat         bool    `json:"hazmat"`
+	HazmatClass    string  `json:"hazmat_class,omitempty"`
+	QuantityOnHand int     `json:"quantity_on_hand" validate:"gte=0"`
+}
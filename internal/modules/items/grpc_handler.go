@@ -0,0 +1,203 @@
+package items
+
+import (
+	"context"
+	pb "dispatch-and-delivery/api/proto/item"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/items/domain"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCHandler is the gRPC handler for the item service.
+// It implements the ItemServiceServer interface generated by protoc.
+type GRPCHandler struct {
+	pb.UnimplementedItemServiceServer
+
+	service ServiceInterface
+}
+
+// NewGRPCHandler creates a new gRPC handler for the item service.
+func NewGRPCHandler(s ServiceInterface) *GRPCHandler {
+	return &GRPCHandler{service: s}
+}
+
+// GetItem handles the gRPC request for looking up a catalog item.
+func (h *GRPCHandler) GetItem(ctx context.Context, req *pb.GetItemRequest) (*pb.ItemResponse, error) {
+	if req.Sku == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku is required")
+	}
+
+	item, err := h.service.GetItem(ctx, req.Sku)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "item not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve item")
+	}
+	return &pb.ItemResponse{Item: toPBItem(item)}, nil
+}
+
+// ListItems handles the gRPC request for paging through the catalog.
+func (h *GRPCHandler) ListItems(ctx context.Context, req *pb.ListItemsRequest) (*pb.ListItemsResponse, error) {
+	page, limit := req.Page, req.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	items, total, err := h.service.ListItems(ctx, page, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list items")
+	}
+
+	res := &pb.ListItemsResponse{Total: int32(total)}
+	for i := range items {
+		res.Items = append(res.Items, toPBItem(&items[i]))
+	}
+	return res, nil
+}
+
+// CreateItem handles the admin gRPC request for adding an item to the catalog.
+func (h *GRPCHandler) CreateItem(ctx context.Context, req *pb.CreateItemRequest) (*pb.ItemResponse, error) {
+	if req.Sku == "" || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku and name are required")
+	}
+	if req.UnitWeightKg <= 0 || req.QuantityOnHand < 0 {
+		return nil, status.Error(codes.InvalidArgument, "unit_weight_kg must be positive and quantity_on_hand must not be negative")
+	}
+
+	item, err := h.service.CreateItem(ctx, domain.CreateItemRequest{
+		SKU:            req.Sku,
+		Name:           req.Name,
+		UnitWeightKg:   req.UnitWeightKg,
+		Hazmat:         req.Hazmat,
+		HazmatClass:    req.HazmatClass,
+		QuantityOnHand: int(req.QuantityOnHand),
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			return nil, status.Error(codes.AlreadyExists, "an item with this sku already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to create item")
+	}
+	return &pb.ItemResponse{Item: toPBItem(item)}, nil
+}
+
+// AdjustStock handles the admin gRPC request for correcting the quantity on hand.
+func (h *GRPCHandler) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.ItemResponse, error) {
+	if req.Sku == "" || req.Delta == 0 {
+		return nil, status.Error(codes.InvalidArgument, "sku and a non-zero delta are required")
+	}
+
+	item, err := h.service.AdjustStock(ctx, req.Sku, int(req.Delta))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "item not found")
+		case errors.Is(err, models.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, "cannot remove stock that is reserved or not on hand")
+		}
+		return nil, status.Error(codes.Internal, "failed to adjust stock")
+	}
+	return &pb.ItemResponse{Item: toPBItem(item)}, nil
+}
+
+// ReserveStock handles the gRPC request for reserving the manifest of an order.
+func (h *GRPCHandler) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	if req.OrderId == "" || len(req.Lines) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order_id and at least one line are required")
+	}
+
+	lines := make([]domain.ReservationLine, 0, len(req.Lines))
+	for _, l := range req.Lines {
+		if l.Sku == "" || l.Quantity <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "every line needs a sku and a positive quantity")
+		}
+		lines = append(lines, domain.ReservationLine{SKU: l.Sku, Quantity: int(l.Quantity)})
+	}
+
+	items, err := h.service.ReserveStock(ctx, req.OrderId, lines)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "item not found")
+		case errors.Is(err, models.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to reserve stock")
+	}
+
+	res := &pb.ReserveStockResponse{}
+	for i := range items {
+		res.Items = append(res.Items, toPBItem(&items[i]))
+	}
+	return res, nil
+}
+
+// ReleaseStock handles the gRPC request for releasing the stock held by an order.
+func (h *GRPCHandler) ReleaseStock(ctx context.Context, req *pb.ReleaseStockRequest) (*pb.ReleaseStockResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if err := h.service.ReleaseStock(ctx, req.OrderId); err != nil {
+		return nil, status.Error(codes.Internal, "failed to release stock")
+	}
+	return &pb.ReleaseStockResponse{}, nil
+}
+
+// FulfillStock handles the gRPC request for consuming the stock held by an order.
+func (h *GRPCHandler) FulfillStock(ctx context.Context, req *pb.FulfillStockRequest) (*pb.FulfillStockResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if err := h.service.FulfillStock(ctx, req.OrderId); err != nil {
+		return nil, status.Error(codes.Internal, "failed to fulfill stock")
+	}
+	return &pb.FulfillStockResponse{}, nil
+}
+
+// ListReservations handles the gRPC request for the stock reservations of an order.
+func (h *GRPCHandler) ListReservations(ctx context.Context, req *pb.ListReservationsRequest) (*pb.ListReservationsResponse, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	reservations, err := h.service.ListReservations(ctx, req.OrderId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list reservations")
+	}
+
+	res := &pb.ListReservationsResponse{}
+	for _, r := range reservations {
+		res.Reservations = append(res.Reservations, &pb.Reservation{
+			Id:        r.ID,
+			OrderId:   r.OrderID,
+			Sku:       r.SKU,
+			Quantity:  int32(r.Quantity),
+			Status:    r.Status,
+			CreatedAt: timestamppb.New(r.CreatedAt),
+			UpdatedAt: timestamppb.New(r.UpdatedAt),
+		})
+	}
+	return res, nil
+}
+
+func toPBItem(i *domain.Item) *pb.Item {
+	return &pb.Item{
+		Sku:              i.SKU,
+		Name:             i.Name,
+		UnitWeightKg:     i.UnitWeightKg,
+		Hazmat:           i.Hazmat,
+		HazmatClass:      i.HazmatClass,
+		QuantityOnHand:   int32(i.QuantityOnHand),
+		QuantityReserved: int32(i.QuantityReserved),
+		CreatedAt:        timestamppb.New(i.CreatedAt),
+		UpdatedAt:        timestamppb.New(i.UpdatedAt),
+	}
+}
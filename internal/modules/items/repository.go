@@ -0,0 +1,278 @@
+package items
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/items/domain"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RepositoryInterface defines methods for interacting with inventory storage.
+type RepositoryInterface interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	WithTx(tx pgx.Tx) *Repository
+
+	CreateItem(ctx context.Context, req domain.CreateItemRequest) (*domain.Item, error)
+	FindBySKU(ctx context.Context, sku string) (*domain.Item, error)
+	FindBySKUForUpdate(ctx context.Context, sku string) (*domain.Item, error)
+	List(ctx context.Context, limit, offset int32) ([]domain.Item, int, error)
+	AdjustStock(ctx context.Context, sku string, delta int) (*domain.Item, error)
+
+	ReserveQuantity(ctx context.Context, sku string, quantity int) error
+	CreateReservation(ctx context.Context, orderID, sku string, quantity int) (*domain.Reservation, error)
+	ListReservations(ctx context.Context, orderID string) ([]domain.Reservation, error)
+	ReleaseReservations(ctx context.Context, orderID string) (int64, error)
+	FulfillReservations(ctx context.Context, orderID string) (int64, error)
+}
+
+// DBExecutor represents anything that can execute a SQL query,
+// which includes both a connection pool and a transaction.
+type DBExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db       *pgxpool.Pool
+	executor DBExecutor
+}
+
+func NewRepository(db *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		db:       db,
+		executor: db,
+	}
+}
+
+// BeginTx starts a new database transaction.
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.db.Begin(ctx)
+}
+
+// WithTx returns a new instance of the Repository that is "scoped" to the provided transaction.
+func (r *Repository) WithTx(tx pgx.Tx) *Repository {
+	return &Repository{
+		db:       r.db,
+		executor: tx,
+	}
+}
+
+const itemColumns = `sku, name, unit_weight_kg, hazmat, hazmat_class, quantity_on_hand, quantity_reserved, created_at, updated_at`
+
+func (r *Repository) scanItem(row pgx.Row) (*domain.Item, error) {
+	var item domain.Item
+	err := row.Scan(
+		&item.SKU,
+		&item.Name,
+		&item.UnitWeightKg,
+		&item.Hazmat,
+		&item.HazmatClass,
+		&item.QuantityOnHand,
+		&item.QuantityReserved,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *Repository) CreateItem(ctx context.Context, req domain.CreateItemRequest) (*domain.Item, error) {
+	query := `
+	INSERT INTO items (sku, name, unit_weight_kg, hazmat, hazmat_class, quantity_on_hand)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING ` + itemColumns
+
+	row := r.executor.QueryRow(ctx, query, req.SKU, req.Name, req.UnitWeightKg, req.Hazmat, req.HazmatClass, req.QuantityOnHand)
+	item, err := r.scanItem(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation on sku
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.CreateItem: %w", err)
+	}
+	return item, nil
+}
+
+func (r *Repository) FindBySKU(ctx context.Context, sku string) (*domain.Item, error) {
+	query := `SELECT ` + itemColumns + ` FROM items WHERE sku = $1`
+
+	item, err := r.scanItem(r.executor.QueryRow(ctx, query, sku))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindBySKU: %w", err)
+	}
+	return item, nil
+}
+
+// FindBySKUForUpdate loads an item and locks its row until the surrounding transaction ends.
+func (r *Repository) FindBySKUForUpdate(ctx context.Context, sku string) (*domain.Item, error) {
+	query := `SELECT ` + itemColumns + ` FROM items WHERE sku = $1 FOR UPDATE`
+
+	item, err := r.scanItem(r.executor.QueryRow(ctx, query, sku))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindBySKUForUpdate: %w", err)
+	}
+	return item, nil
+}
+
+// List returns a page of catalog items ordered by SKU, along with the total item count.
+func (r *Repository) List(ctx context.Context, limit, offset int32) ([]domain.Item, int, error) {
+	var total int
+	if err := r.executor.QueryRow(ctx, `SELECT COUNT(*) FROM items`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("repository.List.Count: %w", err)
+	}
+
+	query := `SELECT ` + itemColumns + ` FROM items ORDER BY sku LIMIT $1 OFFSET $2`
+	rows, err := r.executor.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repository.List: %w", err)
+	}
+	defer rows.Close()
+
+	var items []domain.Item
+	for rows.Next() {
+		item, err := r.scanItem(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("repository.List.Scan: %w", err)
+		}
+		items = append(items, *item)
+	}
+	return items, total, rows.Err()
+}
+
+// AdjustStock adds delta (which may be negative) to the quantity on hand.
+// Stock that is already reserved can never be removed.
+func (r *Repository) AdjustStock(ctx context.Context, sku string, delta int) (*domain.Item, error) {
+	query := `
+	UPDATE items
+	SET quantity_on_hand = quantity_on_hand + $1, updated_at = NOW()
+	WHERE sku = $2 AND quantity_on_hand + $1 >= quantity_reserved
+	RETURNING ` + itemColumns
+
+	item, err := r.scanItem(r.executor.QueryRow(ctx, query, delta, sku))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, findErr := r.FindBySKU(ctx, sku); findErr != nil {
+				return nil, findErr
+			}
+			return nil, models.ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("repository.AdjustStock: %w", err)
+	}
+	return item, nil
+}
+
+// ReserveQuantity moves quantity of an item from available to reserved.
+// It returns models.ErrInsufficientStock if not enough stock is available.
+func (r *Repository) ReserveQuantity(ctx context.Context, sku string, quantity int) error {
+	query := `
+	UPDATE items
+	SET quantity_reserved = quantity_reserved + $1, updated_at = NOW()
+	WHERE sku = $2 AND quantity_on_hand - quantity_reserved >= $1
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, quantity, sku)
+	if err != nil {
+		return fmt.Errorf("repository.ReserveQuantity: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrInsufficientStock
+	}
+	return nil
+}
+
+const reservationColumns = `id, order_id, sku, quantity, status, created_at, updated_at`
+
+func (r *Repository) CreateReservation(ctx context.Context, orderID, sku string, quantity int) (*domain.Reservation, error) {
+	var res domain.Reservation
+	query := `
+	INSERT INTO stock_reservations (order_id, sku, quantity, status)
+	VALUES ($1, $2, $3, $4)
+	RETURNING ` + reservationColumns
+
+	err := r.executor.QueryRow(ctx, query, orderID, sku, quantity, domain.ReservationStatusReserved).Scan(
+		&res.ID, &res.OrderID, &res.SKU, &res.Quantity, &res.Status, &res.CreatedAt, &res.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateReservation: %w", err)
+	}
+	return &res, nil
+}
+
+func (r *Repository) ListReservations(ctx context.Context, orderID string) ([]domain.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` FROM stock_reservations WHERE order_id = $1 ORDER BY sku`
+	rows, err := r.executor.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListReservations: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []domain.Reservation
+	for rows.Next() {
+		var res domain.Reservation
+		if err := rows.Scan(&res.ID, &res.OrderID, &res.SKU, &res.Quantity, &res.Status, &res.CreatedAt, &res.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("repository.ListReservations.Scan: %w", err)
+		}
+		reservations = append(reservations, res)
+	}
+	return reservations, rows.Err()
+}
+
+// ReleaseReservations returns all reserved stock of an order to the available pool.
+// It returns the number of reservations released; releasing twice is a no-op.
+func (r *Repository) ReleaseReservations(ctx context.Context, orderID string) (int64, error) {
+	query := `
+	WITH released AS (
+		UPDATE stock_reservations
+		SET status = $2, updated_at = NOW()
+		WHERE order_id = $1 AND status = $3
+		RETURNING sku, quantity
+	)
+	UPDATE items i
+	SET quantity_reserved = i.quantity_reserved - r.quantity, updated_at = NOW()
+	FROM (SELECT sku, SUM(quantity) AS quantity FROM released GROUP BY sku) r
+	WHERE i.sku = r.sku
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, orderID, domain.ReservationStatusReleased, domain.ReservationStatusReserved)
+	if err != nil {
+		return 0, fmt.Errorf("repository.ReleaseReservations: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// FulfillReservations removes the reserved stock of an order from the inventory
+// once the items have left the warehouse. Fulfilling twice is a no-op.
+func (r *Repository) FulfillReservations(ctx context.Context, orderID string) (int64, error) {
+	query := `
+	WITH fulfilled AS (
+		UPDATE stock_reservations
+		SET status = $2, updated_at = NOW()
+		WHERE order_id = $1 AND status = $3
+		RETURNING sku, quantity
+	)
+	UPDATE items i
+	SET quantity_reserved = i.quantity_reserved - f.quantity,
+		quantity_on_hand = i.quantity_on_hand - f.quantity,
+		updated_at = NOW()
+	FROM (SELECT sku, SUM(quantity) AS quantity FROM fulfilled GROUP BY sku) f
+	WHERE i.sku = f.sku
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, orderID, domain.ReservationStatusFulfilled, domain.ReservationStatusReserved)
+	if err != nil {
+		return 0, fmt.Errorf("repository.FulfillReservations: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
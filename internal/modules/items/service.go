@@ -0,0 +1,148 @@
+package items
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/items/domain"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+)
+
+// ServiceInterface defines methods for inventory business logic.
+type ServiceInterface interface {
+	CreateItem(ctx context.Context, req domain.CreateItemRequest) (*domain.Item, error)
+	GetItem(ctx context.Context, sku string) (*domain.Item, error)
+	ListItems(ctx context.Context, page, limit int32) ([]domain.Item, int, error)
+	AdjustStock(ctx context.Context, sku string, delta int) (*domain.Item, error)
+
+	ReserveStock(ctx context.Context, orderID string, lines []domain.ReservationLine) ([]domain.Item, error)
+	ReleaseStock(ctx context.Context, orderID string) error
+	FulfillStock(ctx context.Context, orderID string) error
+	ListReservations(ctx context.Context, orderID string) ([]domain.Reservation, error)
+}
+
+type Service struct {
+	itemRepo RepositoryInterface
+}
+
+func NewService(itemRepo RepositoryInterface) ServiceInterface {
+	return &Service{itemRepo: itemRepo}
+}
+
+func (s *Service) CreateItem(ctx context.Context, req domain.CreateItemRequest) (*domain.Item, error) {
+	if req.SKU == "" || req.Name == "" || req.UnitWeightKg <= 0 || req.QuantityOnHand < 0 {
+		return nil, fmt.Errorf("service.CreateItem: invalid item definition")
+	}
+	if req.HazmatClass != "" {
+		req.Hazmat = true
+	}
+
+	item, err := s.itemRepo.CreateItem(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("service.CreateItem: %w", err)
+	}
+	return item, nil
+}
+
+func (s *Service) GetItem(ctx context.Context, sku string) (*domain.Item, error) {
+	item, err := s.itemRepo.FindBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetItem: %w", err)
+	}
+	return item, nil
+}
+
+// ListItems returns a page of the catalog along with the total item count.
+func (s *Service) ListItems(ctx context.Context, page, limit int32) ([]domain.Item, int, error) {
+	items, total, err := s.itemRepo.List(ctx, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("service.ListItems: %w", err)
+	}
+	return items, total, nil
+}
+
+func (s *Service) AdjustStock(ctx context.Context, sku string, delta int) (*domain.Item, error) {
+	item, err := s.itemRepo.AdjustStock(ctx, sku, delta)
+	if err != nil {
+		return nil, fmt.Errorf("service.AdjustStock: %w", err)
+	}
+	return item, nil
+}
+
+// ReserveStock reserves every line of an order's manifest, or nothing at all.
+// It returns the catalog entry of each reserved SKU, in SKU order.
+func (s *Service) ReserveStock(ctx context.Context, orderID string, lines []domain.ReservationLine) ([]domain.Item, error) {
+	// 1. Merge duplicate SKUs and sort them, so concurrent reservations lock rows in the same order
+	quantities := make(map[string]int)
+	for _, line := range lines {
+		if line.SKU == "" || line.Quantity <= 0 {
+			return nil, fmt.Errorf("service.ReserveStock: invalid line for sku %q", line.SKU)
+		}
+		quantities[line.SKU] += line.Quantity
+	}
+	skus := make([]string, 0, len(quantities))
+	for sku := range quantities {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	// 2. Reserve all lines in a single transaction
+	tx, err := s.itemRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := s.itemRepo.WithTx(tx)
+	items := make([]domain.Item, 0, len(skus))
+	for _, sku := range skus {
+		item, err := txRepo.FindBySKUForUpdate(ctx, sku)
+		if err != nil {
+			return nil, fmt.Errorf("service.ReserveStock.FindBySKU(%s): %w", sku, err)
+		}
+		if err := txRepo.ReserveQuantity(ctx, sku, quantities[sku]); err != nil {
+			if errors.Is(err, models.ErrInsufficientStock) {
+				return nil, fmt.Errorf("sku %s has %d available: %w", sku, item.Available(), err)
+			}
+			return nil, fmt.Errorf("service.ReserveStock.ReserveQuantity(%s): %w", sku, err)
+		}
+		if _, err := txRepo.CreateReservation(ctx, orderID, sku, quantities[sku]); err != nil {
+			return nil, fmt.Errorf("service.ReserveStock.CreateReservation(%s): %w", sku, err)
+		}
+		item.QuantityReserved += quantities[sku]
+		items = append(items, *item)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ReleaseStock makes the stock reserved for an order available again, e.g. on cancellation.
+func (s *Service) ReleaseStock(ctx context.Context, orderID string) error {
+	released, err := s.itemRepo.ReleaseReservations(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("service.ReleaseStock: %w", err)
+	}
+	log.Printf("INFO: Released stock of %d SKUs for order %s", released, orderID)
+	return nil
+}
+
+// FulfillStock consumes the stock reserved for an order once it has been picked up.
+func (s *Service) FulfillStock(ctx context.Context, orderID string) error {
+	if _, err := s.itemRepo.FulfillReservations(ctx, orderID); err != nil {
+		return fmt.Errorf("service.FulfillStock: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) ListReservations(ctx context.Context, orderID string) ([]domain.Reservation, error) {
+	reservations, err := s.itemRepo.ListReservations(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListReservations: %w", err)
+	}
+	return reservations, nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Failure reason codes a machine reports when it cannot hand an order over.
+const (
+	FailureReasonRecipientUnavailable = "recipient_unavailable"
+	FailureReasonAddressInaccessible  = "address_inaccessible"
+	FailureReasonRecipientRefused     = "recipient_refused"
+	FailureReasonHandoffLocked        = "handoff_locked" // Too many wrong handoff PINs
+	FailureReasonPackageDamaged       = "package_damaged"
+	FailureReasonMachineFault         = "machine_fault"
+)
+
+// Delivery attempt outcomes: the order is either dispatched again later or
+// carried back to its pickup address for good.
+const (
+	AttemptOutcomeReattempt = "reattempt"
+	AttemptOutcomeReturn    = "return"
+)
+
+// ValidFailureReason reports whether reason is a known failure reason code.
+func ValidFailureReason(reason string) bool {
+	switch reason {
+	case FailureReasonRecipientUnavailable, FailureReasonAddressInaccessible, FailureReasonRecipientRefused,
+		FailureReasonHandoffLocked, FailureReasonPackageDamaged, FailureReasonMachineFault:
+		return true
+	}
+	return false
+}
+
+// CarrierFault reports whether a failure was caused by us rather than the
+// recipient. Such failures are never charged to the customer.
+func CarrierFault(reason string) bool {
+	return reason == FailureReasonPackageDamaged || reason == FailureReasonMachineFault
+}
+
+// DeliveryAttempt records a handoff that failed and what happens to the order next.
+type DeliveryAttempt struct {
+	ID              string        `json:"id"`
+	OrderID         string        `json:"order_id"`
+	StopID          *string       `json:"stop_id,omitempty"` // Unset for attempts recorded before stops existed
+	MachineID       string        `json:"machine_id"`
+	AttemptNumber   int           `json:"attempt_number"`
+	Reason          string        `json:"reason"`
+	Notes           string        `json:"notes,omitempty"`
+	TrackingEventID string        `json:"tracking_event_id"`
+	Latitude        float64       `json:"latitude"`
+	Longitude       float64       `json:"longitude"`
+	Outcome         string        `json:"outcome"`
+	Fee             float64       `json:"fee"`                        // Added to the order's cost
+	ReattemptWindow *PickupWindow `json:"reattempt_window,omitempty"` // Set when the outcome is a re-attempt
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+// FailedDeliveryRequest represents the data a machine reports when a handoff fails.
+type FailedDeliveryRequest struct {
+	Reason          string `json:"reason" validate:"required"`
+	TrackingEventID string `json:"tracking_event_id" validate:"required"` // GPS fix at the failed handoff
+	Notes           string `json:"notes,omitempty" validate:"max=500"`
+	StopID          string `json:"stop_id,omitempty"` // Defaults to the next pending stop
+}
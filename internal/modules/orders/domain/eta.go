@@ -0,0 +1,37 @@
+package models
+
+import (
+	"dispatch-and-delivery/pkg/geo"
+	"time"
+)
+
+// RemainingStop is a pending stop on the way of an in-flight order, its own
+// or that of another order on the same trip.
+type RemainingStop struct {
+	ID       string
+	OrderID  string
+	Sequence int
+	Location *geo.Point // Unset when the address is not geocoded
+}
+
+// StopETA is when an in-flight order is expected at one of its stops.
+type StopETA struct {
+	StopID    string    `json:"stop_id"`
+	Sequence  int       `json:"sequence"`
+	ArrivesAt time.Time `json:"arrives_at"`
+}
+
+// OrderETA is the live arrival estimate of an in-flight order, made from the
+// last position its machine reported.
+type OrderETA struct {
+	OrderID                 string    `json:"order_id"`
+	Status                  string    `json:"status"`
+	MachineID               string    `json:"machine_id"`
+	Position                geo.Point `json:"position"`
+	PositionAt              time.Time `json:"position_at"`
+	Stops                   []StopETA `json:"stops"`      // The pending stops of the order, in visit order
+	ArrivesAt               time.Time `json:"arrives_at"` // At the last pending stop
+	RemainingDistanceMeters float64   `json:"remaining_distance_meters"`
+	CalibrationTrips        int       `json:"calibration_trips"` // Past trips the travel time is calibrated from
+	EstimatedAt             time.Time `json:"estimated_at"`
+}
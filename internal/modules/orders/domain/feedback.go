@@ -10,4 +10,37 @@ type Feedback struct {
 	Comment   string    `json:"comment"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
-} 
\ No newline at end of file
+}
+
+// LowRatingThreshold is the highest rating still counted as a bad delivery.
+const LowRatingThreshold = 2
+
+// RatingStats aggregates the feedback ratings of a group of deliveries.
+type RatingStats struct {
+	FeedbackCount  int     `json:"feedback_count"`
+	AverageRating  float64 `json:"average_rating"`
+	LowRatingCount int     `json:"low_rating_count"` // Ratings at or below LowRatingThreshold
+	// RatingCounts holds the number of 1 to 5 star ratings at index 0 to 4.
+	RatingCounts [5]int `json:"rating_counts"`
+}
+
+// MachineRatingStats aggregates the feedback of all orders delivered by one machine.
+type MachineRatingStats struct {
+	MachineID   string `json:"machine_id"`
+	MachineType string `json:"machine_type"`
+	RatingStats
+}
+
+// MachineTypeRatingStats aggregates the feedback of all orders delivered by one machine type.
+type MachineTypeRatingStats struct {
+	MachineType string `json:"machine_type"`
+	RatingStats
+}
+
+// RatingStatsFilter narrows down the feedback included in rating statistics.
+type RatingStatsFilter struct {
+	MachineID        string
+	MachineType      string
+	Since            *time.Time
+	MinFeedbackCount int // Machines with fewer ratings are left out of per-machine stats
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+const (
+	// HandoffPINDigits is the length of the PIN the recipient gives the machine.
+	HandoffPINDigits = 6
+
+	// HandoffMaxAttempts is how many wrong PINs are accepted before the handoff is locked.
+	HandoffMaxAttempts = 5
+
+	// HandoffCodeTTL is how long a handoff PIN stays valid after dispatch.
+	HandoffCodeTTL = 24 * time.Hour
+)
+
+// HandoffCode is the one-time PIN that releases an order to its recipient.
+// Only a hash of the PIN is stored.
+type HandoffCode struct {
+	OrderID    string     `json:"order_id"`
+	CodeHash   string     `json:"-"`
+	Attempts   int        `json:"attempts"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Locked reports whether the handoff can no longer be confirmed with a PIN.
+func (c *HandoffCode) Locked(now time.Time) bool {
+	return c.Attempts >= HandoffMaxAttempts || !now.Before(c.ExpiresAt)
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// OrderItem is one line of an order's item manifest. Customers supply the SKU,
+// quantity and declared value; weight and hazmat flags are copied from the
+// inventory catalog when the order is placed.
+type OrderItem struct {
+	SKU           string  `json:"sku" validate:"required"`
+	Name          string  `json:"name"`
+	Quantity      int     `json:"quantity" validate:"required,gt=0"`
+	UnitWeightKg  float64 `json:"unit_weight_kg"`
+	Hazmat        bool    `json:"hazmat"`
+	HazmatClass   string  `json:"hazmat_class,omitempty"`
+	DeclaredValue float64 `json:"declared_value" validate:"gte=0"` // Total value of the line, used for insurance claims
+}
+
+// WeightKg returns the combined weight of all units on the line.
+func (i OrderItem) WeightKg() float64 {
+	return float64(i.Quantity) * i.UnitWeightKg
+}
+
+// TotalWeightKg returns the combined weight of a manifest.
+func TotalWeightKg(items []OrderItem) float64 {
+	var total float64
+	for _, item := range items {
+		total += item.WeightKg()
+	}
+	return total
+}
+
+// Quote is a route option priced by the dispatch service that an order can be created from.
+type Quote struct {
+	ID               string    `json:"id"`
+	UserID           string    `json:"user_id"`
+	PickupAddressID  string    `json:"pickup_address_id"`
+	DropoffAddressID string    `json:"dropoff_address_id"`
+	MachineType      string    `json:"machine_type"`
+	Price            float64   `json:"price"`
+	OrderID          *string   `json:"order_id,omitempty"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
@@ -1,32 +1,68 @@
 package models
 
 import (
+	dispatchDomain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	usersDomain "dispatch-and-delivery/internal/modules/users/domain"
 	"time"
 )
 
+// Order status constants describing the lifecycle of a delivery order.
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusAssigned  = "assigned"
+	OrderStatusInTransit = "in_transit"
+	OrderStatusDelivered = "delivered"
+	OrderStatusCancelled = "cancelled"
+
+	// OrderStatusDeliveryFailed marks an order whose handoff failed and that
+	// waits to be dispatched again in its re-attempt pickup window.
+	OrderStatusDeliveryFailed = "delivery_failed"
+	// OrderStatusReturning marks an order that is carried back to its pickup address.
+	OrderStatusReturning = "returning"
+	// OrderStatusReturned marks an order that was handed back to the sender.
+	OrderStatusReturned = "returned"
+)
+
+// Address is a saved address of a user. Addresses are owned by the users module.
+type Address = usersDomain.Address
+
+// Dimensions describes the package size in meters.
+type Dimensions = dispatchDomain.Dimensions
+
 // Order represents a delivery order in the system.
 type Order struct {
-	ID               string      `json:"id"`
-	UserID           string      `json:"user_id"`
-	MachineID        *string     `json:"machine_id,omitempty"`
-	PickupAddressID  string      `json:"pickup_address_id"`
-	DropoffAddressID string      `json:"dropoff_address_id"`
-	PickupAddress    *Address    `json:"pickup_address,omitempty"`
-	DropoffAddress   *Address    `json:"dropoff_address,omitempty"`
-	Status           string      `json:"status"`
-	Dimensions       Dimensions  `json:"dimensions"`
-	ItemWeightKg     float64     `json:"item_weight_kg"`
-	Cost             float64     `json:"cost"`
-	Feedback         *Feedback   `json:"feedback,omitempty"`
-	CreatedAt        time.Time   `json:"created_at"`
-	UpdatedAt        time.Time   `json:"updated_at"`
+	ID               string        `json:"id"`
+	UserID           string        `json:"user_id"`
+	MachineID        *string       `json:"machine_id,omitempty"`
+	PickupAddressID  string        `json:"pickup_address_id"`
+	DropoffAddressID string        `json:"dropoff_address_id"`
+	PickupAddress    *Address      `json:"pickup_address,omitempty"`
+	DropoffAddress   *Address      `json:"dropoff_address,omitempty"`
+	Status           string        `json:"status"`
+	MachineType      string        `json:"machine_type"`
+	PickupWindow     *PickupWindow `json:"pickup_window,omitempty"` // Unset for immediate orders
+	ScheduleID       *string       `json:"schedule_id,omitempty"`
+	TripID           *string       `json:"trip_id,omitempty"`
+	Dimensions       Dimensions    `json:"dimensions"`
+	ItemWeightKg     float64       `json:"item_weight_kg"`
+	Cost             float64       `json:"cost"`
+	Items            []OrderItem   `json:"items,omitempty"`
+	Stops            []Stop        `json:"stops,omitempty"`
+	Feedback         *Feedback     `json:"feedback,omitempty"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
 }
 
 // CreateOrderRequest represents the data needed to create a new order from a chosen route option.
 type CreateOrderRequest struct {
-	RouteOptionID string      `json:"route_option_id" validate:"required"`
-	Dimensions    Dimensions  `json:"dimensions" validate:"required"`
-	Items         []byte      `json:"items" validate:"required"`
+	RouteOptionID string        `json:"route_option_id" validate:"required"`
+	Dimensions    Dimensions    `json:"dimensions" validate:"required"`
+	Items         []OrderItem   `json:"items" validate:"required,min=1,dive"`
+	PickupWindow  *PickupWindow `json:"pickup_window,omitempty"`
+	// AdditionalDropoffAddressIDs turns the order into a multi-stop order.
+	// The quoted dropoff is always one of the stops.
+	AdditionalDropoffAddressIDs []string `json:"additional_dropoff_address_ids,omitempty" validate:"max=9"`
 }
 
 // PaymentRequest represents the data needed to pay for an order.
@@ -38,4 +74,4 @@ type PaymentRequest struct {
 type FeedbackRequest struct {
 	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
 	Comment string `json:"comment,omitempty"`
-} 
\ No newline at end of file
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Payment status constants. A payment only becomes succeeded once the
+// provider has confirmed that the funds were captured.
+const (
+	PaymentStatusPending           = "pending"
+	PaymentStatusSucceeded         = "succeeded"
+	PaymentStatusFailed            = "failed"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+	PaymentStatusDisputed          = "disputed"
+)
+
+// Payment represents a single attempt to charge the customer for an order.
+type Payment struct {
+	ID                string    `json:"id"`
+	OrderID           string    `json:"order_id"`
+	Provider          string    `json:"provider"`
+	ProviderPaymentID *string   `json:"provider_payment_id,omitempty"`
+	IdempotencyKey    string    `json:"-"`
+	AmountCents       int64     `json:"amount_cents"`
+	Currency          string    `json:"currency"`
+	Status            string    `json:"status"`
+	FailureReason     *string   `json:"failure_reason,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
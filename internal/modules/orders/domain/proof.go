@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	// ProofRadiusMeters is how far from the dropoff address a machine may report a handoff.
+	ProofRadiusMeters = 50
+
+	// MaxProofFixAge is how much older than the handoff the GPS fix may be.
+	MaxProofFixAge = 10 * time.Minute
+)
+
+// ProofOfDelivery is the evidence a machine records when it hands an order over.
+type ProofOfDelivery struct {
+	OrderID          string    `json:"order_id"`
+	StopID           string    `json:"stop_id"`
+	MachineID        string    `json:"machine_id"`
+	PhotoRef         string    `json:"photo_ref"` // Blob storage reference, e.g. s3://bucket/key
+	TrackingEventID  string    `json:"tracking_event_id"`
+	Latitude         float64   `json:"latitude"`
+	Longitude        float64   `json:"longitude"`
+	FixRecordedAt    time.Time `json:"fix_recorded_at"`
+	DistanceMeters   *float64  `json:"distance_meters,omitempty"` // Unset when the dropoff address is not geocoded
+	LocationVerified bool      `json:"location_verified"`
+	PINVerified      bool      `json:"pin_verified"`
+	DeliveredAt      time.Time `json:"delivered_at"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// ProofOfDeliveryRequest is submitted by a machine when it completes a delivery.
+type ProofOfDeliveryRequest struct {
+	PhotoRef        string    `json:"photo_ref" validate:"required"`
+	TrackingEventID string    `json:"tracking_event_id" validate:"required"`
+	DeliveredAt     time.Time `json:"delivered_at" validate:"required"`
+	RecipientPIN    string    `json:"recipient_pin,omitempty"`
+	StopID          string    `json:"stop_id,omitempty"` // Defaults to the next pending stop
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Refund status constants.
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// Refund reason constants. The reason, together with the order status at the
+// time of the refund, decides how much of the payment is returned.
+const (
+	RefundReasonCustomerCancelled = "customer_cancelled"
+	RefundReasonFailedDelivery    = "failed_delivery"
+	RefundReasonAdmin             = "admin"
+)
+
+// Refund represents money returned to the customer for a payment.
+type Refund struct {
+	ID               string    `json:"id"`
+	PaymentID        string    `json:"payment_id"`
+	OrderID          string    `json:"order_id"`
+	ProviderRefundID *string   `json:"provider_refund_id,omitempty"`
+	IdempotencyKey   string    `json:"-"`
+	AmountCents      int64     `json:"amount_cents"`
+	Currency         string    `json:"currency"`
+	Reason           string    `json:"reason"`
+	Status           string    `json:"status"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// RefundRequest represents the data an admin supplies to refund an order.
+type RefundRequest struct {
+	Reason string `json:"reason" validate:"required"`
+	// AmountCents overrides the amount computed by the refund policy when set.
+	AmountCents *int64 `json:"amount_cents,omitempty" validate:"omitempty,gt=0"`
+}
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxScheduleLeadTime is how far in advance a single order can be scheduled.
+const MaxScheduleLeadTime = 30 * 24 * time.Hour
+
+// PickupWindow is the period in which the machine should collect a scheduled order.
+type PickupWindow struct {
+	Start time.Time `json:"start" validate:"required"`
+	End   time.Time `json:"end" validate:"required,gtfield=Start"`
+}
+
+// Schedule is a recurring delivery that is materialized into concrete orders
+// ahead of time. The route and price are locked in from the quote the
+// schedule was created from.
+type Schedule struct {
+	ID                  string      `json:"id"`
+	UserID              string      `json:"user_id"`
+	PickupAddressID     string      `json:"pickup_address_id"`
+	DropoffAddressID    string      `json:"dropoff_address_id"`
+	MachineType         string      `json:"machine_type"`
+	Price               float64     `json:"price"`
+	Dimensions          Dimensions  `json:"dimensions"`
+	Items               []OrderItem `json:"items"`
+	Rule                string      `json:"rule"`     // Weekly RRULE, e.g. "FREQ=WEEKLY;BYDAY=MO,TH;BYHOUR=9"
+	Timezone            string      `json:"timezone"` // IANA name the rule is evaluated in
+	StartsAt            time.Time   `json:"starts_at"`
+	WindowMinutes       int         `json:"window_minutes"`
+	Active              bool        `json:"active"`
+	MaterializedThrough *time.Time  `json:"materialized_through,omitempty"`
+	CreatedAt           time.Time   `json:"created_at"`
+	UpdatedAt           time.Time   `json:"updated_at"`
+}
+
+// CreateScheduleRequest represents the data needed to set up a recurring delivery.
+type CreateScheduleRequest struct {
+	RouteOptionID string      `json:"route_option_id" validate:"required"`
+	Dimensions    Dimensions  `json:"dimensions" validate:"required"`
+	Items         []OrderItem `json:"items" validate:"required,min=1,dive"`
+	Rule          string      `json:"rule" validate:"required"`
+	Timezone      string      `json:"timezone"`
+	StartsAt      time.Time   `json:"starts_at" validate:"required"`
+	WindowMinutes int         `json:"window_minutes" validate:"required,gt=0"`
+}
+
+// DeliveryStatusProjected marks an upcoming delivery of a schedule occurrence
+// that has not been turned into an order yet.
+const DeliveryStatusProjected = "projected"
+
+// UpcomingDelivery is a pickup window of a user, either of an order that has
+// already been created or of a schedule occurrence that is still to come.
+type UpcomingDelivery struct {
+	OrderID     string       `json:"order_id,omitempty"` // Empty until the occurrence is materialized
+	ScheduleID  string       `json:"schedule_id,omitempty"`
+	Status      string       `json:"status"`
+	Window      PickupWindow `json:"window"`
+	MachineType string       `json:"machine_type"`
+}
+
+// WeeklyRule is the subset of RFC 5545 recurrence rules supported for
+// schedules: FREQ=WEEKLY with INTERVAL, BYDAY, BYHOUR, BYMINUTE and UNTIL.
+type WeeklyRule struct {
+	Interval int
+	Weekdays map[time.Weekday]bool
+	Hour     int
+	Minute   int
+	Until    *time.Time
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// ParseWeeklyRule parses a weekly recurrence rule. Parts that are left out
+// default to the weekday and time of day of start.
+func ParseWeeklyRule(rule string, start time.Time) (*WeeklyRule, error) {
+	r := &WeeklyRule{
+		Interval: 1,
+		Weekdays: map[time.Weekday]bool{},
+		Hour:     start.Hour(),
+		Minute:   start.Minute(),
+	}
+
+	freq := ""
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq = strings.ToUpper(value)
+		case "INTERVAL":
+			r.Interval, err = parseRuleInt(value, 1, 52)
+		case "BYHOUR":
+			r.Hour, err = parseRuleInt(value, 0, 23)
+		case "BYMINUTE":
+			r.Minute, err = parseRuleInt(value, 0, 59)
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY value %q", day)
+				}
+				r.Weekdays[weekday] = true
+			}
+		case "UNTIL":
+			until, parseErr := time.Parse("20060102T150405Z", value)
+			if parseErr != nil {
+				return nil, fmt.Errorf("invalid UNTIL value %q, expected YYYYMMDDTHHMMSSZ", value)
+			}
+			r.Until = &until
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %w", key, value, err)
+		}
+	}
+
+	if freq != "WEEKLY" {
+		return nil, fmt.Errorf("only FREQ=WEEKLY rules are supported")
+	}
+	if len(r.Weekdays) == 0 {
+		r.Weekdays[start.Weekday()] = true
+	}
+	return r, nil
+}
+
+func parseRuleInt(value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("must be between %d and %d", min, max)
+	}
+	return n, nil
+}
+
+// Occurrences returns the pickup times of the rule after `after` and up to
+// and including `until`. start anchors the rule: no occurrence is before it,
+// and INTERVAL counts weeks from the week it falls in. Times are computed in
+// start's location so pickups keep their wall-clock time across DST changes.
+func (r *WeeklyRule) Occurrences(start, after, until time.Time) []time.Time {
+	loc := start.Location()
+	if r.Until != nil && r.Until.Before(until) {
+		until = *r.Until
+	}
+	if after.Before(start) {
+		after = start.Add(-time.Nanosecond)
+	}
+
+	firstWeek := startOfWeek(start)
+	from := after.In(loc)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+
+	var occurrences []time.Time
+	for ; !day.After(until); day = day.AddDate(0, 0, 1) {
+		if !r.Weekdays[day.Weekday()] {
+			continue
+		}
+		weeks := int(startOfWeek(day).Sub(firstWeek).Hours()+12) / (24 * 7)
+		if weeks%r.Interval != 0 {
+			continue
+		}
+		occurrence := time.Date(day.Year(), day.Month(), day.Day(), r.Hour, r.Minute, 0, 0, loc)
+		if occurrence.After(after) && !occurrence.After(until) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
+
+// startOfWeek returns midnight of the Monday of t's week, in t's location.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
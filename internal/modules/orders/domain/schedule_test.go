@@ -0,0 +1,80 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeeklyRule(t *testing.T) {
+	start := time.Date(2026, time.March, 4, 9, 30, 0, 0, time.UTC) // a Wednesday
+
+	r, err := ParseWeeklyRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;BYHOUR=7;BYMINUTE=15;UNTIL=20260601T000000Z", start)
+	if err != nil {
+		t.Fatalf("ParseWeeklyRule: %v", err)
+	}
+	if r.Interval != 2 || r.Hour != 7 || r.Minute != 15 || !r.Weekdays[time.Monday] || !r.Weekdays[time.Friday] || len(r.Weekdays) != 2 {
+		t.Errorf("rule = %+v, want every other Monday and Friday at 07:15", r)
+	}
+	if r.Until == nil || !r.Until.Equal(time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Until = %v, want 2026-06-01", r.Until)
+	}
+
+	defaults, err := ParseWeeklyRule("FREQ=WEEKLY", start)
+	if err != nil {
+		t.Fatalf("ParseWeeklyRule: %v", err)
+	}
+	if defaults.Interval != 1 || defaults.Hour != 9 || defaults.Minute != 30 || !defaults.Weekdays[time.Wednesday] {
+		t.Errorf("rule = %+v, want weekly on Wednesday at 09:30 taken from start", defaults)
+	}
+
+	for _, rule := range []string{
+		"FREQ=DAILY",
+		"BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;INTERVAL=0",
+		"FREQ=WEEKLY;BYHOUR=24",
+		"FREQ=WEEKLY;UNTIL=2026-06-01",
+		"FREQ=WEEKLY;COUNT=3",
+		"FREQ=WEEKLY;BYDAY",
+	} {
+		if _, err := ParseWeeklyRule(rule, start); err == nil {
+			t.Errorf("ParseWeeklyRule(%q) succeeded, want an error", rule)
+		}
+	}
+}
+
+func TestWeeklyRuleOccurrences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+	// Monday 2026-03-16. Berlin switches to summer time on Sunday 2026-03-29.
+	start := time.Date(2026, time.March, 16, 8, 0, 0, 0, berlin)
+
+	r, err := ParseWeeklyRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", start)
+	if err != nil {
+		t.Fatalf("ParseWeeklyRule: %v", err)
+	}
+	got := r.Occurrences(start, start.Add(-time.Hour), start.AddDate(0, 0, 35))
+	want := []time.Time{
+		time.Date(2026, time.March, 16, 8, 0, 0, 0, berlin),
+		time.Date(2026, time.March, 30, 8, 0, 0, 0, berlin),
+		time.Date(2026, time.April, 13, 8, 0, 0, 0, berlin),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Occurrences = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("occurrence %d = %v, want %v (wall-clock time kept across DST)", i, got[i], want[i])
+		}
+	}
+
+	// Occurrences strictly after `after` are returned, and UNTIL caps the range.
+	until := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	r.Until = &until
+	got = r.Occurrences(start, start, start.AddDate(0, 0, 35))
+	if len(got) != 1 || !got[0].Equal(want[1]) {
+		t.Errorf("Occurrences after start and before UNTIL = %v, want only %v", got, want[1])
+	}
+}
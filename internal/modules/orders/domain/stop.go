@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// Stop status constants. An order is finished once none of its stops is pending.
+const (
+	StopStatusPending   = "pending"
+	StopStatusDelivered = "delivered"
+	StopStatusFailed    = "failed"
+)
+
+const (
+	// MaxStopsPerOrder caps the dropoffs of a single multi-stop order.
+	MaxStopsPerOrder = 10
+
+	// MaxOrdersPerTrip caps the orders a machine carries on one trip.
+	MaxOrdersPerTrip = 8
+
+	// ExtraStopFee is charged for every dropoff beyond the quoted one.
+	ExtraStopFee = 2.50
+)
+
+// Trip status constants.
+const (
+	TripStatusActive    = "active"
+	TripStatusCompleted = "completed"
+	TripStatusCancelled = "cancelled" // Every order was taken off the trip before it was served
+)
+
+// Stop is one dropoff of an order. Single-dropoff orders have exactly one.
+type Stop struct {
+	ID                string     `json:"id"`
+	OrderID           string     `json:"order_id"`
+	Sequence          int        `json:"sequence"` // Position in the order as placed, starting at 1
+	AddressID         string     `json:"address_id"`
+	Status            string     `json:"status"`
+	TripID            *string    `json:"trip_id,omitempty"`
+	TripSequence      *int       `json:"trip_sequence,omitempty"`       // Visit position on the trip, starting at 1
+	LegDistanceMeters *float64   `json:"leg_distance_meters,omitempty"` // Straight-line distance from the previous stop
+	DeliveredAt       *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Trip is a single run of a machine from a pickup address over the stops of
+// one or more orders.
+type Trip struct {
+	ID              string    `json:"id"`
+	MachineID       string    `json:"machine_id"`
+	PickupAddressID string    `json:"pickup_address_id"`
+	Status          string    `json:"status"`
+	DistanceMeters  *float64  `json:"distance_meters,omitempty"` // Unset when a stop is not geocoded
+	OrderIDs        []string  `json:"order_ids"`
+	Stops           []Stop    `json:"stops"` // In visit order
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
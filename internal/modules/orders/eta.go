@@ -0,0 +1,125 @@
+package orders
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	dispatchDomain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ETAEstimator predicts how long a machine takes through a list of
+// waypoints. It is implemented by the dispatch ETA model.
+type ETAEstimator interface {
+	EstimateETA(ctx context.Context, machineType string, waypoints []geo.Point, at time.Time) (*dispatchDomain.ETA, error)
+}
+
+// GetOrderETA predicts when an in-flight order reaches each of its pending
+// stops, from the last position its machine reported. A machine that has not
+// left the pickup yet goes there first, and an order sharing its trip waits
+// for the stops of other orders visited before its own.
+func (s *Service) GetOrderETA(ctx context.Context, userID, orderID string) (*domain.OrderETA, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetOrderETA.FindByID: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, models.ErrNotFound
+	}
+	if order.MachineID == nil || (order.Status != domain.OrderStatusAssigned && order.Status != domain.OrderStatusInTransit) {
+		return nil, models.ErrOrderNotInFlight
+	}
+
+	position, positionAt, pickedUp, err := s.machinePosition(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	waypoints := []geo.Point{*position}
+	if !pickedUp && order.Status == domain.OrderStatusAssigned {
+		pickup, err := s.orderRepo.FindAddressLocation(ctx, order.PickupAddressID)
+		if err != nil {
+			return nil, fmt.Errorf("service.GetOrderETA.FindAddressLocation: %w", err)
+		}
+		if pickup != nil {
+			waypoints = append(waypoints, *pickup)
+		}
+	}
+
+	stops, err := s.orderRepo.ListRemainingStops(ctx, order.ID, order.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetOrderETA.ListRemainingStops: %w", err)
+	}
+	var own []domain.RemainingStop
+	var ownWaypoint []int // Index in waypoints of each of own
+	for _, stop := range stops {
+		if stop.Location == nil {
+			continue // Not geocoded; passed on the way without adding to the ETA
+		}
+		waypoints = append(waypoints, *stop.Location)
+		if stop.OrderID == order.ID {
+			own = append(own, stop)
+			ownWaypoint = append(ownWaypoint, len(waypoints)-1)
+		}
+	}
+	if len(own) == 0 {
+		return nil, models.ErrOrderNotInFlight
+	}
+
+	now := time.Now()
+	eta, err := s.eta.EstimateETA(ctx, order.MachineType, waypoints[:ownWaypoint[len(own)-1]+1], now)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetOrderETA.EstimateETA: %w", err)
+	}
+
+	result := &domain.OrderETA{
+		OrderID:                 order.ID,
+		Status:                  order.Status,
+		MachineID:               *order.MachineID,
+		Position:                *position,
+		PositionAt:              *positionAt,
+		RemainingDistanceMeters: eta.DistanceMeters,
+		CalibrationTrips:        eta.Trips,
+		EstimatedAt:             now,
+	}
+	// The machine has been moving since it reported its position.
+	elapsed := max(now.Sub(*positionAt), 0)
+	var travelled time.Duration
+	next := 0
+	for i, leg := range eta.Legs {
+		travelled += leg
+		if next < len(own) && ownWaypoint[next] == i+1 {
+			arrivesAt := positionAt.Add(max(travelled, elapsed))
+			result.Stops = append(result.Stops, domain.StopETA{StopID: own[next].ID, Sequence: own[next].Sequence, ArrivesAt: arrivesAt})
+			next++
+		}
+	}
+	result.ArrivesAt = result.Stops[len(result.Stops)-1].ArrivesAt
+	return result, nil
+}
+
+// machinePosition returns the most recent position of the machine carrying
+// an order: the last tracking event of the order or the machine's last
+// heartbeat, whichever is newer. It also reports whether the machine has left
+// the pickup, which it has once it reports heading to a stop.
+func (s *Service) machinePosition(ctx context.Context, order *domain.Order) (*geo.Point, *time.Time, bool, error) {
+	event, err := s.orderRepo.FindLatestTrackingEvent(ctx, order.ID, *order.MachineID)
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		return nil, nil, false, fmt.Errorf("service.machinePosition.FindLatestTrackingEvent: %w", err)
+	}
+	position, reportedAt, err := s.orderRepo.FindMachinePosition(ctx, *order.MachineID)
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		return nil, nil, false, fmt.Errorf("service.machinePosition.FindMachinePosition: %w", err)
+	}
+
+	switch {
+	case event != nil && (position == nil || !reportedAt.After(event.CreatedAt)):
+		return &geo.Point{Latitude: event.Latitude, Longitude: event.Longitude}, &event.CreatedAt, event.StopID != nil, nil
+	case position != nil:
+		return position, reportedAt, event != nil && event.StopID != nil, nil
+	default:
+		return nil, nil, false, models.ErrNoMachinePosition
+	}
+}
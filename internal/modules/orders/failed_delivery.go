@@ -0,0 +1,294 @@
+package orders
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	emailSvc "dispatch-and-delivery/pkg/email"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+)
+
+// ReportFailedDelivery records a handoff that the machine carrying the order
+// could not complete. The machine takes the package back to the pickup
+// address; the failed delivery policy decides whether the order is then
+// dispatched again in a re-attempt window or returned to the sender, and
+// which fee is added to its cost. Failed stops of multi-stop orders are
+// always returned, and the machine carries on with the remaining stops.
+func (s *Service) ReportFailedDelivery(ctx context.Context, machineID, orderID string, req domain.FailedDeliveryRequest) (*domain.DeliveryAttempt, error) {
+	if !domain.ValidFailureReason(req.Reason) {
+		return nil, fmt.Errorf("service.ReportFailedDelivery: unknown failure reason %q", req.Reason)
+	}
+
+	// 1. Only the machine carrying the order can report on it
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReportFailedDelivery.FindByID: %w", err)
+	}
+	if order.MachineID == nil || *order.MachineID != machineID {
+		return nil, models.ErrNotFound
+	}
+	if order.Status != domain.OrderStatusInTransit {
+		return nil, models.ErrOrderNotInTransit
+	}
+	stops, err := s.orderRepo.ListOrderStops(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReportFailedDelivery.ListOrderStops: %w", err)
+	}
+	stop, err := findReportedStop(stops, req.StopID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. The GPS fix must be a recent tracking event of this machine and order
+	event, err := s.orderRepo.FindTrackingEvent(ctx, req.TrackingEventID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ErrInvalidProofOfDelivery
+		}
+		return nil, fmt.Errorf("service.ReportFailedDelivery.FindTrackingEvent: %w", err)
+	}
+	now := time.Now()
+	if event.OrderID != order.ID || event.MachineID != machineID || (event.StopID != nil && *event.StopID != stop.ID) ||
+		now.Sub(event.CreatedAt) > domain.MaxProofFixAge {
+		return nil, models.ErrInvalidProofOfDelivery
+	}
+
+	// 3. Decide what happens next and what it costs
+	previous, err := s.orderRepo.ListDeliveryAttempts(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ReportFailedDelivery.ListDeliveryAttempts: %w", err)
+	}
+	baseCost := order.Cost
+	for _, a := range previous {
+		baseCost -= a.Fee
+	}
+	// Each stop of a multi-stop order accounts for an equal share of the fare.
+	baseCost /= float64(len(stops))
+
+	attempt := &domain.DeliveryAttempt{
+		OrderID:         order.ID,
+		StopID:          &stop.ID,
+		MachineID:       machineID,
+		AttemptNumber:   len(previous) + 1,
+		Reason:          req.Reason,
+		Notes:           req.Notes,
+		TrackingEventID: event.ID,
+		Latitude:        event.Latitude,
+		Longitude:       event.Longitude,
+	}
+	attempt.Outcome = domain.AttemptOutcomeReturn
+	if len(stops) == 1 {
+		attempt.Outcome = s.failurePolicy.Outcome(req.Reason, attempt.AttemptNumber)
+	}
+	attempt.Fee = s.failurePolicy.Fee(req.Reason, attempt.Outcome, baseCost)
+
+	nextStatus := domain.OrderStatusReturning
+	switch {
+	case attempt.Outcome == domain.AttemptOutcomeReattempt:
+		start := now.Add(s.failurePolicy.ReattemptDelay)
+		attempt.ReattemptWindow = &domain.PickupWindow{Start: start, End: start.Add(s.failurePolicy.ReattemptWindow)}
+		nextStatus = domain.OrderStatusDeliveryFailed
+	case countStops(stops, domain.StopStatusPending, stop.ID) > 0:
+		nextStatus = domain.OrderStatusInTransit
+	}
+
+	// 4. Record the attempt and update the order atomically
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.orderRepo.WithTx(tx)
+
+	created, err := txRepo.CreateDeliveryAttempt(ctx, attempt)
+	if err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			// A concurrent report for the same attempt won.
+			return nil, models.ErrOrderNotInTransit
+		}
+		return nil, fmt.Errorf("service.ReportFailedDelivery.CreateDeliveryAttempt: %w", err)
+	}
+	if attempt.Outcome == domain.AttemptOutcomeReturn {
+		// A re-attempted stop stays pending for the next trip.
+		if err := txRepo.UpdateStopStatus(ctx, stop.ID, domain.StopStatusFailed, nil); err != nil {
+			if errors.Is(err, models.ErrConflict) {
+				return nil, models.ErrStopNotPending
+			}
+			return nil, fmt.Errorf("service.ReportFailedDelivery.UpdateStopStatus: %w", err)
+		}
+	}
+	if err := txRepo.FailDelivery(ctx, order.ID, nextStatus, order.Cost+attempt.Fee, attempt.ReattemptWindow); err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			return nil, models.ErrOrderNotInTransit
+		}
+		return nil, fmt.Errorf("service.ReportFailedDelivery.FailDelivery: %w", err)
+	}
+	if stop.TripID != nil {
+		if err := txRepo.CompleteTripIfDone(ctx, *stop.TripID); err != nil {
+			return nil, fmt.Errorf("service.ReportFailedDelivery.CompleteTripIfDone: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	s.releaseMachine(ctx, machineID)
+
+	// 5. Let the customer know
+	message := fmt.Sprintf("We could not deliver your order because %s.", failureReasonText(created.Reason))
+	switch {
+	case created.ReattemptWindow != nil:
+		message += fmt.Sprintf(" We will try again between %s and %s.",
+			created.ReattemptWindow.Start.UTC().Format(emailTimeLayout), created.ReattemptWindow.End.UTC().Format(emailTimeLayout))
+	case len(stops) > 1:
+		message = fmt.Sprintf("We could not deliver to stop %d of your order because %s. That package is being returned to the pickup address.",
+			stop.Sequence, failureReasonText(created.Reason))
+	default:
+		message += " It is being returned to the pickup address."
+	}
+	s.sendFailedDeliveryEmail(ctx, order, "We Could Not Deliver Your Order", message, created.Fee)
+	return created, nil
+}
+
+// ConfirmReturn completes the return of an order's undelivered packages to its
+// pickup address. The reserved stock is released unless part of the order was
+// delivered, and the customer is refunded the share of the fare of every stop
+// that failed through our fault.
+func (s *Service) ConfirmReturn(ctx context.Context, machineID, orderID string) (*domain.Order, *domain.Refund, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.ConfirmReturn.FindByID: %w", err)
+	}
+	if order.MachineID == nil || *order.MachineID != machineID {
+		return nil, nil, models.ErrNotFound
+	}
+	if order.Status != domain.OrderStatusReturning {
+		return nil, nil, models.ErrOrderNotReturning
+	}
+
+	if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusReturning, domain.OrderStatusReturned); err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			return nil, nil, models.ErrOrderNotReturning
+		}
+		return nil, nil, fmt.Errorf("service.ConfirmReturn.UpdateStatus: %w", err)
+	}
+	order.Status = domain.OrderStatusReturned
+
+	stops, err := s.orderRepo.ListOrderStops(ctx, order.ID)
+	if err != nil {
+		log.Printf("ERROR: Failed to load stops of returned order %s: %v", order.ID, err)
+		return order, nil, nil
+	}
+	if countStops(stops, domain.StopStatusDelivered, "") == 0 {
+		if err := s.inventory.ReleaseStock(ctx, order.ID); err != nil {
+			log.Printf("ERROR: Failed to release stock of returned order %s: %v", order.ID, err)
+		}
+	} else {
+		// Reservations are per order, so the stock of the returned stops must be adjusted by hand.
+		log.Printf("WARN: Order %s was returned after a partial delivery, its stock needs to be adjusted manually", order.ID)
+	}
+	s.sendFailedDeliveryEmail(ctx, order, "Your Order Was Returned", "Your undelivered packages have been handed back at the pickup address.", 0)
+
+	attempts, err := s.orderRepo.ListDeliveryAttempts(ctx, order.ID)
+	if err != nil {
+		log.Printf("ERROR: Failed to load delivery attempts of returned order %s: %v", order.ID, err)
+		return order, nil, nil
+	}
+	carrierFaults, baseCost := 0, order.Cost
+	for _, a := range attempts {
+		baseCost -= a.Fee
+		if a.Outcome == domain.AttemptOutcomeReturn && domain.CarrierFault(a.Reason) {
+			carrierFaults++
+		}
+	}
+	if carrierFaults == 0 {
+		return order, nil, nil
+	}
+	// A full refund unless some stops were served; then only the failed stops' share.
+	var amount *int64
+	if carrierFaults < len(stops) {
+		share := int64(math.Round(baseCost*100)) * int64(carrierFaults) / int64(len(stops))
+		amount = &share
+	}
+	refund, err := s.refundPayment(ctx, order, domain.OrderStatusInTransit, domain.RefundReasonFailedDelivery, amount)
+	if err != nil {
+		// The return stands; the failed refund is recorded and can be retried by an admin.
+		log.Printf("ERROR: Failed to refund returned order %s: %v", order.ID, err)
+		return order, nil, nil
+	}
+	return order, refund, nil
+}
+
+// ListDeliveryAttempts returns the failed handoffs of one of the caller's orders.
+func (s *Service) ListDeliveryAttempts(ctx context.Context, userID, orderID string) ([]domain.DeliveryAttempt, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListDeliveryAttempts.FindByID: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, models.ErrNotFound
+	}
+
+	attempts, err := s.orderRepo.ListDeliveryAttempts(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListDeliveryAttempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// sendFailedDeliveryEmail notifies the customer about a failed or returned delivery without blocking the caller.
+func (s *Service) sendFailedDeliveryEmail(ctx context.Context, order *domain.Order, headline, message string, fee float64) {
+	email, nickname, err := s.orderRepo.FindUserContact(ctx, order.UserID)
+	if err != nil {
+		log.Printf("Failed to look up contact for failed delivery email on order %s: %v", order.ID, err)
+		return
+	}
+
+	data := emailSvc.FailedDeliveryTemplateData{
+		Name:     nickname,
+		OrderID:  order.ID,
+		Headline: headline,
+		Message:  message,
+	}
+	plainTextContent := fmt.Sprintf("%s Order: %s", message, order.ID)
+	if fee > 0 {
+		data.Fee = fmt.Sprintf("%.2f %s", fee, strings.ToUpper(defaultCurrency))
+		plainTextContent += fmt.Sprintf(". A fee of %s has been added to your order.", data.Fee)
+	}
+
+	htmlContent, err := s.templateManager.GenerateFailedDeliveryEmailHTML(data)
+	if err != nil {
+		log.Printf("Failed to generate failed delivery email HTML: %v", err)
+		return
+	}
+
+	emailSubject := "[Circuit] " + headline
+	go func() {
+		// Run in a goroutine so it doesn't block the machine's report
+		err := s.emailer.SendEmail(context.Background(), email, emailSubject, plainTextContent, htmlContent)
+		if err != nil {
+			log.Printf("Failed to send failed delivery email to %s: %v", email, err)
+		}
+	}()
+}
+
+func failureReasonText(reason string) string {
+	switch reason {
+	case domain.FailureReasonRecipientUnavailable:
+		return "nobody was available to receive it"
+	case domain.FailureReasonAddressInaccessible:
+		return "the dropoff address could not be reached"
+	case domain.FailureReasonRecipientRefused:
+		return "the recipient refused it"
+	case domain.FailureReasonHandoffLocked:
+		return "the handoff code was entered incorrectly too many times"
+	case domain.FailureReasonPackageDamaged:
+		return "the package was damaged on the way"
+	default:
+		return "of a problem with our machine"
+	}
+}
@@ -0,0 +1,61 @@
+package orders
+
+import (
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"math"
+	"time"
+)
+
+// FailedDeliveryPolicy decides what happens to an order after a failed
+// handoff and how much the customer is charged for it.
+type FailedDeliveryPolicy struct {
+	// MaxAttempts is how many handoffs are tried before the order is returned.
+	MaxAttempts int
+	// ReattemptDelay is how long after a failure the next pickup window starts.
+	ReattemptDelay time.Duration
+	// ReattemptWindow is the length of the re-attempt pickup window.
+	ReattemptWindow time.Duration
+	// ReattemptFeePercent of the original fare is charged for every re-attempt.
+	ReattemptFeePercent int64
+	// ReturnFeePercent of the original fare is charged for returning the order.
+	ReturnFeePercent int64
+}
+
+// DefaultFailedDeliveryPolicy tries three handoffs, the next one four hours
+// after a failure, and charges for the extra trips only when the recipient
+// caused the failure.
+var DefaultFailedDeliveryPolicy = FailedDeliveryPolicy{
+	MaxAttempts:         3,
+	ReattemptDelay:      4 * time.Hour,
+	ReattemptWindow:     2 * time.Hour,
+	ReattemptFeePercent: 25,
+	ReturnFeePercent:    50,
+}
+
+// Outcome returns whether the order is re-attempted or returned after its
+// attemptNumber-th failed handoff. Only failures that may resolve themselves
+// are re-attempted.
+func (p FailedDeliveryPolicy) Outcome(reason string, attemptNumber int) string {
+	if attemptNumber >= p.MaxAttempts {
+		return domain.AttemptOutcomeReturn
+	}
+	switch reason {
+	case domain.FailureReasonRecipientUnavailable, domain.FailureReasonAddressInaccessible, domain.FailureReasonMachineFault:
+		return domain.AttemptOutcomeReattempt
+	default:
+		return domain.AttemptOutcomeReturn
+	}
+}
+
+// Fee returns the amount added to the order's cost for a failed handoff.
+// baseCost is the fare of the order without earlier fees.
+func (p FailedDeliveryPolicy) Fee(reason, outcome string, baseCost float64) float64 {
+	if domain.CarrierFault(reason) {
+		return 0
+	}
+	percent := p.ReattemptFeePercent
+	if outcome == domain.AttemptOutcomeReturn {
+		percent = p.ReturnFeePercent
+	}
+	return math.Round(baseCost*float64(percent)) / 100
+}
@@ -0,0 +1,51 @@
+package orders
+
+import (
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"testing"
+)
+
+func TestFailedDeliveryPolicyOutcome(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		attempt int
+		want    string
+	}{
+		{"recipient unavailable", domain.FailureReasonRecipientUnavailable, 1, domain.AttemptOutcomeReattempt},
+		{"address inaccessible", domain.FailureReasonAddressInaccessible, 2, domain.AttemptOutcomeReattempt},
+		{"machine fault", domain.FailureReasonMachineFault, 1, domain.AttemptOutcomeReattempt},
+		{"recipient refused", domain.FailureReasonRecipientRefused, 1, domain.AttemptOutcomeReturn},
+		{"handoff locked", domain.FailureReasonHandoffLocked, 1, domain.AttemptOutcomeReturn},
+		{"package damaged", domain.FailureReasonPackageDamaged, 1, domain.AttemptOutcomeReturn},
+		{"last attempt", domain.FailureReasonRecipientUnavailable, 3, domain.AttemptOutcomeReturn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultFailedDeliveryPolicy.Outcome(tt.reason, tt.attempt); got != tt.want {
+				t.Errorf("Outcome(%q, %d) = %q, want %q", tt.reason, tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFailedDeliveryPolicyFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		outcome string
+		want    float64
+	}{
+		{"re-attempt", domain.FailureReasonRecipientUnavailable, domain.AttemptOutcomeReattempt, 3.09},
+		{"return", domain.FailureReasonRecipientRefused, domain.AttemptOutcomeReturn, 6.18},
+		{"machine fault", domain.FailureReasonMachineFault, domain.AttemptOutcomeReattempt, 0},
+		{"damaged package", domain.FailureReasonPackageDamaged, domain.AttemptOutcomeReturn, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultFailedDeliveryPolicy.Fee(tt.reason, tt.outcome, 12.36); got != tt.want {
+				t.Errorf("Fee(%q, %q, 12.36) = %v, want %v", tt.reason, tt.outcome, got, tt.want)
+			}
+		})
+	}
+}
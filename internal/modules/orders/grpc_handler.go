@@ -0,0 +1,904 @@
+package orders
+
+import (
+	"context"
+	pb "dispatch-and-delivery/api/proto/order"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"dispatch-and-delivery/pkg/utils"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCHandler is the gRPC handler for the order service.
+// It implements the OrderServiceServer interface generated by protoc.
+type GRPCHandler struct {
+	pb.UnimplementedOrderServiceServer
+
+	service ServiceInterface
+}
+
+// NewGRPCHandler creates a new gRPC handler for the order service.
+func NewGRPCHandler(s ServiceInterface) *GRPCHandler {
+	return &GRPCHandler{service: s}
+}
+
+// CreateOrder handles the gRPC request for placing an order from a delivery quote.
+func (h *GRPCHandler) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.OrderResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.RouteOptionId == "" || req.Dimensions == nil || len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "route_option_id, dimensions and at least one item are required")
+	}
+	if req.Dimensions.LengthM <= 0 || req.Dimensions.WidthM <= 0 || req.Dimensions.HeightM <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "dimensions must be positive")
+	}
+
+	items, err := fromPBManifest(req.Items)
+	if err != nil {
+		return nil, err
+	}
+	createReq := domain.CreateOrderRequest{
+		RouteOptionID:               req.RouteOptionId,
+		Dimensions:                  fromPBDimensions(req.Dimensions),
+		Items:                       items,
+		AdditionalDropoffAddressIDs: req.AdditionalDropoffAddressIds,
+	}
+
+	if req.PickupWindow != nil {
+		if req.PickupWindow.Start == nil || req.PickupWindow.End == nil {
+			return nil, status.Error(codes.InvalidArgument, "pickup_window needs a start and an end")
+		}
+		createReq.PickupWindow = &domain.PickupWindow{
+			Start: req.PickupWindow.Start.AsTime(),
+			End:   req.PickupWindow.End.AsTime(),
+		}
+	}
+
+	order, err := h.service.CreateOrder(ctx, userID, createReq)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidPickupWindow):
+			return nil, status.Error(codes.InvalidArgument, models.ErrInvalidPickupWindow.Error())
+		case errors.Is(err, models.ErrNoDeliveryCapacity):
+			return nil, status.Error(codes.ResourceExhausted, models.ErrNoDeliveryCapacity.Error())
+		case errors.Is(err, models.ErrRouteOptionExpired):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrRouteOptionExpired.Error())
+		case errors.Is(err, models.ErrRestrictedAirspace):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "one or more items do not exist")
+		case errors.Is(err, models.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrInsufficientStock.Error())
+		case errors.Is(err, models.ErrHazmatNotAllowed):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrHazmatNotAllowed.Error())
+		case errors.Is(err, models.ErrPackageTooLarge):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrPackageTooLarge.Error())
+		case errors.Is(err, models.ErrInvalidStops):
+			return nil, status.Error(codes.InvalidArgument, models.ErrInvalidStops.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to create order")
+	}
+
+	return &pb.OrderResponse{Order: toPBOrder(order)}, nil
+}
+
+// CreateSchedule handles the gRPC request for setting up a recurring delivery.
+func (h *GRPCHandler) CreateSchedule(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.ScheduleResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.RouteOptionId == "" || req.Rule == "" || req.StartsAt == nil || req.Dimensions == nil || len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "route_option_id, rule, starts_at, dimensions and at least one item are required")
+	}
+	if req.Dimensions.LengthM <= 0 || req.Dimensions.WidthM <= 0 || req.Dimensions.HeightM <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "dimensions must be positive")
+	}
+	items, err := fromPBManifest(req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := h.service.CreateSchedule(ctx, userID, domain.CreateScheduleRequest{
+		RouteOptionID: req.RouteOptionId,
+		Dimensions:    fromPBDimensions(req.Dimensions),
+		Items:         items,
+		Rule:          req.Rule,
+		Timezone:      req.Timezone,
+		StartsAt:      req.StartsAt.AsTime(),
+		WindowMinutes: int(req.WindowMinutes),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidPickupWindow):
+			return nil, status.Error(codes.InvalidArgument, models.ErrInvalidPickupWindow.Error())
+		case errors.Is(err, models.ErrRouteOptionExpired):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrRouteOptionExpired.Error())
+		case errors.Is(err, models.ErrRestrictedAirspace):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, models.ErrInvalidScheduleRule), errors.Is(err, models.ErrUnknownTimezone):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, models.ErrInvalidManifest):
+			return nil, status.Error(codes.InvalidArgument, models.ErrInvalidManifest.Error())
+		}
+		log.Printf("ERROR: Failed to create schedule for user %s: %v", userID, err)
+		return nil, status.Error(codes.Internal, "failed to create schedule")
+	}
+	return &pb.ScheduleResponse{Schedule: toPBSchedule(schedule)}, nil
+}
+
+// ListSchedules handles the gRPC request for the recurring deliveries of the caller.
+func (h *GRPCHandler) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := h.service.ListSchedules(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list schedules")
+	}
+
+	res := &pb.ListSchedulesResponse{}
+	for i := range schedules {
+		res.Schedules = append(res.Schedules, toPBSchedule(&schedules[i]))
+	}
+	return res, nil
+}
+
+// CancelSchedule handles the gRPC request for stopping a recurring delivery.
+func (h *GRPCHandler) CancelSchedule(ctx context.Context, req *pb.CancelScheduleRequest) (*pb.CancelScheduleResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.ScheduleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "schedule_id is required")
+	}
+
+	if err := h.service.CancelSchedule(ctx, userID, req.ScheduleId); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "schedule not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to cancel schedule")
+	}
+	return &pb.CancelScheduleResponse{}, nil
+}
+
+// ListUpcomingDeliveries handles the gRPC request for the caller's upcoming pickups.
+func (h *GRPCHandler) ListUpcomingDeliveries(ctx context.Context, req *pb.ListUpcomingDeliveriesRequest) (*pb.ListUpcomingDeliveriesResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Now()
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	to := from.Add(ScheduleHorizon)
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	if !to.After(from) || to.Sub(from) > domain.MaxScheduleLeadTime {
+		return nil, status.Error(codes.InvalidArgument, "to must be after from and at most 30 days later")
+	}
+
+	deliveries, err := h.service.ListUpcomingDeliveries(ctx, userID, from, to)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list upcoming deliveries")
+	}
+
+	res := &pb.ListUpcomingDeliveriesResponse{}
+	for _, d := range deliveries {
+		res.Deliveries = append(res.Deliveries, &pb.UpcomingDelivery{
+			OrderId:      d.OrderID,
+			ScheduleId:   d.ScheduleID,
+			Status:       d.Status,
+			PickupWindow: toPBPickupWindow(d.Window),
+			MachineType:  d.MachineType,
+		})
+	}
+	return res, nil
+}
+
+// PayOrder handles the gRPC request for paying a pending order.
+func (h *GRPCHandler) PayOrder(ctx context.Context, req *pb.PayOrderRequest) (*pb.PaymentResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" || req.PaymentMethodId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id and payment_method_id are required")
+	}
+
+	payment, err := h.service.PayOrder(ctx, userID, req.OrderId, domain.PaymentRequest{PaymentMethodID: req.PaymentMethodId})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrOrderCannotBePaid):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrOrderCannotBePaid.Error())
+		case errors.Is(err, models.ErrPaymentDeclined):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrPaymentDeclined.Error())
+		case errors.Is(err, models.ErrPaymentInProgress):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrPaymentInProgress.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to process payment")
+	}
+
+	return &pb.PaymentResponse{Payment: toPBPayment(payment)}, nil
+}
+
+// CancelOrder handles the gRPC request for cancelling an order and refunding it if paid.
+func (h *GRPCHandler) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	order, refund, err := h.service.CancelOrder(ctx, userID, req.OrderId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrOrderCannotBeCancelled):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrOrderCannotBeCancelled.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to cancel order")
+	}
+
+	res := &pb.CancelOrderResponse{OrderId: order.ID, Status: order.Status}
+	if refund != nil {
+		res.Refund = toPBRefund(refund)
+	}
+	return res, nil
+}
+
+// AssignOrder handles the admin gRPC request for dispatching a paid order to a machine.
+// The admin role is enforced by the auth interceptor.
+func (h *GRPCHandler) AssignOrder(ctx context.Context, req *pb.AssignOrderRequest) (*pb.OrderResponse, error) {
+	if req.OrderId == "" || req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id and machine_id are required")
+	}
+
+	order, err := h.service.AssignMachine(ctx, req.OrderId, req.MachineId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order or machine not found")
+		case errors.Is(err, models.ErrOrderCannotBeAssigned), errors.Is(err, models.ErrMachineUnavailable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to assign order")
+	}
+	return &pb.OrderResponse{Order: toPBOrder(order)}, nil
+}
+
+// AssignTrip handles the admin gRPC request for dispatching a batch of orders on one machine trip.
+// The admin role is enforced by the auth interceptor.
+func (h *GRPCHandler) AssignTrip(ctx context.Context, req *pb.AssignTripRequest) (*pb.TripResponse, error) {
+	if len(req.OrderIds) == 0 || req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_ids and machine_id are required")
+	}
+	if len(req.OrderIds) > domain.MaxOrdersPerTrip {
+		return nil, status.Errorf(codes.InvalidArgument, "a trip can carry at most %d orders", domain.MaxOrdersPerTrip)
+	}
+
+	trip, err := h.service.AssignTrip(ctx, req.OrderIds, req.MachineId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order or machine not found")
+		case errors.Is(err, models.ErrOrderCannotBeAssigned),
+			errors.Is(err, models.ErrInvalidTrip),
+			errors.Is(err, models.ErrPackageTooLarge),
+			errors.Is(err, models.ErrMachineUnavailable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to assign trip")
+	}
+	return &pb.TripResponse{Trip: toPBTrip(trip)}, nil
+}
+
+// ListOrderStops handles the gRPC request for the stops of an order and their status.
+func (h *GRPCHandler) ListOrderStops(ctx context.Context, req *pb.ListOrderStopsRequest) (*pb.ListOrderStopsResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	stops, err := h.service.ListOrderStops(ctx, userID, req.OrderId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to list stops")
+	}
+	return &pb.ListOrderStopsResponse{Stops: toPBStops(stops)}, nil
+}
+
+// GetOrderETA handles the gRPC request for the live arrival estimate of an
+// in-flight order.
+func (h *GRPCHandler) GetOrderETA(ctx context.Context, req *pb.GetOrderETARequest) (*pb.OrderETAResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	eta, err := h.service.GetOrderETA(ctx, userID, req.OrderId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrOrderNotInFlight):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrOrderNotInFlight.Error())
+		case errors.Is(err, models.ErrNoMachinePosition):
+			return nil, status.Error(codes.Unavailable, models.ErrNoMachinePosition.Error())
+		default:
+			return nil, status.Error(codes.Internal, "failed to estimate the arrival")
+		}
+	}
+	return &pb.OrderETAResponse{Eta: toPBOrderETA(eta)}, nil
+}
+
+// RefundOrder handles the admin gRPC request for refunding an order.
+// The admin role is enforced by the auth interceptor.
+func (h *GRPCHandler) RefundOrder(ctx context.Context, req *pb.RefundOrderRequest) (*pb.RefundResponse, error) {
+	if req.OrderId == "" || req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id and reason are required")
+	}
+	if req.AmountCents < 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount_cents must not be negative")
+	}
+	switch req.Reason {
+	case domain.RefundReasonCustomerCancelled, domain.RefundReasonFailedDelivery, domain.RefundReasonAdmin:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "reason must be one of customer_cancelled, failed_delivery or admin")
+	}
+
+	refundReq := domain.RefundRequest{Reason: req.Reason}
+	if req.AmountCents > 0 {
+		refundReq.AmountCents = &req.AmountCents
+	}
+
+	refund, err := h.service.RefundOrder(ctx, req.OrderId, refundReq)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrOrderNotRefundable):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrOrderNotRefundable.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to refund order")
+	}
+
+	return &pb.RefundResponse{Refund: toPBRefund(refund)}, nil
+}
+
+// ConfirmPickup handles the gRPC request a machine sends when it has collected an order.
+// The machine role is enforced by the auth interceptor.
+func (h *GRPCHandler) ConfirmPickup(ctx context.Context, req *pb.ConfirmPickupRequest) (*pb.OrderResponse, error) {
+	machineID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	order, err := h.service.ConfirmPickup(ctx, machineID, req.OrderId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrOrderNotAssigned):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to confirm pickup")
+	}
+	return &pb.OrderResponse{Order: toPBOrder(order)}, nil
+}
+
+// SubmitProofOfDelivery handles the gRPC request a machine sends when it hands an order over.
+// The machine role is enforced by the auth interceptor.
+func (h *GRPCHandler) SubmitProofOfDelivery(ctx context.Context, req *pb.SubmitProofOfDeliveryRequest) (*pb.ProofOfDeliveryResponse, error) {
+	machineID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" || req.PhotoRef == "" || req.TrackingEventId == "" || req.DeliveredAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "order_id, photo_ref, tracking_event_id and delivered_at are required")
+	}
+	if len(req.PhotoRef) > 1024 {
+		return nil, status.Error(codes.InvalidArgument, "photo_ref must be at most 1024 characters")
+	}
+
+	proof, err := h.service.SubmitProofOfDelivery(ctx, machineID, req.OrderId, domain.ProofOfDeliveryRequest{
+		PhotoRef:        req.PhotoRef,
+		TrackingEventID: req.TrackingEventId,
+		DeliveredAt:     req.DeliveredAt.AsTime(),
+		RecipientPIN:    req.RecipientPin,
+		StopID:          req.StopId,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrConflict):
+			return nil, status.Error(codes.AlreadyExists, "proof of delivery has already been submitted")
+		case errors.Is(err, models.ErrOrderNotInTransit),
+			errors.Is(err, models.ErrInvalidProofOfDelivery),
+			errors.Is(err, models.ErrOutsideDropoffRadius):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, models.ErrStopNotPending):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, models.ErrInvalidHandoffPIN):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, models.ErrHandoffLocked):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to submit proof of delivery")
+	}
+	return &pb.ProofOfDeliveryResponse{Proof: toPBProofOfDelivery(proof)}, nil
+}
+
+// GetProofOfDelivery handles the gRPC request for the delivery evidence of an order.
+func (h *GRPCHandler) GetProofOfDelivery(ctx context.Context, req *pb.GetProofOfDeliveryRequest) (*pb.ProofOfDeliveryResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	proofs, err := h.service.GetProofOfDelivery(ctx, userID, req.OrderId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "proof of delivery not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve proof of delivery")
+	}
+	res := &pb.ProofOfDeliveryResponse{}
+	for i := range proofs {
+		res.Proofs = append(res.Proofs, toPBProofOfDelivery(&proofs[i]))
+	}
+	res.Proof = res.Proofs[0]
+	return res, nil
+}
+
+// ReportFailedDelivery handles the gRPC request a machine sends when it cannot hand an order over.
+// The machine role is enforced by the auth interceptor.
+func (h *GRPCHandler) ReportFailedDelivery(ctx context.Context, req *pb.ReportFailedDeliveryRequest) (*pb.DeliveryAttemptResponse, error) {
+	machineID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" || req.Reason == "" || req.TrackingEventId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id, reason and tracking_event_id are required")
+	}
+	if !domain.ValidFailureReason(req.Reason) {
+		return nil, status.Error(codes.InvalidArgument, "reason must be one of recipient_unavailable, address_inaccessible, recipient_refused, handoff_locked, package_damaged or machine_fault")
+	}
+	if len(req.Notes) > 500 {
+		return nil, status.Error(codes.InvalidArgument, "notes must be at most 500 characters")
+	}
+
+	attempt, err := h.service.ReportFailedDelivery(ctx, machineID, req.OrderId, domain.FailedDeliveryRequest{
+		Reason:          req.Reason,
+		TrackingEventID: req.TrackingEventId,
+		Notes:           req.Notes,
+		StopID:          req.StopId,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrOrderNotInTransit),
+			errors.Is(err, models.ErrInvalidProofOfDelivery),
+			errors.Is(err, models.ErrStopNotPending):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to report failed delivery")
+	}
+	return &pb.DeliveryAttemptResponse{Attempt: toPBDeliveryAttempt(attempt)}, nil
+}
+
+// ConfirmReturn handles the gRPC request a machine sends when it hands a returned order back at its pickup address.
+// The machine role is enforced by the auth interceptor.
+func (h *GRPCHandler) ConfirmReturn(ctx context.Context, req *pb.ConfirmReturnRequest) (*pb.ConfirmReturnResponse, error) {
+	machineID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	order, refund, err := h.service.ConfirmReturn(ctx, machineID, req.OrderId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrOrderNotReturning):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to confirm return")
+	}
+
+	res := &pb.ConfirmReturnResponse{OrderId: order.ID, Status: order.Status}
+	if refund != nil {
+		res.Refund = toPBRefund(refund)
+	}
+	return res, nil
+}
+
+// ListDeliveryAttempts handles the gRPC request for the failed handoffs of an order.
+func (h *GRPCHandler) ListDeliveryAttempts(ctx context.Context, req *pb.ListDeliveryAttemptsRequest) (*pb.ListDeliveryAttemptsResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	attempts, err := h.service.ListDeliveryAttempts(ctx, userID, req.OrderId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to list delivery attempts")
+	}
+
+	res := &pb.ListDeliveryAttemptsResponse{}
+	for i := range attempts {
+		res.Attempts = append(res.Attempts, toPBDeliveryAttempt(&attempts[i]))
+	}
+	return res, nil
+}
+
+// SubmitFeedback handles the gRPC request for rating a delivered order.
+func (h *GRPCHandler) SubmitFeedback(ctx context.Context, req *pb.SubmitFeedbackRequest) (*pb.FeedbackResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, status.Error(codes.InvalidArgument, "rating must be between 1 and 5")
+	}
+
+	feedback, err := h.service.SubmitFeedback(ctx, userID, req.OrderId, domain.FeedbackRequest{
+		Rating:  int(req.Rating),
+		Comment: req.Comment,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, models.ErrCannotSubmitFeedback):
+			return nil, status.Error(codes.FailedPrecondition, models.ErrCannotSubmitFeedback.Error())
+		case errors.Is(err, models.ErrFeedbackAlreadySubmitted):
+			return nil, status.Error(codes.AlreadyExists, models.ErrFeedbackAlreadySubmitted.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to submit feedback")
+	}
+
+	return &pb.FeedbackResponse{Feedback: &pb.Feedback{
+		Id:        feedback.ID,
+		OrderId:   feedback.OrderID,
+		Rating:    int32(feedback.Rating),
+		Comment:   feedback.Comment,
+		CreatedAt: timestamppb.New(feedback.CreatedAt),
+		UpdatedAt: timestamppb.New(feedback.UpdatedAt),
+	}}, nil
+}
+
+// GetMachineRatingStats handles the admin gRPC request for delivery rating statistics.
+func (h *GRPCHandler) GetMachineRatingStats(ctx context.Context, req *pb.GetMachineRatingStatsRequest) (*pb.GetMachineRatingStatsResponse, error) {
+	filter := domain.RatingStatsFilter{
+		MachineID:        req.MachineId,
+		MachineType:      req.MachineType,
+		MinFeedbackCount: int(req.MinFeedbackCount),
+	}
+	if req.Since != nil {
+		since := req.Since.AsTime()
+		filter.Since = &since
+	}
+
+	perMachine, perType, err := h.service.GetMachineRatingStats(ctx, filter)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to retrieve rating statistics")
+	}
+
+	res := &pb.GetMachineRatingStatsResponse{}
+	for _, m := range perMachine {
+		res.Machines = append(res.Machines, &pb.MachineRatingStats{
+			MachineId:   m.MachineID,
+			MachineType: m.MachineType,
+			Stats:       toPBRatingStats(m.RatingStats),
+		})
+	}
+	for _, t := range perType {
+		res.MachineTypes = append(res.MachineTypes, &pb.MachineTypeRatingStats{
+			MachineType: t.MachineType,
+			Stats:       toPBRatingStats(t.RatingStats),
+		})
+	}
+	return res, nil
+}
+
+func toPBProofOfDelivery(p *domain.ProofOfDelivery) *pb.ProofOfDelivery {
+	proof := &pb.ProofOfDelivery{
+		OrderId:          p.OrderID,
+		StopId:           p.StopID,
+		MachineId:        p.MachineID,
+		PhotoRef:         p.PhotoRef,
+		TrackingEventId:  p.TrackingEventID,
+		Latitude:         p.Latitude,
+		Longitude:        p.Longitude,
+		FixRecordedAt:    timestamppb.New(p.FixRecordedAt),
+		LocationVerified: p.LocationVerified,
+		PinVerified:      p.PINVerified,
+		DeliveredAt:      timestamppb.New(p.DeliveredAt),
+		CreatedAt:        timestamppb.New(p.CreatedAt),
+	}
+	if p.DistanceMeters != nil {
+		proof.DistanceMeters = *p.DistanceMeters
+	}
+	return proof
+}
+
+func toPBDeliveryAttempt(a *domain.DeliveryAttempt) *pb.DeliveryAttempt {
+	attempt := &pb.DeliveryAttempt{
+		Id:              a.ID,
+		OrderId:         a.OrderID,
+		MachineId:       a.MachineID,
+		AttemptNumber:   int32(a.AttemptNumber),
+		Reason:          a.Reason,
+		Notes:           a.Notes,
+		TrackingEventId: a.TrackingEventID,
+		Latitude:        a.Latitude,
+		Longitude:       a.Longitude,
+		Outcome:         a.Outcome,
+		Fee:             a.Fee,
+		CreatedAt:       timestamppb.New(a.CreatedAt),
+	}
+	if a.StopID != nil {
+		attempt.StopId = *a.StopID
+	}
+	if a.ReattemptWindow != nil {
+		attempt.ReattemptWindow = toPBPickupWindow(*a.ReattemptWindow)
+	}
+	return attempt
+}
+
+func toPBRatingStats(s domain.RatingStats) *pb.RatingStats {
+	stats := &pb.RatingStats{
+		FeedbackCount:  int32(s.FeedbackCount),
+		AverageRating:  s.AverageRating,
+		LowRatingCount: int32(s.LowRatingCount),
+	}
+	for _, count := range s.RatingCounts {
+		stats.RatingCounts = append(stats.RatingCounts, int32(count))
+	}
+	return stats
+}
+
+func fromPBManifest(items []*pb.OrderItem) ([]domain.OrderItem, error) {
+	manifest := make([]domain.OrderItem, 0, len(items))
+	for _, item := range items {
+		if item.Sku == "" || item.Quantity <= 0 || item.DeclaredValue < 0 {
+			return nil, status.Error(codes.InvalidArgument, "every item needs a sku, a positive quantity and a non-negative declared value")
+		}
+		manifest = append(manifest, domain.OrderItem{
+			SKU:           item.Sku,
+			Quantity:      int(item.Quantity),
+			DeclaredValue: item.DeclaredValue,
+		})
+	}
+	return manifest, nil
+}
+
+func fromPBDimensions(d *pb.Dimensions) domain.Dimensions {
+	return domain.Dimensions{Length: d.LengthM, Width: d.WidthM, Height: d.HeightM}
+}
+
+func toPBDimensions(d domain.Dimensions) *pb.Dimensions {
+	return &pb.Dimensions{LengthM: d.Length, WidthM: d.Width, HeightM: d.Height}
+}
+
+func toPBPickupWindow(w domain.PickupWindow) *pb.PickupWindow {
+	return &pb.PickupWindow{Start: timestamppb.New(w.Start), End: timestamppb.New(w.End)}
+}
+
+func toPBOrderItems(items []domain.OrderItem) []*pb.OrderItem {
+	var res []*pb.OrderItem
+	for _, item := range items {
+		res = append(res, &pb.OrderItem{
+			Sku:           item.SKU,
+			Name:          item.Name,
+			Quantity:      int32(item.Quantity),
+			UnitWeightKg:  item.UnitWeightKg,
+			Hazmat:        item.Hazmat,
+			HazmatClass:   item.HazmatClass,
+			DeclaredValue: item.DeclaredValue,
+		})
+	}
+	return res
+}
+
+func toPBSchedule(s *domain.Schedule) *pb.Schedule {
+	return &pb.Schedule{
+		Id:               s.ID,
+		PickupAddressId:  s.PickupAddressID,
+		DropoffAddressId: s.DropoffAddressID,
+		MachineType:      s.MachineType,
+		Price:            s.Price,
+		Dimensions:       toPBDimensions(s.Dimensions),
+		Items:            toPBOrderItems(s.Items),
+		Rule:             s.Rule,
+		Timezone:         s.Timezone,
+		StartsAt:         timestamppb.New(s.StartsAt),
+		WindowMinutes:    int32(s.WindowMinutes),
+		Active:           s.Active,
+		CreatedAt:        timestamppb.New(s.CreatedAt),
+		UpdatedAt:        timestamppb.New(s.UpdatedAt),
+	}
+}
+
+func toPBOrder(o *domain.Order) *pb.Order {
+	order := &pb.Order{
+		Id:               o.ID,
+		UserId:           o.UserID,
+		PickupAddressId:  o.PickupAddressID,
+		DropoffAddressId: o.DropoffAddressID,
+		Status:           o.Status,
+		MachineType:      o.MachineType,
+		Dimensions:       toPBDimensions(o.Dimensions),
+		ItemWeightKg:     o.ItemWeightKg,
+		Cost:             o.Cost,
+		Items:            toPBOrderItems(o.Items),
+		CreatedAt:        timestamppb.New(o.CreatedAt),
+		UpdatedAt:        timestamppb.New(o.UpdatedAt),
+	}
+	if o.MachineID != nil {
+		order.MachineId = *o.MachineID
+	}
+	if o.PickupWindow != nil {
+		order.PickupWindow = toPBPickupWindow(*o.PickupWindow)
+	}
+	if o.ScheduleID != nil {
+		order.ScheduleId = *o.ScheduleID
+	}
+	if o.TripID != nil {
+		order.TripId = *o.TripID
+	}
+	order.Stops = toPBStops(o.Stops)
+	return order
+}
+
+func toPBStops(stops []domain.Stop) []*pb.Stop {
+	var res []*pb.Stop
+	for _, st := range stops {
+		stop := &pb.Stop{
+			Id:        st.ID,
+			OrderId:   st.OrderID,
+			Sequence:  int32(st.Sequence),
+			AddressId: st.AddressID,
+			Status:    st.Status,
+		}
+		if st.TripID != nil {
+			stop.TripId = *st.TripID
+		}
+		if st.TripSequence != nil {
+			stop.TripSequence = int32(*st.TripSequence)
+		}
+		if st.LegDistanceMeters != nil {
+			stop.LegDistanceMeters = *st.LegDistanceMeters
+		}
+		if st.DeliveredAt != nil {
+			stop.DeliveredAt = timestamppb.New(*st.DeliveredAt)
+		}
+		res = append(res, stop)
+	}
+	return res
+}
+
+func toPBOrderETA(e *domain.OrderETA) *pb.OrderETA {
+	eta := &pb.OrderETA{
+		OrderId:                 e.OrderID,
+		Status:                  e.Status,
+		MachineId:               e.MachineID,
+		Latitude:                e.Position.Latitude,
+		Longitude:               e.Position.Longitude,
+		PositionAt:              timestamppb.New(e.PositionAt),
+		ArrivesAt:               timestamppb.New(e.ArrivesAt),
+		RemainingDistanceMeters: e.RemainingDistanceMeters,
+		CalibrationTrips:        int32(e.CalibrationTrips),
+		EstimatedAt:             timestamppb.New(e.EstimatedAt),
+	}
+	for _, st := range e.Stops {
+		eta.Stops = append(eta.Stops, &pb.StopETA{
+			StopId:    st.StopID,
+			Sequence:  int32(st.Sequence),
+			ArrivesAt: timestamppb.New(st.ArrivesAt),
+		})
+	}
+	return eta
+}
+
+func toPBTrip(t *domain.Trip) *pb.Trip {
+	trip := &pb.Trip{
+		Id:              t.ID,
+		MachineId:       t.MachineID,
+		PickupAddressId: t.PickupAddressID,
+		Status:          t.Status,
+		OrderIds:        t.OrderIDs,
+		Stops:           toPBStops(t.Stops),
+		CreatedAt:       timestamppb.New(t.CreatedAt),
+	}
+	if t.DistanceMeters != nil {
+		trip.DistanceMeters = *t.DistanceMeters
+	}
+	return trip
+}
+
+func toPBPayment(p *domain.Payment) *pb.Payment {
+	payment := &pb.Payment{
+		Id:          p.ID,
+		OrderId:     p.OrderID,
+		Provider:    p.Provider,
+		AmountCents: p.AmountCents,
+		Currency:    p.Currency,
+		Status:      p.Status,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+	}
+	if p.ProviderPaymentID != nil {
+		payment.ProviderPaymentId = *p.ProviderPaymentID
+	}
+	if p.FailureReason != nil {
+		payment.FailureReason = *p.FailureReason
+	}
+	return payment
+}
+
+func toPBRefund(r *domain.Refund) *pb.Refund {
+	refund := &pb.Refund{
+		Id:          r.ID,
+		PaymentId:   r.PaymentID,
+		OrderId:     r.OrderID,
+		AmountCents: r.AmountCents,
+		Currency:    r.Currency,
+		Reason:      r.Reason,
+		Status:      r.Status,
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+		UpdatedAt:   timestamppb.New(r.UpdatedAt),
+	}
+	if r.ProviderRefundID != nil {
+		refund.ProviderRefundId = *r.ProviderRefundID
+	}
+	return refund
+}
@@ -0,0 +1,111 @@
+package orders
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	emailSvc "dispatch-and-delivery/pkg/email"
+	"dispatch-and-delivery/pkg/utils"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// emailTimeLayout formats times in customer notifications.
+const emailTimeLayout = "Jan 2, 2006 15:04 MST"
+
+// newHandoffPIN generates a handoff PIN and the hash stored for it.
+func (s *Service) newHandoffPIN(orderID string) (pin, hash string, err error) {
+	pin, err = utils.GenerateNumericCode(domain.HandoffPINDigits)
+	if err != nil {
+		return "", "", err
+	}
+	return pin, s.hashHandoffPIN(orderID, pin), nil
+}
+
+// verifyHandoffPIN checks the PIN the machine collected from the recipient.
+// It returns the handoff code, or nil if the order was dispatched without one.
+// Wrong PINs are counted outside of any transaction so they stick even though
+// the delivery is rejected.
+func (s *Service) verifyHandoffPIN(ctx context.Context, orderID, pin string) (*domain.HandoffCode, error) {
+	code, err := s.orderRepo.FindHandoffCode(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("service.verifyHandoffPIN.FindHandoffCode: %w", err)
+	}
+	if code.Locked(time.Now()) {
+		return nil, models.ErrHandoffLocked
+	}
+	if pin == "" {
+		return nil, models.ErrInvalidHandoffPIN
+	}
+
+	expected, err := hex.DecodeString(code.CodeHash)
+	if err != nil {
+		return nil, fmt.Errorf("service.verifyHandoffPIN: malformed code hash: %w", err)
+	}
+	if hmac.Equal(expected, s.handoffPINMAC(orderID, pin)) {
+		return code, nil
+	}
+
+	attempts, err := s.orderRepo.RecordHandoffAttempt(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.verifyHandoffPIN.RecordHandoffAttempt: %w", err)
+	}
+	if attempts >= domain.HandoffMaxAttempts {
+		log.Printf("WARN: Handoff of order %s locked after %d wrong PINs", orderID, attempts)
+		return nil, models.ErrHandoffLocked
+	}
+	return nil, models.ErrInvalidHandoffPIN
+}
+
+// hashHandoffPIN returns the hex encoded handoff PIN MAC that is stored.
+func (s *Service) hashHandoffPIN(orderID, pin string) string {
+	return hex.EncodeToString(s.handoffPINMAC(orderID, pin))
+}
+
+// handoffPINMAC binds a PIN to its order with an HMAC keyed by the server
+// secret, so the few possible PINs cannot be brute-forced from a leaked hash.
+func (s *Service) handoffPINMAC(orderID, pin string) []byte {
+	mac := hmac.New(sha256.New, s.handoffSecret)
+	mac.Write([]byte(orderID + ":" + pin))
+	return mac.Sum(nil)
+}
+
+// sendHandoffEmail sends the customer the PIN for the handoff without blocking the caller.
+func (s *Service) sendHandoffEmail(ctx context.Context, order *domain.Order, pin string, expiresAt time.Time) {
+	email, nickname, err := s.orderRepo.FindUserContact(ctx, order.UserID)
+	if err != nil {
+		log.Printf("Failed to look up contact for handoff email on order %s: %v", order.ID, err)
+		return
+	}
+
+	expires := expiresAt.UTC().Format(emailTimeLayout)
+	htmlContent, err := s.templateManager.GenerateHandoffEmailHTML(emailSvc.HandoffTemplateData{
+		Name:      nickname,
+		OrderID:   order.ID,
+		PIN:       pin,
+		ExpiresAt: expires,
+	})
+	if err != nil {
+		log.Printf("Failed to generate handoff email HTML: %v", err)
+		return
+	}
+
+	emailSubject := "[Circuit] Your Delivery PIN"
+	plainTextContent := fmt.Sprintf("Your order %s is on its way. Give the PIN %s to the machine to receive it. The PIN expires on %s.", order.ID, pin, expires)
+
+	go func() {
+		// Run in a goroutine so it doesn't block the dispatch response
+		err := s.emailer.SendEmail(context.Background(), email, emailSubject, plainTextContent, htmlContent)
+		if err != nil {
+			log.Printf("Failed to send handoff email to %s: %v", email, err)
+		}
+	}()
+}
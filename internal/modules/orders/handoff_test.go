@@ -0,0 +1,101 @@
+package orders
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+// handoffRepo stores the handoff codes of fakeRepo.
+type handoffRepo struct {
+	*fakeRepo
+	codes map[string]*domain.HandoffCode
+}
+
+func (r *handoffRepo) FindHandoffCode(_ context.Context, orderID string) (*domain.HandoffCode, error) {
+	code, ok := r.codes[orderID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	copied := *code
+	return &copied, nil
+}
+
+func (r *handoffRepo) RecordHandoffAttempt(_ context.Context, orderID string) (int, error) {
+	r.codes[orderID].Attempts++
+	return r.codes[orderID].Attempts, nil
+}
+
+// newHandoffTestService returns a service whose order-1 expects PIN 123456.
+func newHandoffTestService() (*Service, *handoffRepo) {
+	repo := &handoffRepo{fakeRepo: newFakeRepo(), codes: make(map[string]*domain.HandoffCode)}
+	s := &Service{orderRepo: repo, handoffSecret: []byte("test-secret")}
+	repo.codes["order-1"] = &domain.HandoffCode{
+		OrderID:   "order-1",
+		CodeHash:  s.hashHandoffPIN("order-1", "123456"),
+		ExpiresAt: time.Now().Add(domain.HandoffCodeTTL),
+	}
+	return s, repo
+}
+
+func TestVerifyHandoffPIN(t *testing.T) {
+	s, _ := newHandoffTestService()
+	ctx := context.Background()
+
+	code, err := s.verifyHandoffPIN(ctx, "order-1", "123456")
+	if err != nil || code == nil {
+		t.Fatalf("verifyHandoffPIN with the right PIN = %v, %v, want the code", code, err)
+	}
+	if _, err := s.verifyHandoffPIN(ctx, "order-1", ""); !errors.Is(err, models.ErrInvalidHandoffPIN) {
+		t.Errorf("missing PIN = %v, want ErrInvalidHandoffPIN", err)
+	}
+	// Orders dispatched before handoff PINs existed need none.
+	if code, err := s.verifyHandoffPIN(ctx, "order-2", "123456"); code != nil || err != nil {
+		t.Errorf("order without a handoff code = %v, %v, want nil, nil", code, err)
+	}
+
+	other := &Service{handoffSecret: []byte("other-secret")}
+	if other.hashHandoffPIN("order-1", "123456") == s.hashHandoffPIN("order-1", "123456") {
+		t.Error("PIN hashes do not depend on the secret")
+	}
+	if s.hashHandoffPIN("order-2", "123456") == s.hashHandoffPIN("order-1", "123456") {
+		t.Error("equal PINs of different orders hash the same")
+	}
+}
+
+func TestVerifyHandoffPINLockout(t *testing.T) {
+	s, repo := newHandoffTestService()
+	ctx := context.Background()
+
+	for i := 1; i < domain.HandoffMaxAttempts; i++ {
+		if _, err := s.verifyHandoffPIN(ctx, "order-1", "000000"); !errors.Is(err, models.ErrInvalidHandoffPIN) {
+			t.Fatalf("wrong PIN %d = %v, want ErrInvalidHandoffPIN", i, err)
+		}
+	}
+	if _, err := s.verifyHandoffPIN(ctx, "order-1", "000000"); !errors.Is(err, models.ErrHandoffLocked) {
+		t.Fatalf("wrong PIN %d = %v, want ErrHandoffLocked", domain.HandoffMaxAttempts, err)
+	}
+	if got := repo.codes["order-1"].Attempts; got != domain.HandoffMaxAttempts {
+		t.Errorf("recorded %d attempts, want %d", got, domain.HandoffMaxAttempts)
+	}
+
+	// Once locked, even the right PIN is refused and no more attempts are counted.
+	if _, err := s.verifyHandoffPIN(ctx, "order-1", "123456"); !errors.Is(err, models.ErrHandoffLocked) {
+		t.Errorf("right PIN after lockout = %v, want ErrHandoffLocked", err)
+	}
+	if got := repo.codes["order-1"].Attempts; got != domain.HandoffMaxAttempts {
+		t.Errorf("attempts grew to %d after lockout", got)
+	}
+}
+
+func TestVerifyHandoffPINExpired(t *testing.T) {
+	s, repo := newHandoffTestService()
+	repo.codes["order-1"].ExpiresAt = time.Now().Add(-time.Minute)
+
+	if _, err := s.verifyHandoffPIN(context.Background(), "order-1", "123456"); !errors.Is(err, models.ErrHandoffLocked) {
+		t.Errorf("expired PIN = %v, want ErrHandoffLocked", err)
+	}
+}
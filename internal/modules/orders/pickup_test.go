@@ -0,0 +1,162 @@
+package orders
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	trackingDomain "dispatch-and-delivery/internal/modules/tracking/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeTx stands in for a database transaction. Every statement reports one
+// affected row and has its arguments recorded; returned rows scan as zero values.
+type fakeTx struct {
+	pgx.Tx
+	execs     [][]any
+	committed bool
+}
+
+func (tx *fakeTx) Exec(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+	tx.execs = append(tx.execs, args)
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (tx *fakeTx) QueryRow(context.Context, string, ...any) pgx.Row { return zeroRow{} }
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error { return nil }
+
+// ran reports whether a statement was executed with exactly args.
+func (tx *fakeTx) ran(args ...any) bool {
+	return slices.ContainsFunc(tx.execs, func(exec []any) bool { return slices.Equal(exec, args) })
+}
+
+type zeroRow struct{}
+
+func (zeroRow) Scan(...any) error { return nil }
+
+// deliveryRepo runs the transactions of fakeRepo in a fakeTx.
+type deliveryRepo struct {
+	*fakeRepo
+	tx *fakeTx
+}
+
+func (r *deliveryRepo) BeginTx(context.Context) (pgx.Tx, error) {
+	r.tx = &fakeTx{}
+	return r.tx, nil
+}
+
+func (r *deliveryRepo) WithTx(tx pgx.Tx) *Repository {
+	return &Repository{executor: tx}
+}
+
+// FindRefundablePayment finds nothing to refund: the orders were never charged.
+func (r *deliveryRepo) FindRefundablePayment(context.Context, string) (*domain.Payment, error) {
+	return nil, models.ErrNotFound
+}
+
+// fakeFleet records the machines handed back to the fleet.
+type fakeFleet struct {
+	Fleet
+	released []string
+}
+
+func (f *fakeFleet) ReleaseMachine(_ context.Context, machineID string) (*fleetDomain.Machine, error) {
+	f.released = append(f.released, machineID)
+	return &fleetDomain.Machine{ID: machineID, Status: fleetDomain.StatusIdle}, nil
+}
+
+func TestConfirmPickupThenDeliver(t *testing.T) {
+	machine := "robot-1"
+	now := time.Now()
+	dropoff := geo.Point{Latitude: 52.5200, Longitude: 13.4050}
+
+	repo := &deliveryRepo{fakeRepo: newFakeRepo(
+		&domain.Order{ID: "order-1", UserID: "alice", MachineID: &machine, DropoffAddressID: "addr-1", Status: domain.OrderStatusAssigned},
+	)}
+	repo.locations["addr-1"] = &dropoff
+	repo.events["at-door"] = &trackingDomain.TrackingEvent{
+		ID: "at-door", OrderID: "order-1", MachineID: machine,
+		Latitude: dropoff.Latitude, Longitude: dropoff.Longitude, CreatedAt: now.Add(-time.Minute),
+	}
+	fleet := &fakeFleet{}
+	s := &Service{orderRepo: repo, fleet: fleet}
+	ctx := context.Background()
+	proof := domain.ProofOfDeliveryRequest{PhotoRef: "s3://proofs/1.jpg", TrackingEventID: "at-door", DeliveredAt: now}
+
+	if _, err := s.SubmitProofOfDelivery(ctx, machine, "order-1", proof); !errors.Is(err, models.ErrOrderNotInTransit) {
+		t.Fatalf("proof before pickup = %v, want ErrOrderNotInTransit", err)
+	}
+	if _, err := s.ConfirmPickup(ctx, "robot-2", "order-1"); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("pickup by another machine = %v, want ErrNotFound", err)
+	}
+
+	order, err := s.ConfirmPickup(ctx, machine, "order-1")
+	if err != nil {
+		t.Fatalf("ConfirmPickup: %v", err)
+	}
+	if order.Status != domain.OrderStatusInTransit || repo.orders["order-1"].Status != domain.OrderStatusInTransit {
+		t.Fatalf("order after pickup is %s, want %s", repo.orders["order-1"].Status, domain.OrderStatusInTransit)
+	}
+	if _, err := s.ConfirmPickup(ctx, machine, "order-1"); !errors.Is(err, models.ErrOrderNotAssigned) {
+		t.Errorf("second pickup = %v, want ErrOrderNotAssigned", err)
+	}
+
+	if _, err := s.SubmitProofOfDelivery(ctx, machine, "order-1", proof); err != nil {
+		t.Fatalf("SubmitProofOfDelivery after pickup: %v", err)
+	}
+	if !repo.tx.committed {
+		t.Error("proof of delivery was not committed")
+	}
+	// The only stop was delivered, so the order is too.
+	if !repo.tx.ran(domain.OrderStatusDelivered, "order-1", domain.OrderStatusInTransit) {
+		t.Errorf("order was not moved from %s to %s, statements: %v", domain.OrderStatusInTransit, domain.OrderStatusDelivered, repo.tx.execs)
+	}
+	if !slices.Equal(fleet.released, []string{machine}) {
+		t.Errorf("released machines = %v, want %s handed back after its delivery", fleet.released, machine)
+	}
+}
+
+func TestCancelAssignedOrder(t *testing.T) {
+	machine, trip := "robot-1", "trip-1"
+	repo := &deliveryRepo{fakeRepo: newFakeRepo(
+		&domain.Order{ID: "order-1", UserID: "alice", MachineID: &machine, TripID: &trip, Status: domain.OrderStatusAssigned},
+	)}
+	fleet := &fakeFleet{}
+	s := &Service{orderRepo: repo, inventory: &fakeInventory{}, fleet: fleet}
+
+	order, refund, err := s.CancelOrder(context.Background(), "alice", "order-1")
+	if err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if order.Status != domain.OrderStatusCancelled || refund != nil {
+		t.Errorf("CancelOrder = %s, %v, want a cancelled order", order.Status, refund)
+	}
+	if !repo.tx.committed {
+		t.Fatal("cancellation was not committed")
+	}
+	if !repo.tx.ran(domain.OrderStatusCancelled, "order-1", domain.OrderStatusAssigned) {
+		t.Errorf("order was not taken off its trip, statements: %v", repo.tx.execs)
+	}
+	if !repo.tx.ran("order-1", domain.StopStatusPending) {
+		t.Errorf("stops were not taken off the trip, statements: %v", repo.tx.execs)
+	}
+	if !repo.tx.ran(trip, domain.TripStatusActive) {
+		t.Errorf("empty trip was not deleted, statements: %v", repo.tx.execs)
+	}
+	if !slices.Equal(fleet.released, []string{machine}) {
+		t.Errorf("released machines = %v, want %s", fleet.released, machine)
+	}
+}
@@ -0,0 +1,40 @@
+package orders
+
+import domain "dispatch-and-delivery/internal/modules/orders/domain"
+
+// RefundPolicy decides how much of a captured payment is returned to the
+// customer, based on how far the order progressed and why it is refunded.
+type RefundPolicy struct {
+	// AssignedPercent applies once a machine is on its way to the pickup.
+	AssignedPercent int64
+	// InTransitPercent applies after the package has been picked up.
+	InTransitPercent int64
+}
+
+// DefaultRefundPolicy refunds in full before assignment and on failed delivery,
+// and keeps part of the fare once a machine has been committed to the order.
+var DefaultRefundPolicy = RefundPolicy{
+	AssignedPercent:  80,
+	InTransitPercent: 50,
+}
+
+// RefundableCents returns the total amount of paidCents the customer is entitled to.
+// orderStatus is the status of the order before it was cancelled or failed.
+func (p RefundPolicy) RefundableCents(orderStatus, reason string, paidCents int64) int64 {
+	// We failed to deliver, so the customer never pays for it.
+	if reason == domain.RefundReasonFailedDelivery {
+		return paidCents
+	}
+
+	switch orderStatus {
+	case domain.OrderStatusPending, domain.OrderStatusPaid:
+		return paidCents
+	case domain.OrderStatusAssigned:
+		return paidCents * p.AssignedPercent / 100
+	case domain.OrderStatusInTransit:
+		return paidCents * p.InTransitPercent / 100
+	default:
+		// Delivered or already cancelled orders are only refunded by an explicit admin amount.
+		return 0
+	}
+}
@@ -0,0 +1,30 @@
+package orders
+
+import (
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"testing"
+)
+
+func TestRefundPolicyRefundableCents(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		reason string
+		want   int64
+	}{
+		{"pending order", domain.OrderStatusPending, domain.RefundReasonCustomerCancelled, 1000},
+		{"paid order", domain.OrderStatusPaid, domain.RefundReasonCustomerCancelled, 1000},
+		{"assigned order", domain.OrderStatusAssigned, domain.RefundReasonCustomerCancelled, 800},
+		{"order in transit", domain.OrderStatusInTransit, domain.RefundReasonCustomerCancelled, 500},
+		{"delivered order", domain.OrderStatusDelivered, domain.RefundReasonCustomerCancelled, 0},
+		{"failed delivery in transit", domain.OrderStatusInTransit, domain.RefundReasonFailedDelivery, 1000},
+		{"admin refund of a delivered order", domain.OrderStatusDelivered, domain.RefundReasonAdmin, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultRefundPolicy.RefundableCents(tt.status, tt.reason, 1000); got != tt.want {
+				t.Errorf("RefundableCents(%q, %q, 1000) = %d, want %d", tt.status, tt.reason, got, tt.want)
+			}
+		})
+	}
+}
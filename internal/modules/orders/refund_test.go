@@ -0,0 +1,74 @@
+package orders
+
+import (
+	"context"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"dispatch-and-delivery/pkg/payments"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// refundRepo holds one captured payment and the refunds recorded against it.
+type refundRepo struct {
+	*fakeRepo
+	payment *domain.Payment
+	refunds []*domain.Refund
+}
+
+func (r *refundRepo) FindRefundablePayment(context.Context, string) (*domain.Payment, error) {
+	copied := *r.payment
+	return &copied, nil
+}
+
+func (r *refundRepo) SumRefundedCents(context.Context, string) (int64, int, error) {
+	var sum int64
+	for _, refund := range r.refunds {
+		sum += refund.AmountCents
+	}
+	return sum, len(r.refunds), nil
+}
+
+func (r *refundRepo) CreateRefund(_ context.Context, refund *domain.Refund) (*domain.Refund, error) {
+	refund.ID = fmt.Sprintf("refund-%d", len(r.refunds)+1)
+	r.refunds = append(r.refunds, refund)
+	copied := *refund
+	return &copied, nil
+}
+
+// BeginTx fails, so a refund stops right after the provider was asked for it.
+func (r *refundRepo) BeginTx(context.Context) (pgx.Tx, error) {
+	return nil, errors.New("no database in tests")
+}
+
+func TestRefundCancelledCapture(t *testing.T) {
+	ctx := context.Background()
+	provider := payments.NewFakeProvider()
+	intent, err := provider.CreateIntent(ctx, payments.IntentParams{AmountCents: 1236, Currency: "usd"})
+	if err != nil {
+		t.Fatalf("CreateIntent: %v", err)
+	}
+
+	repo := &refundRepo{fakeRepo: newFakeRepo(), payment: &domain.Payment{
+		ID: "payment-1", OrderID: "order-1", ProviderPaymentID: &intent.ID,
+		AmountCents: 1236, Currency: "usd", Status: domain.PaymentStatusSucceeded,
+	}}
+	s := &Service{orderRepo: repo, paymentProvider: provider, refundPolicy: DefaultRefundPolicy}
+
+	// The charge was captured after the customer cancelled the pending order.
+	s.refundCancelledCapture(ctx, &domain.Order{ID: "order-1", UserID: "alice", Status: domain.OrderStatusCancelled})
+
+	if len(repo.refunds) != 1 {
+		t.Fatalf("recorded %d refunds, want 1", len(repo.refunds))
+	}
+	if refund := repo.refunds[0]; refund.AmountCents != 1236 || refund.Reason != domain.RefundReasonCustomerCancelled {
+		t.Errorf("refund = %d cents for %q, want all 1236 cents for %q", refund.AmountCents, refund.Reason, domain.RefundReasonCustomerCancelled)
+	}
+	// Nothing of the charge is left with the provider.
+	extra := payments.RefundParams{IntentID: intent.ID, AmountCents: 1, IdempotencyKey: "extra"}
+	if _, err := provider.CreateRefund(ctx, extra); err == nil {
+		t.Error("provider still holds part of the charge after the refund")
+	}
+}
@@ -0,0 +1,1723 @@
+package orders
+
+import (
+	"context"
+	"database/sql"
+	"dispatch-and-delivery/internal/models"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	trackingDomain "dispatch-and-delivery/internal/modules/tracking/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RepositoryInterface defines methods for interacting with order storage.
+type RepositoryInterface interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	WithTx(tx pgx.Tx) *Repository
+
+	CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
+	FindByID(ctx context.Context, orderID string) (*domain.Order, error)
+	FindByIDForUpdate(ctx context.Context, orderID string) (*domain.Order, error)
+	UpdateStatus(ctx context.Context, orderID, fromStatus, toStatus string) error
+	SetItemWeight(ctx context.Context, orderID string, weightKg float64) error
+	AssignMachine(ctx context.Context, orderID, machineID, tripID, fromStatus string) error
+	ReleaseMachine(ctx context.Context, orderID string) error
+	CancelAssignment(ctx context.Context, orderID string) error
+	ListOrdersOnUnavailableMachines(ctx context.Context) ([]string, error)
+	ListDispatchableOrders(ctx context.Context, readyBy time.Time, limit int) ([]domain.Order, error)
+	CountAssignedOrders(ctx context.Context, machineIDs []string) (map[string]int, error)
+	FailDelivery(ctx context.Context, orderID, toStatus string, cost float64, reattemptWindow *domain.PickupWindow) error
+
+	UpsertHandoffCode(ctx context.Context, orderID, codeHash string, expiresAt time.Time) error
+	FindHandoffCode(ctx context.Context, orderID string) (*domain.HandoffCode, error)
+	RecordHandoffAttempt(ctx context.Context, orderID string) (int, error)
+	MarkHandoffVerified(ctx context.Context, orderID string) error
+
+	AddOrderItems(ctx context.Context, orderID string, items []domain.OrderItem) error
+	ListOrderItems(ctx context.Context, orderID string) ([]domain.OrderItem, error)
+
+	FindQuote(ctx context.Context, quoteID string) (*domain.Quote, error)
+	ClaimQuote(ctx context.Context, quoteID, orderID string) error
+
+	FindAddressLocation(ctx context.Context, addressID string) (*geo.Point, error)
+	CountUserAddresses(ctx context.Context, userID string, addressIDs []string) (int, error)
+	FindTrackingEvent(ctx context.Context, eventID string) (*trackingDomain.TrackingEvent, error)
+	FindLatestTrackingEvent(ctx context.Context, orderID, machineID string) (*trackingDomain.TrackingEvent, error)
+	FindMachinePosition(ctx context.Context, machineID string) (*geo.Point, *time.Time, error)
+	ListRemainingStops(ctx context.Context, orderID string, tripID *string) ([]domain.RemainingStop, error)
+	CreateDeliveryAttempt(ctx context.Context, attempt *domain.DeliveryAttempt) (*domain.DeliveryAttempt, error)
+	ListDeliveryAttempts(ctx context.Context, orderID string) ([]domain.DeliveryAttempt, error)
+	CreateProofOfDelivery(ctx context.Context, proof *domain.ProofOfDelivery) (*domain.ProofOfDelivery, error)
+	ListProofsOfDelivery(ctx context.Context, orderID string) ([]domain.ProofOfDelivery, error)
+
+	CreateOrderStops(ctx context.Context, orderID string, addressIDs []string) ([]domain.Stop, error)
+	ListOrderStops(ctx context.Context, orderID string) ([]domain.Stop, error)
+	UpdateStopStatus(ctx context.Context, stopID, toStatus string, deliveredAt *time.Time) error
+	CreateTrip(ctx context.Context, trip *domain.Trip) (*domain.Trip, error)
+	SetStopRoute(ctx context.Context, stopID, tripID string, tripSequence int, legDistanceMeters *float64) error
+	ClearStopRoutes(ctx context.Context, orderID string) error
+	CompleteTripIfDone(ctx context.Context, tripID string) error
+	CancelTripIfEmpty(ctx context.Context, tripID string) error
+	DeleteTripIfEmpty(ctx context.Context, tripID string) error
+
+	LockCapacity(ctx context.Context, machineType string) error
+	CountAvailableMachines(ctx context.Context, machineType string) (int, error)
+	FindNoFlyZone(ctx context.Context, p geo.Point, altitudeMeters float64, from, to time.Time) (string, error)
+	CountBookedOrders(ctx context.Context, machineType string, window domain.PickupWindow) (int, error)
+	ListUpcomingOrders(ctx context.Context, userID string, from, to time.Time) ([]domain.Order, error)
+
+	CreateSchedule(ctx context.Context, schedule *domain.Schedule) (*domain.Schedule, error)
+	FindSchedule(ctx context.Context, scheduleID string) (*domain.Schedule, error)
+	ListSchedules(ctx context.Context, userID string) ([]domain.Schedule, error)
+	ListDueSchedules(ctx context.Context, horizon time.Time) ([]domain.Schedule, error)
+	ListUnpaidScheduledOrders(ctx context.Context, pickupBefore time.Time) ([]domain.Order, error)
+	DeactivateSchedule(ctx context.Context, userID, scheduleID string) error
+	SetMaterializedThrough(ctx context.Context, scheduleID string, through time.Time) error
+
+	CreatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error)
+	FindPaymentByID(ctx context.Context, paymentID string) (*domain.Payment, error)
+	FindPaymentByIdempotencyKey(ctx context.Context, key string) (*domain.Payment, error)
+	FindActivePayment(ctx context.Context, orderID string) (*domain.Payment, error)
+	CountFailedPayments(ctx context.Context, orderID string) (int, error)
+	FindPaymentByProviderPaymentID(ctx context.Context, provider, providerPaymentID string) (*domain.Payment, error)
+	UpdatePayment(ctx context.Context, paymentID string, providerPaymentID *string, status string, failureReason *string) (*domain.Payment, error)
+
+	FindRefundablePayment(ctx context.Context, orderID string) (*domain.Payment, error)
+	SumRefundedCents(ctx context.Context, paymentID string) (int64, int, error)
+	CreateRefund(ctx context.Context, refund *domain.Refund) (*domain.Refund, error)
+	UpdateRefund(ctx context.Context, refundID string, providerRefundID *string, status string) (*domain.Refund, error)
+
+	FindUserContact(ctx context.Context, userID string) (email string, nickname string, err error)
+
+	CreateFeedback(ctx context.Context, orderID string, req domain.FeedbackRequest) (*domain.Feedback, error)
+	ListMachineRatingStats(ctx context.Context, filter domain.RatingStatsFilter) ([]domain.MachineRatingStats, error)
+	ListMachineTypeRatingStats(ctx context.Context, filter domain.RatingStatsFilter) ([]domain.MachineTypeRatingStats, error)
+
+	WebhookEventExists(ctx context.Context, eventID string) (bool, error)
+	RecordWebhookEvent(ctx context.Context, eventID, provider, eventType string) error
+}
+
+// DBExecutor represents anything that can execute a SQL query,
+// which includes both a connection pool and a transaction.
+type DBExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+type Repository struct {
+	db       *pgxpool.Pool
+	executor DBExecutor
+}
+
+func NewRepository(db *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		db:       db,
+		executor: db,
+	}
+}
+
+// BeginTx starts a new database transaction.
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.db.Begin(ctx)
+}
+
+// WithTx returns a new instance of the Repository that is "scoped" to the provided transaction.
+func (r *Repository) WithTx(tx pgx.Tx) *Repository {
+	return &Repository{
+		db:       r.db,
+		executor: tx,
+	}
+}
+
+const orderColumns = `id, user_id, machine_id, pickup_address_id, dropoff_address_id, status,
+	length_m, width_m, height_m, item_weight_kg, cost, machine_type, pickup_window_start,
+	pickup_window_end, schedule_id, trip_id, created_at, updated_at`
+
+func (r *Repository) scanOrder(row pgx.Row) (*domain.Order, error) {
+	var order domain.Order
+	var machineID, scheduleID, tripID sql.NullString
+	var windowStart, windowEnd sql.NullTime
+
+	err := row.Scan(
+		&order.ID,
+		&order.UserID,
+		&machineID,
+		&order.PickupAddressID,
+		&order.DropoffAddressID,
+		&order.Status,
+		&order.Dimensions.Length,
+		&order.Dimensions.Width,
+		&order.Dimensions.Height,
+		&order.ItemWeightKg,
+		&order.Cost,
+		&order.MachineType,
+		&windowStart,
+		&windowEnd,
+		&scheduleID,
+		&tripID,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if machineID.Valid {
+		order.MachineID = &machineID.String
+	}
+	if windowStart.Valid && windowEnd.Valid {
+		order.PickupWindow = &domain.PickupWindow{Start: windowStart.Time, End: windowEnd.Time}
+	}
+	if scheduleID.Valid {
+		order.ScheduleID = &scheduleID.String
+	}
+	if tripID.Valid {
+		order.TripID = &tripID.String
+	}
+
+	return &order, nil
+}
+
+// CreateOrder inserts a new order and returns it with its generated ID and timestamps.
+// It returns models.ErrConflict if the schedule occurrence was already materialized.
+func (r *Repository) CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	query := `
+	INSERT INTO orders (user_id, pickup_address_id, dropoff_address_id, status,
+		length_m, width_m, height_m, item_weight_kg, cost, machine_type,
+		pickup_window_start, pickup_window_end, schedule_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	RETURNING ` + orderColumns
+
+	var windowStart, windowEnd *time.Time
+	if order.PickupWindow != nil {
+		windowStart, windowEnd = &order.PickupWindow.Start, &order.PickupWindow.End
+	}
+
+	created, err := r.scanOrder(r.executor.QueryRow(ctx, query,
+		order.UserID,
+		order.PickupAddressID,
+		order.DropoffAddressID,
+		order.Status,
+		order.Dimensions.Length,
+		order.Dimensions.Width,
+		order.Dimensions.Height,
+		order.ItemWeightKg,
+		order.Cost,
+		order.MachineType,
+		windowStart,
+		windowEnd,
+		order.ScheduleID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation on (schedule_id, pickup_window_start)
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.CreateOrder: %w", err)
+	}
+	return created, nil
+}
+
+func (r *Repository) FindByID(ctx context.Context, orderID string) (*domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	order, err := r.scanOrder(r.executor.QueryRow(ctx, query, orderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindByID: %w", err)
+	}
+	return order, nil
+}
+
+// FindByIDForUpdate loads an order and locks its row until the surrounding transaction ends.
+// It must be called on a repository created with WithTx().
+func (r *Repository) FindByIDForUpdate(ctx context.Context, orderID string) (*domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1 FOR UPDATE`
+
+	order, err := r.scanOrder(r.executor.QueryRow(ctx, query, orderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindByIDForUpdate: %w", err)
+	}
+	return order, nil
+}
+
+// UpdateStatus moves an order from fromStatus to toStatus.
+// It returns models.ErrConflict if the order is no longer in fromStatus.
+func (r *Repository) UpdateStatus(ctx context.Context, orderID, fromStatus, toStatus string) error {
+	query := `
+	UPDATE orders
+	SET status = $1, updated_at = NOW()
+	WHERE id = $2 AND status = $3
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, toStatus, orderID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("repository.UpdateStatus: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrConflict
+	}
+	return nil
+}
+
+// SetItemWeight records the total weight of an order's item manifest.
+func (r *Repository) SetItemWeight(ctx context.Context, orderID string, weightKg float64) error {
+	query := `UPDATE orders SET item_weight_kg = $1, updated_at = NOW() WHERE id = $2`
+	cmdTag, err := r.executor.Exec(ctx, query, weightKg, orderID)
+	if err != nil {
+		return fmt.Errorf("repository.SetItemWeight: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// AssignMachine hands an order that is ready for dispatch to a machine on a trip.
+// It returns models.ErrConflict if the order is no longer in fromStatus.
+func (r *Repository) AssignMachine(ctx context.Context, orderID, machineID, tripID, fromStatus string) error {
+	query := `
+	UPDATE orders
+	SET machine_id = $1, trip_id = $2, status = $3, updated_at = NOW()
+	WHERE id = $4 AND status = $5
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, machineID, tripID, domain.OrderStatusAssigned, orderID, fromStatus)
+	if err != nil {
+		return fmt.Errorf("repository.AssignMachine: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrConflict
+	}
+	return nil
+}
+
+// ReleaseMachine takes an assigned order off its machine and trip and puts it
+// back in the dispatch queue. It returns models.ErrConflict if the order is no
+// longer assigned.
+func (r *Repository) ReleaseMachine(ctx context.Context, orderID string) error {
+	query := `
+	UPDATE orders
+	SET machine_id = NULL, trip_id = NULL, status = $1, updated_at = NOW()
+	WHERE id = $2 AND status = $3
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, domain.OrderStatusPaid, orderID, domain.OrderStatusAssigned)
+	if err != nil {
+		return fmt.Errorf("repository.ReleaseMachine: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrConflict
+	}
+	return nil
+}
+
+// CancelAssignment cancels an order that is waiting for pickup and takes it
+// off its trip. It returns models.ErrConflict if the order is no longer
+// assigned.
+func (r *Repository) CancelAssignment(ctx context.Context, orderID string) error {
+	query := `
+	UPDATE orders
+	SET trip_id = NULL, status = $1, updated_at = NOW()
+	WHERE id = $2 AND status = $3
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, domain.OrderStatusCancelled, orderID, domain.OrderStatusAssigned)
+	if err != nil {
+		return fmt.Errorf("repository.CancelAssignment: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrConflict
+	}
+	return nil
+}
+
+// ListOrdersOnUnavailableMachines returns the IDs of the orders still waiting
+// for pickup by a machine that went offline, into maintenance or was
+// decommissioned.
+func (r *Repository) ListOrdersOnUnavailableMachines(ctx context.Context) ([]string, error) {
+	query := `
+	SELECT o.id FROM orders o
+	JOIN machines m ON m.id = o.machine_id
+	WHERE o.status = $1 AND m.status IN ('OFFLINE', 'MAINTENANCE', 'DECOMMISSIONED')
+	ORDER BY o.id
+	`
+	rows, err := r.executor.Query(ctx, query, domain.OrderStatusAssigned)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListOrdersOnUnavailableMachines: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("repository.ListOrdersOnUnavailableMachines.Scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ListDispatchableOrders returns the orders waiting for a machine whose pickup
+// window, if any, opens by readyBy: paid orders and orders waiting for a
+// delivery re-attempt. Orders that have waited longest come first.
+func (r *Repository) ListDispatchableOrders(ctx context.Context, readyBy time.Time, limit int) ([]domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders
+	WHERE status IN ($1, $2) AND (pickup_window_start IS NULL OR pickup_window_start <= $3)
+	ORDER BY COALESCE(pickup_window_start, created_at), id
+	LIMIT $4`
+
+	rows, err := r.executor.Query(ctx, query, domain.OrderStatusPaid, domain.OrderStatusDeliveryFailed, readyBy, limit)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListDispatchableOrders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []domain.Order
+	for rows.Next() {
+		order, err := r.scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListDispatchableOrders.Scan: %w", err)
+		}
+		orders = append(orders, *order)
+	}
+	return orders, rows.Err()
+}
+
+// CountAssignedOrders counts the orders each machine has been assigned and
+// not yet delivered. Machines without any are left out of the result.
+func (r *Repository) CountAssignedOrders(ctx context.Context, machineIDs []string) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(machineIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+	SELECT machine_id, COUNT(*) FROM orders
+	WHERE machine_id = ANY($1) AND status IN ($2, $3)
+	GROUP BY machine_id
+	`
+	rows, err := r.executor.Query(ctx, query, machineIDs, domain.OrderStatusAssigned, domain.OrderStatusInTransit)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CountAssignedOrders: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var machineID string
+		var count int
+		if err := rows.Scan(&machineID, &count); err != nil {
+			return nil, fmt.Errorf("repository.CountAssignedOrders.Scan: %w", err)
+		}
+		counts[machineID] = count
+	}
+	return counts, rows.Err()
+}
+
+// FailDelivery moves an in-transit order to toStatus after a failed handoff
+// and charges the fee included in cost. A re-attempt window replaces the
+// pickup window, so the order is dispatched again in it.
+// It returns models.ErrConflict if the order is no longer in transit.
+func (r *Repository) FailDelivery(ctx context.Context, orderID, toStatus string, cost float64, reattemptWindow *domain.PickupWindow) error {
+	var windowStart, windowEnd *time.Time
+	if reattemptWindow != nil {
+		windowStart, windowEnd = &reattemptWindow.Start, &reattemptWindow.End
+	}
+
+	query := `
+	UPDATE orders
+	SET status = $1, cost = $2,
+		pickup_window_start = COALESCE($3, pickup_window_start),
+		pickup_window_end = COALESCE($4, pickup_window_end),
+		updated_at = NOW()
+	WHERE id = $5 AND status = $6
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, toStatus, cost, windowStart, windowEnd, orderID, domain.OrderStatusInTransit)
+	if err != nil {
+		return fmt.Errorf("repository.FailDelivery: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrConflict
+	}
+	return nil
+}
+
+// UpsertHandoffCode stores a new handoff code for an order, replacing any previous one.
+func (r *Repository) UpsertHandoffCode(ctx context.Context, orderID, codeHash string, expiresAt time.Time) error {
+	query := `
+	INSERT INTO handoff_codes (order_id, code_hash, expires_at)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (order_id) DO UPDATE
+	SET code_hash = EXCLUDED.code_hash, attempts = 0, expires_at = EXCLUDED.expires_at,
+		verified_at = NULL, created_at = NOW()
+	`
+	if _, err := r.executor.Exec(ctx, query, orderID, codeHash, expiresAt); err != nil {
+		return fmt.Errorf("repository.UpsertHandoffCode: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) FindHandoffCode(ctx context.Context, orderID string) (*domain.HandoffCode, error) {
+	var c domain.HandoffCode
+	query := `SELECT order_id, code_hash, attempts, expires_at, verified_at, created_at FROM handoff_codes WHERE order_id = $1`
+	err := r.executor.QueryRow(ctx, query, orderID).Scan(&c.OrderID, &c.CodeHash, &c.Attempts, &c.ExpiresAt, &c.VerifiedAt, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindHandoffCode: %w", err)
+	}
+	return &c, nil
+}
+
+// RecordHandoffAttempt counts a PIN attempt and returns the new number of attempts.
+func (r *Repository) RecordHandoffAttempt(ctx context.Context, orderID string) (int, error) {
+	var attempts int
+	query := `UPDATE handoff_codes SET attempts = attempts + 1 WHERE order_id = $1 RETURNING attempts`
+	if err := r.executor.QueryRow(ctx, query, orderID).Scan(&attempts); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, models.ErrNotFound
+		}
+		return 0, fmt.Errorf("repository.RecordHandoffAttempt: %w", err)
+	}
+	return attempts, nil
+}
+
+func (r *Repository) MarkHandoffVerified(ctx context.Context, orderID string) error {
+	query := `UPDATE handoff_codes SET verified_at = NOW() WHERE order_id = $1`
+	if _, err := r.executor.Exec(ctx, query, orderID); err != nil {
+		return fmt.Errorf("repository.MarkHandoffVerified: %w", err)
+	}
+	return nil
+}
+
+// AddOrderItems stores the item manifest of an order.
+func (r *Repository) AddOrderItems(ctx context.Context, orderID string, items []domain.OrderItem) error {
+	query := `
+	INSERT INTO order_items (order_id, sku, name, quantity, unit_weight_kg, hazmat, hazmat_class, declared_value)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	batch := &pgx.Batch{}
+	for _, item := range items {
+		batch.Queue(query, orderID, item.SKU, item.Name, item.Quantity, item.UnitWeightKg, item.Hazmat, item.HazmatClass, item.DeclaredValue)
+	}
+
+	results := r.executor.SendBatch(ctx, batch)
+	defer results.Close()
+	for range items {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("repository.AddOrderItems: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *Repository) ListOrderItems(ctx context.Context, orderID string) ([]domain.OrderItem, error) {
+	query := `
+	SELECT sku, name, quantity, unit_weight_kg, hazmat, hazmat_class, declared_value
+	FROM order_items WHERE order_id = $1 ORDER BY sku
+	`
+	rows, err := r.executor.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListOrderItems: %w", err)
+	}
+	defer rows.Close()
+
+	var items []domain.OrderItem
+	for rows.Next() {
+		var item domain.OrderItem
+		if err := rows.Scan(&item.SKU, &item.Name, &item.Quantity, &item.UnitWeightKg, &item.Hazmat, &item.HazmatClass, &item.DeclaredValue); err != nil {
+			return nil, fmt.Errorf("repository.ListOrderItems.Scan: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// FindQuote loads a route option priced by the dispatch service.
+func (r *Repository) FindQuote(ctx context.Context, quoteID string) (*domain.Quote, error) {
+	var q domain.Quote
+	var orderID sql.NullString
+	query := `
+	SELECT id, user_id, pickup_address_id, dropoff_address_id, machine_type, price, order_id, expires_at
+	FROM route_options WHERE id = $1
+	`
+	err := r.executor.QueryRow(ctx, query, quoteID).Scan(
+		&q.ID, &q.UserID, &q.PickupAddressID, &q.DropoffAddressID, &q.MachineType, &q.Price, &orderID, &q.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindQuote: %w", err)
+	}
+	if orderID.Valid {
+		q.OrderID = &orderID.String
+	}
+	return &q, nil
+}
+
+// ClaimQuote links a quote to the order created from it.
+// It returns models.ErrRouteOptionExpired if the quote expired or was already used.
+func (r *Repository) ClaimQuote(ctx context.Context, quoteID, orderID string) error {
+	query := `
+	UPDATE route_options SET order_id = $1
+	WHERE id = $2 AND order_id IS NULL AND expires_at > NOW()
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, orderID, quoteID)
+	if err != nil {
+		return fmt.Errorf("repository.ClaimQuote: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrRouteOptionExpired
+	}
+	return nil
+}
+
+// FindAddressLocation returns the coordinates of an address, or nil if it has not been geocoded.
+func (r *Repository) FindAddressLocation(ctx context.Context, addressID string) (*geo.Point, error) {
+	var lat, lng sql.NullFloat64
+	query := `SELECT latitude, longitude FROM addresses WHERE id = $1`
+	if err := r.executor.QueryRow(ctx, query, addressID).Scan(&lat, &lng); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindAddressLocation: %w", err)
+	}
+	if !lat.Valid || !lng.Valid {
+		return nil, nil
+	}
+	return &geo.Point{Latitude: lat.Float64, Longitude: lng.Float64}, nil
+}
+
+// CountUserAddresses counts how many of the given addresses belong to the user.
+func (r *Repository) CountUserAddresses(ctx context.Context, userID string, addressIDs []string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM addresses WHERE user_id = $1 AND id = ANY($2)`
+	if err := r.executor.QueryRow(ctx, query, userID, addressIDs).Scan(&count); err != nil {
+		return 0, fmt.Errorf("repository.CountUserAddresses: %w", err)
+	}
+	return count, nil
+}
+
+func (r *Repository) FindTrackingEvent(ctx context.Context, eventID string) (*trackingDomain.TrackingEvent, error) {
+	var e trackingDomain.TrackingEvent
+	query := `SELECT id, order_id, machine_id, stop_id, latitude, longitude, created_at FROM tracking_events WHERE id = $1`
+	err := r.executor.QueryRow(ctx, query, eventID).Scan(&e.ID, &e.OrderID, &e.MachineID, &e.StopID, &e.Latitude, &e.Longitude, &e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindTrackingEvent: %w", err)
+	}
+	return &e, nil
+}
+
+// FindLatestTrackingEvent returns the last position the machine reported
+// while carrying the order.
+func (r *Repository) FindLatestTrackingEvent(ctx context.Context, orderID, machineID string) (*trackingDomain.TrackingEvent, error) {
+	var e trackingDomain.TrackingEvent
+	query := `
+	SELECT id, order_id, machine_id, stop_id, latitude, longitude, created_at FROM tracking_events
+	WHERE order_id = $1 AND machine_id = $2
+	ORDER BY created_at DESC
+	LIMIT 1
+	`
+	err := r.executor.QueryRow(ctx, query, orderID, machineID).Scan(&e.ID, &e.OrderID, &e.MachineID, &e.StopID, &e.Latitude, &e.Longitude, &e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindLatestTrackingEvent: %w", err)
+	}
+	return &e, nil
+}
+
+// FindMachinePosition returns where a machine was at its last heartbeat. The
+// position is nil when the machine has not reported one.
+func (r *Repository) FindMachinePosition(ctx context.Context, machineID string) (*geo.Point, *time.Time, error) {
+	var lat, lng sql.NullFloat64
+	var reportedAt *time.Time
+	query := `SELECT latitude, longitude, last_heartbeat_at FROM machines WHERE id = $1`
+	if err := r.executor.QueryRow(ctx, query, machineID).Scan(&lat, &lng, &reportedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, models.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("repository.FindMachinePosition: %w", err)
+	}
+	if !lat.Valid || !lng.Valid || reportedAt == nil {
+		return nil, nil, nil
+	}
+	return &geo.Point{Latitude: lat.Float64, Longitude: lng.Float64}, reportedAt, nil
+}
+
+const proofColumns = `order_id, stop_id, machine_id, photo_ref, tracking_event_id, latitude, longitude, fix_recorded_at,
+	distance_meters, location_verified, pin_verified, delivered_at, created_at`
+
+func (r *Repository) scanProof(row pgx.Row) (*domain.ProofOfDelivery, error) {
+	var p domain.ProofOfDelivery
+	var distance sql.NullFloat64
+	err := row.Scan(
+		&p.OrderID,
+		&p.StopID,
+		&p.MachineID,
+		&p.PhotoRef,
+		&p.TrackingEventID,
+		&p.Latitude,
+		&p.Longitude,
+		&p.FixRecordedAt,
+		&distance,
+		&p.LocationVerified,
+		&p.PINVerified,
+		&p.DeliveredAt,
+		&p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if distance.Valid {
+		p.DistanceMeters = &distance.Float64
+	}
+	return &p, nil
+}
+
+// CreateProofOfDelivery stores the delivery evidence of an order.
+// It returns models.ErrConflict if the stop already has a proof of delivery.
+func (r *Repository) CreateProofOfDelivery(ctx context.Context, proof *domain.ProofOfDelivery) (*domain.ProofOfDelivery, error) {
+	query := `
+	INSERT INTO proof_of_delivery (order_id, stop_id, machine_id, photo_ref, tracking_event_id, latitude, longitude,
+		fix_recorded_at, distance_meters, location_verified, pin_verified, delivered_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING ` + proofColumns
+
+	created, err := r.scanProof(r.executor.QueryRow(ctx, query,
+		proof.OrderID,
+		proof.StopID,
+		proof.MachineID,
+		proof.PhotoRef,
+		proof.TrackingEventID,
+		proof.Latitude,
+		proof.Longitude,
+		proof.FixRecordedAt,
+		proof.DistanceMeters,
+		proof.LocationVerified,
+		proof.PINVerified,
+		proof.DeliveredAt,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation on stop_id
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.CreateProofOfDelivery: %w", err)
+	}
+	return created, nil
+}
+
+const attemptColumns = `id, order_id, stop_id, machine_id, attempt_number, reason, notes, tracking_event_id,
+	latitude, longitude, outcome, fee, reattempt_window_start, reattempt_window_end, created_at`
+
+func (r *Repository) scanAttempt(row pgx.Row) (*domain.DeliveryAttempt, error) {
+	var a domain.DeliveryAttempt
+	var windowStart, windowEnd *time.Time
+	err := row.Scan(
+		&a.ID,
+		&a.OrderID,
+		&a.StopID,
+		&a.MachineID,
+		&a.AttemptNumber,
+		&a.Reason,
+		&a.Notes,
+		&a.TrackingEventID,
+		&a.Latitude,
+		&a.Longitude,
+		&a.Outcome,
+		&a.Fee,
+		&windowStart,
+		&windowEnd,
+		&a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if windowStart != nil && windowEnd != nil {
+		a.ReattemptWindow = &domain.PickupWindow{Start: *windowStart, End: *windowEnd}
+	}
+	return &a, nil
+}
+
+// CreateDeliveryAttempt records a failed handoff.
+// It returns models.ErrConflict if the attempt number was already recorded.
+func (r *Repository) CreateDeliveryAttempt(ctx context.Context, attempt *domain.DeliveryAttempt) (*domain.DeliveryAttempt, error) {
+	var windowStart, windowEnd *time.Time
+	if attempt.ReattemptWindow != nil {
+		windowStart, windowEnd = &attempt.ReattemptWindow.Start, &attempt.ReattemptWindow.End
+	}
+
+	query := `
+	INSERT INTO delivery_attempts (order_id, stop_id, machine_id, attempt_number, reason, notes, tracking_event_id,
+		latitude, longitude, outcome, fee, reattempt_window_start, reattempt_window_end)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	RETURNING ` + attemptColumns
+
+	created, err := r.scanAttempt(r.executor.QueryRow(ctx, query,
+		attempt.OrderID,
+		attempt.StopID,
+		attempt.MachineID,
+		attempt.AttemptNumber,
+		attempt.Reason,
+		attempt.Notes,
+		attempt.TrackingEventID,
+		attempt.Latitude,
+		attempt.Longitude,
+		attempt.Outcome,
+		attempt.Fee,
+		windowStart,
+		windowEnd,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation on (order_id, attempt_number)
+			return nil, models.ErrConflict
+		}
+		return nil, fmt.Errorf("repository.CreateDeliveryAttempt: %w", err)
+	}
+	return created, nil
+}
+
+// ListDeliveryAttempts returns the failed handoffs of an order, oldest first.
+func (r *Repository) ListDeliveryAttempts(ctx context.Context, orderID string) ([]domain.DeliveryAttempt, error) {
+	query := `SELECT ` + attemptColumns + ` FROM delivery_attempts WHERE order_id = $1 ORDER BY attempt_number`
+
+	rows, err := r.executor.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListDeliveryAttempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []domain.DeliveryAttempt
+	for rows.Next() {
+		attempt, err := r.scanAttempt(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListDeliveryAttempts.Scan: %w", err)
+		}
+		attempts = append(attempts, *attempt)
+	}
+	return attempts, rows.Err()
+}
+
+// ListProofsOfDelivery returns the proofs of an order's delivered stops, in delivery order.
+func (r *Repository) ListProofsOfDelivery(ctx context.Context, orderID string) ([]domain.ProofOfDelivery, error) {
+	query := `SELECT ` + proofColumns + ` FROM proof_of_delivery WHERE order_id = $1 ORDER BY delivered_at`
+
+	rows, err := r.executor.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListProofsOfDelivery: %w", err)
+	}
+	defer rows.Close()
+
+	var proofs []domain.ProofOfDelivery
+	for rows.Next() {
+		proof, err := r.scanProof(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListProofsOfDelivery.Scan: %w", err)
+		}
+		proofs = append(proofs, *proof)
+	}
+	return proofs, rows.Err()
+}
+
+const stopColumns = `id, order_id, sequence, address_id, status, trip_id, trip_sequence,
+	leg_distance_meters, delivered_at, created_at, updated_at`
+
+func (r *Repository) scanStop(row pgx.Row) (*domain.Stop, error) {
+	var st domain.Stop
+	err := row.Scan(
+		&st.ID,
+		&st.OrderID,
+		&st.Sequence,
+		&st.AddressID,
+		&st.Status,
+		&st.TripID,
+		&st.TripSequence,
+		&st.LegDistanceMeters,
+		&st.DeliveredAt,
+		&st.CreatedAt,
+		&st.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+func (r *Repository) queryStops(ctx context.Context, query string, args ...any) ([]domain.Stop, error) {
+	rows, err := r.executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stops []domain.Stop
+	for rows.Next() {
+		stop, err := r.scanStop(rows)
+		if err != nil {
+			return nil, err
+		}
+		stops = append(stops, *stop)
+	}
+	return stops, rows.Err()
+}
+
+// CreateOrderStops stores the dropoffs of an order in the given sequence.
+func (r *Repository) CreateOrderStops(ctx context.Context, orderID string, addressIDs []string) ([]domain.Stop, error) {
+	query := `
+	INSERT INTO order_stops (order_id, sequence, address_id)
+	SELECT $1, s.sequence, s.address_id
+	FROM unnest($2::uuid[]) WITH ORDINALITY AS s(address_id, sequence)
+	RETURNING ` + stopColumns
+
+	stops, err := r.queryStops(ctx, query, orderID, addressIDs)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateOrderStops: %w", err)
+	}
+	return stops, nil
+}
+
+// ListOrderStops returns the stops of an order in visit order. Stops that are
+// not on a trip yet follow the sequence they were placed in.
+func (r *Repository) ListOrderStops(ctx context.Context, orderID string) ([]domain.Stop, error) {
+	query := `SELECT ` + stopColumns + ` FROM order_stops WHERE order_id = $1 ORDER BY trip_sequence NULLS LAST, sequence`
+
+	stops, err := r.queryStops(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListOrderStops: %w", err)
+	}
+	return stops, nil
+}
+
+// ListRemainingStops returns the pending stops of an order and, when it is
+// on a trip, of the other orders on the trip, in visit order.
+func (r *Repository) ListRemainingStops(ctx context.Context, orderID string, tripID *string) ([]domain.RemainingStop, error) {
+	query := `
+	SELECT s.id, s.order_id, s.sequence, a.latitude, a.longitude
+	FROM order_stops s
+	JOIN addresses a ON a.id = s.address_id
+	WHERE s.status = $1 AND (s.order_id = $2 OR s.trip_id = $3)
+	ORDER BY s.trip_sequence NULLS LAST, s.sequence
+	`
+	rows, err := r.executor.Query(ctx, query, domain.StopStatusPending, orderID, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListRemainingStops: %w", err)
+	}
+	defer rows.Close()
+
+	var stops []domain.RemainingStop
+	for rows.Next() {
+		var stop domain.RemainingStop
+		var lat, lng sql.NullFloat64
+		if err := rows.Scan(&stop.ID, &stop.OrderID, &stop.Sequence, &lat, &lng); err != nil {
+			return nil, fmt.Errorf("repository.ListRemainingStops.Scan: %w", err)
+		}
+		if lat.Valid && lng.Valid {
+			stop.Location = &geo.Point{Latitude: lat.Float64, Longitude: lng.Float64}
+		}
+		stops = append(stops, stop)
+	}
+	return stops, rows.Err()
+}
+
+// UpdateStopStatus completes a pending stop.
+// It returns models.ErrConflict if the stop is no longer pending.
+func (r *Repository) UpdateStopStatus(ctx context.Context, stopID, toStatus string, deliveredAt *time.Time) error {
+	query := `
+	UPDATE order_stops
+	SET status = $1, delivered_at = $2, updated_at = NOW()
+	WHERE id = $3 AND status = $4
+	`
+	cmdTag, err := r.executor.Exec(ctx, query, toStatus, deliveredAt, stopID, domain.StopStatusPending)
+	if err != nil {
+		return fmt.Errorf("repository.UpdateStopStatus: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrConflict
+	}
+	return nil
+}
+
+// CreateTrip stores a new machine trip.
+func (r *Repository) CreateTrip(ctx context.Context, trip *domain.Trip) (*domain.Trip, error) {
+	query := `
+	INSERT INTO delivery_trips (machine_id, pickup_address_id, status, distance_meters)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, machine_id, pickup_address_id, status, distance_meters, created_at, updated_at
+	`
+	var t domain.Trip
+	err := r.executor.QueryRow(ctx, query, trip.MachineID, trip.PickupAddressID, domain.TripStatusActive, trip.DistanceMeters).Scan(
+		&t.ID, &t.MachineID, &t.PickupAddressID, &t.Status, &t.DistanceMeters, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateTrip: %w", err)
+	}
+	return &t, nil
+}
+
+// SetStopRoute places a stop on a trip at the given visit position.
+func (r *Repository) SetStopRoute(ctx context.Context, stopID, tripID string, tripSequence int, legDistanceMeters *float64) error {
+	query := `
+	UPDATE order_stops
+	SET trip_id = $1, trip_sequence = $2, leg_distance_meters = $3, updated_at = NOW()
+	WHERE id = $4
+	`
+	if _, err := r.executor.Exec(ctx, query, tripID, tripSequence, legDistanceMeters, stopID); err != nil {
+		return fmt.Errorf("repository.SetStopRoute: %w", err)
+	}
+	return nil
+}
+
+// ClearStopRoutes takes the pending stops of an order off their trip.
+func (r *Repository) ClearStopRoutes(ctx context.Context, orderID string) error {
+	query := `
+	UPDATE order_stops
+	SET trip_id = NULL, trip_sequence = NULL, leg_distance_meters = NULL, updated_at = NOW()
+	WHERE order_id = $1 AND status = $2
+	`
+	if _, err := r.executor.Exec(ctx, query, orderID, domain.StopStatusPending); err != nil {
+		return fmt.Errorf("repository.ClearStopRoutes: %w", err)
+	}
+	return nil
+}
+
+// CancelTripIfEmpty marks a trip cancelled once no order is on it anymore.
+func (r *Repository) CancelTripIfEmpty(ctx context.Context, tripID string) error {
+	query := `
+	UPDATE delivery_trips
+	SET status = $1, updated_at = NOW()
+	WHERE id = $2 AND status = $3
+		AND NOT EXISTS (SELECT 1 FROM orders WHERE trip_id = $2)
+	`
+	if _, err := r.executor.Exec(ctx, query, domain.TripStatusCancelled, tripID, domain.TripStatusActive); err != nil {
+		return fmt.Errorf("repository.CancelTripIfEmpty: %w", err)
+	}
+	return nil
+}
+
+// DeleteTripIfEmpty deletes an active trip once neither an order nor a stop
+// is on it anymore.
+func (r *Repository) DeleteTripIfEmpty(ctx context.Context, tripID string) error {
+	query := `
+	DELETE FROM delivery_trips
+	WHERE id = $1 AND status = $2
+		AND NOT EXISTS (SELECT 1 FROM orders WHERE trip_id = $1)
+		AND NOT EXISTS (SELECT 1 FROM order_stops WHERE trip_id = $1)
+	`
+	if _, err := r.executor.Exec(ctx, query, tripID, domain.TripStatusActive); err != nil {
+		return fmt.Errorf("repository.DeleteTripIfEmpty: %w", err)
+	}
+	return nil
+}
+
+// CompleteTripIfDone marks a trip completed once none of its orders has a
+// stop left to serve. Orders taken off the trip for a re-attempt do not count.
+func (r *Repository) CompleteTripIfDone(ctx context.Context, tripID string) error {
+	query := `
+	UPDATE delivery_trips
+	SET status = $1, updated_at = NOW()
+	WHERE id = $2 AND status = $3
+		AND NOT EXISTS (
+			SELECT 1 FROM order_stops s
+			JOIN orders o ON o.id = s.order_id
+			WHERE s.trip_id = $2 AND s.status = $4 AND o.trip_id = $2 AND o.status IN ($5, $6)
+		)
+	`
+	_, err := r.executor.Exec(ctx, query, domain.TripStatusCompleted, tripID, domain.TripStatusActive, domain.StopStatusPending,
+		domain.OrderStatusAssigned, domain.OrderStatusInTransit)
+	if err != nil {
+		return fmt.Errorf("repository.CompleteTripIfDone: %w", err)
+	}
+	return nil
+}
+
+// LockCapacity serializes capacity checks for a machine type until the
+// surrounding transaction ends, so two bookings cannot take the last machine.
+// It must be called on a repository created with WithTx().
+func (r *Repository) LockCapacity(ctx context.Context, machineType string) error {
+	if _, err := r.executor.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('capacity:' || $1))`, machineType); err != nil {
+		return fmt.Errorf("repository.LockCapacity: %w", err)
+	}
+	return nil
+}
+
+// FindNoFlyZone returns the name of a no-fly zone that covers p from the
+// ground up to altitudeMeters at any time between from and to, or "" when
+// there is none.
+func (r *Repository) FindNoFlyZone(ctx context.Context, p geo.Point, altitudeMeters float64, from, to time.Time) (string, error) {
+	query := `
+	SELECT name FROM no_fly_zones
+	WHERE ST_Covers(area, ST_SetSRID(ST_MakePoint($1, $2), 4326))
+		AND floor_meters <= $3
+		AND (active_from IS NULL OR active_from <= $5)
+		AND (active_until IS NULL OR active_until > $4)
+	ORDER BY name
+	LIMIT 1
+	`
+	var name string
+	if err := r.executor.QueryRow(ctx, query, p.Longitude, p.Latitude, altitudeMeters, from, to).Scan(&name); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("repository.FindNoFlyZone: %w", err)
+	}
+	return name, nil
+}
+
+// CountAvailableMachines counts the machines of a type that can take deliveries,
+// i.e. machines that are idle, in transit or charging. Offline machines, those
+// under maintenance and decommissioned ones are left out.
+func (r *Repository) CountAvailableMachines(ctx context.Context, machineType string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM machines WHERE type = $1 AND status IN ('IDLE', 'IN_TRANSIT', 'CHARGING')`
+	if err := r.executor.QueryRow(ctx, query, machineType).Scan(&count); err != nil {
+		return 0, fmt.Errorf("repository.CountAvailableMachines: %w", err)
+	}
+	return count, nil
+}
+
+// CountBookedOrders counts the open scheduled orders of a machine type whose
+// pickup window overlaps the given window.
+func (r *Repository) CountBookedOrders(ctx context.Context, machineType string, window domain.PickupWindow) (int, error) {
+	var count int
+	query := `
+	SELECT COUNT(*) FROM orders
+	WHERE machine_type = $1
+		AND status IN ($2, $3, $4, $5)
+		AND pickup_window_start < $7
+		AND pickup_window_end > $6
+	`
+	err := r.executor.QueryRow(ctx, query, machineType,
+		domain.OrderStatusPending, domain.OrderStatusPaid, domain.OrderStatusAssigned, domain.OrderStatusDeliveryFailed,
+		window.Start, window.End,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("repository.CountBookedOrders: %w", err)
+	}
+	return count, nil
+}
+
+// ListUpcomingOrders returns the open scheduled orders of a user whose pickup
+// window starts in [from, to), earliest first.
+func (r *Repository) ListUpcomingOrders(ctx context.Context, userID string, from, to time.Time) ([]domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders
+	WHERE user_id = $1
+		AND pickup_window_start >= $2 AND pickup_window_start < $3
+		AND status IN ($4, $5, $6, $7, $8)
+	ORDER BY pickup_window_start`
+
+	rows, err := r.executor.Query(ctx, query, userID, from, to,
+		domain.OrderStatusPending, domain.OrderStatusPaid, domain.OrderStatusAssigned, domain.OrderStatusInTransit,
+		domain.OrderStatusDeliveryFailed,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListUpcomingOrders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []domain.Order
+	for rows.Next() {
+		order, err := r.scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListUpcomingOrders.Scan: %w", err)
+		}
+		orders = append(orders, *order)
+	}
+	return orders, rows.Err()
+}
+
+// ListUnpaidScheduledOrders returns the pending orders materialized from
+// schedules whose pickup window starts before pickupBefore.
+func (r *Repository) ListUnpaidScheduledOrders(ctx context.Context, pickupBefore time.Time) ([]domain.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders
+	WHERE schedule_id IS NOT NULL AND status = $1 AND pickup_window_start < $2
+	ORDER BY pickup_window_start`
+
+	rows, err := r.executor.Query(ctx, query, domain.OrderStatusPending, pickupBefore)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListUnpaidScheduledOrders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []domain.Order
+	for rows.Next() {
+		order, err := r.scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.ListUnpaidScheduledOrders.Scan: %w", err)
+		}
+		orders = append(orders, *order)
+	}
+	return orders, rows.Err()
+}
+
+const scheduleColumns = `id, user_id, pickup_address_id, dropoff_address_id, machine_type, price,
+	length_m, width_m, height_m, items, rule, timezone, starts_at, window_minutes, active,
+	materialized_through, created_at, updated_at`
+
+func (r *Repository) scanSchedule(row pgx.Row) (*domain.Schedule, error) {
+	var s domain.Schedule
+	var items []byte
+	var materializedThrough sql.NullTime
+
+	err := row.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.PickupAddressID,
+		&s.DropoffAddressID,
+		&s.MachineType,
+		&s.Price,
+		&s.Dimensions.Length,
+		&s.Dimensions.Width,
+		&s.Dimensions.Height,
+		&items,
+		&s.Rule,
+		&s.Timezone,
+		&s.StartsAt,
+		&s.WindowMinutes,
+		&s.Active,
+		&materializedThrough,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(items, &s.Items); err != nil {
+		return nil, fmt.Errorf("decoding item manifest: %w", err)
+	}
+	if materializedThrough.Valid {
+		s.MaterializedThrough = &materializedThrough.Time
+	}
+	return &s, nil
+}
+
+func (r *Repository) CreateSchedule(ctx context.Context, schedule *domain.Schedule) (*domain.Schedule, error) {
+	items, err := json.Marshal(schedule.Items)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateSchedule: %w", err)
+	}
+
+	query := `
+	INSERT INTO delivery_schedules (user_id, pickup_address_id, dropoff_address_id, machine_type, price,
+		length_m, width_m, height_m, items, rule, timezone, starts_at, window_minutes)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	RETURNING ` + scheduleColumns
+
+	created, err := r.scanSchedule(r.executor.QueryRow(ctx, query,
+		schedule.UserID,
+		schedule.PickupAddressID,
+		schedule.DropoffAddressID,
+		schedule.MachineType,
+		schedule.Price,
+		schedule.Dimensions.Length,
+		schedule.Dimensions.Width,
+		schedule.Dimensions.Height,
+		items,
+		schedule.Rule,
+		schedule.Timezone,
+		schedule.StartsAt,
+		schedule.WindowMinutes,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateSchedule: %w", err)
+	}
+	return created, nil
+}
+
+func (r *Repository) FindSchedule(ctx context.Context, scheduleID string) (*domain.Schedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM delivery_schedules WHERE id = $1`
+
+	schedule, err := r.scanSchedule(r.executor.QueryRow(ctx, query, scheduleID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindSchedule: %w", err)
+	}
+	return schedule, nil
+}
+
+func (r *Repository) ListSchedules(ctx context.Context, userID string) ([]domain.Schedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM delivery_schedules WHERE user_id = $1 ORDER BY created_at`
+	return r.querySchedules(ctx, "ListSchedules", query, userID)
+}
+
+// ListDueSchedules returns the active schedules that have not been materialized up to horizon.
+func (r *Repository) ListDueSchedules(ctx context.Context, horizon time.Time) ([]domain.Schedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM delivery_schedules
+	WHERE active AND (materialized_through IS NULL OR materialized_through < $1)
+	ORDER BY materialized_through NULLS FIRST`
+	return r.querySchedules(ctx, "ListDueSchedules", query, horizon)
+}
+
+func (r *Repository) querySchedules(ctx context.Context, op, query string, args ...any) ([]domain.Schedule, error) {
+	rows, err := r.executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repository.%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var schedules []domain.Schedule
+	for rows.Next() {
+		schedule, err := r.scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("repository.%s.Scan: %w", op, err)
+		}
+		schedules = append(schedules, *schedule)
+	}
+	return schedules, rows.Err()
+}
+
+// DeactivateSchedule stops a user's schedule from materializing new orders.
+func (r *Repository) DeactivateSchedule(ctx context.Context, userID, scheduleID string) error {
+	query := `UPDATE delivery_schedules SET active = FALSE, updated_at = NOW() WHERE id = $1 AND user_id = $2`
+	cmdTag, err := r.executor.Exec(ctx, query, scheduleID, userID)
+	if err != nil {
+		return fmt.Errorf("repository.DeactivateSchedule: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return models.ErrNotFound
+	}
+	return nil
+}
+
+// SetMaterializedThrough records that all occurrences of a schedule up to through have been materialized.
+func (r *Repository) SetMaterializedThrough(ctx context.Context, scheduleID string, through time.Time) error {
+	query := `UPDATE delivery_schedules SET materialized_through = $1, updated_at = NOW() WHERE id = $2`
+	if _, err := r.executor.Exec(ctx, query, through, scheduleID); err != nil {
+		return fmt.Errorf("repository.SetMaterializedThrough: %w", err)
+	}
+	return nil
+}
+
+const paymentColumns = `id, order_id, provider, provider_payment_id, idempotency_key, amount_cents,
+	currency, status, failure_reason, created_at, updated_at`
+
+func (r *Repository) scanPayment(row pgx.Row) (*domain.Payment, error) {
+	var p domain.Payment
+	var providerPaymentID, failureReason sql.NullString
+
+	err := row.Scan(
+		&p.ID,
+		&p.OrderID,
+		&p.Provider,
+		&providerPaymentID,
+		&p.IdempotencyKey,
+		&p.AmountCents,
+		&p.Currency,
+		&p.Status,
+		&failureReason,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if providerPaymentID.Valid {
+		p.ProviderPaymentID = &providerPaymentID.String
+	}
+	if failureReason.Valid {
+		p.FailureReason = &failureReason.String
+	}
+
+	return &p, nil
+}
+
+// CreatePayment inserts a pending payment record. If a payment with the same
+// idempotency key already exists, the existing record is returned instead.
+func (r *Repository) CreatePayment(ctx context.Context, payment *domain.Payment) (*domain.Payment, error) {
+	query := `
+	INSERT INTO payments (order_id, provider, idempotency_key, amount_cents, currency, status)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (idempotency_key) DO UPDATE SET updated_at = payments.updated_at
+	RETURNING ` + paymentColumns
+
+	row := r.executor.QueryRow(ctx, query,
+		payment.OrderID, payment.Provider, payment.IdempotencyKey, payment.AmountCents, payment.Currency, payment.Status,
+	)
+	created, err := r.scanPayment(row)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreatePayment: %w", err)
+	}
+	return created, nil
+}
+
+func (r *Repository) FindPaymentByID(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = $1`
+
+	payment, err := r.scanPayment(r.executor.QueryRow(ctx, query, paymentID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindPaymentByID: %w", err)
+	}
+	return payment, nil
+}
+
+func (r *Repository) FindPaymentByIdempotencyKey(ctx context.Context, key string) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE idempotency_key = $1`
+
+	payment, err := r.scanPayment(r.executor.QueryRow(ctx, query, key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindPaymentByIdempotencyKey: %w", err)
+	}
+	return payment, nil
+}
+
+func (r *Repository) FindPaymentByProviderPaymentID(ctx context.Context, provider, providerPaymentID string) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE provider = $1 AND provider_payment_id = $2`
+
+	payment, err := r.scanPayment(r.executor.QueryRow(ctx, query, provider, providerPaymentID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindPaymentByProviderPaymentID: %w", err)
+	}
+	return payment, nil
+}
+
+// UpdatePayment records the outcome reported by the payment provider.
+func (r *Repository) UpdatePayment(ctx context.Context, paymentID string, providerPaymentID *string, status string, failureReason *string) (*domain.Payment, error) {
+	query := `
+	UPDATE payments
+	SET provider_payment_id = COALESCE($1, provider_payment_id), status = $2, failure_reason = $3, updated_at = NOW()
+	WHERE id = $4
+	RETURNING ` + paymentColumns
+
+	payment, err := r.scanPayment(r.executor.QueryRow(ctx, query, providerPaymentID, status, failureReason, paymentID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.UpdatePayment: %w", err)
+	}
+	return payment, nil
+}
+
+// FindRefundablePayment returns the most recent captured payment of an order.
+func (r *Repository) FindRefundablePayment(ctx context.Context, orderID string) (*domain.Payment, error) {
+	query := `
+	SELECT ` + paymentColumns + `
+	FROM payments
+	WHERE order_id = $1 AND status IN ($2, $3)
+	ORDER BY created_at DESC
+	LIMIT 1
+	`
+	row := r.executor.QueryRow(ctx, query, orderID, domain.PaymentStatusSucceeded, domain.PaymentStatusPartiallyRefunded)
+	payment, err := r.scanPayment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindRefundablePayment: %w", err)
+	}
+	return payment, nil
+}
+
+// SumRefundedCents returns the amount already refunded (or being refunded) for a
+// payment, along with the total number of refund attempts made against it.
+func (r *Repository) SumRefundedCents(ctx context.Context, paymentID string) (int64, int, error) {
+	var total int64
+	var attempts int
+	query := `
+	SELECT COALESCE(SUM(amount_cents) FILTER (WHERE status <> $2), 0), COUNT(*)
+	FROM refunds
+	WHERE payment_id = $1
+	`
+	if err := r.executor.QueryRow(ctx, query, paymentID, domain.RefundStatusFailed).Scan(&total, &attempts); err != nil {
+		return 0, 0, fmt.Errorf("repository.SumRefundedCents: %w", err)
+	}
+	return total, attempts, nil
+}
+
+const refundColumns = `id, payment_id, order_id, provider_refund_id, idempotency_key, amount_cents,
+	currency, reason, status, created_at, updated_at`
+
+func (r *Repository) scanRefund(row pgx.Row) (*domain.Refund, error) {
+	var refund domain.Refund
+	var providerRefundID sql.NullString
+
+	err := row.Scan(
+		&refund.ID,
+		&refund.PaymentID,
+		&refund.OrderID,
+		&providerRefundID,
+		&refund.IdempotencyKey,
+		&refund.AmountCents,
+		&refund.Currency,
+		&refund.Reason,
+		&refund.Status,
+		&refund.CreatedAt,
+		&refund.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if providerRefundID.Valid {
+		refund.ProviderRefundID = &providerRefundID.String
+	}
+
+	return &refund, nil
+}
+
+// CreateRefund inserts a pending refund record linked to a payment.
+func (r *Repository) CreateRefund(ctx context.Context, refund *domain.Refund) (*domain.Refund, error) {
+	query := `
+	INSERT INTO refunds (payment_id, order_id, idempotency_key, amount_cents, currency, reason, status)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING ` + refundColumns
+
+	row := r.executor.QueryRow(ctx, query,
+		refund.PaymentID, refund.OrderID, refund.IdempotencyKey, refund.AmountCents, refund.Currency, refund.Reason, refund.Status,
+	)
+	created, err := r.scanRefund(row)
+	if err != nil {
+		return nil, fmt.Errorf("repository.CreateRefund: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateRefund records the outcome reported by the payment provider.
+func (r *Repository) UpdateRefund(ctx context.Context, refundID string, providerRefundID *string, status string) (*domain.Refund, error) {
+	query := `
+	UPDATE refunds
+	SET provider_refund_id = COALESCE($1, provider_refund_id), status = $2, updated_at = NOW()
+	WHERE id = $3
+	RETURNING ` + refundColumns
+
+	refund, err := r.scanRefund(r.executor.QueryRow(ctx, query, providerRefundID, status, refundID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.UpdateRefund: %w", err)
+	}
+	return refund, nil
+}
+
+// FindUserContact returns the email address and nickname used to notify the owner of an order.
+func (r *Repository) FindUserContact(ctx context.Context, userID string) (string, string, error) {
+	var email, nickname string
+	query := `SELECT email, nickname FROM users WHERE id = $1`
+	if err := r.executor.QueryRow(ctx, query, userID).Scan(&email, &nickname); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", models.ErrNotFound
+		}
+		return "", "", fmt.Errorf("repository.FindUserContact: %w", err)
+	}
+	return email, nickname, nil
+}
+
+// CreateFeedback stores the feedback for an order.
+// It returns models.ErrFeedbackAlreadySubmitted if the order already has feedback.
+func (r *Repository) CreateFeedback(ctx context.Context, orderID string, req domain.FeedbackRequest) (*domain.Feedback, error) {
+	var feedback domain.Feedback
+	query := `
+	INSERT INTO feedback (order_id, rating, comment)
+	VALUES ($1, $2, $3)
+	RETURNING id, order_id, rating, comment, created_at, updated_at
+	`
+	err := r.executor.QueryRow(ctx, query, orderID, req.Rating, req.Comment).Scan(
+		&feedback.ID, &feedback.OrderID, &feedback.Rating, &feedback.Comment, &feedback.CreatedAt, &feedback.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation on order_id
+			return nil, models.ErrFeedbackAlreadySubmitted
+		}
+		return nil, fmt.Errorf("repository.CreateFeedback: %w", err)
+	}
+	return &feedback, nil
+}
+
+// ratingStatsSelect aggregates feedback ratings; its placeholders start at $1 (the low rating threshold).
+const ratingStatsSelect = `
+	COUNT(*),
+	AVG(f.rating)::float8,
+	COUNT(*) FILTER (WHERE f.rating <= $1),
+	COUNT(*) FILTER (WHERE f.rating = 1),
+	COUNT(*) FILTER (WHERE f.rating = 2),
+	COUNT(*) FILTER (WHERE f.rating = 3),
+	COUNT(*) FILTER (WHERE f.rating = 4),
+	COUNT(*) FILTER (WHERE f.rating = 5)`
+
+// buildRatingStatsWhere builds the WHERE clause for rating statistics queries.
+// The returned args start with the low rating threshold used by ratingStatsSelect.
+func buildRatingStatsWhere(filter domain.RatingStatsFilter) (string, []any) {
+	whereClauses := []string{"o.machine_id IS NOT NULL"}
+	args := []any{domain.LowRatingThreshold}
+	argIdx := 2
+
+	if filter.MachineID != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("o.machine_id = $%d", argIdx))
+		args = append(args, filter.MachineID)
+		argIdx++
+	}
+	if filter.MachineType != "" {
+		whereClauses = append(whereClauses, fmt.Sprintf("m.type = $%d", argIdx))
+		args = append(args, filter.MachineType)
+		argIdx++
+	}
+	if filter.Since != nil {
+		whereClauses = append(whereClauses, fmt.Sprintf("f.created_at >= $%d", argIdx))
+		args = append(args, *filter.Since)
+		argIdx++
+	}
+
+	return strings.Join(whereClauses, " AND "), args
+}
+
+func scanRatingStats(rows pgx.Rows, prefix []any, stats *domain.RatingStats) error {
+	dest := append(prefix,
+		&stats.FeedbackCount,
+		&stats.AverageRating,
+		&stats.LowRatingCount,
+		&stats.RatingCounts[0],
+		&stats.RatingCounts[1],
+		&stats.RatingCounts[2],
+		&stats.RatingCounts[3],
+		&stats.RatingCounts[4],
+	)
+	return rows.Scan(dest...)
+}
+
+// ListMachineRatingStats aggregates feedback per machine, worst average rating first.
+func (r *Repository) ListMachineRatingStats(ctx context.Context, filter domain.RatingStatsFilter) ([]domain.MachineRatingStats, error) {
+	where, args := buildRatingStatsWhere(filter)
+	args = append(args, filter.MinFeedbackCount)
+
+	query := fmt.Sprintf(`
+	SELECT o.machine_id, m.type, %s
+	FROM feedback f
+	JOIN orders o ON o.id = f.order_id
+	JOIN machines m ON m.id = o.machine_id
+	WHERE %s
+	GROUP BY o.machine_id, m.type
+	HAVING COUNT(*) >= $%d
+	ORDER BY AVG(f.rating) ASC, COUNT(*) DESC
+	`, ratingStatsSelect, where, len(args))
+
+	rows, err := r.executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListMachineRatingStats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []domain.MachineRatingStats
+	for rows.Next() {
+		var s domain.MachineRatingStats
+		if err := scanRatingStats(rows, []any{&s.MachineID, &s.MachineType}, &s.RatingStats); err != nil {
+			return nil, fmt.Errorf("repository.ListMachineRatingStats.Scan: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// ListMachineTypeRatingStats aggregates feedback per machine type, worst average rating first.
+func (r *Repository) ListMachineTypeRatingStats(ctx context.Context, filter domain.RatingStatsFilter) ([]domain.MachineTypeRatingStats, error) {
+	where, args := buildRatingStatsWhere(filter)
+
+	query := fmt.Sprintf(`
+	SELECT m.type, %s
+	FROM feedback f
+	JOIN orders o ON o.id = f.order_id
+	JOIN machines m ON m.id = o.machine_id
+	WHERE %s
+	GROUP BY m.type
+	ORDER BY AVG(f.rating) ASC
+	`, ratingStatsSelect, where)
+
+	rows, err := r.executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repository.ListMachineTypeRatingStats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []domain.MachineTypeRatingStats
+	for rows.Next() {
+		var s domain.MachineTypeRatingStats
+		if err := scanRatingStats(rows, []any{&s.MachineType}, &s.RatingStats); err != nil {
+			return nil, fmt.Errorf("repository.ListMachineTypeRatingStats.Scan: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// WebhookEventExists reports whether a webhook event with the given ID was already processed.
+func (r *Repository) WebhookEventExists(ctx context.Context, eventID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM webhook_events WHERE id = $1);`
+	if err := r.executor.QueryRow(ctx, query, eventID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("repository.WebhookEventExists: %w", err)
+	}
+	return exists, nil
+}
+
+// RecordWebhookEvent marks a webhook event as processed. Recording the same event twice is a no-op.
+func (r *Repository) RecordWebhookEvent(ctx context.Context, eventID, provider, eventType string) error {
+	query := `
+	INSERT INTO webhook_events (id, provider, type)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (id) DO NOTHING
+	`
+	if _, err := r.executor.Exec(ctx, query, eventID, provider, eventType); err != nil {
+		return fmt.Errorf("repository.RecordWebhookEvent: %w", err)
+	}
+	return nil
+}
+
+// FindActivePayment returns the most recent payment of an order that is still
+// processing or has been captured.
+func (r *Repository) FindActivePayment(ctx context.Context, orderID string) (*domain.Payment, error) {
+	query := `
+	SELECT ` + paymentColumns + `
+	FROM payments
+	WHERE order_id = $1 AND status IN ($2, $3)
+	ORDER BY created_at DESC
+	LIMIT 1
+	`
+	row := r.executor.QueryRow(ctx, query, orderID, domain.PaymentStatusPending, domain.PaymentStatusSucceeded)
+	payment, err := r.scanPayment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("repository.FindActivePayment: %w", err)
+	}
+	return payment, nil
+}
+
+// CountFailedPayments counts the payment attempts of an order that failed.
+func (r *Repository) CountFailedPayments(ctx context.Context, orderID string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM payments WHERE order_id = $1 AND status = $2`
+	if err := r.executor.QueryRow(ctx, query, orderID, domain.PaymentStatusFailed).Scan(&count); err != nil {
+		return 0, fmt.Errorf("repository.CountFailedPayments: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,302 @@
+package orders
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	// ScheduleHorizon is how far ahead recurring schedules are turned into orders.
+	ScheduleHorizon = 7 * 24 * time.Hour
+
+	// maxScheduleWindowMinutes caps the pickup window of a recurring schedule.
+	maxScheduleWindowMinutes = 12 * 60
+
+	// ScheduledPaymentDeadline is how long before its pickup window a
+	// materialized order must be paid. Orders still unpaid by then expire.
+	ScheduledPaymentDeadline = 2 * time.Hour
+)
+
+// CreateSchedule sets up a recurring delivery from a quote. The quoted route
+// and price are locked in for every occurrence.
+func (s *Service) CreateSchedule(ctx context.Context, userID string, req domain.CreateScheduleRequest) (*domain.Schedule, error) {
+	if err := validateManifest(req.Items); err != nil {
+		return nil, fmt.Errorf("service.CreateSchedule: %w", err)
+	}
+	if req.WindowMinutes <= 0 || req.WindowMinutes > maxScheduleWindowMinutes {
+		return nil, models.ErrInvalidPickupWindow
+	}
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("service.CreateSchedule: %w: %q", models.ErrUnknownTimezone, req.Timezone)
+	}
+	if !req.StartsAt.After(time.Now()) {
+		return nil, models.ErrInvalidPickupWindow
+	}
+	if _, err := domain.ParseWeeklyRule(req.Rule, req.StartsAt.In(loc)); err != nil {
+		return nil, fmt.Errorf("service.CreateSchedule: %w: %v", models.ErrInvalidScheduleRule, err)
+	}
+
+	quote, err := s.findUsableQuote(ctx, userID, req.RouteOptionID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.MachineType == fleetDomain.MachineTypeDrone {
+		first := &domain.PickupWindow{Start: req.StartsAt, End: req.StartsAt.Add(time.Duration(req.WindowMinutes) * time.Minute)}
+		if err := s.checkAirspace(ctx, quote.PickupAddressID, []domain.Stop{{AddressID: quote.DropoffAddressID}}, first); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule, err := s.orderRepo.CreateSchedule(ctx, &domain.Schedule{
+		UserID:           userID,
+		PickupAddressID:  quote.PickupAddressID,
+		DropoffAddressID: quote.DropoffAddressID,
+		MachineType:      quote.MachineType,
+		Price:            quote.Price,
+		Dimensions:       req.Dimensions,
+		Items:            req.Items,
+		Rule:             req.Rule,
+		Timezone:         req.Timezone,
+		StartsAt:         req.StartsAt,
+		WindowMinutes:    req.WindowMinutes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.CreateSchedule: %w", err)
+	}
+	return schedule, nil
+}
+
+func (s *Service) ListSchedules(ctx context.Context, userID string) ([]domain.Schedule, error) {
+	schedules, err := s.orderRepo.ListSchedules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListSchedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// CancelSchedule stops a schedule from creating further orders. Orders that
+// were already materialized stay and can be cancelled one by one.
+func (s *Service) CancelSchedule(ctx context.Context, userID, scheduleID string) error {
+	if err := s.orderRepo.DeactivateSchedule(ctx, userID, scheduleID); err != nil {
+		return fmt.Errorf("service.CancelSchedule: %w", err)
+	}
+	return nil
+}
+
+// MaterializeSchedules creates the orders of every active schedule whose
+// occurrences fall within ScheduleHorizon of now. It returns the number of
+// orders created. Running it again is safe: occurrences are only created once.
+func (s *Service) MaterializeSchedules(ctx context.Context, now time.Time) (int, error) {
+	horizon := now.Add(ScheduleHorizon)
+	schedules, err := s.orderRepo.ListDueSchedules(ctx, horizon)
+	if err != nil {
+		return 0, fmt.Errorf("service.MaterializeSchedules: %w", err)
+	}
+
+	created := 0
+	for i := range schedules {
+		n, err := s.materializeSchedule(ctx, &schedules[i], now, horizon)
+		created += n
+		if err != nil {
+			// Leave the schedule due so the next run retries it.
+			log.Printf("ERROR: Failed to materialize schedule %s: %v", schedules[i].ID, err)
+		}
+	}
+	return created, nil
+}
+
+func (s *Service) materializeSchedule(ctx context.Context, schedule *domain.Schedule, now, horizon time.Time) (int, error) {
+	rule, start, err := parseSchedule(schedule)
+	if err != nil {
+		return 0, err
+	}
+
+	// Never create orders for pickups that are already in the past.
+	after := now
+	if schedule.MaterializedThrough != nil && schedule.MaterializedThrough.After(after) {
+		after = *schedule.MaterializedThrough
+	}
+
+	created := 0
+	window := time.Duration(schedule.WindowMinutes) * time.Minute
+	for _, occurrence := range rule.Occurrences(start, after, horizon) {
+		_, err := s.placeOrder(ctx, &domain.Order{
+			UserID:           schedule.UserID,
+			PickupAddressID:  schedule.PickupAddressID,
+			DropoffAddressID: schedule.DropoffAddressID,
+			Status:           domain.OrderStatusPending,
+			MachineType:      schedule.MachineType,
+			PickupWindow:     &domain.PickupWindow{Start: occurrence, End: occurrence.Add(window)},
+			ScheduleID:       &schedule.ID,
+			Dimensions:       schedule.Dimensions,
+			Cost:             schedule.Price,
+		}, schedule.Items, "")
+
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, models.ErrConflict):
+			// Already materialized by an earlier, interrupted run.
+		case errors.Is(err, models.ErrNoDeliveryCapacity),
+			errors.Is(err, models.ErrInsufficientStock),
+			errors.Is(err, models.ErrNotFound),
+			errors.Is(err, models.ErrHazmatNotAllowed),
+			errors.Is(err, models.ErrPackageTooLarge):
+			// The occurrence cannot be served; skip it rather than blocking the schedule.
+			log.Printf("WARN: Skipping occurrence %s of schedule %s: %v", occurrence.Format(time.RFC3339), schedule.ID, err)
+		default:
+			return created, err
+		}
+	}
+
+	if err := s.orderRepo.SetMaterializedThrough(ctx, schedule.ID, horizon); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// ExpireUnpaidOrders cancels the materialized orders that are still unpaid
+// when their payment deadline passes, and hands their reserved stock back to
+// the inventory. It returns the number of orders that expired.
+func (s *Service) ExpireUnpaidOrders(ctx context.Context, now time.Time) (int, error) {
+	orders, err := s.orderRepo.ListUnpaidScheduledOrders(ctx, now.Add(ScheduledPaymentDeadline))
+	if err != nil {
+		return 0, fmt.Errorf("service.ExpireUnpaidOrders: %w", err)
+	}
+
+	expired := 0
+	for i := range orders {
+		order := &orders[i]
+		// Orders paid in the meantime are left alone; a charge that is still
+		// in flight is refunded once it is captured.
+		if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusPending, domain.OrderStatusCancelled); err != nil {
+			if errors.Is(err, models.ErrConflict) {
+				continue
+			}
+			return expired, fmt.Errorf("service.ExpireUnpaidOrders.UpdateStatus: %w", err)
+		}
+		expired++
+
+		if err := s.inventory.ReleaseStock(ctx, order.ID); err != nil {
+			log.Printf("ERROR: Failed to release stock of expired order %s: %v", order.ID, err)
+		}
+	}
+	return expired, nil
+}
+
+// parseSchedule returns the recurrence rule of a schedule and its start in the schedule's timezone.
+func parseSchedule(schedule *domain.Schedule) (*domain.WeeklyRule, time.Time, error) {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("schedule %s: %w", schedule.ID, err)
+	}
+	start := schedule.StartsAt.In(loc)
+	rule, err := domain.ParseWeeklyRule(schedule.Rule, start)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("schedule %s: %w", schedule.ID, err)
+	}
+	return rule, start, nil
+}
+
+// ListUpcomingDeliveries returns the pickups of a user in [from, to): the
+// scheduled orders that exist already, plus the occurrences of active
+// schedules that have not been materialized yet.
+func (s *Service) ListUpcomingDeliveries(ctx context.Context, userID string, from, to time.Time) ([]domain.UpcomingDelivery, error) {
+	orders, err := s.orderRepo.ListUpcomingOrders(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListUpcomingDeliveries.ListUpcomingOrders: %w", err)
+	}
+	schedules, err := s.orderRepo.ListSchedules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListUpcomingDeliveries.ListSchedules: %w", err)
+	}
+
+	var deliveries []domain.UpcomingDelivery
+	for _, o := range orders {
+		d := domain.UpcomingDelivery{
+			OrderID:     o.ID,
+			Status:      o.Status,
+			Window:      *o.PickupWindow,
+			MachineType: o.MachineType,
+		}
+		if o.ScheduleID != nil {
+			d.ScheduleID = *o.ScheduleID
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	for i := range schedules {
+		schedule := &schedules[i]
+		if !schedule.Active {
+			continue
+		}
+		rule, start, err := parseSchedule(schedule)
+		if err != nil {
+			log.Printf("ERROR: %v", err)
+			continue
+		}
+
+		// Occurrences up to materialized_through are already covered by orders.
+		after := from.Add(-time.Nanosecond)
+		if schedule.MaterializedThrough != nil && schedule.MaterializedThrough.After(after) {
+			after = *schedule.MaterializedThrough
+		}
+		window := time.Duration(schedule.WindowMinutes) * time.Minute
+		for _, occurrence := range rule.Occurrences(start, after, to) {
+			if !occurrence.Before(to) {
+				continue
+			}
+			deliveries = append(deliveries, domain.UpcomingDelivery{
+				ScheduleID:  schedule.ID,
+				Status:      domain.DeliveryStatusProjected,
+				Window:      domain.PickupWindow{Start: occurrence, End: occurrence.Add(window)},
+				MachineType: schedule.MachineType,
+			})
+		}
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].Window.Start.Before(deliveries[j].Window.Start)
+	})
+	return deliveries, nil
+}
+
+// RunScheduleMaterializer materializes schedules, and expires the orders left
+// unpaid, every interval until ctx is cancelled.
+func RunScheduleMaterializer(ctx context.Context, s ServiceInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := s.MaterializeSchedules(ctx, time.Now())
+		if err != nil {
+			log.Printf("ERROR: Schedule materialization failed: %v", err)
+		} else if created > 0 {
+			log.Printf("INFO: Materialized %d scheduled orders", created)
+		}
+
+		expired, err := s.ExpireUnpaidOrders(ctx, time.Now())
+		if err != nil {
+			log.Printf("ERROR: Expiring unpaid scheduled orders failed: %v", err)
+		} else if expired > 0 {
+			log.Printf("INFO: Expired %d unpaid scheduled orders", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
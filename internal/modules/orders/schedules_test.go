@@ -0,0 +1,76 @@
+package orders
+
+import (
+	"context"
+	itemDomain "dispatch-and-delivery/internal/modules/items/domain"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"slices"
+	"testing"
+	"time"
+)
+
+// expiryRepo lists the unpaid scheduled orders of fakeRepo.
+type expiryRepo struct {
+	*fakeRepo
+}
+
+func (r *expiryRepo) ListUnpaidScheduledOrders(_ context.Context, pickupBefore time.Time) ([]domain.Order, error) {
+	var orders []domain.Order
+	for _, o := range r.orders {
+		if o.ScheduleID != nil && o.Status == domain.OrderStatusPending && o.PickupWindow.Start.Before(pickupBefore) {
+			orders = append(orders, *o)
+		}
+	}
+	return orders, nil
+}
+
+// fakeInventory records the orders whose stock was released.
+type fakeInventory struct {
+	released []string
+}
+
+func (i *fakeInventory) ReserveStock(context.Context, string, []itemDomain.ReservationLine) ([]itemDomain.Item, error) {
+	return nil, nil
+}
+
+func (i *fakeInventory) ReleaseStock(_ context.Context, orderID string) error {
+	i.released = append(i.released, orderID)
+	return nil
+}
+
+func TestExpireUnpaidOrders(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	schedule := "schedule-1"
+	scheduled := func(id, status string, pickupIn time.Duration) *domain.Order {
+		start := now.Add(pickupIn)
+		return &domain.Order{
+			ID: id, UserID: "alice", Status: status, ScheduleID: &schedule,
+			PickupWindow: &domain.PickupWindow{Start: start, End: start.Add(time.Hour)},
+		}
+	}
+
+	repo := &expiryRepo{newFakeRepo(
+		scheduled("due-unpaid", domain.OrderStatusPending, time.Hour),
+		scheduled("due-paid", domain.OrderStatusPaid, time.Hour),
+		scheduled("later-unpaid", domain.OrderStatusPending, 2*ScheduledPaymentDeadline),
+	)}
+	inventory := &fakeInventory{}
+	s := &Service{orderRepo: repo, inventory: inventory}
+
+	expired, err := s.ExpireUnpaidOrders(context.Background(), now)
+	if err != nil {
+		t.Fatalf("ExpireUnpaidOrders: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expired %d orders, want 1", expired)
+	}
+	if got := repo.orders["due-unpaid"].Status; got != domain.OrderStatusCancelled {
+		t.Errorf("unpaid order past its deadline is %s, want cancelled", got)
+	}
+	if got := repo.orders["later-unpaid"].Status; got != domain.OrderStatusPending {
+		t.Errorf("unpaid order before its deadline is %s, want pending", got)
+	}
+	if !slices.Equal(inventory.released, []string{"due-unpaid"}) {
+		t.Errorf("released the stock of %v, want only due-unpaid", inventory.released)
+	}
+}
@@ -0,0 +1,1103 @@
+package orders
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	dispatchDomain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	itemDomain "dispatch-and-delivery/internal/modules/items/domain"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	emailSvc "dispatch-and-delivery/pkg/email"
+	"dispatch-and-delivery/pkg/geo"
+	"dispatch-and-delivery/pkg/payments"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+)
+
+// defaultCurrency is the currency all orders are charged in.
+const defaultCurrency = "usd"
+
+// ServiceInterface defines methods for order business logic.
+type ServiceInterface interface {
+	CreateOrder(ctx context.Context, userID string, req domain.CreateOrderRequest) (*domain.Order, error)
+
+	CreateSchedule(ctx context.Context, userID string, req domain.CreateScheduleRequest) (*domain.Schedule, error)
+	ListSchedules(ctx context.Context, userID string) ([]domain.Schedule, error)
+	CancelSchedule(ctx context.Context, userID, scheduleID string) error
+	MaterializeSchedules(ctx context.Context, now time.Time) (int, error)
+	ExpireUnpaidOrders(ctx context.Context, now time.Time) (int, error)
+	ListUpcomingDeliveries(ctx context.Context, userID string, from, to time.Time) ([]domain.UpcomingDelivery, error)
+
+	PayOrder(ctx context.Context, userID, orderID string, req domain.PaymentRequest) (*domain.Payment, error)
+	HandlePaymentEvent(ctx context.Context, event *payments.Event) error
+
+	CancelOrder(ctx context.Context, userID, orderID string) (*domain.Order, *domain.Refund, error)
+	RefundOrder(ctx context.Context, orderID string, req domain.RefundRequest) (*domain.Refund, error)
+
+	AssignMachine(ctx context.Context, orderID, machineID string) (*domain.Order, error)
+	AssignTrip(ctx context.Context, orderIDs []string, machineID string) (*domain.Trip, error)
+	RedispatchOrdersOfUnavailableMachines(ctx context.Context) (int, error)
+	ListDispatchQueue(ctx context.Context, now time.Time, limit int) ([]dispatchDomain.Job, error)
+	CountAssignedOrders(ctx context.Context, machineIDs []string) (map[string]int, error)
+	DispatchOrder(ctx context.Context, orderID, machineID string) (*domain.Order, error)
+	ListOrderStops(ctx context.Context, userID, orderID string) ([]domain.Stop, error)
+	ConfirmPickup(ctx context.Context, machineID, orderID string) (*domain.Order, error)
+	SubmitProofOfDelivery(ctx context.Context, machineID, orderID string, req domain.ProofOfDeliveryRequest) (*domain.ProofOfDelivery, error)
+	GetProofOfDelivery(ctx context.Context, userID, orderID string) ([]domain.ProofOfDelivery, error)
+	ReportFailedDelivery(ctx context.Context, machineID, orderID string, req domain.FailedDeliveryRequest) (*domain.DeliveryAttempt, error)
+	ConfirmReturn(ctx context.Context, machineID, orderID string) (*domain.Order, *domain.Refund, error)
+	ListDeliveryAttempts(ctx context.Context, userID, orderID string) ([]domain.DeliveryAttempt, error)
+	GetOrderETA(ctx context.Context, userID, orderID string) (*domain.OrderETA, error)
+
+	SubmitFeedback(ctx context.Context, userID, orderID string, req domain.FeedbackRequest) (*domain.Feedback, error)
+	GetMachineRatingStats(ctx context.Context, filter domain.RatingStatsFilter) ([]domain.MachineRatingStats, []domain.MachineTypeRatingStats, error)
+}
+
+// Inventory reserves and releases the stock of an order's item manifest.
+// It is implemented by the items module.
+type Inventory interface {
+	ReserveStock(ctx context.Context, orderID string, lines []itemDomain.ReservationLine) ([]itemDomain.Item, error)
+	ReleaseStock(ctx context.Context, orderID string) error
+}
+
+// Fleet claims the machines orders are dispatched to, and hands them back
+// once their trips ended.
+type Fleet interface {
+	ClaimMachine(ctx context.Context, machineID string) (*fleetDomain.Machine, error)
+	CheckMachineDispatchable(ctx context.Context, machineID string) error
+	ReleaseMachine(ctx context.Context, machineID string) (*fleetDomain.Machine, error)
+}
+
+type Service struct {
+	orderRepo       RepositoryInterface
+	inventory       Inventory
+	fleet           Fleet
+	eta             ETAEstimator
+	paymentProvider payments.PaymentProvider
+	emailer         emailSvc.ServiceInterface
+	templateManager *emailSvc.TemplateManager
+	refundPolicy    RefundPolicy
+	failurePolicy   FailedDeliveryPolicy
+	handoffSecret   []byte // Keys the HMAC of handoff PINs
+}
+
+func NewService(
+	orderRepo RepositoryInterface,
+	inventory Inventory,
+	fleet Fleet,
+	eta ETAEstimator,
+	paymentProvider payments.PaymentProvider,
+	emailer emailSvc.ServiceInterface,
+	tm *emailSvc.TemplateManager,
+	handoffSecret string,
+) ServiceInterface {
+	return &Service{
+		orderRepo:       orderRepo,
+		inventory:       inventory,
+		fleet:           fleet,
+		eta:             eta,
+		paymentProvider: paymentProvider,
+		emailer:         emailer,
+		templateManager: tm,
+		refundPolicy:    DefaultRefundPolicy,
+		failurePolicy:   DefaultFailedDeliveryPolicy,
+		handoffSecret:   []byte(handoffSecret),
+	}
+}
+
+// CreateOrder places a pending order from a delivery quote. The stock of every
+// manifest line is reserved, and the order's weight is computed from the catalog.
+// Orders with a pickup window are scheduled and only accepted if a machine is free.
+// Additional dropoffs make it a multi-stop order, charged ExtraStopFee per stop.
+func (s *Service) CreateOrder(ctx context.Context, userID string, req domain.CreateOrderRequest) (*domain.Order, error) {
+	if err := validateManifest(req.Items); err != nil {
+		return nil, fmt.Errorf("service.CreateOrder: %w", err)
+	}
+	if req.PickupWindow != nil {
+		if err := validatePickupWindow(*req.PickupWindow, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. Load the quote the customer picked
+	quote, err := s.findUsableQuote(ctx, userID, req.RouteOptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Check the extra dropoffs of multi-stop orders
+	stops, err := s.buildStops(ctx, userID, quote.DropoffAddressID, req.AdditionalDropoffAddressIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Drones cannot take off or land in restricted airspace
+	if quote.MachineType == fleetDomain.MachineTypeDrone {
+		if err := s.checkAirspace(ctx, quote.PickupAddressID, stops, req.PickupWindow); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. Place the order at the quoted price
+	return s.placeOrder(ctx, &domain.Order{
+		UserID:           userID,
+		PickupAddressID:  quote.PickupAddressID,
+		DropoffAddressID: quote.DropoffAddressID,
+		Status:           domain.OrderStatusPending,
+		MachineType:      quote.MachineType,
+		PickupWindow:     req.PickupWindow,
+		Dimensions:       req.Dimensions,
+		Cost:             quote.Price + domain.ExtraStopFee*float64(len(stops)-1),
+		Stops:            stops,
+	}, req.Items, quote.ID)
+}
+
+// buildStops returns the stops of a new order: the quoted dropoff followed by
+// the additional dropoffs, which must be distinct addresses of the customer.
+func (s *Service) buildStops(ctx context.Context, userID, dropoffAddressID string, additional []string) ([]domain.Stop, error) {
+	stops := []domain.Stop{{AddressID: dropoffAddressID}}
+	if len(additional) == 0 {
+		return stops, nil
+	}
+	if len(additional)+1 > domain.MaxStopsPerOrder {
+		return nil, models.ErrInvalidStops
+	}
+
+	seen := map[string]bool{dropoffAddressID: true}
+	for _, id := range additional {
+		if id == "" || seen[id] {
+			return nil, models.ErrInvalidStops
+		}
+		seen[id] = true
+		stops = append(stops, domain.Stop{AddressID: id})
+	}
+	owned, err := s.orderRepo.CountUserAddresses(ctx, userID, additional)
+	if err != nil {
+		return nil, fmt.Errorf("service.buildStops: %w", err)
+	}
+	if owned != len(additional) {
+		return nil, models.ErrInvalidStops
+	}
+	return stops, nil
+}
+
+// checkAirspace returns models.ErrRestrictedAirspace when the pickup or a
+// stop lies in a no-fly zone in force during the pickup window, or in the
+// next hour for immediate orders. Addresses that are not geocoded are not
+// checked; the drone router refuses them at dispatch.
+func (s *Service) checkAirspace(ctx context.Context, pickupAddressID string, stops []domain.Stop, window *domain.PickupWindow) error {
+	from := time.Now()
+	to := from.Add(time.Hour)
+	if window != nil {
+		from, to = window.Start, window.End
+	}
+
+	addressIDs := []string{pickupAddressID}
+	for _, stop := range stops {
+		addressIDs = append(addressIDs, stop.AddressID)
+	}
+	for _, addressID := range addressIDs {
+		location, err := s.orderRepo.FindAddressLocation(ctx, addressID)
+		if err != nil {
+			return fmt.Errorf("service.checkAirspace.FindAddressLocation: %w", err)
+		}
+		if location == nil {
+			continue
+		}
+		zone, err := s.orderRepo.FindNoFlyZone(ctx, *location, dispatchDomain.DroneCruiseAltitudeMeters, from, to)
+		if err != nil {
+			return fmt.Errorf("service.checkAirspace: %w", err)
+		}
+		if zone != "" {
+			return fmt.Errorf("%w: inside %s", models.ErrRestrictedAirspace, zone)
+		}
+	}
+	return nil
+}
+
+// findUsableQuote loads a quote that belongs to the user and can still be ordered.
+func (s *Service) findUsableQuote(ctx context.Context, userID, quoteID string) (*domain.Quote, error) {
+	quote, err := s.orderRepo.FindQuote(ctx, quoteID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ErrRouteOptionExpired
+		}
+		return nil, fmt.Errorf("service.findUsableQuote: %w", err)
+	}
+	if quote.UserID != userID || quote.OrderID != nil || !time.Now().Before(quote.ExpiresAt) {
+		return nil, models.ErrRouteOptionExpired
+	}
+	return quote, nil
+}
+
+// validateManifest checks the customer supplied part of an item manifest.
+func validateManifest(items []domain.OrderItem) error {
+	if len(items) == 0 {
+		return models.ErrInvalidManifest
+	}
+	for _, item := range items {
+		if item.SKU == "" || item.Quantity <= 0 || item.DeclaredValue < 0 {
+			return fmt.Errorf("invalid manifest line for sku %q: %w", item.SKU, models.ErrInvalidManifest)
+		}
+	}
+	return nil
+}
+
+// validatePickupWindow checks that a scheduled pickup lies in the bookable future.
+func validatePickupWindow(window domain.PickupWindow, now time.Time) error {
+	if !window.End.After(window.Start) || !window.Start.After(now) || window.Start.Sub(now) > domain.MaxScheduleLeadTime {
+		return models.ErrInvalidPickupWindow
+	}
+	return nil
+}
+
+// placeOrder creates an order from a draft and its manifest. It claims the
+// quote (if any), checks fleet capacity for scheduled pickups, records the
+// stops, reserves the stock and records the manifest and total weight, all or
+// nothing. Drafts without stops get a single stop at their dropoff address.
+func (s *Service) placeOrder(ctx context.Context, draft *domain.Order, manifest []domain.OrderItem, quoteID string) (*domain.Order, error) {
+	lines := make([]itemDomain.ReservationLine, 0, len(manifest))
+	for _, item := range manifest {
+		lines = append(lines, itemDomain.ReservationLine{SKU: item.SKU, Quantity: item.Quantity})
+	}
+
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.orderRepo.WithTx(tx)
+
+	// 1. Make sure a machine is free for scheduled pickups
+	if draft.PickupWindow != nil {
+		if err := s.checkCapacity(ctx, txRepo, draft.MachineType, *draft.PickupWindow); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Create the order and claim the quote so it cannot be used twice
+	order, err := txRepo.CreateOrder(ctx, draft)
+	if err != nil {
+		return nil, fmt.Errorf("service.placeOrder.CreateOrder: %w", err)
+	}
+	if quoteID != "" {
+		if err := txRepo.ClaimQuote(ctx, quoteID, order.ID); err != nil {
+			return nil, err
+		}
+	}
+	stopAddresses := []string{draft.DropoffAddressID}
+	if len(draft.Stops) > 0 {
+		stopAddresses = stopAddresses[:0]
+		for _, stop := range draft.Stops {
+			stopAddresses = append(stopAddresses, stop.AddressID)
+		}
+	}
+	stops, err := txRepo.CreateOrderStops(ctx, order.ID, stopAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("service.placeOrder.CreateOrderStops: %w", err)
+	}
+
+	// 3. Reserve the stock. From here on, failures must hand the stock back.
+	catalog, err := s.inventory.ReserveStock(ctx, order.ID, lines)
+	if err != nil {
+		return nil, fmt.Errorf("service.placeOrder.ReserveStock: %w", err)
+	}
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if err := s.inventory.ReleaseStock(context.WithoutCancel(ctx), order.ID); err != nil {
+			log.Printf("ERROR: Failed to release stock of abandoned order %s: %v", order.ID, err)
+		}
+	}()
+
+	// 4. Complete the manifest from the catalog and make sure the machine can carry it
+	items, err := buildManifest(manifest, catalog)
+	if err != nil {
+		return nil, err
+	}
+	weight := domain.TotalWeightKg(items)
+	for _, item := range items {
+		if item.Hazmat && !fleetDomain.CanCarryHazmat(order.MachineType) {
+			return nil, models.ErrHazmatNotAllowed
+		}
+	}
+	if maxPayload, ok := fleetDomain.MaxPayloadKg[order.MachineType]; ok && weight > maxPayload {
+		return nil, models.ErrPackageTooLarge
+	}
+
+	if err := txRepo.AddOrderItems(ctx, order.ID, items); err != nil {
+		return nil, fmt.Errorf("service.placeOrder.AddOrderItems: %w", err)
+	}
+	if err := txRepo.SetItemWeight(ctx, order.ID, weight); err != nil {
+		return nil, fmt.Errorf("service.placeOrder.SetItemWeight: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	committed = true
+
+	order.Items = items
+	order.ItemWeightKg = weight
+	order.Stops = stops
+	return order, nil
+}
+
+// checkCapacity rejects a scheduled pickup when every machine of the type is
+// already booked for an overlapping window. Each machine serves one scheduled
+// order per window; immediate orders are dispatched from whatever is left.
+func (s *Service) checkCapacity(ctx context.Context, txRepo *Repository, machineType string, window domain.PickupWindow) error {
+	if err := txRepo.LockCapacity(ctx, machineType); err != nil {
+		return err
+	}
+	available, err := txRepo.CountAvailableMachines(ctx, machineType)
+	if err != nil {
+		return err
+	}
+	booked, err := txRepo.CountBookedOrders(ctx, machineType, window)
+	if err != nil {
+		return err
+	}
+	if booked >= available {
+		return models.ErrNoDeliveryCapacity
+	}
+	return nil
+}
+
+// buildManifest merges duplicate SKUs of a requested manifest and fills in
+// name, weight and hazmat flags from the reserved catalog entries.
+func buildManifest(requested []domain.OrderItem, catalog []itemDomain.Item) ([]domain.OrderItem, error) {
+	bySKU := make(map[string]itemDomain.Item, len(catalog))
+	for _, c := range catalog {
+		bySKU[c.SKU] = c
+	}
+
+	var items []domain.OrderItem
+	index := make(map[string]int)
+	for _, req := range requested {
+		if i, ok := index[req.SKU]; ok {
+			items[i].Quantity += req.Quantity
+			items[i].DeclaredValue += req.DeclaredValue
+			continue
+		}
+		c, ok := bySKU[req.SKU]
+		if !ok {
+			return nil, fmt.Errorf("buildManifest: sku %s missing from reservation", req.SKU)
+		}
+		index[req.SKU] = len(items)
+		items = append(items, domain.OrderItem{
+			SKU:           c.SKU,
+			Name:          c.Name,
+			Quantity:      req.Quantity,
+			UnitWeightKg:  c.UnitWeightKg,
+			Hazmat:        c.Hazmat,
+			HazmatClass:   c.HazmatClass,
+			DeclaredValue: req.DeclaredValue,
+		})
+	}
+	return items, nil
+}
+
+// paymentIdempotencyKey derives the provider idempotency key for an order.
+// Retrying with the same payment method replays the original charge until
+// that charge fails; each failed payment of the order starts a new attempt,
+// so a declined card can be tried again once the customer has sorted it out.
+func paymentIdempotencyKey(orderID, paymentMethodID string, failedPayments int) string {
+	return fmt.Sprintf("order_%s_%s_%d", orderID, paymentMethodID, failedPayments)
+}
+
+// PayOrder charges the customer for a pending order. The order only moves to
+// paid once the provider confirms that the funds were captured; payments that
+// are still processing are settled later by the provider webhook. While one
+// payment method is being charged, another is refused, so that the customer
+// cannot be charged twice.
+func (s *Service) PayOrder(ctx context.Context, userID, orderID string, req domain.PaymentRequest) (*domain.Payment, error) {
+	// 1. Load the order and make sure it belongs to the caller
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.PayOrder.FindByID: %w", err)
+	}
+	if order.UserID != userID {
+		// Do not reveal that the order exists
+		return nil, models.ErrNotFound
+	}
+	if order.Status != domain.OrderStatusPending {
+		return nil, models.ErrOrderCannotBePaid
+	}
+
+	amountCents := int64(math.Round(order.Cost * 100))
+	if amountCents <= 0 {
+		return nil, fmt.Errorf("service.PayOrder: order %s has no payable amount", order.ID)
+	}
+
+	// 2. Record the attempt before calling the provider so it can be reconciled later
+	payment, err := s.createPayment(ctx, order.ID, req.PaymentMethodID, &domain.Payment{
+		OrderID:     order.ID,
+		Provider:    s.paymentProvider.Name(),
+		AmountCents: amountCents,
+		Currency:    defaultCurrency,
+		Status:      domain.PaymentStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch payment.Status {
+	case domain.PaymentStatusSucceeded:
+		return payment, nil
+	case domain.PaymentStatusFailed:
+		return nil, models.ErrPaymentDeclined
+	}
+
+	// 3. Charge the payment method
+	intent, err := s.paymentProvider.CreateIntent(ctx, payments.IntentParams{
+		AmountCents:     payment.AmountCents,
+		Currency:        payment.Currency,
+		PaymentMethodID: req.PaymentMethodID,
+		IdempotencyKey:  payment.IdempotencyKey,
+		Metadata:        map[string]string{"order_id": order.ID, "payment_id": payment.ID},
+	})
+	if err != nil {
+		if errors.Is(err, payments.ErrCardDeclined) {
+			reason := err.Error()
+			if _, err := s.orderRepo.UpdatePayment(ctx, payment.ID, nil, domain.PaymentStatusFailed, &reason); err != nil {
+				log.Printf("Failed to mark payment %s as failed: %v", payment.ID, err)
+			}
+			return nil, models.ErrPaymentDeclined
+		}
+		// The payment stays pending so the same idempotency key can be retried.
+		return nil, fmt.Errorf("service.PayOrder.CreateIntent: %w", err)
+	}
+
+	// 4. Apply the provider's answer to the payment and the order
+	payment, err = s.settlePayment(ctx, payment, intent)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status == domain.PaymentStatusFailed {
+		return nil, models.ErrPaymentDeclined
+	}
+	return payment, nil
+}
+
+// createPayment records a payment attempt for a pending order, or returns the
+// existing one with the same idempotency key. The order row is locked so that
+// concurrent attempts with different payment methods cannot both go ahead.
+// It returns models.ErrPaymentInProgress when another attempt is processing
+// or was captured.
+func (s *Service) createPayment(ctx context.Context, orderID, paymentMethodID string, payment *domain.Payment) (*domain.Payment, error) {
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := s.orderRepo.WithTx(tx)
+	order, err := txRepo.FindByIDForUpdate(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.createPayment.FindByIDForUpdate: %w", err)
+	}
+	if order.Status != domain.OrderStatusPending {
+		return nil, models.ErrOrderCannotBePaid
+	}
+
+	failed, err := txRepo.CountFailedPayments(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.createPayment.CountFailedPayments: %w", err)
+	}
+	payment.IdempotencyKey = paymentIdempotencyKey(orderID, paymentMethodID, failed)
+
+	active, err := txRepo.FindActivePayment(ctx, orderID)
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		return nil, fmt.Errorf("service.createPayment.FindActivePayment: %w", err)
+	}
+	if active != nil && active.IdempotencyKey != payment.IdempotencyKey {
+		return nil, models.ErrPaymentInProgress
+	}
+
+	created, err := txRepo.CreatePayment(ctx, payment)
+	if err != nil {
+		return nil, fmt.Errorf("service.createPayment.CreatePayment: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// settlePayment records the state of a provider intent on the payment and,
+// when the funds were captured, moves the order from pending to paid in the
+// same transaction.
+func (s *Service) settlePayment(ctx context.Context, payment *domain.Payment, intent *payments.Intent) (*domain.Payment, error) {
+	var failureReason *string
+	if intent.FailureReason != "" {
+		failureReason = &intent.FailureReason
+	}
+
+	switch intent.Status {
+	case payments.IntentStatusSucceeded:
+		tx, err := s.orderRepo.BeginTx(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback(ctx)
+
+		txRepo := s.orderRepo.WithTx(tx)
+		order, err := txRepo.FindByIDForUpdate(ctx, payment.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("service.settlePayment.FindOrder: %w", err)
+		}
+
+		updated, err := txRepo.UpdatePayment(ctx, payment.ID, &intent.ID, domain.PaymentStatusSucceeded, nil)
+		if err != nil {
+			return nil, fmt.Errorf("service.settlePayment.UpdatePayment: %w", err)
+		}
+
+		switch order.Status {
+		case domain.OrderStatusPending:
+			if err := txRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusPending, domain.OrderStatusPaid); err != nil {
+				return nil, fmt.Errorf("service.settlePayment.UpdateStatus: %w", err)
+			}
+		case domain.OrderStatusCancelled:
+			// The customer cancelled while the charge was in flight; the
+			// payment is refunded once it is recorded as captured.
+		default:
+			log.Printf("WARN: payment %s captured for order %s in status %s", payment.ID, order.ID, order.Status)
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		if order.Status == domain.OrderStatusCancelled {
+			s.refundCancelledCapture(ctx, order)
+		}
+		return updated, nil
+
+	case payments.IntentStatusRequiresPaymentMethod, payments.IntentStatusCanceled:
+		updated, err := s.orderRepo.UpdatePayment(ctx, payment.ID, &intent.ID, domain.PaymentStatusFailed, failureReason)
+		if err != nil {
+			return nil, fmt.Errorf("service.settlePayment.UpdatePayment: %w", err)
+		}
+		return updated, nil
+
+	default:
+		// processing or requires_action: the outcome will arrive asynchronously.
+		updated, err := s.orderRepo.UpdatePayment(ctx, payment.ID, &intent.ID, domain.PaymentStatusPending, nil)
+		if err != nil {
+			return nil, fmt.Errorf("service.settlePayment.UpdatePayment: %w", err)
+		}
+		return updated, nil
+	}
+}
+
+// refundCancelledCapture refunds a payment that was captured after its order
+// had been cancelled. The order was cancelled before it was paid, so the
+// refund policy returns the whole amount.
+func (s *Service) refundCancelledCapture(ctx context.Context, order *domain.Order) {
+	refund, err := s.refundPayment(ctx, order, domain.OrderStatusPending, domain.RefundReasonCustomerCancelled, nil)
+	if err != nil {
+		// The failed refund is recorded and can be retried by an admin.
+		log.Printf("ERROR: Failed to refund payment captured for cancelled order %s: %v", order.ID, err)
+		return
+	}
+	log.Printf("INFO: Refunded %d cents captured for cancelled order %s", refund.AmountCents, order.ID)
+}
+
+// HandlePaymentEvent applies an authenticated provider webhook event to the
+// matching payment. Events are deduplicated by ID, and every transition is
+// safe to apply twice, so concurrent redeliveries cannot corrupt state.
+func (s *Service) HandlePaymentEvent(ctx context.Context, event *payments.Event) error {
+	// 1. Drop events we have already processed
+	processed, err := s.orderRepo.WebhookEventExists(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("service.HandlePaymentEvent.WebhookEventExists: %w", err)
+	}
+	if processed {
+		log.Printf("INFO: Skipping duplicate webhook event %s", event.ID)
+		return nil
+	}
+
+	// 2. Find the payment the event refers to
+	payment, err := s.findPaymentForEvent(ctx, event)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			// Not one of ours (e.g. a charge created from the dashboard); acknowledge it.
+			log.Printf("INFO: No payment found for webhook event %s (%s)", event.ID, event.Type)
+			return s.orderRepo.RecordWebhookEvent(ctx, event.ID, s.paymentProvider.Name(), event.Type)
+		}
+		return fmt.Errorf("service.HandlePaymentEvent.FindPayment: %w", err)
+	}
+
+	// 3. Drive the payment (and order) state
+	switch event.Type {
+	case payments.EventPaymentIntentSucceeded, payments.EventPaymentIntentFailed:
+		// Events can arrive out of order; never move a captured, refunded or
+		// disputed payment back to an earlier state.
+		if event.Intent != nil && (payment.Status == domain.PaymentStatusPending || payment.Status == domain.PaymentStatusFailed) {
+			if _, err := s.settlePayment(ctx, payment, event.Intent); err != nil {
+				return err
+			}
+		}
+	case payments.EventChargeRefunded:
+		if event.Refunded {
+			if _, err := s.orderRepo.UpdatePayment(ctx, payment.ID, nil, domain.PaymentStatusRefunded, nil); err != nil {
+				return fmt.Errorf("service.HandlePaymentEvent.UpdatePayment: %w", err)
+			}
+		}
+	case payments.EventChargeDisputeCreated:
+		if _, err := s.orderRepo.UpdatePayment(ctx, payment.ID, nil, domain.PaymentStatusDisputed, nil); err != nil {
+			return fmt.Errorf("service.HandlePaymentEvent.UpdatePayment: %w", err)
+		}
+		log.Printf("WARN: Payment %s for order %s has been disputed", payment.ID, payment.OrderID)
+	default:
+		log.Printf("INFO: Ignoring unhandled webhook event type %s", event.Type)
+	}
+
+	// 4. Remember the event so redeliveries are ignored
+	return s.orderRepo.RecordWebhookEvent(ctx, event.ID, s.paymentProvider.Name(), event.Type)
+}
+
+// findPaymentForEvent looks a payment up by provider intent ID, falling back to
+// the payment ID we attach as metadata in case the intent ID was never stored.
+func (s *Service) findPaymentForEvent(ctx context.Context, event *payments.Event) (*domain.Payment, error) {
+	if event.PaymentIntentID != "" {
+		payment, err := s.orderRepo.FindPaymentByProviderPaymentID(ctx, s.paymentProvider.Name(), event.PaymentIntentID)
+		if err == nil || !errors.Is(err, models.ErrNotFound) {
+			return payment, err
+		}
+	}
+	if paymentID := event.Metadata["payment_id"]; paymentID != "" {
+		return s.orderRepo.FindPaymentByID(ctx, paymentID)
+	}
+	return nil, models.ErrNotFound
+}
+
+// CancelOrder cancels an order on behalf of its owner and refunds any captured
+// payment according to the refund policy. Orders that have been picked up can
+// no longer be cancelled.
+func (s *Service) CancelOrder(ctx context.Context, userID, orderID string) (*domain.Order, *domain.Refund, error) {
+	// 1. Load the order and make sure it belongs to the caller
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.CancelOrder.FindByID: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, nil, models.ErrNotFound
+	}
+
+	switch order.Status {
+	case domain.OrderStatusPending, domain.OrderStatusPaid, domain.OrderStatusAssigned:
+	default:
+		return nil, nil, models.ErrOrderCannotBeCancelled
+	}
+
+	// 2. Cancel the order, guarding against a concurrent status change (e.g. pickup).
+	// An assigned order also gives up its place on the machine's trip.
+	previousStatus := order.Status
+	if previousStatus == domain.OrderStatusAssigned {
+		err = s.cancelAssignedOrder(ctx, order)
+	} else {
+		err = s.orderRepo.UpdateStatus(ctx, order.ID, previousStatus, domain.OrderStatusCancelled)
+	}
+	if err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			return nil, nil, models.ErrOrderCannotBeCancelled
+		}
+		return nil, nil, fmt.Errorf("service.CancelOrder: %w", err)
+	}
+	order.Status = domain.OrderStatusCancelled
+
+	// 3. Hand the reserved stock back to the inventory
+	if err := s.inventory.ReleaseStock(ctx, order.ID); err != nil {
+		log.Printf("ERROR: Failed to release stock of cancelled order %s: %v", order.ID, err)
+	}
+
+	// 4. Refund the customer if they already paid
+	if previousStatus == domain.OrderStatusPending {
+		return order, nil, nil
+	}
+	refund, err := s.refundPayment(ctx, order, previousStatus, domain.RefundReasonCustomerCancelled, nil)
+	if err != nil {
+		// The cancellation stands; the failed refund is recorded and can be retried by an admin.
+		log.Printf("ERROR: Failed to refund cancelled order %s: %v", order.ID, err)
+		return order, nil, nil
+	}
+	return order, refund, nil
+}
+
+// RefundOrder issues an admin-initiated refund. Without an explicit amount,
+// the refund policy decides how much is returned.
+func (s *Service) RefundOrder(ctx context.Context, orderID string, req domain.RefundRequest) (*domain.Refund, error) {
+	switch req.Reason {
+	case domain.RefundReasonCustomerCancelled, domain.RefundReasonFailedDelivery, domain.RefundReasonAdmin:
+	default:
+		return nil, fmt.Errorf("service.RefundOrder: unknown refund reason %q", req.Reason)
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.RefundOrder.FindByID: %w", err)
+	}
+
+	return s.refundPayment(ctx, order, order.Status, req.Reason, req.AmountCents)
+}
+
+// refundPayment refunds the captured payment of an order. amountOverride, when
+// set, replaces the policy amount but may never exceed what is left to refund.
+func (s *Service) refundPayment(ctx context.Context, order *domain.Order, orderStatus, reason string, amountOverride *int64) (*domain.Refund, error) {
+	// 1. Work out how much can still be refunded
+	payment, err := s.orderRepo.FindRefundablePayment(ctx, order.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ErrOrderNotRefundable
+		}
+		return nil, fmt.Errorf("service.refundPayment.FindRefundablePayment: %w", err)
+	}
+	if payment.ProviderPaymentID == nil {
+		return nil, models.ErrOrderNotRefundable
+	}
+	refunded, attempts, err := s.orderRepo.SumRefundedCents(ctx, payment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.refundPayment.SumRefundedCents: %w", err)
+	}
+	remaining := payment.AmountCents - refunded
+
+	amount := s.refundPolicy.RefundableCents(orderStatus, reason, payment.AmountCents) - refunded
+	if amountOverride != nil {
+		amount = *amountOverride
+	}
+	if amount <= 0 || amount > remaining {
+		return nil, models.ErrOrderNotRefundable
+	}
+
+	// 2. Record the refund before calling the provider
+	refund, err := s.orderRepo.CreateRefund(ctx, &domain.Refund{
+		PaymentID:      payment.ID,
+		OrderID:        order.ID,
+		IdempotencyKey: fmt.Sprintf("refund_%s_%d", payment.ID, attempts+1),
+		AmountCents:    amount,
+		Currency:       payment.Currency,
+		Reason:         reason,
+		Status:         domain.RefundStatusPending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service.refundPayment.CreateRefund: %w", err)
+	}
+
+	// 3. Ask the provider to return the money
+	providerRefund, err := s.paymentProvider.CreateRefund(ctx, payments.RefundParams{
+		IntentID:       *payment.ProviderPaymentID,
+		AmountCents:    amount,
+		IdempotencyKey: refund.IdempotencyKey,
+		Metadata:       map[string]string{"order_id": order.ID, "refund_id": refund.ID},
+	})
+	if err != nil {
+		if _, updateErr := s.orderRepo.UpdateRefund(ctx, refund.ID, nil, domain.RefundStatusFailed); updateErr != nil {
+			log.Printf("Failed to mark refund %s as failed: %v", refund.ID, updateErr)
+		}
+		return nil, fmt.Errorf("service.refundPayment.CreateRefund: %w", err)
+	}
+
+	refundStatus := domain.RefundStatusPending
+	switch providerRefund.Status {
+	case payments.RefundStatusSucceeded:
+		refundStatus = domain.RefundStatusSucceeded
+	case payments.RefundStatusFailed, payments.RefundStatusCanceled:
+		refundStatus = domain.RefundStatusFailed
+	}
+
+	// 4. Update the refund and the payment together
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := s.orderRepo.WithTx(tx)
+	refund, err = txRepo.UpdateRefund(ctx, refund.ID, &providerRefund.ID, refundStatus)
+	if err != nil {
+		return nil, fmt.Errorf("service.refundPayment.UpdateRefund: %w", err)
+	}
+	if refundStatus != domain.RefundStatusFailed {
+		paymentStatus := domain.PaymentStatusPartiallyRefunded
+		if refunded+amount >= payment.AmountCents {
+			paymentStatus = domain.PaymentStatusRefunded
+		}
+		if _, err := txRepo.UpdatePayment(ctx, payment.ID, nil, paymentStatus, payment.FailureReason); err != nil {
+			return nil, fmt.Errorf("service.refundPayment.UpdatePayment: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	if refund.Status != domain.RefundStatusFailed {
+		s.sendRefundEmail(ctx, order, refund)
+	}
+	return refund, nil
+}
+
+// sendRefundEmail notifies the customer about a refund without blocking the caller.
+func (s *Service) sendRefundEmail(ctx context.Context, order *domain.Order, refund *domain.Refund) {
+	email, nickname, err := s.orderRepo.FindUserContact(ctx, order.UserID)
+	if err != nil {
+		log.Printf("Failed to look up contact for refund email on order %s: %v", order.ID, err)
+		return
+	}
+
+	amount := fmt.Sprintf("%.2f %s", float64(refund.AmountCents)/100, strings.ToUpper(refund.Currency))
+	reason := refundReasonText(refund.Reason)
+
+	htmlContent, err := s.templateManager.GenerateRefundEmailHTML(emailSvc.RefundTemplateData{
+		Name:    nickname,
+		OrderID: order.ID,
+		Amount:  amount,
+		Reason:  reason,
+	})
+	if err != nil {
+		log.Printf("Failed to generate refund email HTML: %v", err)
+		return
+	}
+
+	emailSubject := "[Circuit] Your Refund Has Been Issued"
+	plainTextContent := fmt.Sprintf("We have issued a refund of %s for your order %s. Reason: %s", amount, order.ID, reason)
+
+	go func() {
+		// Run in a goroutine so it doesn't block the refund response
+		err := s.emailer.SendEmail(context.Background(), email, emailSubject, plainTextContent, htmlContent)
+		if err != nil {
+			log.Printf("Failed to send refund email to %s: %v", email, err)
+		}
+	}()
+}
+
+func refundReasonText(reason string) string {
+	switch reason {
+	case domain.RefundReasonCustomerCancelled:
+		return "you cancelled the order"
+	case domain.RefundReasonFailedDelivery:
+		return "we were unable to deliver your order"
+	default:
+		return "adjustment by our support team"
+	}
+}
+
+// ConfirmPickup records that the machine assigned to an order has collected
+// its package, which puts the order in transit.
+func (s *Service) ConfirmPickup(ctx context.Context, machineID, orderID string) (*domain.Order, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ConfirmPickup.FindByID: %w", err)
+	}
+	if order.MachineID == nil || *order.MachineID != machineID {
+		return nil, models.ErrNotFound
+	}
+	if order.Status != domain.OrderStatusAssigned {
+		return nil, models.ErrOrderNotAssigned
+	}
+
+	if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusAssigned, domain.OrderStatusInTransit); err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			return nil, models.ErrOrderNotAssigned
+		}
+		return nil, fmt.Errorf("service.ConfirmPickup.UpdateStatus: %w", err)
+	}
+	order.Status = domain.OrderStatusInTransit
+	return order, nil
+}
+
+// SubmitProofOfDelivery records the evidence of a handoff at one stop of an
+// order, reported by the machine carrying it. The GPS fix must come from the
+// machine's own tracking events for this order and lie within
+// ProofRadiusMeters of the stop's address. Orders dispatched with a handoff
+// PIN are only released when the recipient's PIN matches. Once no stop is
+// pending, the order is delivered, or returned if some stops failed.
+func (s *Service) SubmitProofOfDelivery(ctx context.Context, machineID, orderID string, req domain.ProofOfDeliveryRequest) (*domain.ProofOfDelivery, error) {
+	// 1. Only the machine carrying the order can deliver it
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.SubmitProofOfDelivery.FindByID: %w", err)
+	}
+	if order.MachineID == nil || *order.MachineID != machineID {
+		return nil, models.ErrNotFound
+	}
+	if order.Status != domain.OrderStatusInTransit {
+		return nil, models.ErrOrderNotInTransit
+	}
+	stops, err := s.orderRepo.ListOrderStops(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.SubmitProofOfDelivery.ListOrderStops: %w", err)
+	}
+	stop, err := findReportedStop(stops, req.StopID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. The GPS fix must be a recent tracking event of this machine and order
+	event, err := s.orderRepo.FindTrackingEvent(ctx, req.TrackingEventID)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ErrInvalidProofOfDelivery
+		}
+		return nil, fmt.Errorf("service.SubmitProofOfDelivery.FindTrackingEvent: %w", err)
+	}
+	if event.OrderID != order.ID || event.MachineID != machineID || (event.StopID != nil && *event.StopID != stop.ID) {
+		return nil, models.ErrInvalidProofOfDelivery
+	}
+	const clockSkew = time.Minute
+	if req.DeliveredAt.After(time.Now().Add(clockSkew)) ||
+		event.CreatedAt.After(req.DeliveredAt.Add(clockSkew)) ||
+		req.DeliveredAt.Sub(event.CreatedAt) > domain.MaxProofFixAge {
+		return nil, models.ErrInvalidProofOfDelivery
+	}
+
+	// 3. The recipient must confirm the handoff with their PIN
+	handoff, err := s.verifyHandoffPIN(ctx, order.ID, req.RecipientPIN)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &domain.ProofOfDelivery{
+		OrderID:         order.ID,
+		StopID:          stop.ID,
+		MachineID:       machineID,
+		PhotoRef:        req.PhotoRef,
+		TrackingEventID: event.ID,
+		Latitude:        event.Latitude,
+		Longitude:       event.Longitude,
+		FixRecordedAt:   event.CreatedAt,
+		DeliveredAt:     req.DeliveredAt,
+		PINVerified:     handoff != nil,
+	}
+
+	// 4. Check the fix against the stop's address
+	dropoff, err := s.orderRepo.FindAddressLocation(ctx, stop.AddressID)
+	if err != nil {
+		return nil, fmt.Errorf("service.SubmitProofOfDelivery.FindAddressLocation: %w", err)
+	}
+	if dropoff != nil {
+		distance := geo.DistanceMeters(*dropoff, geo.Point{Latitude: event.Latitude, Longitude: event.Longitude})
+		if distance > domain.ProofRadiusMeters {
+			return nil, models.ErrOutsideDropoffRadius
+		}
+		proof.DistanceMeters = &distance
+		proof.LocationVerified = true
+	} else {
+		// Without coordinates the handoff is accepted but flagged as unverified for disputes.
+		log.Printf("WARN: Address of stop %s of order %s is not geocoded, proof of delivery location is unverified", stop.ID, order.ID)
+	}
+
+	// 5. Store the proof and complete the stop, and the order after its last stop, atomically
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.orderRepo.WithTx(tx)
+
+	created, err := txRepo.CreateProofOfDelivery(ctx, proof)
+	if err != nil {
+		return nil, fmt.Errorf("service.SubmitProofOfDelivery.CreateProofOfDelivery: %w", err)
+	}
+	if err := txRepo.UpdateStopStatus(ctx, stop.ID, domain.StopStatusDelivered, &req.DeliveredAt); err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			return nil, models.ErrStopNotPending
+		}
+		return nil, fmt.Errorf("service.SubmitProofOfDelivery.UpdateStopStatus: %w", err)
+	}
+	if countStops(stops, domain.StopStatusPending, stop.ID) == 0 {
+		finalStatus := domain.OrderStatusDelivered
+		if countStops(stops, domain.StopStatusFailed, stop.ID) > 0 {
+			finalStatus = domain.OrderStatusReturning
+		}
+		if err := txRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusInTransit, finalStatus); err != nil {
+			if errors.Is(err, models.ErrConflict) {
+				return nil, models.ErrOrderNotInTransit
+			}
+			return nil, fmt.Errorf("service.SubmitProofOfDelivery.UpdateStatus: %w", err)
+		}
+		if handoff != nil {
+			if err := txRepo.MarkHandoffVerified(ctx, order.ID); err != nil {
+				return nil, fmt.Errorf("service.SubmitProofOfDelivery.MarkHandoffVerified: %w", err)
+			}
+		}
+	}
+	if stop.TripID != nil {
+		if err := txRepo.CompleteTripIfDone(ctx, *stop.TripID); err != nil {
+			return nil, fmt.Errorf("service.SubmitProofOfDelivery.CompleteTripIfDone: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	// 6. Free the machine once its trip is over
+	s.releaseMachine(ctx, machineID)
+	return created, nil
+}
+
+// GetProofOfDelivery returns the delivery evidence of one of the caller's
+// orders, one proof per delivered stop.
+func (s *Service) GetProofOfDelivery(ctx context.Context, userID, orderID string) ([]domain.ProofOfDelivery, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetProofOfDelivery.FindByID: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, models.ErrNotFound
+	}
+
+	proofs, err := s.orderRepo.ListProofsOfDelivery(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetProofOfDelivery: %w", err)
+	}
+	if len(proofs) == 0 {
+		return nil, models.ErrNotFound
+	}
+	return proofs, nil
+}
+
+// SubmitFeedback records the customer's rating of a delivered order.
+// Each order accepts feedback exactly once.
+func (s *Service) SubmitFeedback(ctx context.Context, userID, orderID string, req domain.FeedbackRequest) (*domain.Feedback, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.SubmitFeedback.FindByID: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, models.ErrNotFound
+	}
+	if order.Status != domain.OrderStatusDelivered {
+		return nil, models.ErrCannotSubmitFeedback
+	}
+
+	// The unique constraint on feedback.order_id enforces once-per-order, even under concurrent submissions.
+	feedback, err := s.orderRepo.CreateFeedback(ctx, order.ID, req)
+	if err != nil {
+		if errors.Is(err, models.ErrFeedbackAlreadySubmitted) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("service.SubmitFeedback.CreateFeedback: %w", err)
+	}
+	return feedback, nil
+}
+
+// GetMachineRatingStats aggregates delivery ratings per machine and per machine type,
+// so fleet operators can spot machines with consistently bad deliveries.
+func (s *Service) GetMachineRatingStats(ctx context.Context, filter domain.RatingStatsFilter) ([]domain.MachineRatingStats, []domain.MachineTypeRatingStats, error) {
+	perMachine, err := s.orderRepo.ListMachineRatingStats(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.GetMachineRatingStats.PerMachine: %w", err)
+	}
+
+	perType, err := s.orderRepo.ListMachineTypeRatingStats(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.GetMachineRatingStats.PerType: %w", err)
+	}
+
+	return perMachine, perType, nil
+}
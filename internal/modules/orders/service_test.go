@@ -0,0 +1,269 @@
+package orders
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	itemDomain "dispatch-and-delivery/internal/modules/items/domain"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	trackingDomain "dispatch-and-delivery/internal/modules/tracking/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeRepo keeps orders in memory. Methods a test does not override panic
+// through the nil embedded interface.
+type fakeRepo struct {
+	RepositoryInterface
+	orders    map[string]*domain.Order
+	feedback  map[string]*domain.Feedback
+	events    map[string]*trackingDomain.TrackingEvent
+	locations map[string]*geo.Point // keyed by address ID
+}
+
+func newFakeRepo(orders ...*domain.Order) *fakeRepo {
+	r := &fakeRepo{
+		orders:    make(map[string]*domain.Order),
+		feedback:  make(map[string]*domain.Feedback),
+		events:    make(map[string]*trackingDomain.TrackingEvent),
+		locations: make(map[string]*geo.Point),
+	}
+	for _, o := range orders {
+		r.orders[o.ID] = o
+	}
+	return r
+}
+
+func (r *fakeRepo) FindByID(_ context.Context, orderID string) (*domain.Order, error) {
+	order, ok := r.orders[orderID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	copied := *order
+	return &copied, nil
+}
+
+// UpdateStatus only changes orders still in fromStatus, like the database does.
+func (r *fakeRepo) UpdateStatus(_ context.Context, orderID, fromStatus, toStatus string) error {
+	order, ok := r.orders[orderID]
+	if !ok || order.Status != fromStatus {
+		return models.ErrConflict
+	}
+	order.Status = toStatus
+	return nil
+}
+
+func (r *fakeRepo) FindTrackingEvent(_ context.Context, eventID string) (*trackingDomain.TrackingEvent, error) {
+	event, ok := r.events[eventID]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return event, nil
+}
+
+func (r *fakeRepo) FindAddressLocation(_ context.Context, addressID string) (*geo.Point, error) {
+	return r.locations[addressID], nil
+}
+
+func (r *fakeRepo) FindHandoffCode(context.Context, string) (*domain.HandoffCode, error) {
+	return nil, models.ErrNotFound
+}
+
+// ListOrderStops returns a single pending stop at the order's dropoff address.
+func (r *fakeRepo) ListOrderStops(_ context.Context, orderID string) ([]domain.Stop, error) {
+	order, ok := r.orders[orderID]
+	if !ok {
+		return nil, nil
+	}
+	return []domain.Stop{{
+		ID: orderID + "-stop-1", OrderID: orderID, Sequence: 1,
+		AddressID: order.DropoffAddressID, Status: domain.StopStatusPending,
+	}}, nil
+}
+
+func (r *fakeRepo) CreateFeedback(_ context.Context, orderID string, req domain.FeedbackRequest) (*domain.Feedback, error) {
+	if _, ok := r.feedback[orderID]; ok {
+		return nil, models.ErrFeedbackAlreadySubmitted
+	}
+	feedback := &domain.Feedback{OrderID: orderID, Rating: req.Rating, Comment: req.Comment}
+	r.feedback[orderID] = feedback
+	return feedback, nil
+}
+
+func TestSubmitFeedback(t *testing.T) {
+	repo := newFakeRepo(
+		&domain.Order{ID: "delivered", UserID: "alice", Status: domain.OrderStatusDelivered},
+		&domain.Order{ID: "in-transit", UserID: "alice", Status: domain.OrderStatusInTransit},
+	)
+	s := &Service{orderRepo: repo}
+	ctx := context.Background()
+	req := domain.FeedbackRequest{Rating: 4, Comment: "on time"}
+
+	if _, err := s.SubmitFeedback(ctx, "bob", "delivered", req); !errors.Is(err, models.ErrNotFound) {
+		t.Errorf("feedback on another user's order = %v, want ErrNotFound", err)
+	}
+	if _, err := s.SubmitFeedback(ctx, "alice", "in-transit", req); !errors.Is(err, models.ErrCannotSubmitFeedback) {
+		t.Errorf("feedback on an undelivered order = %v, want ErrCannotSubmitFeedback", err)
+	}
+
+	feedback, err := s.SubmitFeedback(ctx, "alice", "delivered", req)
+	if err != nil {
+		t.Fatalf("SubmitFeedback: %v", err)
+	}
+	if feedback.Rating != 4 || feedback.OrderID != "delivered" {
+		t.Errorf("feedback = %+v, want a 4 star rating of the delivered order", feedback)
+	}
+	if _, err := s.SubmitFeedback(ctx, "alice", "delivered", req); !errors.Is(err, models.ErrFeedbackAlreadySubmitted) {
+		t.Errorf("second feedback = %v, want ErrFeedbackAlreadySubmitted", err)
+	}
+}
+
+func TestBuildManifest(t *testing.T) {
+	catalog := []itemDomain.Item{
+		{SKU: "BOOK", Name: "Book", UnitWeightKg: 0.5},
+		{SKU: "BATT", Name: "Lithium battery", UnitWeightKg: 0.2, Hazmat: true, HazmatClass: "9"},
+	}
+	requested := []domain.OrderItem{
+		{SKU: "BOOK", Quantity: 2, DeclaredValue: 20},
+		{SKU: "BATT", Quantity: 1, DeclaredValue: 15},
+		{SKU: "BOOK", Quantity: 1, DeclaredValue: 10},
+	}
+
+	items, err := buildManifest(requested, catalog)
+	if err != nil {
+		t.Fatalf("buildManifest: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d manifest lines, want duplicate SKUs merged into 2", len(items))
+	}
+	book, batt := items[0], items[1]
+	if book.SKU != "BOOK" || book.Quantity != 3 || book.DeclaredValue != 30 || book.Name != "Book" {
+		t.Errorf("book line = %+v, want 3 books declared at 30", book)
+	}
+	if !batt.Hazmat || batt.HazmatClass != "9" {
+		t.Errorf("battery line = %+v, want the hazmat flags copied from the catalog", batt)
+	}
+	if got := domain.TotalWeightKg(items); math.Abs(got-1.7) > 1e-9 {
+		t.Errorf("TotalWeightKg = %v, want 1.7", got)
+	}
+
+	if _, err := buildManifest([]domain.OrderItem{{SKU: "LAMP", Quantity: 1}}, catalog); err == nil {
+		t.Error("buildManifest accepted a SKU missing from the reservation")
+	}
+}
+
+func TestValidateManifest(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []domain.OrderItem
+		ok    bool
+	}{
+		{"valid", []domain.OrderItem{{SKU: "BOOK", Quantity: 1, DeclaredValue: 10}}, true},
+		{"empty", nil, false},
+		{"missing sku", []domain.OrderItem{{Quantity: 1}}, false},
+		{"zero quantity", []domain.OrderItem{{SKU: "BOOK"}}, false},
+		{"negative value", []domain.OrderItem{{SKU: "BOOK", Quantity: 1, DeclaredValue: -1}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateManifest(tt.items)
+			if tt.ok && err != nil {
+				t.Errorf("validateManifest = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, models.ErrInvalidManifest) {
+				t.Errorf("validateManifest = %v, want ErrInvalidManifest", err)
+			}
+		})
+	}
+}
+
+func TestValidatePickupWindow(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	window := func(start time.Time, length time.Duration) domain.PickupWindow {
+		return domain.PickupWindow{Start: start, End: start.Add(length)}
+	}
+
+	tests := []struct {
+		name   string
+		window domain.PickupWindow
+		ok     bool
+	}{
+		{"tomorrow", window(now.Add(24*time.Hour), time.Hour), true},
+		{"in the past", window(now.Add(-time.Hour), 2*time.Hour), false},
+		{"ends before it starts", window(now.Add(time.Hour), -time.Minute), false},
+		{"beyond the lead time", window(now.Add(domain.MaxScheduleLeadTime+time.Hour), time.Hour), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePickupWindow(tt.window, now)
+			if tt.ok && err != nil {
+				t.Errorf("validatePickupWindow = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, models.ErrInvalidPickupWindow) {
+				t.Errorf("validatePickupWindow = %v, want ErrInvalidPickupWindow", err)
+			}
+		})
+	}
+}
+
+func TestSubmitProofOfDeliveryRejectsBadEvidence(t *testing.T) {
+	machine := "robot-1"
+	now := time.Now()
+	dropoff := geo.Point{Latitude: 52.5200, Longitude: 13.4050}
+
+	repo := newFakeRepo(
+		&domain.Order{ID: "order-1", UserID: "alice", MachineID: &machine, DropoffAddressID: "addr-1", Status: domain.OrderStatusInTransit},
+		&domain.Order{ID: "assigned", UserID: "alice", MachineID: &machine, DropoffAddressID: "addr-1", Status: domain.OrderStatusAssigned},
+	)
+	repo.locations["addr-1"] = &dropoff
+	fix := func(id, orderID string, lat float64, age time.Duration) {
+		repo.events[id] = &trackingDomain.TrackingEvent{
+			ID: id, OrderID: orderID, MachineID: machine,
+			Latitude: lat, Longitude: dropoff.Longitude, CreatedAt: now.Add(-age),
+		}
+	}
+	fix("at-door", "order-1", dropoff.Latitude, time.Minute)
+	fix("other-order", "assigned", dropoff.Latitude, time.Minute)
+	fix("stale", "order-1", dropoff.Latitude, domain.MaxProofFixAge+time.Minute)
+	fix("down-the-road", "order-1", dropoff.Latitude+0.001, time.Minute) // about 111 m north
+
+	s := &Service{orderRepo: repo}
+	tests := []struct {
+		name    string
+		machine string
+		orderID string
+		eventID string
+		want    error
+	}{
+		{"another machine", "robot-2", "order-1", "at-door", models.ErrNotFound},
+		{"order not picked up", machine, "assigned", "other-order", models.ErrOrderNotInTransit},
+		{"unknown tracking event", machine, "order-1", "missing", models.ErrInvalidProofOfDelivery},
+		{"fix of another order", machine, "order-1", "other-order", models.ErrInvalidProofOfDelivery},
+		{"stale fix", machine, "order-1", "stale", models.ErrInvalidProofOfDelivery},
+		{"outside the dropoff radius", machine, "order-1", "down-the-road", models.ErrOutsideDropoffRadius},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := domain.ProofOfDeliveryRequest{PhotoRef: "s3://proofs/1.jpg", TrackingEventID: tt.eventID, DeliveredAt: now}
+			if _, err := s.SubmitProofOfDelivery(context.Background(), tt.machine, tt.orderID, req); !errors.Is(err, tt.want) {
+				t.Errorf("SubmitProofOfDelivery = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaymentIdempotencyKey(t *testing.T) {
+	first := paymentIdempotencyKey("order-1", "pm_card", 0)
+	if again := paymentIdempotencyKey("order-1", "pm_card", 0); again != first {
+		t.Errorf("retry with the same card = %q, want the original key %q", again, first)
+	}
+	// Once the charge was declined, the same card starts a new attempt.
+	if retry := paymentIdempotencyKey("order-1", "pm_card", 1); retry == first {
+		t.Errorf("retry after a declined payment reuses key %q", retry)
+	}
+	if other := paymentIdempotencyKey("order-1", "pm_other", 0); other == first {
+		t.Errorf("another payment method reuses key %q", other)
+	}
+}
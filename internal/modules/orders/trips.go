@@ -0,0 +1,480 @@
+package orders
+
+import (
+	"context"
+	"dispatch-and-delivery/internal/models"
+	"dispatch-and-delivery/internal/modules/dispatch"
+	dispatchDomain "dispatch-and-delivery/internal/modules/dispatch/domain"
+	fleetDomain "dispatch-and-delivery/internal/modules/fleet/domain"
+	domain "dispatch-and-delivery/internal/modules/orders/domain"
+	"dispatch-and-delivery/pkg/geo"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// AssignMachine dispatches a single order to a machine on a trip of its own.
+func (s *Service) AssignMachine(ctx context.Context, orderID, machineID string) (*domain.Order, error) {
+	_, orders, err := s.assignTrip(ctx, []string{orderID}, machineID, false)
+	if err != nil {
+		return nil, err
+	}
+	return orders[0], nil
+}
+
+// DispatchOrder is AssignMachine for the dispatch engine: the machine must be
+// idle, and the fleet claims it by moving it into transit before the order is
+// assigned, so two orders cannot be given the same machine. It returns
+// models.ErrMachineNotIdle when another order took the machine first.
+func (s *Service) DispatchOrder(ctx context.Context, orderID, machineID string) (*domain.Order, error) {
+	_, orders, err := s.assignTrip(ctx, []string{orderID}, machineID, true)
+	if err != nil {
+		return nil, err
+	}
+	return orders[0], nil
+}
+
+// AssignTrip dispatches a batch of orders to one machine trip. The orders
+// must share their pickup address and machine type and fit the machine's
+// payload together. The pending stops of all orders are sequenced into one
+// route from the pickup.
+func (s *Service) AssignTrip(ctx context.Context, orderIDs []string, machineID string) (*domain.Trip, error) {
+	trip, _, err := s.assignTrip(ctx, orderIDs, machineID, false)
+	return trip, err
+}
+
+// ListDispatchQueue returns the orders the dispatch engine should find a
+// machine for at now, oldest first: paid orders and delivery re-attempts
+// whose pickup window opens within dispatchDomain.DispatchLeadTime. Orders
+// with an address that is not geocoded cannot be scored and are left to
+// manual dispatch.
+func (s *Service) ListDispatchQueue(ctx context.Context, now time.Time, limit int) ([]dispatchDomain.Job, error) {
+	orders, err := s.orderRepo.ListDispatchableOrders(ctx, now.Add(dispatchDomain.DispatchLeadTime), limit)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListDispatchQueue: %w", err)
+	}
+
+	jobs := make([]dispatchDomain.Job, 0, len(orders))
+	for _, order := range orders {
+		job, err := s.dispatchJob(ctx, &order)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			jobs = append(jobs, *job)
+		}
+	}
+	return jobs, nil
+}
+
+// dispatchJob describes an order to the dispatch engine. It returns nil when
+// the pickup or a pending stop is not geocoded.
+func (s *Service) dispatchJob(ctx context.Context, order *domain.Order) (*dispatchDomain.Job, error) {
+	pickup, err := s.orderRepo.FindAddressLocation(ctx, order.PickupAddressID)
+	if err != nil {
+		return nil, fmt.Errorf("service.dispatchJob.FindAddressLocation: %w", err)
+	}
+	if pickup == nil {
+		return nil, nil
+	}
+	stops, err := s.orderRepo.ListOrderStops(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.dispatchJob.ListOrderStops: %w", err)
+	}
+	job := &dispatchDomain.Job{
+		OrderID:     order.ID,
+		MachineType: order.MachineType,
+		WeightKg:    order.ItemWeightKg,
+		Pickup:      *pickup,
+		QueuedAt:    order.CreatedAt,
+	}
+	if order.PickupWindow != nil {
+		job.QueuedAt = order.PickupWindow.Start
+	}
+	for _, stop := range stops {
+		if stop.Status != domain.StopStatusPending {
+			continue
+		}
+		location, err := s.orderRepo.FindAddressLocation(ctx, stop.AddressID)
+		if err != nil {
+			return nil, fmt.Errorf("service.dispatchJob.FindAddressLocation: %w", err)
+		}
+		if location == nil {
+			return nil, nil
+		}
+		job.Dropoffs = append(job.Dropoffs, *location)
+	}
+	return job, nil
+}
+
+// CountAssignedOrders counts the undelivered orders of each machine.
+func (s *Service) CountAssignedOrders(ctx context.Context, machineIDs []string) (map[string]int, error) {
+	counts, err := s.orderRepo.CountAssignedOrders(ctx, machineIDs)
+	if err != nil {
+		return nil, fmt.Errorf("service.CountAssignedOrders: %w", err)
+	}
+	return counts, nil
+}
+
+// assignTrip dispatches paid orders, or orders waiting for a delivery
+// re-attempt, to a machine in service and issues the handoff PIN every
+// recipient must give the machine at the dropoff. The PINs are emailed to the
+// customers and only their hashes are stored; a re-attempt gets a fresh PIN.
+// With claim set, the machine must be idle and is moved into transit.
+func (s *Service) assignTrip(ctx context.Context, orderIDs []string, machineID string, claim bool) (*domain.Trip, []*domain.Order, error) {
+	// Lock the orders in a stable order so concurrent batches cannot deadlock.
+	ids := uniqueSorted(orderIDs)
+	if len(ids) == 0 || len(ids) > domain.MaxOrdersPerTrip {
+		return nil, nil, models.ErrInvalidTrip
+	}
+
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.orderRepo.WithTx(tx)
+
+	// 1. Every order must be ready for dispatch and fit on the same trip
+	orders := make([]*domain.Order, 0, len(ids))
+	var weight float64
+	for _, id := range ids {
+		order, err := txRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("service.assignTrip.FindByIDForUpdate: %w", err)
+		}
+		if order.Status != domain.OrderStatusPaid && order.Status != domain.OrderStatusDeliveryFailed {
+			return nil, nil, models.ErrOrderCannotBeAssigned
+		}
+		if len(orders) > 0 && (order.PickupAddressID != orders[0].PickupAddressID || order.MachineType != orders[0].MachineType) {
+			return nil, nil, models.ErrInvalidTrip
+		}
+		weight += order.ItemWeightKg
+		orders = append(orders, order)
+	}
+	if maxPayload, ok := fleetDomain.MaxPayloadKg[orders[0].MachineType]; ok && weight > maxPayload {
+		return nil, nil, models.ErrPackageTooLarge
+	}
+	// The fleet claims the machine in a transaction of its own. From here on,
+	// failures must hand it back.
+	committed := false
+	if claim {
+		if _, err := s.fleet.ClaimMachine(ctx, machineID); err != nil {
+			return nil, nil, fmt.Errorf("service.assignTrip.ClaimMachine: %w", err)
+		}
+		defer func() {
+			if !committed {
+				s.releaseMachine(context.WithoutCancel(ctx), machineID)
+			}
+		}()
+	} else if err := s.fleet.CheckMachineDispatchable(ctx, machineID); err != nil {
+		return nil, nil, fmt.Errorf("service.assignTrip.CheckMachineDispatchable: %w", err)
+	}
+
+	// 2. Route the machine over the pending stops of all orders
+	var stops []domain.Stop
+	for _, order := range orders {
+		orderStops, err := txRepo.ListOrderStops(ctx, order.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("service.assignTrip.ListOrderStops: %w", err)
+		}
+		for _, stop := range orderStops {
+			if stop.Status == domain.StopStatusPending {
+				stops = append(stops, stop)
+			}
+		}
+	}
+	route, err := s.routeStops(ctx, orders[0].PickupAddressID, stops)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trip, err := txRepo.CreateTrip(ctx, &domain.Trip{
+		MachineID:       machineID,
+		PickupAddressID: orders[0].PickupAddressID,
+		DistanceMeters:  route.distance,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("service.assignTrip.CreateTrip: %w", err)
+	}
+	for i, idx := range route.order {
+		stop := &stops[idx]
+		sequence := i + 1
+		stop.TripID, stop.TripSequence = &trip.ID, &sequence
+		if route.legs != nil {
+			stop.LegDistanceMeters = &route.legs[i]
+		}
+		if err := txRepo.SetStopRoute(ctx, stop.ID, trip.ID, sequence, stop.LegDistanceMeters); err != nil {
+			return nil, nil, fmt.Errorf("service.assignTrip.SetStopRoute: %w", err)
+		}
+		trip.Stops = append(trip.Stops, *stop)
+	}
+
+	// 3. Assign the orders and issue their handoff PINs
+	pins := make([]string, len(orders))
+	expiresAt := time.Now().Add(domain.HandoffCodeTTL)
+	for i, order := range orders {
+		if err := txRepo.AssignMachine(ctx, order.ID, machineID, trip.ID, order.Status); err != nil {
+			if errors.Is(err, models.ErrConflict) {
+				return nil, nil, models.ErrOrderCannotBeAssigned
+			}
+			return nil, nil, fmt.Errorf("service.assignTrip.AssignMachine: %w", err)
+		}
+		pin, hash, err := s.newHandoffPIN(order.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("service.assignTrip.newHandoffPIN: %w", err)
+		}
+		if err := txRepo.UpsertHandoffCode(ctx, order.ID, hash, expiresAt); err != nil {
+			return nil, nil, fmt.Errorf("service.assignTrip.UpsertHandoffCode: %w", err)
+		}
+		pins[i] = pin
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	committed = true
+
+	for i, order := range orders {
+		order.Status = domain.OrderStatusAssigned
+		order.MachineID = &machineID
+		order.TripID = &trip.ID
+		trip.OrderIDs = append(trip.OrderIDs, order.ID)
+		for _, stop := range trip.Stops {
+			if stop.OrderID == order.ID {
+				order.Stops = append(order.Stops, stop)
+			}
+		}
+		s.sendHandoffEmail(ctx, order, pins[i], expiresAt)
+	}
+	return trip, orders, nil
+}
+
+// cancelAssignedOrder cancels an order before its machine picked it up. The
+// order and its stops are taken off the trip, which is deleted once empty, and
+// the machine is handed back to the fleet when it has no trip left.
+func (s *Service) cancelAssignedOrder(ctx context.Context, order *domain.Order) error {
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.orderRepo.WithTx(tx)
+
+	if err := txRepo.CancelAssignment(ctx, order.ID); err != nil {
+		return fmt.Errorf("service.cancelAssignedOrder.CancelAssignment: %w", err)
+	}
+	if err := txRepo.ClearStopRoutes(ctx, order.ID); err != nil {
+		return fmt.Errorf("service.cancelAssignedOrder.ClearStopRoutes: %w", err)
+	}
+	if order.TripID != nil {
+		if err := txRepo.DeleteTripIfEmpty(ctx, *order.TripID); err != nil {
+			return fmt.Errorf("service.cancelAssignedOrder.DeleteTripIfEmpty: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if order.MachineID != nil {
+		s.releaseMachine(ctx, *order.MachineID)
+	}
+	return nil
+}
+
+// releaseMachine hands a machine back to the fleet, which keeps it in transit
+// while it has a trip left. Failures are logged: the machine can still report
+// idle itself.
+func (s *Service) releaseMachine(ctx context.Context, machineID string) {
+	if _, err := s.fleet.ReleaseMachine(ctx, machineID); err != nil {
+		log.Printf("ERROR: Failed to release machine %s: %v", machineID, err)
+	}
+}
+
+// RedispatchOrdersOfUnavailableMachines puts the orders assigned to machines
+// that went offline, into maintenance or were decommissioned back in the
+// dispatch queue, before they were picked up. Their trips are cancelled once
+// empty. Orders already in transit stay with their machine. It returns the
+// number of orders released.
+func (s *Service) RedispatchOrdersOfUnavailableMachines(ctx context.Context) (int, error) {
+	orderIDs, err := s.orderRepo.ListOrdersOnUnavailableMachines(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("service.RedispatchOrdersOfUnavailableMachines: %w", err)
+	}
+
+	released := 0
+	for _, orderID := range orderIDs {
+		if err := s.releaseOrder(ctx, orderID); err != nil {
+			if errors.Is(err, models.ErrConflict) {
+				continue // Picked up or released concurrently
+			}
+			return released, err
+		}
+		released++
+	}
+	return released, nil
+}
+
+// releaseOrder takes an assigned order off its machine and trip.
+func (s *Service) releaseOrder(ctx context.Context, orderID string) error {
+	tx, err := s.orderRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	txRepo := s.orderRepo.WithTx(tx)
+
+	order, err := txRepo.FindByIDForUpdate(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("service.releaseOrder.FindByIDForUpdate: %w", err)
+	}
+	if order.Status != domain.OrderStatusAssigned {
+		return models.ErrConflict
+	}
+	if err := txRepo.ReleaseMachine(ctx, orderID); err != nil {
+		return fmt.Errorf("service.releaseOrder.ReleaseMachine: %w", err)
+	}
+	if err := txRepo.ClearStopRoutes(ctx, orderID); err != nil {
+		return fmt.Errorf("service.releaseOrder.ClearStopRoutes: %w", err)
+	}
+	if order.TripID != nil {
+		if err := txRepo.CancelTripIfEmpty(ctx, *order.TripID); err != nil {
+			return fmt.Errorf("service.releaseOrder.CancelTripIfEmpty: %w", err)
+		}
+		if err := txRepo.CompleteTripIfDone(ctx, *order.TripID); err != nil {
+			return fmt.Errorf("service.releaseOrder.CompleteTripIfDone: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	log.Printf("WARN: Order %s was released from unavailable machine %s for re-dispatch", orderID, *order.MachineID)
+	return nil
+}
+
+// RunRedispatcher releases the orders of unavailable machines every interval
+// until ctx is cancelled.
+func RunRedispatcher(ctx context.Context, s ServiceInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RedispatchOrdersOfUnavailableMachines(ctx); err != nil {
+			log.Printf("ERROR: Re-dispatch of orders on unavailable machines failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopRoute is the visit order of a trip's stops, as indexes into the stops.
+// legs and distance are unset when a stop is not geocoded.
+type stopRoute struct {
+	order    []int
+	legs     []float64
+	distance *float64
+}
+
+// routeStops sequences stops into the shortest trip from the pickup address
+// the dispatch module can find. Without coordinates for every address, the
+// stops are visited in the order they were placed.
+func (s *Service) routeStops(ctx context.Context, pickupAddressID string, stops []domain.Stop) (*stopRoute, error) {
+	placed := &stopRoute{order: make([]int, len(stops))}
+	for i := range stops {
+		placed.order[i] = i
+	}
+
+	pickup, err := s.orderRepo.FindAddressLocation(ctx, pickupAddressID)
+	if err != nil {
+		return nil, fmt.Errorf("service.routeStops.FindAddressLocation: %w", err)
+	}
+	if pickup == nil {
+		return placed, nil
+	}
+	points := make([]geo.Point, len(stops))
+	for i, stop := range stops {
+		location, err := s.orderRepo.FindAddressLocation(ctx, stop.AddressID)
+		if err != nil {
+			return nil, fmt.Errorf("service.routeStops.FindAddressLocation: %w", err)
+		}
+		if location == nil {
+			return placed, nil
+		}
+		points[i] = *location
+	}
+
+	order, legs := dispatch.SequenceStops(*pickup, points)
+	var distance float64
+	for _, leg := range legs {
+		distance += leg
+	}
+	return &stopRoute{order: order, legs: legs, distance: &distance}, nil
+}
+
+// ListOrderStops returns the stops of one of the caller's orders in visit order.
+func (s *Service) ListOrderStops(ctx context.Context, userID, orderID string) ([]domain.Stop, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListOrderStops.FindByID: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, models.ErrNotFound
+	}
+
+	stops, err := s.orderRepo.ListOrderStops(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListOrderStops: %w", err)
+	}
+	return stops, nil
+}
+
+// findReportedStop returns the stop a machine reports on: the given stop, or
+// the next pending one in visit order. The stop must still be pending.
+func findReportedStop(stops []domain.Stop, stopID string) (*domain.Stop, error) {
+	for i := range stops {
+		stop := &stops[i]
+		if stopID != "" && stop.ID != stopID {
+			continue
+		}
+		if stop.Status == domain.StopStatusPending {
+			return stop, nil
+		}
+		if stopID != "" {
+			return nil, models.ErrStopNotPending
+		}
+	}
+	if stopID != "" {
+		return nil, models.ErrNotFound
+	}
+	return nil, models.ErrStopNotPending
+}
+
+// countStops counts the stops other than except that are in status.
+func countStops(stops []domain.Stop, status, except string) int {
+	n := 0
+	for _, stop := range stops {
+		if stop.ID != except && stop.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,58 @@
+package orders
+
+import (
+	"dispatch-and-delivery/pkg/payments"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+)
+
+// maxWebhookBodyBytes caps the size of a webhook payload we are willing to read.
+const maxWebhookBodyBytes = 64 * 1024
+
+// WebhookHandler receives payment provider webhooks over HTTP.
+// Stripe cannot speak gRPC, so this is the only plain HTTP endpoint of the order service.
+type WebhookHandler struct {
+	service       ServiceInterface
+	signingSecret string
+}
+
+// NewWebhookHandler creates a new HTTP handler for Stripe webhooks.
+func NewWebhookHandler(s ServiceInterface, signingSecret string) *WebhookHandler {
+	return &WebhookHandler{service: s, signingSecret: signingSecret}
+}
+
+// ServeHTTP verifies the Stripe-Signature header and applies the event.
+// A non-2xx response makes Stripe redeliver the event later.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	event, err := payments.ConstructStripeEvent(payload, r.Header.Get("Stripe-Signature"), h.signingSecret)
+	if err != nil {
+		if errors.Is(err, payments.ErrInvalidSignature) {
+			log.Printf("WARN: Rejected webhook: %v", err)
+			http.Error(w, "invalid signature", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.HandlePaymentEvent(r.Context(), event); err != nil {
+		log.Printf("Failed to handle webhook event %s (%s): %v", event.ID, event.Type, err)
+		http.Error(w, "failed to process event", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
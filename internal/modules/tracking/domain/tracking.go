@@ -7,6 +7,7 @@ type TrackingEvent struct {
 	ID        string    `json:"id"`
 	OrderID   string    `json:"order_id"`
 	MachineID string    `json:"machine_id"`
+	StopID    *string   `json:"stop_id,omitempty"` // Stop the machine is heading to
 	Latitude  float64   `json:"latitude"`
 	Longitude float64   `json:"longitude"`
 	CreatedAt time.Time `json:"created_at"`
@@ -16,6 +17,7 @@ type TrackingEvent struct {
 // a new tracking event.
 type TrackingEventRequest struct {
 	MachineID string  `json:"machine_id"`
+	StopID    string  `json:"stop_id,omitempty"`
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
-}
\ No newline at end of file
+}
@@ -10,6 +10,9 @@ import (
 type TemplateManager struct {
 	ActivationTmpl *template.Template
 	ResetPassTmpl  *template.Template
+	RefundTmpl     *template.Template
+	HandoffTmpl    *template.Template
+	FailedTmpl     *template.Template
 }
 
 // NewTemplateManager parses all email templates at startup.
@@ -24,10 +27,28 @@ func NewTemplateManager() (*TemplateManager, error) {
 		return nil, err
 	}
 
+	refundTmpl, err := template.New("refund").Parse(refundTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	handoffTmpl, err := template.New("handoff").Parse(handoffTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	failedTmpl, err := template.New("failedDelivery").Parse(failedDeliveryTemplate)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Println("Email templates parsed successfully.")
 	return &TemplateManager{
 		ActivationTmpl: activationTmpl,
 		ResetPassTmpl:  resetPassTmpl,
+		RefundTmpl:     refundTmpl,
+		HandoffTmpl:    handoffTmpl,
+		FailedTmpl:     failedTmpl,
 	}, nil
 }
 
@@ -37,6 +58,31 @@ type TemplateData struct {
 	Link string
 }
 
+// RefundTemplateData holds the dynamic data for a refund notification email.
+type RefundTemplateData struct {
+	Name    string
+	OrderID string
+	Amount  string // Formatted amount, e.g. "12.50 USD"
+	Reason  string
+}
+
+// HandoffTemplateData holds the dynamic data for a handoff PIN email.
+type HandoffTemplateData struct {
+	Name      string
+	OrderID   string
+	PIN       string
+	ExpiresAt string // Formatted expiry time
+}
+
+// FailedDeliveryTemplateData holds the dynamic data for a failed delivery or return email.
+type FailedDeliveryTemplateData struct {
+	Name     string
+	OrderID  string
+	Headline string // e.g. "We Missed You"
+	Message  string // What went wrong and what happens next
+	Fee      string // Formatted fee; empty when nothing is charged
+}
+
 // GenerateActivationEmailHTML executes the activation template with the provided data.
 func (tm *TemplateManager) GenerateActivateAccountEmailHTML(data TemplateData) (string, error) {
 	var body bytes.Buffer
@@ -55,6 +101,33 @@ func (tm *TemplateManager) GenerateResetPasswordEmailHTML(data TemplateData) (st
 	return body.String(), nil
 }
 
+// GenerateRefundEmailHTML executes the refund notification template.
+func (tm *TemplateManager) GenerateRefundEmailHTML(data RefundTemplateData) (string, error) {
+	var body bytes.Buffer
+	if err := tm.RefundTmpl.Execute(&body, data); err != nil {
+		return "", err
+	}
+	return body.String(), nil
+}
+
+// GenerateHandoffEmailHTML executes the handoff PIN template.
+func (tm *TemplateManager) GenerateHandoffEmailHTML(data HandoffTemplateData) (string, error) {
+	var body bytes.Buffer
+	if err := tm.HandoffTmpl.Execute(&body, data); err != nil {
+		return "", err
+	}
+	return body.String(), nil
+}
+
+// GenerateFailedDeliveryEmailHTML executes the failed delivery template.
+func (tm *TemplateManager) GenerateFailedDeliveryEmailHTML(data FailedDeliveryTemplateData) (string, error) {
+	var body bytes.Buffer
+	if err := tm.FailedTmpl.Execute(&body, data); err != nil {
+		return "", err
+	}
+	return body.String(), nil
+}
+
 // --- HTML Template Definitions ---
 
 const accountActivTemplate = `
@@ -89,3 +162,51 @@ const passwordResetTemplate = `
 </body>
 </html>
 `
+
+const refundTemplate = `
+<!DOCTYPE html>
+<html>
+<head>
+	<title>Your Refund Is On Its Way</title>
+</head>
+<body style="font-family: Arial, sans-serif;">
+	<h2>Refund Issued</h2>
+	<p>Hello {{.Name}},</p>
+	<p>We have issued a refund of <strong>{{.Amount}}</strong> for your order {{.OrderID}}.</p>
+	<p>Reason: {{.Reason}}</p>
+	<p>Depending on your bank, it may take 5-10 business days for the refund to appear on your statement.</p>
+</body>
+</html>
+`
+
+const handoffTemplate = `
+<!DOCTYPE html>
+<html>
+<head>
+	<title>Your Delivery Code</title>
+</head>
+<body style="font-family: Arial, sans-serif;">
+	<h2>Your Order Is On Its Way</h2>
+	<p>Hello {{.Name}},</p>
+	<p>A machine has been dispatched for your order {{.OrderID}}. Enter this code on the machine to receive your delivery:</p>
+	<p style="font-size: 28px; letter-spacing: 6px;"><strong>{{.PIN}}</strong></p>
+	<p>The code is valid until {{.ExpiresAt}}. Never share it with anyone who contacts you about the delivery.</p>
+</body>
+</html>
+`
+
+const failedDeliveryTemplate = `
+<!DOCTYPE html>
+<html>
+<head>
+	<title>{{.Headline}}</title>
+</head>
+<body style="font-family: Arial, sans-serif;">
+	<h2>{{.Headline}}</h2>
+	<p>Hello {{.Name}},</p>
+	<p>{{.Message}}</p>
+	<p>Order: {{.OrderID}}</p>
+	{{if .Fee}}<p>A fee of <strong>{{.Fee}}</strong> has been added to your order.</p>{{end}}
+</body>
+</html>
+`
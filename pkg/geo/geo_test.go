@@ -0,0 +1,48 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceMeters(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+		tol  float64
+	}{
+		{"same point", Point{52.52, 13.405}, Point{52.52, 13.405}, 0, 1e-6},
+		{"one degree of latitude", Point{0, 0}, Point{1, 0}, 111195, 1},
+		{"Berlin to Paris", Point{52.5200, 13.4050}, Point{48.8566, 2.3522}, 877_500, 1500},
+		{"antipodes", Point{0, 0}, Point{0, 180}, math.Pi * earthRadiusMeters, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceMeters(tt.a, tt.b)
+			if math.Abs(got-tt.want) > tt.tol {
+				t.Errorf("DistanceMeters(%v, %v) = %.1f, want %.1f ± %.1f", tt.a, tt.b, got, tt.want, tt.tol)
+			}
+			if back := DistanceMeters(tt.b, tt.a); math.Abs(back-got) > 1e-6 {
+				t.Errorf("DistanceMeters is not symmetric: %.3f vs %.3f", got, back)
+			}
+		})
+	}
+}
+
+func TestPointValid(t *testing.T) {
+	tests := []struct {
+		p    Point
+		want bool
+	}{
+		{Point{52.52, 13.405}, true},
+		{Point{-90, 180}, true},
+		{Point{90.1, 0}, false},
+		{Point{0, -180.5}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Valid(); got != tt.want {
+			t.Errorf("%v.Valid() = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// ErrInvalidPolyline is returned when a string is not an encoded polyline.
+var ErrInvalidPolyline = errors.New("invalid encoded polyline")
+
+// EncodePolyline encodes points with the Google encoded polyline algorithm
+// at five decimal places, the format map SDKs draw routes from.
+func EncodePolyline(points []Point) string {
+	var b strings.Builder
+	var prevLat, prevLng int64
+	for _, p := range points {
+		lat := int64(math.Round(p.Latitude * 1e5))
+		lng := int64(math.Round(p.Longitude * 1e5))
+		encodePolylineValue(&b, lat-prevLat)
+		encodePolylineValue(&b, lng-prevLng)
+		prevLat, prevLng = lat, lng
+	}
+	return b.String()
+}
+
+// DecodePolyline decodes a Google encoded polyline.
+func DecodePolyline(encoded string) ([]Point, error) {
+	var points []Point
+	var lat, lng int64
+	for i := 0; i < len(encoded); {
+		dLat, n, err := decodePolylineValue(encoded[i:])
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		dLng, n, err := decodePolylineValue(encoded[i:])
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		lat, lng = lat+dLat, lng+dLng
+		points = append(points, Point{Latitude: float64(lat) / 1e5, Longitude: float64(lng) / 1e5})
+	}
+	return points, nil
+}
+
+func encodePolylineValue(b *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b.WriteByte(byte(0x20|(u&0x1f)) + 63)
+		u >>= 5
+	}
+	b.WriteByte(byte(u) + 63)
+}
+
+// decodePolylineValue returns the value at the start of s and the number of
+// bytes it takes up.
+func decodePolylineValue(s string) (int64, int, error) {
+	var u uint64
+	for i, shift := 0, uint(0); i < len(s) && shift < 64; i, shift = i+1, shift+5 {
+		c := uint64(s[i]) - 63
+		if c > 0x3f {
+			return 0, 0, ErrInvalidPolyline
+		}
+		u |= (c & 0x1f) << shift
+		if c < 0x20 {
+			v := int64(u >> 1)
+			if u&1 != 0 {
+				v = ^v
+			}
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, ErrInvalidPolyline
+}
@@ -0,0 +1,115 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// googleExample is the example of Google's encoded polyline format
+// documentation.
+var googleExample = []Point{
+	{Latitude: 38.5, Longitude: -120.2},
+	{Latitude: 40.7, Longitude: -120.95},
+	{Latitude: 43.252, Longitude: -126.453},
+}
+
+func TestEncodePolyline(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		want   string
+	}{
+		{"empty", nil, ""},
+		{"google example", googleExample, "_p~iF~ps|U_ulLnnqC_mqNvxq`@"},
+		{"origin", []Point{{0, 0}}, "??"},
+		{"negative value", []Point{{Latitude: -179.9832104, Longitude: 0}}, "`~oia@?"},
+		{
+			"negative deltas",
+			[]Point{{Latitude: 1, Longitude: 1}, {Latitude: 0.99999, Longitude: 0.99998}},
+			"_ibE_ibE@B",
+		},
+		{"rounds to five decimals", []Point{{Latitude: 38.500004, Longitude: -120.199996}}, "_p~iF~ps|U"},
+		{"rounds half away from zero", []Point{{Latitude: 0.000005, Longitude: -0.000005}}, "A@"},
+		{"repeated point", []Point{{1, 1}, {1, 1}}, "_ibE_ibE??"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EncodePolyline(tt.points); got != tt.want {
+				t.Errorf("EncodePolyline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePolyline(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		want    []Point
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"google example", "_p~iF~ps|U_ulLnnqC_mqNvxq`@", googleExample, false},
+		{"negative value", "`~oia@?", []Point{{Latitude: -179.98321, Longitude: 0}}, false},
+		{
+			"negative deltas",
+			"_ibE_ibE@B",
+			[]Point{{Latitude: 1, Longitude: 1}, {Latitude: 0.99999, Longitude: 0.99998}},
+			false,
+		},
+		{"latitude without longitude", "_p~iF", nil, true},
+		{"unterminated value", "_p~iF~ps|", nil, true},
+		{"byte below the alphabet", "_p~iF ", nil, true},
+		{"byte above the alphabet", "_p~iF\x7f", nil, true},
+		{"value too long", "~~~~~~~~~~~~~~~~~~?", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodePolyline(tt.encoded)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPolyline) {
+					t.Fatalf("DecodePolyline() error = %v, want ErrInvalidPolyline", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodePolyline() error = %v", err)
+			}
+			if !pointsNear(got, tt.want) {
+				t.Errorf("DecodePolyline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolylineRoundTrip(t *testing.T) {
+	points := []Point{
+		{Latitude: 37.77493, Longitude: -122.41942},
+		{Latitude: 37.76012, Longitude: -122.43518},
+		{Latitude: -33.86882, Longitude: 151.20929},
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 89.99999, Longitude: -179.99999},
+	}
+	got, err := DecodePolyline(EncodePolyline(points))
+	if err != nil {
+		t.Fatalf("DecodePolyline() error = %v", err)
+	}
+	if !pointsNear(got, points) {
+		t.Errorf("round trip = %v, want %v", got, points)
+	}
+}
+
+// pointsNear reports whether two lists of points match to the precision of
+// the polyline format.
+func pointsNear(a, b []Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i].Latitude-b[i].Latitude) > 1e-9 || math.Abs(a[i].Longitude-b[i].Longitude) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
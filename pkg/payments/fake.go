@@ -0,0 +1,130 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FakeProvider is an in-memory PaymentProvider for tests and local development.
+// It never talks to the network and honours idempotency keys like Stripe does.
+type FakeProvider struct {
+	mu      sync.Mutex
+	intents map[string]*Intent // keyed by intent ID
+	byKey   map[string]string  // idempotency key -> intent ID
+	refunds map[string]*Refund // keyed by idempotency key
+	nextID  int
+
+	// NextStatus is the status assigned to newly created intents.
+	// It defaults to IntentStatusSucceeded.
+	NextStatus string
+	// DeclinedPaymentMethods lists payment method IDs that will be declined.
+	DeclinedPaymentMethods map[string]bool
+}
+
+// NewFakeProvider creates an empty in-memory payment provider.
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		intents:                make(map[string]*Intent),
+		byKey:                  make(map[string]string),
+		refunds:                make(map[string]*Refund),
+		NextStatus:             IntentStatusSucceeded,
+		DeclinedPaymentMethods: make(map[string]bool),
+	}
+}
+
+func (f *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (f *FakeProvider) CreateIntent(ctx context.Context, params IntentParams) (*Intent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if id, ok := f.byKey[params.IdempotencyKey]; ok && params.IdempotencyKey != "" {
+		intent := *f.intents[id]
+		return &intent, nil
+	}
+
+	if f.DeclinedPaymentMethods[params.PaymentMethodID] {
+		return nil, fmt.Errorf("%w: card %s", ErrCardDeclined, params.PaymentMethodID)
+	}
+
+	f.nextID++
+	intent := &Intent{
+		ID:          fmt.Sprintf("pi_fake_%d", f.nextID),
+		Status:      f.NextStatus,
+		AmountCents: params.AmountCents,
+		Currency:    params.Currency,
+	}
+	f.intents[intent.ID] = intent
+	if params.IdempotencyKey != "" {
+		f.byKey[params.IdempotencyKey] = intent.ID
+	}
+
+	result := *intent
+	return &result, nil
+}
+
+func (f *FakeProvider) GetIntent(ctx context.Context, intentID string) (*Intent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	intent, ok := f.intents[intentID]
+	if !ok {
+		return nil, fmt.Errorf("fake.GetIntent: intent %s not found", intentID)
+	}
+	result := *intent
+	return &result, nil
+}
+
+func (f *FakeProvider) CreateRefund(ctx context.Context, params RefundParams) (*Refund, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if refund, ok := f.refunds[params.IdempotencyKey]; ok && params.IdempotencyKey != "" {
+		result := *refund
+		return &result, nil
+	}
+
+	intent, ok := f.intents[params.IntentID]
+	if !ok {
+		return nil, fmt.Errorf("fake.CreateRefund: intent %s not found", params.IntentID)
+	}
+	if !intent.Captured() {
+		return nil, fmt.Errorf("fake.CreateRefund: intent %s has not been captured", params.IntentID)
+	}
+
+	var refunded int64
+	for _, r := range f.refunds {
+		if r.IntentID == intent.ID {
+			refunded += r.AmountCents
+		}
+	}
+	if refunded+params.AmountCents > intent.AmountCents {
+		return nil, fmt.Errorf("fake.CreateRefund: amount exceeds the remaining %d cents", intent.AmountCents-refunded)
+	}
+
+	f.nextID++
+	refund := &Refund{
+		ID:          fmt.Sprintf("re_fake_%d", f.nextID),
+		IntentID:    intent.ID,
+		Status:      RefundStatusSucceeded,
+		AmountCents: params.AmountCents,
+	}
+	f.refunds[params.IdempotencyKey] = refund
+
+	result := *refund
+	return &result, nil
+}
+
+// SetIntentStatus changes the status of an existing intent, simulating
+// an asynchronous transition on the provider side.
+func (f *FakeProvider) SetIntentStatus(intentID, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if intent, ok := f.intents[intentID]; ok {
+		intent.Status = status
+	}
+}
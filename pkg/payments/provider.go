@@ -0,0 +1,81 @@
+// Package payments abstracts the third-party payment processor behind a small
+// interface so the order flow does not depend on a specific vendor.
+package payments
+
+import (
+	"context"
+	"errors"
+)
+
+// Intent status constants, mirroring the states of a Stripe PaymentIntent.
+const (
+	IntentStatusSucceeded             = "succeeded"
+	IntentStatusProcessing            = "processing"
+	IntentStatusRequiresAction        = "requires_action"
+	IntentStatusRequiresPaymentMethod = "requires_payment_method"
+	IntentStatusCanceled              = "canceled"
+)
+
+// ErrCardDeclined is returned when the provider refuses to charge the payment method.
+var ErrCardDeclined = errors.New("payment method was declined")
+
+// PaymentProvider defines the operations the order flow needs from a payment processor.
+type PaymentProvider interface {
+	// Name identifies the provider, e.g. "stripe". It is stored on payment records.
+	Name() string
+	// CreateIntent creates and confirms a payment intent for the given amount.
+	// Calls with the same IdempotencyKey must return the same intent.
+	CreateIntent(ctx context.Context, params IntentParams) (*Intent, error)
+	// GetIntent fetches the current state of a previously created intent.
+	GetIntent(ctx context.Context, intentID string) (*Intent, error)
+	// CreateRefund returns all or part of a captured intent to the customer.
+	// Calls with the same IdempotencyKey must return the same refund.
+	CreateRefund(ctx context.Context, params RefundParams) (*Refund, error)
+}
+
+// IntentParams holds the data required to charge a customer.
+type IntentParams struct {
+	AmountCents     int64
+	Currency        string
+	PaymentMethodID string
+	IdempotencyKey  string
+	Metadata        map[string]string
+}
+
+// Intent is the provider-agnostic view of a payment intent.
+type Intent struct {
+	ID            string
+	Status        string
+	AmountCents   int64
+	Currency      string
+	FailureReason string
+}
+
+// Captured reports whether the funds for the intent have been captured.
+func (i *Intent) Captured() bool {
+	return i.Status == IntentStatusSucceeded
+}
+
+// Refund status constants, mirroring the states of a Stripe Refund.
+const (
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusPending   = "pending"
+	RefundStatusFailed    = "failed"
+	RefundStatusCanceled  = "canceled"
+)
+
+// RefundParams holds the data required to refund a captured intent.
+type RefundParams struct {
+	IntentID       string
+	AmountCents    int64
+	IdempotencyKey string
+	Metadata       map[string]string
+}
+
+// Refund is the provider-agnostic view of a refund.
+type Refund struct {
+	ID          string
+	IntentID    string
+	Status      string
+	AmountCents int64
+}
@@ -0,0 +1,173 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const stripeAPIBase = "https://api.stripe.com/v1"
+
+// StripeProvider implements PaymentProvider using the Stripe PaymentIntents API.
+type StripeProvider struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewStripeProvider creates a new Stripe-backed payment provider.
+func NewStripeProvider(apiKey string) *StripeProvider {
+	return &StripeProvider{
+		apiKey:     apiKey,
+		baseURL:    stripeAPIBase,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// stripePaymentIntent is the subset of the Stripe PaymentIntent object we use.
+type stripePaymentIntent struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	Amount           int64  `json:"amount"`
+	Currency         string `json:"currency"`
+	LastPaymentError *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"last_payment_error"`
+}
+
+// stripeRefund is the subset of the Stripe Refund object we use.
+type stripeRefund struct {
+	ID            string `json:"id"`
+	PaymentIntent string `json:"payment_intent"`
+	Status        string `json:"status"`
+	Amount        int64  `json:"amount"`
+}
+
+// stripeErrorResponse is the error envelope returned by the Stripe API.
+type stripeErrorResponse struct {
+	Error struct {
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *StripeProvider) Name() string {
+	return "stripe"
+}
+
+// CreateIntent creates a PaymentIntent and confirms it immediately with automatic capture.
+func (p *StripeProvider) CreateIntent(ctx context.Context, params IntentParams) (*Intent, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(params.AmountCents, 10))
+	form.Set("currency", strings.ToLower(params.Currency))
+	form.Set("payment_method", params.PaymentMethodID)
+	form.Set("confirm", "true")
+	form.Set("capture_method", "automatic")
+	// Redirect-based payment methods would require a return_url, which a gRPC client cannot provide.
+	form.Set("automatic_payment_methods[enabled]", "true")
+	form.Set("automatic_payment_methods[allow_redirects]", "never")
+	for k, v := range params.Metadata {
+		form.Set(fmt.Sprintf("metadata[%s]", k), v)
+	}
+
+	var pi stripePaymentIntent
+	if err := p.do(ctx, http.MethodPost, "/payment_intents", form, params.IdempotencyKey, &pi); err != nil {
+		return nil, fmt.Errorf("stripe.CreateIntent: %w", err)
+	}
+	return pi.toIntent(), nil
+}
+
+// GetIntent retrieves a PaymentIntent by ID.
+func (p *StripeProvider) GetIntent(ctx context.Context, intentID string) (*Intent, error) {
+	var pi stripePaymentIntent
+	if err := p.do(ctx, http.MethodGet, "/payment_intents/"+url.PathEscape(intentID), nil, "", &pi); err != nil {
+		return nil, fmt.Errorf("stripe.GetIntent: %w", err)
+	}
+	return pi.toIntent(), nil
+}
+
+// CreateRefund refunds all or part of a PaymentIntent.
+func (p *StripeProvider) CreateRefund(ctx context.Context, params RefundParams) (*Refund, error) {
+	form := url.Values{}
+	form.Set("payment_intent", params.IntentID)
+	form.Set("amount", strconv.FormatInt(params.AmountCents, 10))
+	for k, v := range params.Metadata {
+		form.Set(fmt.Sprintf("metadata[%s]", k), v)
+	}
+
+	var r stripeRefund
+	if err := p.do(ctx, http.MethodPost, "/refunds", form, params.IdempotencyKey, &r); err != nil {
+		return nil, fmt.Errorf("stripe.CreateRefund: %w", err)
+	}
+	return &Refund{
+		ID:          r.ID,
+		IntentID:    r.PaymentIntent,
+		Status:      r.Status,
+		AmountCents: r.Amount,
+	}, nil
+}
+
+// do sends a form-encoded request to the Stripe API and decodes the JSON response into out.
+func (p *StripeProvider) do(ctx context.Context, method, path string, form url.Values, idempotencyKey string, out any) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.apiKey, "")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed reading response body: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		var errResp stripeErrorResponse
+		if err := json.Unmarshal(contents, &errResp); err != nil {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		if errResp.Error.Type == "card_error" {
+			return fmt.Errorf("%w: %s", ErrCardDeclined, errResp.Error.Message)
+		}
+		return fmt.Errorf("stripe error (%s): %s", errResp.Error.Type, errResp.Error.Message)
+	}
+
+	return json.Unmarshal(contents, out)
+}
+
+func (pi *stripePaymentIntent) toIntent() *Intent {
+	intent := &Intent{
+		ID:          pi.ID,
+		Status:      pi.Status,
+		AmountCents: pi.Amount,
+		Currency:    pi.Currency,
+	}
+	if pi.LastPaymentError != nil {
+		intent.FailureReason = pi.LastPaymentError.Message
+	}
+	return intent
+}
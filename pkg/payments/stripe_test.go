@@ -0,0 +1,134 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestStripeProvider points a Stripe provider at a test server.
+func newTestStripeProvider(t *testing.T, handler http.HandlerFunc) *StripeProvider {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	p := NewStripeProvider("sk_test_123")
+	p.baseURL = srv.URL
+	return p
+}
+
+func TestStripeCreateIntent(t *testing.T) {
+	p := newTestStripeProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/payment_intents" {
+			t.Errorf("request = %s %s, want POST /payment_intents", r.Method, r.URL.Path)
+		}
+		if user, _, _ := r.BasicAuth(); user != "sk_test_123" {
+			t.Errorf("basic auth user = %q, want the API key", user)
+		}
+		if got := r.Header.Get("Idempotency-Key"); got != "order_1_pm_card" {
+			t.Errorf("Idempotency-Key = %q, want order_1_pm_card", got)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		for key, want := range map[string]string{
+			"amount":             "1250",
+			"currency":           "usd",
+			"payment_method":     "pm_card",
+			"confirm":            "true",
+			"metadata[order_id]": "1",
+		} {
+			if got := r.PostForm.Get(key); got != want {
+				t.Errorf("form %s = %q, want %q", key, got, want)
+			}
+		}
+		w.Write([]byte(`{"id":"pi_1","status":"succeeded","amount":1250,"currency":"usd"}`))
+	})
+
+	intent, err := p.CreateIntent(context.Background(), IntentParams{
+		AmountCents:     1250,
+		Currency:        "USD",
+		PaymentMethodID: "pm_card",
+		IdempotencyKey:  "order_1_pm_card",
+		Metadata:        map[string]string{"order_id": "1"},
+	})
+	if err != nil {
+		t.Fatalf("CreateIntent: %v", err)
+	}
+	if intent.ID != "pi_1" || !intent.Captured() || intent.AmountCents != 1250 {
+		t.Errorf("intent = %+v, want captured pi_1 of 1250", intent)
+	}
+}
+
+func TestStripeCreateIntentErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantDeclined bool
+	}{
+		{"card declined", http.StatusPaymentRequired, `{"error":{"type":"card_error","code":"card_declined","message":"Your card was declined."}}`, true},
+		{"api error", http.StatusInternalServerError, `{"error":{"type":"api_error","message":"Something went wrong."}}`, false},
+		{"malformed error", http.StatusBadGateway, `<html>`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestStripeProvider(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := p.CreateIntent(context.Background(), IntentParams{AmountCents: 100, Currency: "usd", PaymentMethodID: "pm_card"})
+			if err == nil {
+				t.Fatal("CreateIntent succeeded, want an error")
+			}
+			if got := errors.Is(err, ErrCardDeclined); got != tt.wantDeclined {
+				t.Errorf("errors.Is(%v, ErrCardDeclined) = %v, want %v", err, got, tt.wantDeclined)
+			}
+		})
+	}
+}
+
+func TestStripeGetIntentFailureReason(t *testing.T) {
+	p := newTestStripeProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/payment_intents/pi_1" {
+			t.Errorf("path = %s, want /payment_intents/pi_1", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"pi_1","status":"requires_payment_method","amount":100,"currency":"usd",
+			"last_payment_error":{"code":"insufficient_funds","message":"Your card has insufficient funds."}}`))
+	})
+
+	intent, err := p.GetIntent(context.Background(), "pi_1")
+	if err != nil {
+		t.Fatalf("GetIntent: %v", err)
+	}
+	if intent.Captured() || intent.FailureReason != "Your card has insufficient funds." {
+		t.Errorf("intent = %+v, want an uncaptured intent with the failure reason", intent)
+	}
+}
+
+func TestFakeProviderReplaysIdempotencyKey(t *testing.T) {
+	f := NewFakeProvider()
+	params := IntentParams{AmountCents: 500, Currency: "usd", PaymentMethodID: "pm_card", IdempotencyKey: "order_1_pm_card"}
+
+	first, err := f.CreateIntent(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CreateIntent: %v", err)
+	}
+	second, err := f.CreateIntent(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CreateIntent: %v", err)
+	}
+	if first.ID != second.ID {
+		t.Errorf("retry created intent %s, want the original %s", second.ID, first.ID)
+	}
+
+	f.DeclinedPaymentMethods["pm_declined"] = true
+	params.PaymentMethodID, params.IdempotencyKey = "pm_declined", "order_1_pm_declined"
+	if _, err := f.CreateIntent(context.Background(), params); !errors.Is(err, ErrCardDeclined) {
+		t.Errorf("CreateIntent with a declined card = %v, want ErrCardDeclined", err)
+	}
+}
@@ -0,0 +1,104 @@
+package payments
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerifyStripeSignature(t *testing.T) {
+	const secret = "whsec_test"
+	payload := []byte(`{"id":"evt_1"}`)
+	signedAt := time.Unix(1700000000, 0)
+	// Computed independently as HMAC-SHA256("1700000000." + payload) keyed by the secret.
+	const knownSig = "c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925"
+
+	tests := []struct {
+		name    string
+		header  string
+		secret  string
+		payload []byte
+		now     time.Time
+		wantErr bool
+	}{
+		{"known signature", "t=1700000000,v1=" + knownSig, secret, payload, signedAt, false},
+		{"signed by SignStripePayload", SignStripePayload(payload, secret, signedAt), secret, payload, signedAt.Add(time.Minute), false},
+		{"one of several signatures matches", "t=1700000000,v1=deadbeef,v1=" + knownSig, secret, payload, signedAt, false},
+		{"tampered payload", "t=1700000000,v1=" + knownSig, secret, []byte(`{"id":"evt_2"}`), signedAt, true},
+		{"wrong secret", "t=1700000000,v1=" + knownSig, "whsec_other", payload, signedAt, true},
+		{"no secret configured", "t=1700000000,v1=" + knownSig, "", payload, signedAt, true},
+		{"outside tolerance", "t=1700000000,v1=" + knownSig, secret, payload, signedAt.Add(DefaultSignatureTolerance + time.Second), true},
+		{"missing timestamp", "v1=" + knownSig, secret, payload, signedAt, true},
+		{"missing signature", "t=1700000000", secret, payload, signedAt, true},
+		{"malformed timestamp", "t=yesterday,v1=" + knownSig, secret, payload, signedAt, true},
+		{"non-hex signature", "t=1700000000,v1=zz", secret, payload, signedAt, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyStripeSignature(tt.payload, tt.header, tt.secret, DefaultSignatureTolerance, tt.now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSignature) {
+					t.Errorf("VerifyStripeSignature = %v, want ErrInvalidSignature", err)
+				}
+			} else if err != nil {
+				t.Errorf("VerifyStripeSignature = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestParseStripeEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		wantIntent string
+		wantStatus string
+		wantReason string
+		wantRefund int64
+	}{
+		{
+			name: "payment intent failed",
+			payload: `{"id":"evt_1","type":"payment_intent.payment_failed","created":1700000000,"data":{"object":{
+				"id":"pi_1","object":"payment_intent","status":"requires_payment_method","amount":1250,"currency":"usd",
+				"last_payment_error":{"code":"card_declined","message":"Your card was declined."},
+				"metadata":{"order_id":"order_1"}}}}`,
+			wantIntent: "pi_1",
+			wantStatus: IntentStatusRequiresPaymentMethod,
+			wantReason: "Your card was declined.",
+		},
+		{
+			name: "charge refunded",
+			payload: `{"id":"evt_2","type":"charge.refunded","created":1700000000,"data":{"object":{
+				"id":"ch_1","object":"charge","payment_intent":"pi_1","refunded":true,"amount_refunded":1250}}}`,
+			wantIntent: "pi_1",
+			wantRefund: 1250,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ParseStripeEvent([]byte(tt.payload))
+			if err != nil {
+				t.Fatalf("ParseStripeEvent: %v", err)
+			}
+			if event.PaymentIntentID != tt.wantIntent {
+				t.Errorf("PaymentIntentID = %q, want %q", event.PaymentIntentID, tt.wantIntent)
+			}
+			if event.AmountRefundedCents != tt.wantRefund {
+				t.Errorf("AmountRefundedCents = %d, want %d", event.AmountRefundedCents, tt.wantRefund)
+			}
+			if tt.wantStatus == "" {
+				if event.Intent != nil {
+					t.Errorf("Intent = %+v, want nil for a %s event", event.Intent, event.Type)
+				}
+				return
+			}
+			if event.Intent == nil || event.Intent.Status != tt.wantStatus || event.Intent.FailureReason != tt.wantReason {
+				t.Errorf("Intent = %+v, want status %q and failure reason %q", event.Intent, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+
+	if _, err := ParseStripeEvent([]byte(`{"type":"charge.refunded","data":{"object":{}}}`)); err == nil {
+		t.Error("ParseStripeEvent accepted an event without an id")
+	}
+}
@@ -0,0 +1,273 @@
+// Package pki runs the local certificate authority that issues the client
+// certificates delivery machines use to authenticate over mutual TLS.
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// MachineURIPrefix prefixes the machine ID in the URI SAN of machine certificates.
+const MachineURIPrefix = "urn:laas:machine:"
+
+// MachineCertificateTTL is how long a machine certificate is valid.
+const MachineCertificateTTL = 365 * 24 * time.Hour
+
+const (
+	caTTL                = 10 * 365 * 24 * time.Hour
+	serverCertificateTTL = 365 * 24 * time.Hour
+)
+
+// CA is a certificate authority whose key is held by this process.
+type CA struct {
+	cert    *x509.Certificate
+	key     crypto.Signer
+	certPEM []byte
+}
+
+// IssuedCertificate is a freshly issued certificate along with its private
+// key. The key is handed out once and never stored by us.
+type IssuedCertificate struct {
+	SerialNumber   string // Hex encoded
+	CertificatePEM []byte
+	PrivateKeyPEM  []byte
+	ExpiresAt      time.Time
+}
+
+// LoadOrCreateCA loads the CA certificate and key from PEM files, creating a
+// new self-signed CA there if neither file exists yet.
+func LoadOrCreateCA(certFile, keyFile string) (*CA, error) {
+	certPEM, certErr := os.ReadFile(certFile)
+	keyPEM, keyErr := os.ReadFile(keyFile)
+	if errors.Is(certErr, os.ErrNotExist) && errors.Is(keyErr, os.ErrNotExist) {
+		return createCA(certFile, keyFile)
+	}
+	if certErr != nil {
+		return nil, fmt.Errorf("pki.LoadOrCreateCA: %w", certErr)
+	}
+	if keyErr != nil {
+		return nil, fmt.Errorf("pki.LoadOrCreateCA: %w", keyErr)
+	}
+
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("pki.LoadOrCreateCA: %w", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("pki.LoadOrCreateCA: %w", err)
+	}
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok || !cert.IsCA {
+		return nil, fmt.Errorf("pki.LoadOrCreateCA: %s is not a CA certificate with a signing key", certFile)
+	}
+	return &CA{cert: cert, key: key, certPEM: certPEM}, nil
+}
+
+func createCA(certFile, keyFile string) (*CA, error) {
+	key, keyPEM, err := newKey()
+	if err != nil {
+		return nil, fmt.Errorf("pki.createCA: %w", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, fmt.Errorf("pki.createCA: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "Machine CA"},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(caTTL),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("pki.createCA: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("pki.createCA: %w", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	// The key file is created first and exclusively, so two services starting
+	// at once cannot overwrite each other's CA.
+	if err := writeNewFile(keyFile, keyPEM, 0o600); err != nil {
+		return nil, fmt.Errorf("pki.createCA: %w", err)
+	}
+	if err := writeNewFile(certFile, certPEM, 0o644); err != nil {
+		return nil, fmt.Errorf("pki.createCA: %w", err)
+	}
+	return &CA{cert: cert, key: key, certPEM: certPEM}, nil
+}
+
+// CertificatePEM returns the CA certificate clients use to verify us.
+func (ca *CA) CertificatePEM() []byte {
+	return ca.certPEM
+}
+
+// CertPool returns a pool that trusts only this CA.
+func (ca *CA) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// IssueMachineCertificate issues a client certificate that identifies the
+// machine by a URI SAN of the form MachineURIPrefix + machineID.
+func (ca *CA) IssueMachineCertificate(machineID string) (*IssuedCertificate, error) {
+	id, err := url.Parse(MachineURIPrefix + machineID)
+	if err != nil {
+		return nil, fmt.Errorf("pki.IssueMachineCertificate: %w", err)
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: machineID},
+		URIs:        []*url.URL{id},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	issued, err := ca.issue(template, MachineCertificateTTL)
+	if err != nil {
+		return nil, fmt.Errorf("pki.IssueMachineCertificate: %w", err)
+	}
+	return issued, nil
+}
+
+// IssueServerCertificate issues a serving certificate for the given host
+// names and IP addresses.
+func (ca *CA) IssueServerCertificate(hosts ...string) (tls.Certificate, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: hosts[0]},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	issued, err := ca.issue(template, serverCertificateTTL)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("pki.IssueServerCertificate: %w", err)
+	}
+	return tls.X509KeyPair(issued.CertificatePEM, issued.PrivateKeyPEM)
+}
+
+func (ca *CA) issue(template *x509.Certificate, ttl time.Duration) (*IssuedCertificate, error) {
+	key, keyPEM, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template.SerialNumber = serial
+	template.NotBefore = now.Add(-time.Minute) // Tolerate small clock skew
+	template.NotAfter = now.Add(ttl)
+	if template.NotAfter.After(ca.cert.NotAfter) {
+		template.NotAfter = ca.cert.NotAfter
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedCertificate{
+		SerialNumber:   SerialNumberHex(serial),
+		CertificatePEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		PrivateKeyPEM:  keyPEM,
+		ExpiresAt:      template.NotAfter,
+	}, nil
+}
+
+// ServerTLSConfig returns the TLS configuration of a gRPC server that asks
+// for client certificates signed by ca. Clients without one can still connect
+// and authenticate with a token instead.
+func ServerTLSConfig(ca *CA, serverCert tls.Certificate) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    ca.CertPool(),
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// MachineIDFromCertificate returns the machine ID in the URI SAN of a
+// machine certificate.
+func MachineIDFromCertificate(cert *x509.Certificate) (string, bool) {
+	for _, uri := range cert.URIs {
+		if id, ok := strings.CutPrefix(uri.String(), MachineURIPrefix); ok && id != "" {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// SerialNumberHex formats a certificate serial number the way it is stored.
+func SerialNumberHex(serial *big.Int) string {
+	return hex.EncodeToString(serial.Bytes())
+}
+
+func newKey() (crypto.Signer, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// newSerialNumber returns a random 128-bit certificate serial number.
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func writeNewFile(name string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadServerCertificate loads the serving certificate from PEM files, or has
+// ca issue one for hosts when no files are configured.
+func LoadServerCertificate(ca *CA, certFile, keyFile string, hosts ...string) (tls.Certificate, error) {
+	if certFile != "" || keyFile != "" {
+		return tls.LoadX509KeyPair(certFile, keyFile)
+	}
+	return ca.IssueServerCertificate(hosts...)
+}
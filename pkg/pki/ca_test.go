@@ -0,0 +1,76 @@
+package pki
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadOrCreateCA(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca-key.pem")
+
+	created, err := LoadOrCreateCA(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("creating the CA: %v", err)
+	}
+	loaded, err := LoadOrCreateCA(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("loading the CA: %v", err)
+	}
+	if string(loaded.CertificatePEM()) != string(created.CertificatePEM()) {
+		t.Error("loading the CA again returned another certificate")
+	}
+
+	if _, err := LoadOrCreateCA(certFile, filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("loading a CA without its key succeeded, want an error")
+	}
+}
+
+func TestIssueMachineCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := LoadOrCreateCA(filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca-key.pem"))
+	if err != nil {
+		t.Fatalf("creating the CA: %v", err)
+	}
+
+	issued, err := ca.IssueMachineCertificate("robot-1")
+	if err != nil {
+		t.Fatalf("IssueMachineCertificate: %v", err)
+	}
+	block, _ := pem.Decode(issued.CertificatePEM)
+	if block == nil {
+		t.Fatal("issued certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parsing the issued certificate: %v", err)
+	}
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     ca.CertPool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		t.Errorf("issued certificate does not verify as a client certificate: %v", err)
+	}
+	if id, ok := MachineIDFromCertificate(cert); !ok || id != "robot-1" {
+		t.Errorf("MachineIDFromCertificate = %q, %v, want robot-1", id, ok)
+	}
+	if got := SerialNumberHex(cert.SerialNumber); got != issued.SerialNumber {
+		t.Errorf("serial number = %s, want %s", got, issued.SerialNumber)
+	}
+	if ttl := time.Until(issued.ExpiresAt); ttl > MachineCertificateTTL || ttl < MachineCertificateTTL-time.Hour {
+		t.Errorf("certificate expires in %v, want about %v", ttl, MachineCertificateTTL)
+	}
+
+	other, err := ca.IssueServerCertificate("localhost", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("IssueServerCertificate: %v", err)
+	}
+	if id, ok := MachineIDFromCertificate(other.Leaf); ok {
+		t.Errorf("server certificate identifies machine %q", id)
+	}
+}
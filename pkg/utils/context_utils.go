@@ -22,6 +22,15 @@ func GetUserIDFromContext(ctx context.Context) (string, error) {
 	return userID, nil
 }
 
+// GetUserRoleFromContext gets the caller's role from context (set by interceptor)
+func GetUserRoleFromContext(ctx context.Context) (string, error) {
+	role, ok := ctx.Value(middleware.UserRoleContextKey).(string)
+	if !ok || role == "" {
+		return "", status.Error(codes.Unauthenticated, "user role not found in context")
+	}
+	return role, nil
+}
+
 // GetPaginationParams processes pagination parameters from a gRPC request.
 // It takes the page and limit provided by the client and returns sanitized values
 // with sensible defaults.
@@ -14,3 +14,25 @@ func GenerateSecureToken(length int) (string, error) {
 	}
 	return hex.EncodeToString(b), nil
 }
+
+// GenerateNumericCode creates a random code of the given number of decimal
+// digits, e.g. a PIN a person can type on a keypad.
+func GenerateNumericCode(digits int) (string, error) {
+	b := make([]byte, digits)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("rand.Read failed: %w", err)
+	}
+	code := make([]byte, digits)
+	for i, v := range b {
+		// 250 is the largest multiple of 10 below 256; rejecting larger values avoids modulo bias.
+		for v >= 250 {
+			var retry [1]byte
+			if _, err := rand.Read(retry[:]); err != nil {
+				return "", fmt.Errorf("rand.Read failed: %w", err)
+			}
+			v = retry[0]
+		}
+		code[i] = '0' + v%10
+	}
+	return string(code), nil
+}